@@ -0,0 +1,148 @@
+// Package akismet classifies form submissions through the Akismet spam
+// filtering API and reports the ones it got wrong so that it learns from
+// them
+package akismet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const apiURL = "https://rest.akismet.com/1.1/"
+
+// Verdict is how Akismet classified a submission
+type Verdict string
+
+const (
+	Ham  Verdict = "ham"
+	Spam Verdict = "spam"
+	// Blatant is spam Akismet is confident enough about to suggest
+	// discarding it unseen
+	Blatant Verdict = "blatant"
+)
+
+// Kinds of submission
+const (
+	ContactForm = "contact-form"
+	Comment     = "comment"
+)
+
+// Submission is what Akismet looks at to classify a message
+type Submission struct {
+	Type        string // ContactForm or Comment
+	IPAddress   string
+	UserAgent   string
+	Author      string
+	AuthorEmail string
+	Content     string
+	CreatedAt   time.Time
+}
+
+// Client calls the Akismet API for one site
+type Client struct {
+	key    string
+	site   string
+	client *http.Client
+}
+
+// NewClient returns a client for the API key and the address of the site
+// it was registered for
+func NewClient(key, site string) *Client {
+	return &Client{
+		key:    key,
+		site:   site,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Check classifies a submission
+func (c *Client) Check(ctx context.Context, s *Submission) (Verdict, error) {
+	resp, body, err := c.call(ctx, "comment-check", s)
+	if err != nil {
+		return "", err
+	}
+	switch body {
+	case "true":
+		if resp.Header.Get("X-akismet-pro-tip") == "discard" {
+			return Blatant, nil
+		}
+		return Spam, nil
+	case "false":
+		return Ham, nil
+	}
+	return "", invalidResponse("comment-check", resp, body)
+}
+
+// SubmitSpam reports a submission Check let through as ham
+func (c *Client) SubmitSpam(ctx context.Context, s *Submission) error {
+	return c.submit(ctx, "submit-spam", s)
+}
+
+// SubmitHam reports a submission Check wrongly classified as spam
+func (c *Client) SubmitHam(ctx context.Context, s *Submission) error {
+	return c.submit(ctx, "submit-ham", s)
+}
+
+func (c *Client) submit(ctx context.Context, method string, s *Submission) error {
+	resp, body, err := c.call(ctx, method, s)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(body, "Thanks") {
+		return invalidResponse(method, resp, body)
+	}
+	return nil
+}
+
+// call posts a submission to an API method and returns the response with
+// its trimmed body
+func (c *Client) call(ctx context.Context, method string, s *Submission) (*http.Response, string, error) {
+	form := url.Values{
+		"api_key":              {c.key},
+		"blog":                 {c.site},
+		"user_ip":              {s.IPAddress},
+		"user_agent":           {s.UserAgent},
+		"comment_type":         {s.Type},
+		"comment_author":       {s.Author},
+		"comment_author_email": {s.AuthorEmail},
+		"comment_content":      {s.Content},
+	}
+	if !s.CreatedAt.IsZero() {
+		form.Set("comment_date_gmt", s.CreatedAt.UTC().Format(time.RFC3339))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+method, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("akismet: %s failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	if err != nil {
+		return nil, "", fmt.Errorf("akismet: %s failed: %w", method, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("akismet: %s returned %s", method, resp.Status)
+	}
+	return resp, strings.TrimSpace(string(body)), nil
+}
+
+// invalidResponse describes an unexpected answer, which Akismet explains in
+// a debug header, e.g. for an invalid key
+func invalidResponse(method string, resp *http.Response, body string) error {
+	if help := resp.Header.Get("X-akismet-debug-help"); help != "" {
+		return fmt.Errorf("akismet: %s returned %q: %s", method, body, help)
+	}
+	return fmt.Errorf("akismet: %s returned %q", method, body)
+}
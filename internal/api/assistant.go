@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SuggestProjectDescription drafts a project description (admin only)
+// @Summary Suggest project description
+// @Description Asks the configured language model to draft a description of the project from the README of its GitHub repository. The draft is returned for review and not saved (admin only)
+// @Tags assistant
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param request body service.WritingRequest true "Extra instructions, may be empty"
+// @Success 200 {object} service.WritingSuggestion
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /admin/projects/{id}/description/suggestion [post]
+func (h *Handlers) SuggestProjectDescription(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req service.WritingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	suggestion, err := h.assistantService.SuggestProjectDescription(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "assistant not configured":
+			respondError(c, http.StatusNotFound, "No language model is configured")
+		case "project not found":
+			respondError(c, http.StatusNotFound, "Project not found")
+		case "no github repository":
+			respondError(c, http.StatusBadRequest, "Project has no GitHub repository")
+		case "readme unavailable":
+			respondError(c, http.StatusBadGateway, "Could not fetch the repository README")
+		case "assistant unavailable":
+			respondError(c, http.StatusBadGateway, "The language model is unavailable, please try again later")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to suggest a description")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, suggestion)
+}
+
+// SuggestProfileSummary drafts a tighter profile summary (admin only)
+// @Summary Suggest profile summary
+// @Description Asks the configured language model to tighten the profile summary without adding facts. The draft is returned for review and not saved (admin only)
+// @Tags assistant
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body service.WritingRequest true "Extra instructions, may be empty"
+// @Success 200 {object} service.WritingSuggestion
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /admin/profile/summary/suggestion [post]
+func (h *Handlers) SuggestProfileSummary(c *gin.Context) {
+	var req service.WritingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	suggestion, err := h.assistantService.SuggestProfileSummary(c.Request.Context(), &req)
+	if err != nil {
+		switch err.Error() {
+		case "assistant not configured":
+			respondError(c, http.StatusNotFound, "No language model is configured")
+		case "empty summary":
+			respondError(c, http.StatusBadRequest, "The profile has no summary to tighten")
+		case "assistant unavailable":
+			respondError(c, http.StatusBadGateway, "The language model is unavailable, please try again later")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to suggest a summary")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, suggestion)
+}
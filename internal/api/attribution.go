@@ -0,0 +1,45 @@
+package api
+
+import (
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// isAdmin reports whether the request carries a valid admin token
+func isAdmin(c *gin.Context) bool {
+	return c.GetString("user_role") == "admin"
+}
+
+// withoutAttribution returns copies of items with the admin usernames
+// cleared by clear. Services may hand the same slice to concurrent requests,
+// so the items themselves are left alone.
+func withoutAttribution[T any](items []T, clear func(*T)) []T {
+	if items == nil {
+		return nil
+	}
+	stripped := make([]T, len(items))
+	copy(stripped, items)
+	for i := range stripped {
+		clear(&stripped[i])
+	}
+	return stripped
+}
+
+func clearSkill(skill *models.Skill) {
+	skill.Attribution = models.Attribution{}
+}
+
+func clearExperience(experience *models.Experience) {
+	experience.Attribution = models.Attribution{}
+	experience.Skills = withoutAttribution(experience.Skills, clearSkill)
+}
+
+func clearProject(project *models.Project) {
+	project.Attribution = models.Attribution{}
+	project.Skills = withoutAttribution(project.Skills, clearSkill)
+}
+
+func clearPost(post *models.Post) {
+	post.Attribution = models.Attribution{}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UploadAvatar replaces the profile avatar with a cropped upload (admin only)
+// @Summary Upload avatar
+// @Description Uploads an image (JPEG, PNG, GIF, WebP) as multipart form field "file" and crops it to the rectangle given by x, y, width and height, in pixels of the original. A rectangle that is not square is trimmed evenly on its longer side; without one the largest centered square is kept. The profile avatar is set to the 512px variant and avatar_variants lists the small (64px), medium (256px) and large (512px) copies (admin only)
+// @Tags profile
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image to upload"
+// @Param x formData int false "Left edge of the crop"
+// @Param y formData int false "Top edge of the crop"
+// @Param width formData int false "Width of the crop"
+// @Param height formData int false "Height of the crop"
+// @Success 200 {object} models.Profile
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 413 {object} map[string]interface{}
+// @Router /admin/profile/avatar [post]
+func (h *Handlers) UploadAvatar(c *gin.Context) {
+	// Leave room for the multipart framing around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.mediaService.MaxSize()+1<<20)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(c, http.StatusRequestEntityTooLarge, "File too large")
+			return
+		}
+		respondError(c, http.StatusBadRequest, "Missing file")
+		return
+	}
+
+	crop, ok := avatarCrop(c)
+	if !ok {
+		respondError(c, http.StatusBadRequest, "Invalid crop")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid file")
+		return
+	}
+	defer file.Close()
+
+	profile, err := h.profileService.UploadAvatar(c.Request.Context(), filepath.Base(header.Filename), file, crop)
+	if err != nil {
+		switch err.Error() {
+		case "unsupported file type":
+			respondError(c, http.StatusBadRequest, "Unsupported file type")
+		case "invalid image":
+			respondError(c, http.StatusBadRequest, "Invalid image")
+		case "invalid crop":
+			respondError(c, http.StatusBadRequest, "Crop must lie within the image")
+		case "file too large":
+			respondError(c, http.StatusRequestEntityTooLarge, "File too large")
+		case "profile not found":
+			respondError(c, http.StatusNotFound, "Profile not found")
+		case "version conflict":
+			respondError(c, http.StatusConflict, "Profile was changed while uploading; try again")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to upload avatar")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, profile)
+}
+
+// avatarCrop reads the crop rectangle from the form. Fields left out count
+// as zero.
+func avatarCrop(c *gin.Context) (service.AvatarCrop, bool) {
+	var values [4]int
+	for i, field := range []string{"x", "y", "width", "height"} {
+		raw := c.PostForm(field)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return service.AvatarCrop{}, false
+		}
+		values[i] = value
+	}
+	return service.AvatarCrop{X: values[0], Y: values[1], Width: values[2], Height: values[3]}, true
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAwards returns all awards
+// @Summary Get awards
+// @Description Returns all awards and honors, newest first
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Award
+// @Router /awards [get]
+func (h *Handlers) GetAwards(c *gin.Context) {
+	awards, err := h.awardService.GetAwards(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get awards")
+		return
+	}
+	respondList(c, awards)
+}
+
+// CreateAward creates a new award entry
+// @Summary Create award
+// @Description Creates a new award entry (admin only)
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param award body service.AwardCreateRequest true "Award data"
+// @Success 201 {object} models.Award
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/awards [post]
+func (h *Handlers) CreateAward(c *gin.Context) {
+	var req service.AwardCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	award, err := h.awardService.CreateAward(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to create award")
+		return
+	}
+
+	respond(c, http.StatusCreated, award)
+}
+
+// UpdateAward updates an existing award entry
+// @Summary Update award
+// @Description Updates an existing award entry (admin only)
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Award ID"
+// @Param award body service.AwardUpdateRequest true "Award data"
+// @Success 200 {object} models.Award
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/awards/{id} [put]
+func (h *Handlers) UpdateAward(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid award ID")
+		return
+	}
+
+	var req service.AwardUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	award, err := h.awardService.UpdateAward(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "award not found" {
+			respondError(c, http.StatusNotFound, "Award not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update award")
+		return
+	}
+
+	respond(c, http.StatusOK, award)
+}
+
+// DeleteAward deletes a award entry
+// @Summary Delete award
+// @Description Deletes a award entry (admin only)
+// @Tags awards
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Award ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/awards/{id} [delete]
+func (h *Handlers) DeleteAward(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid award ID")
+		return
+	}
+
+	err = h.awardService.DeleteAward(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "award not found" {
+			respondError(c, http.StatusNotFound, "Award not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete award")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBackupStatus reports the database backup schedule and stored backups (admin only)
+// @Summary Get backup status
+// @Description Returns the backup schedule, the outcome of the latest run and the backups kept in storage (admin only)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.BackupStatus
+// @Failure 401 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /admin/backups [get]
+func (h *Handlers) GetBackupStatus(c *gin.Context) {
+	status, err := h.backupService.GetStatus(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusBadGateway, "Failed to get backup status")
+		return
+	}
+	respond(c, http.StatusOK, status)
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"stackwhiz-portfolio-backend/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCacheStats summarizes the cache lookups of this server process
+// @Summary Get cache statistics
+// @Description Returns hits, misses, stale entries, Redis errors, the hit ratio and the mean lookup time of every cache since this server started (default tenant admins only)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} metrics.CacheStats
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /admin/cache/stats [get]
+func (h *Handlers) GetCacheStats(c *gin.Context) {
+	respondList(c, metrics.CacheSummary())
+}
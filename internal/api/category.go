@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCategories returns all project categories
+// @Summary Get project categories
+// @Description Returns all project categories ordered by name
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Category
+// @Router /categories [get]
+func (h *Handlers) GetCategories(c *gin.Context) {
+	categories, err := h.categoryService.GetCategories(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get categories")
+		return
+	}
+	respondList(c, categories)
+}
+
+// CreateCategory creates a new project category
+// @Summary Create project category
+// @Description Creates a new project category; names must be unique regardless of case (admin only)
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category body service.CategoryCreateRequest true "Category data"
+// @Success 201 {object} models.Category
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/categories [post]
+func (h *Handlers) CreateCategory(c *gin.Context) {
+	var req service.CategoryCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "category already exists" {
+			respondError(c, http.StatusConflict, "Category already exists")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create category")
+		return
+	}
+
+	respond(c, http.StatusCreated, category)
+}
+
+// UpdateCategory updates an existing project category
+// @Summary Update project category
+// @Description Updates an existing project category (admin only)
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param category body service.CategoryUpdateRequest true "Category data"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/categories/{id} [put]
+func (h *Handlers) UpdateCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	var req service.CategoryUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "category not found":
+			respondError(c, http.StatusNotFound, "Category not found")
+		case "category already exists":
+			respondError(c, http.StatusConflict, "Category already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update category")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, category)
+}
+
+// DeleteCategory deletes a project category
+// @Summary Delete project category
+// @Description Deletes a project category; its projects become uncategorized (admin only)
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/categories/{id} [delete]
+func (h *Handlers) DeleteCategory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	err = h.categoryService.DeleteCategory(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "category not found" {
+			respondError(c, http.StatusNotFound, "Category not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete category")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
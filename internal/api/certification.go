@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCertifications returns all certifications
+// @Summary Get certifications
+// @Description Returns all certifications, most recently issued first, with expired ones flagged; when EXPIRED_CERTIFICATIONS is hide they are only listed for admins
+// @Tags certifications
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Certification
+// @Router /certifications [get]
+func (h *Handlers) GetCertifications(c *gin.Context) {
+	certifications, err := h.certificationService.GetCertifications(c.Request.Context(), isAdmin(c))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get certifications")
+		return
+	}
+	respondList(c, certifications)
+}
+
+// CreateCertification creates a new certification entry
+// @Summary Create certification
+// @Description Creates a new certification entry (admin only)
+// @Tags certifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param certification body service.CertificationCreateRequest true "Certification data"
+// @Success 201 {object} models.Certification
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/certifications [post]
+func (h *Handlers) CreateCertification(c *gin.Context) {
+	var req service.CertificationCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	certification, err := h.certificationService.CreateCertification(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to create certification")
+		return
+	}
+
+	respond(c, http.StatusCreated, certification)
+}
+
+// UpdateCertification updates an existing certification entry
+// @Summary Update certification
+// @Description Updates an existing certification entry (admin only)
+// @Tags certifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Certification ID"
+// @Param certification body service.CertificationUpdateRequest true "Certification data"
+// @Success 200 {object} models.Certification
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/certifications/{id} [put]
+func (h *Handlers) UpdateCertification(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid certification ID")
+		return
+	}
+
+	var req service.CertificationUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	certification, err := h.certificationService.UpdateCertification(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "certification not found" {
+			respondError(c, http.StatusNotFound, "Certification not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update certification")
+		return
+	}
+
+	respond(c, http.StatusOK, certification)
+}
+
+// DeleteCertification deletes a certification entry
+// @Summary Delete certification
+// @Description Deletes a certification entry (admin only)
+// @Tags certifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Certification ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/certifications/{id} [delete]
+func (h *Handlers) DeleteCertification(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid certification ID")
+		return
+	}
+
+	err = h.certificationService.DeleteCertification(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "certification not found" {
+			respondError(c, http.StatusNotFound, "Certification not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete certification")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
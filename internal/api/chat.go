@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Chat answers a visitor's question about the portfolio
+// @Summary Ask the portfolio chatbot
+// @Description Answers a question such as "Has this person worked with Kafka?" with the configured language model, from the published experiences, projects and skills that mention the question's words. The answer is streamed as Server-Sent Events: "sources" lists the content given to the model, "delta" events carry the answer as it is written, and "done" the whole answer; "error" ends a stream that failed. Questions the content does not answer get a fixed refusal. Every IP address may ask 20 questions an hour
+// @Tags chat
+// @Accept json
+// @Produce text/event-stream
+// @Param question body service.ChatRequest true "Question"
+// @Success 200 {object} service.ChatReply
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /chat [post]
+func (h *Handlers) Chat(c *gin.Context) {
+	var req service.ChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	req.IPAddress = c.ClientIP()
+
+	ctx := c.Request.Context()
+	reply, err := h.chatService.Ask(ctx, &req)
+	if err != nil {
+		switch err.Error() {
+		case "chat not configured":
+			respondError(c, http.StatusNotFound, "Chat is not available")
+		case "rate limit exceeded":
+			respondError(c, http.StatusTooManyRequests, "Too many questions, try again later")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to answer the question")
+		}
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+
+	c.SSEvent("sources", reply.Sources)
+	c.Writer.Flush()
+
+	answer, err := h.chatService.Answer(ctx, reply, func(text string) error {
+		c.SSEvent("delta", gin.H{"text": text})
+		c.Writer.Flush()
+		return ctx.Err()
+	})
+	if err != nil {
+		c.SSEvent("error", gin.H{"error": "The answer could not be completed, please try again later"})
+		c.Writer.Flush()
+		return
+	}
+	c.SSEvent("done", gin.H{"answer": answer})
+	c.Writer.Flush()
+}
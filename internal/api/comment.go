@@ -0,0 +1,261 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// projectCommentTarget names the project in the request path, reporting
+// an invalid ID
+func projectCommentTarget(c *gin.Context) (service.CommentTarget, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return service.CommentTarget{}, false
+	}
+	return service.CommentTarget{Type: models.CommentOnProject, ID: uint(id)}, true
+}
+
+func postCommentTarget(c *gin.Context) service.CommentTarget {
+	return service.CommentTarget{Type: models.CommentOnPost, Slug: c.Param("slug")}
+}
+
+// GetProjectComments returns the approved comments on a project
+// @Summary Get project comments
+// @Description Returns the approved comments on a published project, oldest first
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.Comment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /projects/{id}/comments [get]
+func (h *Handlers) GetProjectComments(c *gin.Context) {
+	if target, ok := projectCommentTarget(c); ok {
+		h.getComments(c, target)
+	}
+}
+
+// GetPostComments returns the approved comments on a blog post
+// @Summary Get post comments
+// @Description Returns the approved comments on a published post, oldest first
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Success 200 {array} models.Comment
+// @Failure 404 {object} map[string]interface{}
+// @Router /posts/{slug}/comments [get]
+func (h *Handlers) GetPostComments(c *gin.Context) {
+	h.getComments(c, postCommentTarget(c))
+}
+
+func (h *Handlers) getComments(c *gin.Context, target service.CommentTarget) {
+	comments, err := h.commentService.GetComments(c.Request.Context(), target)
+	if err != nil {
+		if respondCommentTargetError(c, err) {
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get comments")
+		return
+	}
+	respondList(c, comments)
+}
+
+// CreateProjectComment submits a comment on a project
+// @Summary Comment on project
+// @Description Submits a comment on a published project. Comments appear once approved by an admin; those scored as spam are kept for review only. Limited to 5 comments per IP address per hour.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param comment body service.CommentCreateRequest true "Comment"
+// @Success 201 {object} models.Comment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /projects/{id}/comments [post]
+func (h *Handlers) CreateProjectComment(c *gin.Context) {
+	if target, ok := projectCommentTarget(c); ok {
+		h.createComment(c, target)
+	}
+}
+
+// CreatePostComment submits a comment on a blog post
+// @Summary Comment on post
+// @Description Submits a comment on a published post. Comments appear once approved by an admin; those scored as spam are kept for review only. Limited to 5 comments per IP address per hour.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Param comment body service.CommentCreateRequest true "Comment"
+// @Success 201 {object} models.Comment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /posts/{slug}/comments [post]
+func (h *Handlers) CreatePostComment(c *gin.Context) {
+	h.createComment(c, postCommentTarget(c))
+}
+
+func (h *Handlers) createComment(c *gin.Context, target service.CommentTarget) {
+	var req service.CommentCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Add client information
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	comment, err := h.commentService.CreateComment(c.Request.Context(), target, &req)
+	if err != nil {
+		if respondCommentTargetError(c, err) {
+			return
+		}
+		if err.Error() == "rate limit exceeded" {
+			respondError(c, http.StatusTooManyRequests, "Too many comments, try again later")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create comment")
+		return
+	}
+
+	respond(c, http.StatusCreated, comment)
+}
+
+// respondCommentTargetError reports a missing project or post and whether
+// it did
+func respondCommentTargetError(c *gin.Context, err error) bool {
+	switch err.Error() {
+	case "project not found":
+		respondError(c, http.StatusNotFound, "Project not found")
+	case "post not found":
+		respondError(c, http.StatusNotFound, "Post not found")
+	default:
+		return false
+	}
+	return true
+}
+
+// GetCommentQueue returns comments awaiting moderation (admin only)
+// @Summary Get comment moderation queue
+// @Description Returns the comments with a status, newest first; pending comments by default (admin only)
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending, approved, rejected or spam" default(pending)
+// @Success 200 {array} models.Comment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/comments [get]
+func (h *Handlers) GetCommentQueue(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", "pending", "approved", "rejected", "spam":
+	default:
+		respondError(c, http.StatusBadRequest, "Status must be pending, approved, rejected or spam")
+		return
+	}
+
+	comments, err := h.commentService.GetModerationQueue(c.Request.Context(), status)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get comments")
+		return
+	}
+	respondList(c, comments)
+}
+
+// ApproveComment publishes a comment
+// @Summary Approve comment
+// @Description Approves a comment so it appears on its project or post (admin only)
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Comment ID"
+// @Success 200 {object} models.Comment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/comments/{id}/approve [put]
+func (h *Handlers) ApproveComment(c *gin.Context) {
+	h.moderateComment(c, true)
+}
+
+// RejectComment hides a comment
+// @Summary Reject comment
+// @Description Rejects a comment so it is not shown, or no longer shown, on its project or post (admin only)
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Comment ID"
+// @Success 200 {object} models.Comment
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/comments/{id}/reject [put]
+func (h *Handlers) RejectComment(c *gin.Context) {
+	h.moderateComment(c, false)
+}
+
+func (h *Handlers) moderateComment(c *gin.Context, approved bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	comment, err := h.commentService.ModerateComment(c.Request.Context(), uint(id), approved)
+	if err != nil {
+		if err.Error() == "comment not found" {
+			respondError(c, http.StatusNotFound, "Comment not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to moderate comment")
+		return
+	}
+
+	respond(c, http.StatusOK, comment)
+}
+
+// DeleteComment deletes a comment
+// @Summary Delete comment
+// @Description Deletes a comment (admin only)
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Comment ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/comments/{id} [delete]
+func (h *Handlers) DeleteComment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	err = h.commentService.DeleteComment(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "comment not found" {
+			respondError(c, http.StatusNotFound, "Comment not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete comment")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCompanies returns all companies
+// @Summary Get companies
+// @Description Returns the employers and clients that experiences and testimonials reference, ordered by name
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Company
+// @Router /companies [get]
+func (h *Handlers) GetCompanies(c *gin.Context) {
+	companies, err := h.companyService.GetCompanies(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get companies")
+		return
+	}
+	respondList(c, companies)
+}
+
+// GetCompany returns a company with its roles and testimonials
+// @Summary Get company
+// @Description Returns a company with the published experiences held there, most recent first, and its approved testimonials; admins may add include_drafts=true
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param include_drafts query bool false "Include unpublished experiences and unapproved testimonials (admin only)"
+// @Success 200 {object} repository.CompanyHistory
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /companies/{id} [get]
+func (h *Handlers) GetCompany(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid company ID")
+		return
+	}
+	drafts, ok := includeDrafts(c)
+	if !ok {
+		return
+	}
+
+	history, err := h.companyService.GetCompanyHistory(c.Request.Context(), uint(id), drafts)
+	if err != nil {
+		if err.Error() == "company not found" {
+			respondError(c, http.StatusNotFound, "Company not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get company")
+		return
+	}
+	if !isAdmin(c) {
+		history.Experiences = withoutAttribution(history.Experiences, clearExperience)
+	}
+	respond(c, http.StatusOK, history)
+}
+
+// CreateCompany creates a new company
+// @Summary Create company
+// @Description Creates a new company; names must be unique regardless of case (admin only)
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param company body service.CompanyCreateRequest true "Company data"
+// @Success 201 {object} models.Company
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/companies [post]
+func (h *Handlers) CreateCompany(c *gin.Context) {
+	var req service.CompanyCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	company, err := h.companyService.CreateCompany(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "company already exists" {
+			respondError(c, http.StatusConflict, "Company already exists")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create company")
+		return
+	}
+
+	respond(c, http.StatusCreated, company)
+}
+
+// UpdateCompany updates an existing company
+// @Summary Update company
+// @Description Updates an existing company; a new name is carried over to its experiences and testimonials (admin only)
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Company ID"
+// @Param company body service.CompanyUpdateRequest true "Company data"
+// @Success 200 {object} models.Company
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/companies/{id} [put]
+func (h *Handlers) UpdateCompany(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid company ID")
+		return
+	}
+
+	var req service.CompanyUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	company, err := h.companyService.UpdateCompany(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "company not found":
+			respondError(c, http.StatusNotFound, "Company not found")
+		case "company already exists":
+			respondError(c, http.StatusConflict, "Company already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update company")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, company)
+}
+
+// DeleteCompany deletes a company
+// @Summary Delete company
+// @Description Deletes a company; its experiences and testimonials are unlinked and keep its name (admin only)
+// @Tags companies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Company ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/companies/{id} [delete]
+func (h *Handlers) DeleteCompany(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid company ID")
+		return
+	}
+
+	err = h.companyService.DeleteCompany(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "company not found" {
+			respondError(c, http.StatusNotFound, "Company not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete company")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
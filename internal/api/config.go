@@ -0,0 +1,21 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetConfig returns the effective configuration (admin only)
+// @Summary Get configuration
+// @Description Returns the configuration in effect, including runtime settings reloaded from the config file, with secrets redacted (admin only)
+// @Tags config
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} config.Snapshot
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/config [get]
+func (h *Handlers) GetConfig(c *gin.Context) {
+	respond(c, http.StatusOK, h.settings.Snapshot())
+}
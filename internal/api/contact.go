@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReplyToContact emails a reply to a contact submitter (admin only)
+// @Summary Reply to contact
+// @Description Emails a reply to the submitter, stores it in the contact's thread and sets the status to replied (admin only)
+// @Tags contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Contact ID"
+// @Param reply body service.ContactReplyRequest true "Reply"
+// @Success 201 {object} models.ContactMessage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /admin/contacts/{id}/reply [post]
+func (h *Handlers) ReplyToContact(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid contact ID")
+		return
+	}
+
+	var req service.ContactReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	message, err := h.contactService.ReplyToContact(c.Request.Context(), uint(id), currentUserID(c), &req)
+	if err != nil {
+		switch err.Error() {
+		case "contact not found":
+			respondError(c, http.StatusNotFound, "Contact not found")
+		case "failed to send reply":
+			respondError(c, http.StatusBadGateway, "Failed to send reply email")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to reply to contact")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, message)
+}
+
+// GetContactMessages returns the replies sent to a contact (admin only)
+// @Summary Get contact replies
+// @Description Returns the replies sent to a contact submitter, oldest first (admin only)
+// @Tags contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Contact ID"
+// @Success 200 {array} models.ContactMessage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/contacts/{id}/messages [get]
+func (h *Handlers) GetContactMessages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid contact ID")
+		return
+	}
+
+	messages, err := h.contactService.GetMessages(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "contact not found" {
+			respondError(c, http.StatusNotFound, "Contact not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get contact replies")
+		return
+	}
+	respondList(c, messages)
+}
+
+// CreateContactNote adds an internal note to a contact (admin only)
+// @Summary Add contact note
+// @Description Records an internal follow-up note on a contact submission, attributed to the current admin (admin only)
+// @Tags contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Contact ID"
+// @Param note body service.ContactNoteRequest true "Note"
+// @Success 201 {object} models.ContactNote
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/contacts/{id}/notes [post]
+func (h *Handlers) CreateContactNote(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid contact ID")
+		return
+	}
+
+	var req service.ContactNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	note, err := h.contactService.AddNote(c.Request.Context(), uint(id), currentUserID(c), c.GetString("username"), &req)
+	if err != nil {
+		if err.Error() == "contact not found" {
+			respondError(c, http.StatusNotFound, "Contact not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to add contact note")
+		return
+	}
+
+	respond(c, http.StatusCreated, note)
+}
+
+// GetContactNotes returns the internal notes on a contact (admin only)
+// @Summary Get contact notes
+// @Description Returns the internal notes on a contact submission, oldest first (admin only)
+// @Tags contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Contact ID"
+// @Success 200 {array} models.ContactNote
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/contacts/{id}/notes [get]
+func (h *Handlers) GetContactNotes(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid contact ID")
+		return
+	}
+
+	notes, err := h.contactService.GetNotes(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "contact not found" {
+			respondError(c, http.StatusNotFound, "Contact not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get contact notes")
+		return
+	}
+	respondList(c, notes)
+}
+
+// ExportContacts streams contact submissions as a file (admin only)
+// @Summary Export contacts
+// @Description Streams contact submissions as CSV, oldest first, using the same status filter as the list endpoint (admin only)
+// @Tags contact
+// @Produce text/csv
+// @Security BearerAuth
+// @Param format query string false "Export format (csv)"
+// @Param status query string false "Filter by status (new, read, replied, spam)"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/contacts/export [get]
+func (h *Handlers) ExportContacts(c *gin.Context) {
+	if format := c.DefaultQuery("format", "csv"); format != "csv" {
+		respondError(c, http.StatusBadRequest, "Unsupported export format")
+		return
+	}
+
+	filename := "contacts-" + time.Now().UTC().Format("20060102") + ".csv"
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	// The header has been sent by now, so a failure can only cut the file short
+	if err := h.contactService.ExportContactsCSV(c.Request.Context(), c.Writer, c.Query("status")); err != nil {
+		log.Printf("Warning: contact export failed: %v", err)
+	}
+}
+
+// MarkContactSpam moves a contact submission to spam (admin only)
+// @Summary Mark contact as spam
+// @Description Sets the status to spam. With AKISMET_API_KEY set the submission is also reported to Akismet in the background so that similar messages are caught (admin only)
+// @Tags contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Contact ID"
+// @Success 200 {object} models.Contact
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/contacts/{id}/spam [post]
+func (h *Handlers) MarkContactSpam(c *gin.Context) {
+	h.markContact(c, h.contactService.MarkContactSpam)
+}
+
+// MarkContactHam marks a contact submission as not spam (admin only)
+// @Summary Mark contact as not spam
+// @Description Returns a submission stored as spam to the inbox with status new; other statuses are kept. With AKISMET_API_KEY set the submission is also reported to Akismet in the background as a false positive (admin only)
+// @Tags contact
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Contact ID"
+// @Success 200 {object} models.Contact
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/contacts/{id}/ham [post]
+func (h *Handlers) MarkContactHam(c *gin.Context) {
+	h.markContact(c, h.contactService.MarkContactHam)
+}
+
+func (h *Handlers) markContact(c *gin.Context, mark func(ctx context.Context, id uint) (*models.Contact, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid contact ID")
+		return
+	}
+
+	contact, err := mark(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "contact not found" {
+			respondError(c, http.StatusNotFound, "Contact not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update contact status")
+		return
+	}
+
+	respond(c, http.StatusOK, contact)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const streamHeartbeatInterval = 25 * time.Second
+
+// StreamContacts streams newly created contacts as Server-Sent Events (admin only)
+// @Summary Stream new contact submissions
+// @Description Pushes each new contact submission as a "contact" Server-Sent Event; a "ping" event is sent periodically to keep the connection alive (admin only)
+// @Tags contact
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} models.Contact
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/contacts/stream [get]
+func (h *Handlers) StreamContacts(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	contacts, err := h.contactStreamService.Subscribe(ctx)
+	if err != nil {
+		respondError(c, http.StatusServiceUnavailable, "Contact stream unavailable")
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering (nginx)
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.SSEvent("ready", gin.H{"connected_at": time.Now()})
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case contact, ok := <-contacts:
+			if !ok {
+				return false
+			}
+			c.SSEvent("contact", json.RawMessage(contact))
+			return true
+		case t := <-heartbeat.C:
+			c.SSEvent("ping", t.Unix())
+			return true
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDashboard returns the admin overview
+// @Summary Get admin dashboard
+// @Description Returns content counts and last-updated times, contact totals, recent activity and the cache hit rate in one call (admin only)
+// @Tags dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.Dashboard
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/dashboard [get]
+func (h *Handlers) GetDashboard(c *gin.Context) {
+	dashboard, err := h.dashboardService.GetDashboard(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get dashboard")
+		return
+	}
+	respond(c, http.StatusOK, dashboard)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEducation returns all education entries
+// @Summary Get education
+// @Description Returns education entries in display order
+// @Tags education
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Education
+// @Router /education [get]
+func (h *Handlers) GetEducation(c *gin.Context) {
+	education, err := h.educationService.GetEducation(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get education")
+		return
+	}
+	respondList(c, education)
+}
+
+// CreateEducation creates a new education entry
+// @Summary Create education
+// @Description Creates a new education entry (admin only)
+// @Tags education
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param education body service.EducationCreateRequest true "Education data"
+// @Success 201 {object} models.Education
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/education [post]
+func (h *Handlers) CreateEducation(c *gin.Context) {
+	var req service.EducationCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	education, err := h.educationService.CreateEducation(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to create education")
+		return
+	}
+
+	respond(c, http.StatusCreated, education)
+}
+
+// UpdateEducation updates an existing education entry
+// @Summary Update education
+// @Description Updates an existing education entry (admin only)
+// @Tags education
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Education ID"
+// @Param education body service.EducationUpdateRequest true "Education data"
+// @Success 200 {object} models.Education
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/education/{id} [put]
+func (h *Handlers) UpdateEducation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid education ID")
+		return
+	}
+
+	var req service.EducationUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	education, err := h.educationService.UpdateEducation(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "education not found" {
+			respondError(c, http.StatusNotFound, "Education not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update education")
+		return
+	}
+
+	respond(c, http.StatusOK, education)
+}
+
+// DeleteEducation deletes a education entry
+// @Summary Delete education
+// @Description Deletes a education entry (admin only)
+// @Tags education
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Education ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/education/{id} [delete]
+func (h *Handlers) DeleteEducation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid education ID")
+		return
+	}
+
+	err = h.educationService.DeleteEducation(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "education not found" {
+			respondError(c, http.StatusNotFound, "Education not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete education")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
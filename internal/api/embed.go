@@ -0,0 +1,355 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	embedCacheControl   = "public, max-age=300"
+	defaultEmbedWidth   = 600
+	defaultEmbedHeight  = 400
+	embedTokenHeader    = "X-Embed-Token"
+	embedProjectsWidget = "projects"
+	embedSkillsWidget   = "skills"
+)
+
+var embedTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif;margin:0;padding:12px;color:#24292f}
+ul{list-style:none;margin:0;padding:0}
+li{padding:8px 0;border-bottom:1px solid #d0d7de}
+.name{font-weight:600}
+.meta{color:#57606a;font-size:0.9em}
+</style>
+</head>
+<body>
+<ul>
+{{range .Items}}<li><div class="name">{{if .Link}}<a href="{{.Link}}" target="_blank" rel="noopener">{{.Name}}</a>{{else}}{{.Name}}{{end}}</div>{{if .Meta}}<div class="meta">{{.Meta}}</div>{{end}}</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+type embedItem struct {
+	Name string
+	Link string
+	Meta string
+}
+
+type embedPage struct {
+	Title string
+	Items []embedItem
+}
+
+// OEmbedResponse is an oEmbed 1.0 "rich" response
+type OEmbedResponse struct {
+	Version      string `json:"version"`
+	Type         string `json:"type"`
+	Title        string `json:"title"`
+	ProviderName string `json:"provider_name"`
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// EmbedProjects returns the projects widget
+// @Summary Embeddable projects widget
+// @Description Returns projects for embedding on third-party sites as JSON, iframe HTML or oEmbed. Requires an embed token bound to the embedding origin.
+// @Tags embed
+// @Produce json,html
+// @Param token query string true "Embed token (or X-Embed-Token header)"
+// @Param format query string false "json (default), html or oembed"
+// @Param featured query bool false "Filter by featured status"
+// @Success 200 {array} models.Project
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /embed/projects [get]
+func (h *Handlers) EmbedProjects(c *gin.Context) {
+	if !h.authorizeEmbed(c) {
+		return
+	}
+
+	var featuredFilter *bool
+	if featured, err := strconv.ParseBool(c.Query("featured")); err == nil {
+		featuredFilter = &featured
+	}
+
+	projects, err := h.projectService.GetProjects(c.Request.Context(), featuredFilter, nil, "", false, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get projects"})
+		return
+	}
+
+	page := embedPage{Title: "Projects"}
+	for _, project := range projects {
+		link := project.LiveURL
+		if link == "" {
+			link = project.GitHubURL
+		}
+		page.Items = append(page.Items, embedItem{
+			Name: project.Name,
+			Link: link,
+			Meta: project.Description,
+		})
+	}
+
+	h.writeEmbed(c, embedProjectsWidget, withoutAttribution(projects, clearProject), page)
+}
+
+// EmbedSkills returns the skills widget
+// @Summary Embeddable skills widget
+// @Description Returns skills for embedding on third-party sites as JSON, iframe HTML or oEmbed. Requires an embed token bound to the embedding origin.
+// @Tags embed
+// @Produce json,html
+// @Param token query string true "Embed token (or X-Embed-Token header)"
+// @Param format query string false "json (default), html or oembed"
+// @Success 200 {array} models.Skill
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /embed/skills [get]
+func (h *Handlers) EmbedSkills(c *gin.Context) {
+	if !h.authorizeEmbed(c) {
+		return
+	}
+
+	skills, err := h.skillService.GetSkills(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get skills"})
+		return
+	}
+
+	page := embedPage{Title: "Skills"}
+	for _, skill := range skills {
+		page.Items = append(page.Items, embedItem{
+			Name: skill.Name,
+			Meta: fmt.Sprintf("%s · %d/10", skill.Category, skill.Level),
+		})
+	}
+
+	h.writeEmbed(c, embedSkillsWidget, withoutAttribution(skills, clearSkill), page)
+}
+
+// authorizeEmbed validates the embed token against the requesting origin and
+// sets the CORS headers for it. It aborts the request when access is denied.
+func (h *Handlers) authorizeEmbed(c *gin.Context) bool {
+	token := c.Query("token")
+	if token == "" {
+		token = c.GetHeader(embedTokenHeader)
+	}
+
+	// Browsers send Origin on fetch requests and Referer when loading iframes
+	origin := c.GetHeader("Origin")
+	if origin == "" {
+		origin = c.GetHeader("Referer")
+	}
+
+	allowedOrigin, err := h.embedService.Authorize(c.Request.Context(), token, origin)
+	if err != nil {
+		switch err.Error() {
+		case "embed token required", "embed token not found":
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid embed token"})
+		case "origin not allowed":
+			c.JSON(http.StatusForbidden, gin.H{"error": "Origin not allowed for this embed token"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify embed token"})
+		}
+		c.Abort()
+		return false
+	}
+
+	c.Header("Access-Control-Allow-Origin", allowedOrigin)
+	// Embeds never carry credentials, whatever the CORS middleware allowed
+	c.Writer.Header().Del("Access-Control-Allow-Credentials")
+	c.Header("Vary", "Origin")
+	return true
+}
+
+// writeEmbed renders the widget in the requested format with caching headers
+func (h *Handlers) writeEmbed(c *gin.Context, widget string, data interface{}, page embedPage) {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		contentType = "application/json; charset=utf-8"
+		body, err = json.Marshal(data)
+	case "html":
+		contentType = "text/html; charset=utf-8"
+		var buf bytes.Buffer
+		err = embedTemplate.Execute(&buf, page)
+		body = buf.Bytes()
+
+		// The widget is meant to be framed, so relax the global frame policy
+		c.Header("X-Frame-Options", "")
+		if origin := c.Writer.Header().Get("Access-Control-Allow-Origin"); origin != "" && origin != "*" {
+			c.Header("Content-Security-Policy", "frame-ancestors "+origin)
+		}
+	case "oembed":
+		contentType = "application/json; charset=utf-8"
+		body, err = json.Marshal(oEmbed(c, widget, page.Title))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported format"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render widget"})
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("Cache-Control", embedCacheControl)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// oEmbed builds an oEmbed document pointing at the HTML rendering of the widget
+func oEmbed(c *gin.Context, widget, title string) OEmbedResponse {
+	width := defaultEmbedWidth
+	if maxWidth, err := strconv.Atoi(c.Query("maxwidth")); err == nil && maxWidth > 0 && maxWidth < width {
+		width = maxWidth
+	}
+	height := defaultEmbedHeight
+	if maxHeight, err := strconv.Atoi(c.Query("maxheight")); err == nil && maxHeight > 0 && maxHeight < height {
+		height = maxHeight
+	}
+
+	query := url.Values{}
+	query.Set("format", "html")
+	query.Set("token", c.Query("token"))
+	if featured := c.Query("featured"); featured != "" {
+		query.Set("featured", featured)
+	}
+	src := requestBaseURL(c) + "/embed/" + widget + "?" + query.Encode()
+
+	return OEmbedResponse{
+		Version:      "1.0",
+		Type:         "rich",
+		Title:        title,
+		ProviderName: "Portfolio",
+		HTML: fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" frameborder="0" loading="lazy"></iframe>`,
+			template.HTMLEscapeString(src), width, height),
+		Width:  width,
+		Height: height,
+	}
+}
+
+// requestBaseURL reconstructs the public base URL of the API from the request
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// GetEmbedTokens returns all embed tokens (admin only)
+// @Summary Get embed tokens
+// @Description Returns all embed tokens (admin only)
+// @Tags embed
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.EmbedToken
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/embed-tokens [get]
+func (h *Handlers) GetEmbedTokens(c *gin.Context) {
+	tokens, err := h.embedService.GetTokens(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get embed tokens")
+		return
+	}
+	respondList(c, tokens)
+}
+
+// CreateEmbedToken issues a new embed token (admin only)
+// @Summary Create embed token
+// @Description Issues a new embed token bound to a set of allowed origins (admin only)
+// @Tags embed
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token body service.EmbedTokenCreateRequest true "Embed token data"
+// @Success 201 {object} models.EmbedToken
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/embed-tokens [post]
+func (h *Handlers) CreateEmbedToken(c *gin.Context) {
+	var req service.EmbedTokenCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	token, err := h.embedService.CreateToken(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "invalid origin" {
+			respondError(c, http.StatusBadRequest, "Allowed origins must be absolute URLs such as https://example.com")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create embed token")
+		return
+	}
+
+	respond(c, http.StatusCreated, token)
+}
+
+// DeleteEmbedToken revokes an embed token (admin only)
+// @Summary Delete embed token
+// @Description Revokes an embed token (admin only)
+// @Tags embed
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Embed token ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/embed-tokens/{id} [delete]
+func (h *Handlers) DeleteEmbedToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid embed token ID")
+		return
+	}
+
+	err = h.embedService.DeleteToken(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "embed token not found" {
+			respondError(c, http.StatusNotFound, "Embed token not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete embed token")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetGitHubActivity returns the owner's recent GitHub activity
+// @Summary Get GitHub activity
+// @Description Returns the contribution calendar and recent public events of the configured GitHub user, cached server-side for an hour
+// @Tags activity
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.GitHubActivity
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /activity/github [get]
+func (h *Handlers) GetGitHubActivity(c *gin.Context) {
+	activity, err := h.githubService.GetActivity(c.Request.Context())
+	if err != nil {
+		switch err.Error() {
+		case "github activity not configured":
+			respondError(c, http.StatusNotFound, "GitHub activity is not configured")
+		case "github unavailable":
+			respondError(c, http.StatusBadGateway, "GitHub is unavailable, please try again later")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to get GitHub activity")
+		}
+		return
+	}
+	respond(c, http.StatusOK, activity)
+}
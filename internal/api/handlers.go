@@ -2,39 +2,197 @@ package api
 
 import (
 	"net/http"
+	"stackwhiz-portfolio-backend/internal/config"
 	"stackwhiz-portfolio-backend/internal/service"
+	"stackwhiz-portfolio-backend/internal/slo"
+	"stackwhiz-portfolio-backend/internal/ws"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Handlers struct {
-	profileService    *service.ProfileService
-	experienceService *service.ExperienceService
-	skillService      *service.SkillService
-	projectService    *service.ProjectService
-	contactService    *service.ContactService
-	authService       *service.AuthService
+	profileService       ProfileService
+	experienceService    ExperienceService
+	skillService         SkillService
+	projectService       ProjectService
+	contactService       ContactService
+	authService          *service.AuthService
+	slotService          *service.SlotService
+	embedService         *service.EmbedService
+	sloTracker           *slo.Tracker
+	webhookService       *service.WebhookService
+	contactStreamService *service.ContactStreamService
+	hub                  *ws.Hub
+	postService          *service.PostService
+	testimonialService   *service.TestimonialService
+	commentService       *service.CommentService
+	educationService     *service.EducationService
+	certificationService *service.CertificationService
+	publicationService   *service.PublicationService
+	awardService         *service.AwardService
+	portfolioService     *service.PortfolioService
+	offeringService      *service.OfferingService
+	talkService          *service.TalkService
+	mediaService         *service.MediaService
+	resumeService        *service.ResumeService
+	categoryService      *service.CategoryService
+	companyService       *service.CompanyService
+	tagService           *service.TagService
+	dashboardService     *service.DashboardService
+	githubService        *service.GitHubService
+	transferService      *service.TransferService
+	backupService        *service.BackupService
+	tenantService        *service.TenantService
+	ipRuleService        *service.IPRuleService
+	jobService           *service.JobService
+	shortLinkService     *service.ShortLinkService
+	searchService        *service.SearchService
+	embeddingService     *service.EmbeddingService
+	assistantService     *service.AssistantService
+	chatService          *service.ChatService
+	newsletterService    *service.NewsletterService
+	settings             *config.Live
 }
 
 func NewHandlers(
-	profileService *service.ProfileService,
-	experienceService *service.ExperienceService,
-	skillService *service.SkillService,
-	projectService *service.ProjectService,
-	contactService *service.ContactService,
+	profileService ProfileService,
+	experienceService ExperienceService,
+	skillService SkillService,
+	projectService ProjectService,
+	contactService ContactService,
 	authService *service.AuthService,
+	slotService *service.SlotService,
+	embedService *service.EmbedService,
+	sloTracker *slo.Tracker,
+	webhookService *service.WebhookService,
+	contactStreamService *service.ContactStreamService,
+	hub *ws.Hub,
+	postService *service.PostService,
+	testimonialService *service.TestimonialService,
+	commentService *service.CommentService,
+	educationService *service.EducationService,
+	certificationService *service.CertificationService,
+	publicationService *service.PublicationService,
+	awardService *service.AwardService,
+	portfolioService *service.PortfolioService,
+	offeringService *service.OfferingService,
+	talkService *service.TalkService,
+	mediaService *service.MediaService,
+	resumeService *service.ResumeService,
+	categoryService *service.CategoryService,
+	companyService *service.CompanyService,
+	tagService *service.TagService,
+	dashboardService *service.DashboardService,
+	githubService *service.GitHubService,
+	transferService *service.TransferService,
+	backupService *service.BackupService,
+	tenantService *service.TenantService,
+	ipRuleService *service.IPRuleService,
+	jobService *service.JobService,
+	shortLinkService *service.ShortLinkService,
+	searchService *service.SearchService,
+	embeddingService *service.EmbeddingService,
+	assistantService *service.AssistantService,
+	chatService *service.ChatService,
+	newsletterService *service.NewsletterService,
+	settings *config.Live,
 ) *Handlers {
 	return &Handlers{
-		profileService:    profileService,
-		experienceService: experienceService,
-		skillService:      skillService,
-		projectService:    projectService,
-		contactService:    contactService,
-		authService:       authService,
+		profileService:       profileService,
+		experienceService:    experienceService,
+		skillService:         skillService,
+		projectService:       projectService,
+		contactService:       contactService,
+		authService:          authService,
+		slotService:          slotService,
+		embedService:         embedService,
+		sloTracker:           sloTracker,
+		webhookService:       webhookService,
+		contactStreamService: contactStreamService,
+		hub:                  hub,
+		postService:          postService,
+		testimonialService:   testimonialService,
+		commentService:       commentService,
+		educationService:     educationService,
+		certificationService: certificationService,
+		publicationService:   publicationService,
+		awardService:         awardService,
+		portfolioService:     portfolioService,
+		offeringService:      offeringService,
+		talkService:          talkService,
+		mediaService:         mediaService,
+		resumeService:        resumeService,
+		categoryService:      categoryService,
+		companyService:       companyService,
+		tagService:           tagService,
+		dashboardService:     dashboardService,
+		githubService:        githubService,
+		transferService:      transferService,
+		backupService:        backupService,
+		tenantService:        tenantService,
+		ipRuleService:        ipRuleService,
+		jobService:           jobService,
+		shortLinkService:     shortLinkService,
+		searchService:        searchService,
+		embeddingService:     embeddingService,
+		assistantService:     assistantService,
+		chatService:          chatService,
+		newsletterService:    newsletterService,
+		settings:             settings,
 	}
 }
 
+// includeDrafts reports whether an admin asked for unpublished content with
+// ?include_drafts=true. Anonymous requests for drafts are rejected and ok is
+// false.
+func includeDrafts(c *gin.Context) (include bool, ok bool) {
+	if c.Query("include_drafts") != "true" {
+		return false, true
+	}
+	if c.GetString("user_role") != "admin" {
+		respondError(c, http.StatusUnauthorized, "Authorization required to include drafts")
+		return false, false
+	}
+	return true, true
+}
+
+// includeArchived reports whether an admin asked for archived projects with
+// ?include_archived=true, rejecting anonymous requests like includeDrafts
+func includeArchived(c *gin.Context) (include bool, ok bool) {
+	if c.Query("include_archived") != "true" {
+		return false, true
+	}
+	if c.GetString("user_role") != "admin" {
+		respondError(c, http.StatusUnauthorized, "Authorization required to include archived projects")
+		return false, false
+	}
+	return true, true
+}
+
+// ifMatchVersion fills version from an If-Match header carrying the version
+// the client last read, e.g. "3", unless the body already gave one. It
+// writes a 400 response and returns false when the header is not a version.
+func ifMatchVersion(c *gin.Context, version *uint) bool {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" || *version != 0 {
+		return true
+	}
+	v, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(header, "W/"), `"`), 10, 32)
+	if err != nil || v == 0 {
+		respondError(c, http.StatusBadRequest, "If-Match must carry the version being edited")
+		return false
+	}
+	*version = uint(v)
+	return true
+}
+
+// currentUserID returns the ID of the authenticated admin
+func currentUserID(c *gin.Context) uint {
+	return uint(c.GetInt("user_id"))
+}
+
 // HealthCheck returns the health status of the API
 // @Summary Health check endpoint
 // @Description Returns the health status of the API
@@ -57,15 +215,19 @@ func (h *Handlers) HealthCheck(c *gin.Context) {
 // @Tags profile
 // @Accept json
 // @Produce json
+// @Param render query string false "Set to html to add summary_html, rendered from Markdown to sanitized HTML"
 // @Success 200 {object} models.Profile
 // @Router /profile [get]
 func (h *Handlers) GetProfile(c *gin.Context) {
-	profile, err := h.profileService.GetProfile()
+	profile, err := h.profileService.GetProfile(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get profile"})
+		respondError(c, http.StatusInternalServerError, "Failed to get profile")
 		return
 	}
-	c.JSON(http.StatusOK, profile)
+	if renderHTML(c) {
+		profile = renderProfile(profile)
+	}
+	respond(c, http.StatusOK, profile)
 }
 
 // UpdateProfile updates the main profile information
@@ -76,41 +238,60 @@ func (h *Handlers) GetProfile(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param profile body models.Profile true "Profile data"
+// @Param If-Match header string false "Version the edit is based on, when not sent as version in the body"
 // @Success 200 {object} models.Profile
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Router /admin/profile [put]
 func (h *Handlers) UpdateProfile(c *gin.Context) {
 	var profile service.ProfileUpdateRequest
 	if err := c.ShouldBindJSON(&profile); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+	if !ifMatchVersion(c, &profile.Version) {
 		return
 	}
 
-	updatedProfile, err := h.profileService.UpdateProfile(&profile)
+	updatedProfile, err := h.profileService.UpdateProfile(c.Request.Context(), &profile)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
+		if err.Error() == "version conflict" {
+			respondError(c, http.StatusConflict, "Profile was changed since it was loaded; reload it and try again")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update profile")
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedProfile)
+	respond(c, http.StatusOK, updatedProfile)
 }
 
 // GetExperiences returns all work experiences
 // @Summary Get work experiences
-// @Description Returns all work experiences ordered by start date
+// @Description Returns published work experiences, pinned first, then in curated order and by start date; admins may add include_drafts=true
 // @Tags experiences
 // @Accept json
 // @Produce json
+// @Param include_drafts query bool false "Include unpublished entries (admin only)"
 // @Success 200 {array} models.Experience
+// @Failure 401 {object} map[string]interface{}
 // @Router /experiences [get]
 func (h *Handlers) GetExperiences(c *gin.Context) {
-	experiences, err := h.experienceService.GetExperiences()
+	drafts, ok := includeDrafts(c)
+	if !ok {
+		return
+	}
+
+	experiences, err := h.experienceService.GetExperiences(c.Request.Context(), drafts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get experiences"})
+		respondError(c, http.StatusInternalServerError, "Failed to get experiences")
 		return
 	}
-	c.JSON(http.StatusOK, experiences)
+	if !isAdmin(c) {
+		experiences = withoutAttribution(experiences, clearExperience)
+	}
+	respondList(c, experiences)
 }
 
 // CreateExperience creates a new work experience
@@ -128,17 +309,24 @@ func (h *Handlers) GetExperiences(c *gin.Context) {
 func (h *Handlers) CreateExperience(c *gin.Context) {
 	var req service.ExperienceCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	experience, err := h.experienceService.CreateExperience(&req)
+	experience, err := h.experienceService.CreateExperience(c.Request.Context(), c.GetString("username"), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create experience"})
+		switch err.Error() {
+		case "skill not found":
+			respondError(c, http.StatusBadRequest, "Skill not found")
+		case "company not found":
+			respondError(c, http.StatusBadRequest, "Company not found")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create experience")
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, experience)
+	respond(c, http.StatusCreated, experience)
 }
 
 // UpdateExperience updates an existing work experience
@@ -150,35 +338,79 @@ func (h *Handlers) CreateExperience(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Experience ID"
 // @Param experience body service.ExperienceUpdateRequest true "Experience data"
+// @Param If-Match header string false "Version the edit is based on, when not sent as version in the body"
 // @Success 200 {object} models.Experience
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Router /admin/experiences/{id} [put]
 func (h *Handlers) UpdateExperience(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid experience ID"})
+		respondError(c, http.StatusBadRequest, "Invalid experience ID")
 		return
 	}
 
 	var req service.ExperienceUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+	if !ifMatchVersion(c, &req.Version) {
 		return
 	}
 
-	experience, err := h.experienceService.UpdateExperience(uint(id), &req)
+	experience, err := h.experienceService.UpdateExperience(c.Request.Context(), uint(id), c.GetString("username"), &req)
 	if err != nil {
-		if err.Error() == "experience not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Experience not found"})
+		switch err.Error() {
+		case "experience not found":
+			respondError(c, http.StatusNotFound, "Experience not found")
+		case "skill not found":
+			respondError(c, http.StatusBadRequest, "Skill not found")
+		case "company not found":
+			respondError(c, http.StatusBadRequest, "Company not found")
+		case "version conflict":
+			respondError(c, http.StatusConflict, "Experience was changed since it was loaded; reload it and try again")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update experience")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, experience)
+}
+
+// ReorderExperiences sets the display order of work experiences
+// @Summary Reorder experiences
+// @Description Sets the display order of work experiences from an ordered list of every experience ID; pinned entries still come first (admin only)
+// @Tags experiences
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order body service.ExperienceReorderRequest true "Experience IDs in display order"
+// @Success 200 {array} models.Experience
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/experiences/reorder [put]
+func (h *Handlers) ReorderExperiences(c *gin.Context) {
+	var req service.ExperienceReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	experiences, err := h.experienceService.ReorderExperiences(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "invalid order" {
+			respondError(c, http.StatusBadRequest, "Order must list every experience exactly once")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update experience"})
+		respondError(c, http.StatusInternalServerError, "Failed to reorder experiences")
 		return
 	}
 
-	c.JSON(http.StatusOK, experience)
+	respondList(c, experiences)
 }
 
 // DeleteExperience deletes a work experience
@@ -197,17 +429,17 @@ func (h *Handlers) UpdateExperience(c *gin.Context) {
 func (h *Handlers) DeleteExperience(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid experience ID"})
+		respondError(c, http.StatusBadRequest, "Invalid experience ID")
 		return
 	}
 
-	err = h.experienceService.DeleteExperience(uint(id))
+	err = h.experienceService.DeleteExperience(c.Request.Context(), uint(id))
 	if err != nil {
 		if err.Error() == "experience not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Experience not found"})
+			respondError(c, http.StatusNotFound, "Experience not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete experience"})
+		respondError(c, http.StatusInternalServerError, "Failed to delete experience")
 		return
 	}
 
@@ -216,19 +448,129 @@ func (h *Handlers) DeleteExperience(c *gin.Context) {
 
 // GetSkills returns all skills
 // @Summary Get skills
-// @Description Returns all skills grouped by category
+// @Description Returns all skills in display order, then by category and name, with years of use computed from linked experiences
 // @Tags skills
 // @Accept json
 // @Produce json
 // @Success 200 {array} models.Skill
 // @Router /skills [get]
 func (h *Handlers) GetSkills(c *gin.Context) {
-	skills, err := h.skillService.GetSkills()
+	skills, err := h.skillService.GetSkills(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get skills")
+		return
+	}
+	if !isAdmin(c) {
+		skills = withoutAttribution(skills, clearSkill)
+	}
+	respondList(c, skills)
+}
+
+// GetSkillGroups returns the skills grouped by category
+// @Summary Get skills by category
+// @Description Returns one entry per skill category with its skill count and its skills in display order. Categories are ordered by their first skill
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Success 200 {array} service.SkillGroup
+// @Router /skills/grouped [get]
+func (h *Handlers) GetSkillGroups(c *gin.Context) {
+	groups, err := h.skillService.GetSkillGroups(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get skills")
+		return
+	}
+	if !isAdmin(c) {
+		for i := range groups {
+			groups[i].Skills = withoutAttribution(groups[i].Skills, clearSkill)
+		}
+	}
+	respondList(c, groups)
+}
+
+// GetSkillCategories returns the skill categories in use
+// @Summary Get skill categories
+// @Description Returns the distinct skill categories with the number of skills in each, in the same order as /skills/grouped
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Success 200 {array} service.SkillCategory
+// @Router /skills/categories [get]
+func (h *Handlers) GetSkillCategories(c *gin.Context) {
+	categories, err := h.skillService.GetSkillCategories(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get skill categories")
+		return
+	}
+	respondList(c, categories)
+}
+
+// GetSkillProjects returns the projects demonstrating a skill
+// @Summary Get projects for a skill
+// @Description Returns the published projects linked to a skill
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Success 200 {array} models.Project
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /skills/{id}/projects [get]
+func (h *Handlers) GetSkillProjects(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get skills"})
+		respondError(c, http.StatusBadRequest, "Invalid skill ID")
 		return
 	}
-	c.JSON(http.StatusOK, skills)
+
+	projects, err := h.projectService.GetProjectsBySkill(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "skill not found" {
+			respondError(c, http.StatusNotFound, "Skill not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get projects")
+		return
+	}
+	if !isAdmin(c) {
+		projects = withoutAttribution(projects, clearProject)
+	}
+	respondList(c, projects)
+}
+
+// EndorseSkill records an anonymous endorsement of a skill
+// @Summary Endorse skill
+// @Description Counts one endorsement per visitor and skill; repeats are accepted but not counted. Limited per IP address.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Success 200 {object} service.EndorsementResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /skills/{id}/endorse [post]
+func (h *Handlers) EndorseSkill(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid skill ID")
+		return
+	}
+
+	result, err := h.skillService.EndorseSkill(c.Request.Context(), uint(id), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		switch err.Error() {
+		case "skill not found":
+			respondError(c, http.StatusNotFound, "Skill not found")
+		case "rate limit exceeded":
+			respondError(c, http.StatusTooManyRequests, "Too many endorsements, try again later")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to endorse skill")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, result)
 }
 
 // CreateSkill creates a new skill
@@ -246,17 +588,17 @@ func (h *Handlers) GetSkills(c *gin.Context) {
 func (h *Handlers) CreateSkill(c *gin.Context) {
 	var req service.SkillCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	skill, err := h.skillService.CreateSkill(&req)
+	skill, err := h.skillService.CreateSkill(c.Request.Context(), c.GetString("username"), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create skill"})
+		respondError(c, http.StatusInternalServerError, "Failed to create skill")
 		return
 	}
 
-	c.JSON(http.StatusCreated, skill)
+	respond(c, http.StatusCreated, skill)
 }
 
 // UpdateSkill updates an existing skill
@@ -268,35 +610,186 @@ func (h *Handlers) CreateSkill(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Skill ID"
 // @Param skill body service.SkillUpdateRequest true "Skill data"
+// @Param If-Match header string false "Version the edit is based on, when not sent as version in the body"
 // @Success 200 {object} models.Skill
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Router /admin/skills/{id} [put]
 func (h *Handlers) UpdateSkill(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		respondError(c, http.StatusBadRequest, "Invalid skill ID")
 		return
 	}
 
 	var req service.SkillUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+	if !ifMatchVersion(c, &req.Version) {
 		return
 	}
 
-	skill, err := h.skillService.UpdateSkill(uint(id), &req)
+	skill, err := h.skillService.UpdateSkill(c.Request.Context(), uint(id), c.GetString("username"), &req)
 	if err != nil {
-		if err.Error() == "skill not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Skill not found"})
+		switch err.Error() {
+		case "skill not found":
+			respondError(c, http.StatusNotFound, "Skill not found")
+		case "version conflict":
+			respondError(c, http.StatusConflict, "Skill was changed since it was loaded; reload it and try again")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update skill")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, skill)
+}
+
+// ReorderSkills sets the display order of skills
+// @Summary Reorder skills
+// @Description Sets the display order of skills from an ordered list of every skill ID (admin only)
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order body service.SkillReorderRequest true "Skill IDs in display order"
+// @Success 200 {array} models.Skill
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/skills/reorder [put]
+func (h *Handlers) ReorderSkills(c *gin.Context) {
+	var req service.SkillReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	skills, err := h.skillService.ReorderSkills(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "invalid order" {
+			respondError(c, http.StatusBadRequest, "Order must list every skill exactly once")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update skill"})
+		respondError(c, http.StatusInternalServerError, "Failed to reorder skills")
 		return
 	}
 
-	c.JSON(http.StatusOK, skill)
+	respondList(c, skills)
+}
+
+// RenameSkillCategory renames a skill category
+// @Summary Rename skill category
+// @Description Renames a category on every skill using it, in one transaction. The new name must not be in use; merge categories to combine them (admin only)
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rename body service.SkillCategoryRenameRequest true "Current and new category name"
+// @Success 200 {array} service.SkillCategory
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/skills/categories/rename [post]
+func (h *Handlers) RenameSkillCategory(c *gin.Context) {
+	var req service.SkillCategoryRenameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	categories, err := h.skillService.RenameSkillCategory(c.Request.Context(), c.GetString("username"), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid category":
+			respondError(c, http.StatusBadRequest, "Category names must be non-empty and differ")
+		case "category not found":
+			respondError(c, http.StatusNotFound, "Category not found")
+		case "category already exists":
+			respondError(c, http.StatusConflict, "Category already exists; merge the categories instead")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to rename category")
+		}
+		return
+	}
+
+	respondList(c, categories)
+}
+
+// MergeSkillCategories merges skill categories into one
+// @Summary Merge skill categories
+// @Description Moves the skills of every listed category into the target category, which may be new or existing, in one transaction (admin only)
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param merge body service.SkillCategoryMergeRequest true "Categories to merge and the category to merge into"
+// @Success 200 {array} service.SkillCategory
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/skills/categories/merge [post]
+func (h *Handlers) MergeSkillCategories(c *gin.Context) {
+	var req service.SkillCategoryMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	categories, err := h.skillService.MergeSkillCategories(c.Request.Context(), c.GetString("username"), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid category":
+			respondError(c, http.StatusBadRequest, "List at least one category other than the target")
+		case "category not found":
+			respondError(c, http.StatusNotFound, "None of the categories is in use")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to merge categories")
+		}
+		return
+	}
+
+	respondList(c, categories)
+}
+
+// MergeSkills merges duplicate skills into one
+// @Summary Merge skills
+// @Description Folds duplicate skills, such as Postgres and PostgreSQL, into the target skill in one transaction: projects and experiences linked to a source are linked to the target, endorsements are added up and the sources are deleted (admin only)
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param merge body service.SkillMergeRequest true "Skills to merge away and the skill to keep"
+// @Success 200 {object} models.Skill
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/skills/merge [post]
+func (h *Handlers) MergeSkills(c *gin.Context) {
+	var req service.SkillMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	skill, err := h.skillService.MergeSkills(c.Request.Context(), c.GetString("username"), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid merge":
+			respondError(c, http.StatusBadRequest, "The target skill cannot be one of the sources")
+		case "skill not found":
+			respondError(c, http.StatusNotFound, "Skill not found")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to merge skills")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, skill)
 }
 
 // DeleteSkill deletes a skill
@@ -315,17 +808,17 @@ func (h *Handlers) UpdateSkill(c *gin.Context) {
 func (h *Handlers) DeleteSkill(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skill ID"})
+		respondError(c, http.StatusBadRequest, "Invalid skill ID")
 		return
 	}
 
-	err = h.skillService.DeleteSkill(uint(id))
+	err = h.skillService.DeleteSkill(c.Request.Context(), uint(id))
 	if err != nil {
 		if err.Error() == "skill not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Skill not found"})
+			respondError(c, http.StatusNotFound, "Skill not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete skill"})
+		respondError(c, http.StatusInternalServerError, "Failed to delete skill")
 		return
 	}
 
@@ -334,14 +827,32 @@ func (h *Handlers) DeleteSkill(c *gin.Context) {
 
 // GetProjects returns all projects
 // @Summary Get projects
-// @Description Returns all projects, optionally filtered by featured status
+// @Description Returns published projects that are not archived, optionally filtered by featured status; admins may add include_drafts=true and include_archived=true
 // @Tags projects
 // @Accept json
 // @Produce json
 // @Param featured query bool false "Filter by featured status"
+// @Param category_id query int false "Filter by category ID"
+// @Param tag query string false "Filter by tag slug"
+// @Param include_drafts query bool false "Include unpublished projects (admin only)"
+// @Param include_archived query bool false "Include archived projects (admin only)"
+// @Param render query string false "Set to html to add long_description_html, rendered from Markdown to sanitized HTML"
+// @Param order query string false "created_at (newest first, the default) or featured_rank (ranked featured projects first)"
+// @Param limit query int false "Return at most this many projects (1-100)"
 // @Success 200 {array} models.Project
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
 // @Router /projects [get]
 func (h *Handlers) GetProjects(c *gin.Context) {
+	drafts, ok := includeDrafts(c)
+	if !ok {
+		return
+	}
+	archived, ok := includeArchived(c)
+	if !ok {
+		return
+	}
+
 	featured := c.Query("featured")
 	var featuredFilter *bool
 	if featured != "" {
@@ -352,14 +863,131 @@ func (h *Handlers) GetProjects(c *gin.Context) {
 		}
 	}
 
-	projects, err := h.projectService.GetProjects(featuredFilter)
+	var categoryFilter *uint
+	if categoryID := c.Query("category_id"); categoryID != "" {
+		id, err := strconv.ParseUint(categoryID, 10, 32)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid category ID")
+			return
+		}
+		categoryFilter = &[]uint{uint(id)}[0]
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPerPage {
+			respondError(c, http.StatusBadRequest, "Limit must be between 1 and 100")
+			return
+		}
+		limit = n
+	}
+
+	projects, err := h.projectService.GetProjects(c.Request.Context(), featuredFilter, categoryFilter, c.Query("tag"), drafts, archived)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get projects"})
+		respondError(c, http.StatusInternalServerError, "Failed to get projects")
 		return
 	}
-	c.JSON(http.StatusOK, projects)
+	projects, err = service.OrderProjects(projects, c.Query("order"), limit)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Order must be created_at or featured_rank")
+		return
+	}
+	if renderHTML(c) {
+		projects = renderProjects(projects)
+	}
+	if !isAdmin(c) {
+		projects = withoutAttribution(projects, clearProject)
+	}
+	respondList(c, projects)
 }
 
+// GetProject returns a single project
+// @Summary Get project
+// @Description Returns a published project that is not archived, with its gallery and demo video; admins may add include_drafts=true and include_archived=true
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param include_drafts query bool false "Allow unpublished projects (admin only)"
+// @Param include_archived query bool false "Allow archived projects (admin only)"
+// @Param render query string false "Set to html to add long_description_html, rendered from Markdown to sanitized HTML"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /projects/{id} [get]
+func (h *Handlers) GetProject(c *gin.Context) {
+	drafts, ok := includeDrafts(c)
+	if !ok {
+		return
+	}
+	archived, ok := includeArchived(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	project, err := h.projectService.GetProject(c.Request.Context(), uint(id), drafts, archived)
+	if err != nil {
+		if err.Error() == "project not found" {
+			respondError(c, http.StatusNotFound, "Project not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get project")
+		return
+	}
+	if renderHTML(c) {
+		project = renderProject(project)
+	}
+	if !isAdmin(c) {
+		stripped := *project
+		clearProject(&stripped)
+		project = &stripped
+	}
+	respond(c, http.StatusOK, project)
+}
+
+// GetProjectSkills returns the skills a project demonstrates
+// @Summary Get skills for a project
+// @Description Returns the skills linked to a published project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {array} models.Skill
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /projects/{id}/skills [get]
+func (h *Handlers) GetProjectSkills(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	skills, err := h.projectService.GetProjectSkills(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "project not found" {
+			respondError(c, http.StatusNotFound, "Project not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get skills")
+		return
+	}
+	if !isAdmin(c) {
+		skills = withoutAttribution(skills, clearSkill)
+	}
+	respondList(c, skills)
+}
+
+const demoVideoHint = "Demo video must be a YouTube or Vimeo link or an .mp4, .m4v, .webm, .ogv or .mov file"
+
 // CreateProject creates a new project
 // @Summary Create project
 // @Description Creates a new project entry (admin only)
@@ -375,17 +1003,26 @@ func (h *Handlers) GetProjects(c *gin.Context) {
 func (h *Handlers) CreateProject(c *gin.Context) {
 	var req service.ProjectCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	project, err := h.projectService.CreateProject(&req)
+	project, err := h.projectService.CreateProject(c.Request.Context(), c.GetString("username"), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project"})
+		switch err.Error() {
+		case "category not found":
+			respondError(c, http.StatusBadRequest, "Category not found")
+		case "skill not found":
+			respondError(c, http.StatusBadRequest, "Skill not found")
+		case "unsupported demo video":
+			respondError(c, http.StatusBadRequest, demoVideoHint)
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create project")
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, project)
+	respond(c, http.StatusCreated, project)
 }
 
 // UpdateProject updates an existing project
@@ -397,35 +1034,135 @@ func (h *Handlers) CreateProject(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Project ID"
 // @Param project body service.ProjectUpdateRequest true "Project data"
+// @Param If-Match header string false "Version the edit is based on, when not sent as version in the body"
 // @Success 200 {object} models.Project
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
 // @Router /admin/projects/{id} [put]
 func (h *Handlers) UpdateProject(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
 		return
 	}
 
 	var req service.ProjectUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
+		return
+	}
+	if !ifMatchVersion(c, &req.Version) {
 		return
 	}
 
-	project, err := h.projectService.UpdateProject(uint(id), &req)
+	project, err := h.projectService.UpdateProject(c.Request.Context(), uint(id), c.GetString("username"), &req)
+	if err != nil {
+		switch err.Error() {
+		case "project not found":
+			respondError(c, http.StatusNotFound, "Project not found")
+		case "category not found":
+			respondError(c, http.StatusBadRequest, "Category not found")
+		case "skill not found":
+			respondError(c, http.StatusBadRequest, "Skill not found")
+		case "unsupported demo video":
+			respondError(c, http.StatusBadRequest, demoVideoHint)
+		case "version conflict":
+			respondError(c, http.StatusConflict, "Project was changed since it was loaded; reload it and try again")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update project")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, project)
+}
+
+// ReorderFeaturedProjects ranks the featured projects
+// @Summary Reorder featured projects
+// @Description Sets featured_rank from an ordered list of every featured project ID, for listings requested with order=featured_rank (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param order body service.ProjectReorderRequest true "Featured project IDs in rank order"
+// @Success 200 {array} models.Project
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/projects/featured/reorder [put]
+func (h *Handlers) ReorderFeaturedProjects(c *gin.Context) {
+	var req service.ProjectReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	projects, err := h.projectService.ReorderFeaturedProjects(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "invalid order" {
+			respondError(c, http.StatusBadRequest, "Order must list every featured project exactly once")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to reorder featured projects")
+		return
+	}
+
+	respondList(c, projects)
+}
+
+// ArchiveProject archives a project
+// @Summary Archive project
+// @Description Hides a project from public listings, search and the portfolio without deleting it; admins still see it with include_archived=true (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/archive [put]
+func (h *Handlers) ArchiveProject(c *gin.Context) {
+	h.archiveProject(c, true)
+}
+
+// UnarchiveProject restores an archived project
+// @Summary Unarchive project
+// @Description Shows an archived project in listings again (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/unarchive [put]
+func (h *Handlers) UnarchiveProject(c *gin.Context) {
+	h.archiveProject(c, false)
+}
+
+func (h *Handlers) archiveProject(c *gin.Context, archived bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	project, err := h.projectService.ArchiveProject(c.Request.Context(), uint(id), c.GetString("username"), archived)
 	if err != nil {
 		if err.Error() == "project not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			respondError(c, http.StatusNotFound, "Project not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update project"})
+		respondError(c, http.StatusInternalServerError, "Failed to archive project")
 		return
 	}
 
-	c.JSON(http.StatusOK, project)
+	respond(c, http.StatusOK, project)
 }
 
 // DeleteProject deletes a project
@@ -444,17 +1181,17 @@ func (h *Handlers) UpdateProject(c *gin.Context) {
 func (h *Handlers) DeleteProject(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
 		return
 	}
 
-	err = h.projectService.DeleteProject(uint(id))
+	err = h.projectService.DeleteProject(c.Request.Context(), uint(id))
 	if err != nil {
 		if err.Error() == "project not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+			respondError(c, http.StatusNotFound, "Project not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete project"})
+		respondError(c, http.StatusInternalServerError, "Failed to delete project")
 		return
 	}
 
@@ -470,11 +1207,12 @@ func (h *Handlers) DeleteProject(c *gin.Context) {
 // @Param contact body service.ContactCreateRequest true "Contact data"
 // @Success 201 {object} models.Contact
 // @Failure 400 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
 // @Router /contact [post]
 func (h *Handlers) CreateContact(c *gin.Context) {
 	var req service.ContactCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
@@ -482,32 +1220,42 @@ func (h *Handlers) CreateContact(c *gin.Context) {
 	req.IPAddress = c.ClientIP()
 	req.UserAgent = c.GetHeader("User-Agent")
 
-	contact, err := h.contactService.CreateContact(&req)
+	contact, err := h.contactService.CreateContact(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create contact"})
+		switch err.Error() {
+		case "captcha token required", "captcha verification failed":
+			respondError(c, http.StatusBadRequest, err.Error())
+		case "captcha unavailable":
+			respondError(c, http.StatusServiceUnavailable, "Captcha verification unavailable, please try again")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create contact")
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, contact)
+	respond(c, http.StatusCreated, contact)
 }
 
 // GetContacts returns all contact submissions (admin only)
 // @Summary Get contact submissions
-// @Description Returns all contact form submissions (admin only)
+// @Description Returns contact form submissions, hiding spam unless it is requested by status. Submissions from the same email address share a contact_group, and all but the first are flagged as repeat (admin only)
 // @Tags contact
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param status query string false "Filter by status (new, read, replied, spam)"
+// @Param country query string false "Filter by ISO 3166-1 alpha-2 country code of the sender's IP address"
+// @Param email query string false "Show the full history of one sender, spam included unless a status is given; case is ignored"
 // @Success 200 {array} models.Contact
 // @Failure 401 {object} map[string]interface{}
 // @Router /admin/contacts [get]
 func (h *Handlers) GetContacts(c *gin.Context) {
-	contacts, err := h.contactService.GetContacts()
+	contacts, err := h.contactService.GetContacts(c.Request.Context(), c.Query("status"), c.Query("country"), c.Query("email"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get contacts"})
+		respondError(c, http.StatusInternalServerError, "Failed to get contacts")
 		return
 	}
-	c.JSON(http.StatusOK, contacts)
+	respondList(c, contacts)
 }
 
 // UpdateContactStatus updates the status of a contact submission
@@ -527,27 +1275,27 @@ func (h *Handlers) GetContacts(c *gin.Context) {
 func (h *Handlers) UpdateContactStatus(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contact ID"})
+		respondError(c, http.StatusBadRequest, "Invalid contact ID")
 		return
 	}
 
 	var req service.ContactStatusUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	contact, err := h.contactService.UpdateContactStatus(uint(id), req.Status)
+	contact, err := h.contactService.UpdateContactStatus(c.Request.Context(), uint(id), req.Status)
 	if err != nil {
 		if err.Error() == "contact not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Contact not found"})
+			respondError(c, http.StatusNotFound, "Contact not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contact status"})
+		respondError(c, http.StatusInternalServerError, "Failed to update contact status")
 		return
 	}
 
-	c.JSON(http.StatusOK, contact)
+	respond(c, http.StatusOK, contact)
 }
 
 // Login authenticates a user and returns a JWT token
@@ -564,15 +1312,19 @@ func (h *Handlers) UpdateContactStatus(c *gin.Context) {
 func (h *Handlers) Login(c *gin.Context) {
 	var req service.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	response, err := h.authService.Login(&req)
+	// Add client information
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	response, err := h.authService.Login(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		respondError(c, http.StatusUnauthorized, "Invalid credentials")
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	respond(c, http.StatusOK, response)
 }
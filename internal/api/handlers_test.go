@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"stackwhiz-portfolio-backend/internal/api/mocks"
+	"stackwhiz-portfolio-backend/internal/middleware"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"stackwhiz-portfolio-backend/internal/token"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestRouter serves the admin contact list the way main.go does, for
+// the default tenant and for tenant "acme" (ID 2) under /t/acme
+func newTestRouter(h *Handlers) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	resolve := func(ctx context.Context, host, slug string) (uint, error) {
+		switch slug {
+		case "":
+			return tenant.DefaultID, nil
+		case "acme":
+			return 2, nil
+		}
+		return 0, errors.New("tenant not found")
+	}
+	for _, prefix := range []string{"", "/t/:tenant"} {
+		admin := router.Group(prefix + "/api/v1/admin")
+		admin.Use(middleware.Tenant(resolve), middleware.AuthMiddleware("secret"))
+		admin.GET("/contacts", h.GetContacts)
+	}
+	return router
+}
+
+func TestAdminRoutesCheckTokenAndTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	contacts := mocks.NewMockContactService(ctrl)
+	router := newTestRouter(&Handlers{contactService: contacts})
+
+	acmeAdmin, err := token.Issue("secret", token.Claims{UserID: 4, Username: "ada", Role: "admin", TenantID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the request with acme's own token reaches the service, scoped to acme
+	contacts.EXPECT().GetContacts(gomock.Any(), "new", "", "").DoAndReturn(func(ctx context.Context, status, country, email string) ([]models.Contact, error) {
+		if id, _ := tenant.FromContext(ctx); id != 2 {
+			t.Errorf("service called for tenant %d, want 2", id)
+		}
+		return []models.Contact{}, nil
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		bearer string
+		status int
+	}{
+		{"no token", "/t/acme/api/v1/admin/contacts?status=new", "", http.StatusUnauthorized},
+		{"invalid token", "/t/acme/api/v1/admin/contacts?status=new", "not-a-token", http.StatusUnauthorized},
+		{"token of another tenant", "/api/v1/admin/contacts?status=new", acmeAdmin, http.StatusUnauthorized},
+		{"unknown tenant", "/t/initech/api/v1/admin/contacts?status=new", acmeAdmin, http.StatusNotFound},
+		{"token of the tenant", "/t/acme/api/v1/admin/contacts?status=new", acmeAdmin, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.bearer)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.status)
+		}
+	}
+}
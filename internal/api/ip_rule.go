@@ -0,0 +1,155 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetIPRules returns the IP allow and deny rules
+// @Summary Get IP rules
+// @Description Returns the IP allow and deny rules. Deny rules block every request; once an allow rule exists only the addresses it covers may reach the admin API (default tenant admins only)
+// @Tags security
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.IPRule
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /admin/security/ip-rules [get]
+func (h *Handlers) GetIPRules(c *gin.Context) {
+	rules, err := h.ipRuleService.GetRules(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get IP rules")
+		return
+	}
+	respondList(c, rules)
+}
+
+// CreateIPRule adds an IP allow or deny rule
+// @Summary Create IP rule
+// @Description Adds an allow or deny rule for an address or CIDR range. Rules that would lock out the requesting address are rejected (default tenant admins only)
+// @Tags security
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body service.IPRuleRequest true "Rule data"
+// @Success 201 {object} models.IPRule
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/security/ip-rules [post]
+func (h *Handlers) CreateIPRule(c *gin.Context) {
+	var req service.IPRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	rule, err := h.ipRuleService.CreateRule(c.Request.Context(), &req, c.ClientIP())
+	if err != nil {
+		switch err.Error() {
+		case "invalid action":
+			respondError(c, http.StatusBadRequest, "Action must be allow or deny")
+		case "invalid cidr":
+			respondError(c, http.StatusBadRequest, "Invalid IP address or CIDR range")
+		case "rule would lock you out":
+			respondError(c, http.StatusConflict, "Rule would lock your address out of the admin API")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create IP rule")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, rule)
+}
+
+// UpdateIPRule updates an IP allow or deny rule
+// @Summary Update IP rule
+// @Description Updates an existing rule. Changes that would lock out the requesting address are rejected (default tenant admins only)
+// @Tags security
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rule ID"
+// @Param rule body service.IPRuleRequest true "Rule data"
+// @Success 200 {object} models.IPRule
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/security/ip-rules/{id} [put]
+func (h *Handlers) UpdateIPRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	var req service.IPRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	rule, err := h.ipRuleService.UpdateRule(c.Request.Context(), uint(id), &req, c.ClientIP())
+	if err != nil {
+		switch err.Error() {
+		case "ip rule not found":
+			respondError(c, http.StatusNotFound, "IP rule not found")
+		case "invalid action":
+			respondError(c, http.StatusBadRequest, "Action must be allow or deny")
+		case "invalid cidr":
+			respondError(c, http.StatusBadRequest, "Invalid IP address or CIDR range")
+		case "rule would lock you out":
+			respondError(c, http.StatusConflict, "Rule would lock your address out of the admin API")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update IP rule")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, rule)
+}
+
+// DeleteIPRule deletes an IP allow or deny rule
+// @Summary Delete IP rule
+// @Description Deletes a rule. Removing the allow rule covering the requesting address is rejected while other allow rules remain (default tenant admins only)
+// @Tags security
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/security/ip-rules/{id} [delete]
+func (h *Handlers) DeleteIPRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	err = h.ipRuleService.DeleteRule(c.Request.Context(), uint(id), c.ClientIP())
+	if err != nil {
+		switch err.Error() {
+		case "ip rule not found":
+			respondError(c, http.StatusNotFound, "IP rule not found")
+		case "rule would lock you out":
+			respondError(c, http.StatusConflict, "Rule would lock your address out of the admin API")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to delete IP rule")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
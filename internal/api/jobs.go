@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDeadJobs returns the background jobs that ran out of retries
+// @Summary Get dead jobs
+// @Description Returns the background jobs, such as contact notification emails, that failed on every attempt, most recent failure first, with the last error of each (default tenant admins only)
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} service.Job
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /admin/jobs/dead [get]
+func (h *Handlers) GetDeadJobs(c *gin.Context) {
+	jobs, err := h.jobService.GetDeadJobs(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get dead jobs")
+		return
+	}
+	respondList(c, jobs)
+}
+
+// RequeueDeadJob queues a dead job again
+// @Summary Requeue dead job
+// @Description Moves a dead job back onto the queue with a fresh set of retries; it runs straight away (default tenant admins only)
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} service.Job
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/jobs/dead/{id}/requeue [post]
+func (h *Handlers) RequeueDeadJob(c *gin.Context) {
+	job, err := h.jobService.RequeueDeadJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err.Error() == "job not found" {
+			respondError(c, http.StatusNotFound, "Job not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to requeue job")
+		return
+	}
+
+	respond(c, http.StatusOK, job)
+}
+
+// DeleteDeadJob discards a dead job
+// @Summary Delete dead job
+// @Description Removes a dead job without running it again (default tenant admins only)
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/jobs/dead/{id} [delete]
+func (h *Handlers) DeleteDeadJob(c *gin.Context) {
+	if err := h.jobService.DeleteDeadJob(c.Request.Context(), c.Param("id")); err != nil {
+		if err.Error() == "job not found" {
+			respondError(c, http.StatusNotFound, "Job not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete job")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
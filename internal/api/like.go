@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Most liked projects page sizes
+const (
+	defaultLikedProjects = 10
+	maxLikedProjects     = 100
+)
+
+// LikeProject records an anonymous like of a project
+// @Summary Like project
+// @Description Counts one like per visitor, project and day; repeats are accepted but not counted. Limited per IP address.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} service.LikeResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /projects/{id}/like [post]
+func (h *Handlers) LikeProject(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	result, err := h.projectService.LikeProject(c.Request.Context(), uint(id), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		switch err.Error() {
+		case "project not found":
+			respondError(c, http.StatusNotFound, "Project not found")
+		case "rate limit exceeded":
+			respondError(c, http.StatusTooManyRequests, "Too many likes, try again later")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to like project")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, result)
+}
+
+// GetMostLikedProjects returns the projects with the most likes (admin only)
+// @Summary Get most liked projects
+// @Description Returns projects ranked by likes, drafts included, with likes not yet written to the database counted (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of projects, at most 100" default(10)
+// @Success 200 {array} service.ProjectLikes
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/projects/likes [get]
+func (h *Handlers) GetMostLikedProjects(c *gin.Context) {
+	limit := defaultLikedProjects
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLikedProjects {
+			respondError(c, http.StatusBadRequest, "Limit must be between 1 and 100")
+			return
+		}
+		limit = n
+	}
+
+	ranking, err := h.projectService.GetMostLikedProjects(c.Request.Context(), limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get project likes")
+		return
+	}
+	respondList(c, ranking)
+}
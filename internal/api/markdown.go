@@ -0,0 +1,63 @@
+package api
+
+import (
+	"stackwhiz-portfolio-backend/internal/markdown"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// renderHTML reports whether the request asked for Markdown fields to be
+// rendered with ?render=html
+func renderHTML(c *gin.Context) bool {
+	return c.Query("render") == "html"
+}
+
+// The render helpers fill the *_html fields of copies, since services may
+// hand the same value to concurrent requests
+
+func renderProfile(profile *models.Profile) *models.Profile {
+	if profile == nil {
+		return nil
+	}
+	rendered := *profile
+	rendered.SummaryHTML = markdown.ToHTML(profile.Summary)
+	return &rendered
+}
+
+func renderProject(project *models.Project) *models.Project {
+	rendered := *project
+	rendered.LongDescriptionHTML = markdown.ToHTML(project.LongDescription)
+	return &rendered
+}
+
+func renderProjects(projects []models.Project) []models.Project {
+	rendered := make([]models.Project, len(projects))
+	for i := range projects {
+		rendered[i] = *renderProject(&projects[i])
+	}
+	return rendered
+}
+
+func renderPost(post *models.Post) *models.Post {
+	rendered := *post
+	rendered.BodyHTML = markdown.ToHTML(post.Body)
+	return &rendered
+}
+
+func renderPosts(posts []models.Post) []models.Post {
+	rendered := make([]models.Post, len(posts))
+	for i := range posts {
+		rendered[i] = *renderPost(&posts[i])
+	}
+	return rendered
+}
+
+func renderPortfolio(portfolio *service.Portfolio) *service.Portfolio {
+	rendered := *portfolio
+	rendered.Profile = renderProfile(portfolio.Profile)
+	rendered.Projects = renderProjects(portfolio.Projects)
+	rendered.FeaturedProjects = renderProjects(portfolio.FeaturedProjects)
+	return &rendered
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMedia returns all uploaded media (admin only)
+// @Summary Get media
+// @Description Returns all uploaded files, newest first (admin only)
+// @Tags media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Media
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/media [get]
+func (h *Handlers) GetMedia(c *gin.Context) {
+	media, err := h.mediaService.GetMedia(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get media")
+		return
+	}
+	respondList(c, media)
+}
+
+// UploadMedia uploads a file (admin only)
+// @Summary Upload media
+// @Description Uploads an image (JPEG, PNG, GIF, WebP) or PDF as multipart form field "file". Private files are only served through signed URLs from /admin/media/{id}/url (admin only)
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "File to upload"
+// @Param private formData bool false "Keep the file out of public reach"
+// @Success 201 {object} models.Media
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 413 {object} map[string]interface{}
+// @Router /admin/media [post]
+func (h *Handlers) UploadMedia(c *gin.Context) {
+	// Leave room for the multipart framing around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.mediaService.MaxSize()+1<<20)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(c, http.StatusRequestEntityTooLarge, "File too large")
+			return
+		}
+		respondError(c, http.StatusBadRequest, "Missing file")
+		return
+	}
+
+	private := false
+	if raw := c.PostForm("private"); raw != "" {
+		private, err = strconv.ParseBool(raw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid private flag")
+			return
+		}
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid file")
+		return
+	}
+	defer file.Close()
+
+	media, err := h.mediaService.Upload(c.Request.Context(), filepath.Base(header.Filename), file, private)
+	if err != nil {
+		switch err.Error() {
+		case "unsupported file type":
+			respondError(c, http.StatusBadRequest, "Unsupported file type")
+		case "file too large":
+			respondError(c, http.StatusRequestEntityTooLarge, "File too large")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to upload media")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, media)
+}
+
+// GetMediaURL returns a signed download URL for an uploaded file (admin only)
+// @Summary Get signed media URL
+// @Description Returns a URL that downloads the file, or one of its variants, without credentials until it expires. This is how private files are shared (admin only)
+// @Tags media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Media ID"
+// @Param variant query string false "Variant name, e.g. thumb"
+// @Param ttl query int false "Lifetime in seconds, at most a week" default(900)
+// @Success 200 {object} service.MediaURL
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/media/{id}/url [get]
+func (h *Handlers) GetMediaURL(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	ttl := service.DefaultSignedURLTTL
+	if raw := c.Query("ttl"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid ttl")
+			return
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+
+	mediaURL, err := h.mediaService.SignedURL(c.Request.Context(), uint(id), c.Query("variant"), ttl)
+	if err != nil {
+		switch err.Error() {
+		case "invalid ttl":
+			respondError(c, http.StatusBadRequest, "ttl must be between 1 second and 7 days")
+		case "media not found":
+			respondError(c, http.StatusNotFound, "Media not found")
+		case "variant not found":
+			respondError(c, http.StatusNotFound, "Variant not found")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to sign media URL")
+		}
+		return
+	}
+
+	// Local storage links back to this server
+	if strings.HasPrefix(mediaURL.URL, "/") {
+		mediaURL.URL = requestBaseURL(c) + mediaURL.URL
+	}
+	respond(c, http.StatusOK, mediaURL)
+}
+
+// DeleteMedia deletes an uploaded file (admin only)
+// @Summary Delete media
+// @Description Deletes an uploaded file and its metadata (admin only)
+// @Tags media
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Media ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/media/{id} [delete]
+func (h *Handlers) DeleteMedia(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	err = h.mediaService.DeleteMedia(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "media not found" {
+			respondError(c, http.StatusNotFound, "Media not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete media")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ServeMedia serves an uploaded file
+// @Summary Serve media
+// @Description Returns the contents of an uploaded file or of one of its resized variants. Private files need the expires and signature parameters of a signed URL
+// @Tags media
+// @Produce octet-stream
+// @Param name path string true "Stored file name"
+// @Param expires query int false "Expiry of a signed URL"
+// @Param signature query string false "Signature of a signed URL"
+// @Success 200 {file} binary
+// @Failure 404 {object} map[string]interface{}
+// @Router /media/{name} [get]
+func (h *Handlers) ServeMedia(c *gin.Context) {
+	file, err := h.mediaService.Open(c.Request.Context(), c.Param("name"), c.Request.URL.Query())
+	if err != nil {
+		if err.Error() == "media not found" {
+			respondError(c, http.StatusNotFound, "Media not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get media")
+		return
+	}
+	defer file.Close()
+
+	// Stored names are random and never reused, so the contents never change.
+	// Private files must not outlive their signed URL in shared caches.
+	if file.Private {
+		c.Header("Cache-Control", "private, no-store")
+	} else {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	}
+	c.Header("ETag", `"`+file.Checksum+`"`)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, file.Size, file.MimeType, file, nil)
+}
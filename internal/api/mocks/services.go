@@ -0,0 +1,849 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=mocks/services.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	models "stackwhiz-portfolio-backend/internal/models"
+	service "stackwhiz-portfolio-backend/internal/service"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfileService is a mock of ProfileService interface.
+type MockProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileServiceMockRecorder
+}
+
+// MockProfileServiceMockRecorder is the mock recorder for MockProfileService.
+type MockProfileServiceMockRecorder struct {
+	mock *MockProfileService
+}
+
+// NewMockProfileService creates a new mock instance.
+func NewMockProfileService(ctrl *gomock.Controller) *MockProfileService {
+	mock := &MockProfileService{ctrl: ctrl}
+	mock.recorder = &MockProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileService) EXPECT() *MockProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// GetProfile mocks base method.
+func (m *MockProfileService) GetProfile(ctx context.Context) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockProfileServiceMockRecorder) GetProfile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockProfileService)(nil).GetProfile), ctx)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockProfileService) UpdateProfile(ctx context.Context, req *service.ProfileUpdateRequest) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, req)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockProfileServiceMockRecorder) UpdateProfile(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockProfileService)(nil).UpdateProfile), ctx, req)
+}
+
+// UploadAvatar mocks base method.
+func (m *MockProfileService) UploadAvatar(ctx context.Context, filename string, r io.Reader, crop service.AvatarCrop) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, filename, r, crop)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockProfileServiceMockRecorder) UploadAvatar(ctx, filename, r, crop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockProfileService)(nil).UploadAvatar), ctx, filename, r, crop)
+}
+
+// MockExperienceService is a mock of ExperienceService interface.
+type MockExperienceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExperienceServiceMockRecorder
+}
+
+// MockExperienceServiceMockRecorder is the mock recorder for MockExperienceService.
+type MockExperienceServiceMockRecorder struct {
+	mock *MockExperienceService
+}
+
+// NewMockExperienceService creates a new mock instance.
+func NewMockExperienceService(ctrl *gomock.Controller) *MockExperienceService {
+	mock := &MockExperienceService{ctrl: ctrl}
+	mock.recorder = &MockExperienceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExperienceService) EXPECT() *MockExperienceServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateExperience mocks base method.
+func (m *MockExperienceService) CreateExperience(ctx context.Context, username string, req *service.ExperienceCreateRequest) (*models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExperience", ctx, username, req)
+	ret0, _ := ret[0].(*models.Experience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExperience indicates an expected call of CreateExperience.
+func (mr *MockExperienceServiceMockRecorder) CreateExperience(ctx, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExperience", reflect.TypeOf((*MockExperienceService)(nil).CreateExperience), ctx, username, req)
+}
+
+// DeleteExperience mocks base method.
+func (m *MockExperienceService) DeleteExperience(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExperience", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExperience indicates an expected call of DeleteExperience.
+func (mr *MockExperienceServiceMockRecorder) DeleteExperience(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExperience", reflect.TypeOf((*MockExperienceService)(nil).DeleteExperience), ctx, id)
+}
+
+// GetExperiences mocks base method.
+func (m *MockExperienceService) GetExperiences(ctx context.Context, includeDrafts bool) ([]models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExperiences", ctx, includeDrafts)
+	ret0, _ := ret[0].([]models.Experience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExperiences indicates an expected call of GetExperiences.
+func (mr *MockExperienceServiceMockRecorder) GetExperiences(ctx, includeDrafts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExperiences", reflect.TypeOf((*MockExperienceService)(nil).GetExperiences), ctx, includeDrafts)
+}
+
+// ReorderExperiences mocks base method.
+func (m *MockExperienceService) ReorderExperiences(ctx context.Context, req *service.ExperienceReorderRequest) ([]models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderExperiences", ctx, req)
+	ret0, _ := ret[0].([]models.Experience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderExperiences indicates an expected call of ReorderExperiences.
+func (mr *MockExperienceServiceMockRecorder) ReorderExperiences(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderExperiences", reflect.TypeOf((*MockExperienceService)(nil).ReorderExperiences), ctx, req)
+}
+
+// UpdateExperience mocks base method.
+func (m *MockExperienceService) UpdateExperience(ctx context.Context, id uint, username string, req *service.ExperienceUpdateRequest) (*models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExperience", ctx, id, username, req)
+	ret0, _ := ret[0].(*models.Experience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExperience indicates an expected call of UpdateExperience.
+func (mr *MockExperienceServiceMockRecorder) UpdateExperience(ctx, id, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExperience", reflect.TypeOf((*MockExperienceService)(nil).UpdateExperience), ctx, id, username, req)
+}
+
+// MockSkillService is a mock of SkillService interface.
+type MockSkillService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSkillServiceMockRecorder
+}
+
+// MockSkillServiceMockRecorder is the mock recorder for MockSkillService.
+type MockSkillServiceMockRecorder struct {
+	mock *MockSkillService
+}
+
+// NewMockSkillService creates a new mock instance.
+func NewMockSkillService(ctrl *gomock.Controller) *MockSkillService {
+	mock := &MockSkillService{ctrl: ctrl}
+	mock.recorder = &MockSkillServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSkillService) EXPECT() *MockSkillServiceMockRecorder {
+	return m.recorder
+}
+
+// AcceptSkillSuggestions mocks base method.
+func (m *MockSkillService) AcceptSkillSuggestions(ctx context.Context, username string, req *service.SkillSuggestionAcceptRequest) (*service.SkillSuggestionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptSkillSuggestions", ctx, username, req)
+	ret0, _ := ret[0].(*service.SkillSuggestionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptSkillSuggestions indicates an expected call of AcceptSkillSuggestions.
+func (mr *MockSkillServiceMockRecorder) AcceptSkillSuggestions(ctx, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptSkillSuggestions", reflect.TypeOf((*MockSkillService)(nil).AcceptSkillSuggestions), ctx, username, req)
+}
+
+// CreateSkill mocks base method.
+func (m *MockSkillService) CreateSkill(ctx context.Context, username string, req *service.SkillCreateRequest) (*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSkill", ctx, username, req)
+	ret0, _ := ret[0].(*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSkill indicates an expected call of CreateSkill.
+func (mr *MockSkillServiceMockRecorder) CreateSkill(ctx, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSkill", reflect.TypeOf((*MockSkillService)(nil).CreateSkill), ctx, username, req)
+}
+
+// DeleteSkill mocks base method.
+func (m *MockSkillService) DeleteSkill(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSkill", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSkill indicates an expected call of DeleteSkill.
+func (mr *MockSkillServiceMockRecorder) DeleteSkill(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSkill", reflect.TypeOf((*MockSkillService)(nil).DeleteSkill), ctx, id)
+}
+
+// EndorseSkill mocks base method.
+func (m *MockSkillService) EndorseSkill(ctx context.Context, id uint, ipAddress, userAgent string) (*service.EndorsementResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndorseSkill", ctx, id, ipAddress, userAgent)
+	ret0, _ := ret[0].(*service.EndorsementResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndorseSkill indicates an expected call of EndorseSkill.
+func (mr *MockSkillServiceMockRecorder) EndorseSkill(ctx, id, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndorseSkill", reflect.TypeOf((*MockSkillService)(nil).EndorseSkill), ctx, id, ipAddress, userAgent)
+}
+
+// GetSkillCategories mocks base method.
+func (m *MockSkillService) GetSkillCategories(ctx context.Context) ([]service.SkillCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkillCategories", ctx)
+	ret0, _ := ret[0].([]service.SkillCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkillCategories indicates an expected call of GetSkillCategories.
+func (mr *MockSkillServiceMockRecorder) GetSkillCategories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillCategories", reflect.TypeOf((*MockSkillService)(nil).GetSkillCategories), ctx)
+}
+
+// GetSkillGroups mocks base method.
+func (m *MockSkillService) GetSkillGroups(ctx context.Context) ([]service.SkillGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkillGroups", ctx)
+	ret0, _ := ret[0].([]service.SkillGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkillGroups indicates an expected call of GetSkillGroups.
+func (mr *MockSkillServiceMockRecorder) GetSkillGroups(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillGroups", reflect.TypeOf((*MockSkillService)(nil).GetSkillGroups), ctx)
+}
+
+// GetSkillSuggestions mocks base method.
+func (m *MockSkillService) GetSkillSuggestions(ctx context.Context) ([]service.SkillSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkillSuggestions", ctx)
+	ret0, _ := ret[0].([]service.SkillSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkillSuggestions indicates an expected call of GetSkillSuggestions.
+func (mr *MockSkillServiceMockRecorder) GetSkillSuggestions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillSuggestions", reflect.TypeOf((*MockSkillService)(nil).GetSkillSuggestions), ctx)
+}
+
+// GetSkills mocks base method.
+func (m *MockSkillService) GetSkills(ctx context.Context) ([]models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkills", ctx)
+	ret0, _ := ret[0].([]models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkills indicates an expected call of GetSkills.
+func (mr *MockSkillServiceMockRecorder) GetSkills(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkills", reflect.TypeOf((*MockSkillService)(nil).GetSkills), ctx)
+}
+
+// MergeSkillCategories mocks base method.
+func (m *MockSkillService) MergeSkillCategories(ctx context.Context, username string, req *service.SkillCategoryMergeRequest) ([]service.SkillCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeSkillCategories", ctx, username, req)
+	ret0, _ := ret[0].([]service.SkillCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeSkillCategories indicates an expected call of MergeSkillCategories.
+func (mr *MockSkillServiceMockRecorder) MergeSkillCategories(ctx, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeSkillCategories", reflect.TypeOf((*MockSkillService)(nil).MergeSkillCategories), ctx, username, req)
+}
+
+// MergeSkills mocks base method.
+func (m *MockSkillService) MergeSkills(ctx context.Context, username string, req *service.SkillMergeRequest) (*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeSkills", ctx, username, req)
+	ret0, _ := ret[0].(*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeSkills indicates an expected call of MergeSkills.
+func (mr *MockSkillServiceMockRecorder) MergeSkills(ctx, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeSkills", reflect.TypeOf((*MockSkillService)(nil).MergeSkills), ctx, username, req)
+}
+
+// RenameSkillCategory mocks base method.
+func (m *MockSkillService) RenameSkillCategory(ctx context.Context, username string, req *service.SkillCategoryRenameRequest) ([]service.SkillCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameSkillCategory", ctx, username, req)
+	ret0, _ := ret[0].([]service.SkillCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameSkillCategory indicates an expected call of RenameSkillCategory.
+func (mr *MockSkillServiceMockRecorder) RenameSkillCategory(ctx, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameSkillCategory", reflect.TypeOf((*MockSkillService)(nil).RenameSkillCategory), ctx, username, req)
+}
+
+// ReorderSkills mocks base method.
+func (m *MockSkillService) ReorderSkills(ctx context.Context, req *service.SkillReorderRequest) ([]models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderSkills", ctx, req)
+	ret0, _ := ret[0].([]models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderSkills indicates an expected call of ReorderSkills.
+func (mr *MockSkillServiceMockRecorder) ReorderSkills(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSkills", reflect.TypeOf((*MockSkillService)(nil).ReorderSkills), ctx, req)
+}
+
+// UpdateSkill mocks base method.
+func (m *MockSkillService) UpdateSkill(ctx context.Context, id uint, username string, req *service.SkillUpdateRequest) (*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSkill", ctx, id, username, req)
+	ret0, _ := ret[0].(*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSkill indicates an expected call of UpdateSkill.
+func (mr *MockSkillServiceMockRecorder) UpdateSkill(ctx, id, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSkill", reflect.TypeOf((*MockSkillService)(nil).UpdateSkill), ctx, id, username, req)
+}
+
+// MockProjectService is a mock of ProjectService interface.
+type MockProjectService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectServiceMockRecorder
+}
+
+// MockProjectServiceMockRecorder is the mock recorder for MockProjectService.
+type MockProjectServiceMockRecorder struct {
+	mock *MockProjectService
+}
+
+// NewMockProjectService creates a new mock instance.
+func NewMockProjectService(ctrl *gomock.Controller) *MockProjectService {
+	mock := &MockProjectService{ctrl: ctrl}
+	mock.recorder = &MockProjectServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectService) EXPECT() *MockProjectServiceMockRecorder {
+	return m.recorder
+}
+
+// AddProjectImage mocks base method.
+func (m *MockProjectService) AddProjectImage(ctx context.Context, projectID uint, req *service.ProjectImageRequest) (*models.ProjectImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProjectImage", ctx, projectID, req)
+	ret0, _ := ret[0].(*models.ProjectImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProjectImage indicates an expected call of AddProjectImage.
+func (mr *MockProjectServiceMockRecorder) AddProjectImage(ctx, projectID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProjectImage", reflect.TypeOf((*MockProjectService)(nil).AddProjectImage), ctx, projectID, req)
+}
+
+// ArchiveProject mocks base method.
+func (m *MockProjectService) ArchiveProject(ctx context.Context, id uint, username string, archived bool) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveProject", ctx, id, username, archived)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveProject indicates an expected call of ArchiveProject.
+func (mr *MockProjectServiceMockRecorder) ArchiveProject(ctx, id, username, archived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProject", reflect.TypeOf((*MockProjectService)(nil).ArchiveProject), ctx, id, username, archived)
+}
+
+// CreateProject mocks base method.
+func (m *MockProjectService) CreateProject(ctx context.Context, username string, req *service.ProjectCreateRequest) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", ctx, username, req)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockProjectServiceMockRecorder) CreateProject(ctx, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProjectService)(nil).CreateProject), ctx, username, req)
+}
+
+// CreateProjectMilestone mocks base method.
+func (m *MockProjectService) CreateProjectMilestone(ctx context.Context, projectID uint, req *service.ProjectMilestoneRequest) (*models.ProjectMilestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProjectMilestone", ctx, projectID, req)
+	ret0, _ := ret[0].(*models.ProjectMilestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProjectMilestone indicates an expected call of CreateProjectMilestone.
+func (mr *MockProjectServiceMockRecorder) CreateProjectMilestone(ctx, projectID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectMilestone", reflect.TypeOf((*MockProjectService)(nil).CreateProjectMilestone), ctx, projectID, req)
+}
+
+// DeleteProject mocks base method.
+func (m *MockProjectService) DeleteProject(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockProjectServiceMockRecorder) DeleteProject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProjectService)(nil).DeleteProject), ctx, id)
+}
+
+// DeleteProjectImage mocks base method.
+func (m *MockProjectService) DeleteProjectImage(ctx context.Context, projectID, imageID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProjectImage", ctx, projectID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProjectImage indicates an expected call of DeleteProjectImage.
+func (mr *MockProjectServiceMockRecorder) DeleteProjectImage(ctx, projectID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectImage", reflect.TypeOf((*MockProjectService)(nil).DeleteProjectImage), ctx, projectID, imageID)
+}
+
+// DeleteProjectMilestone mocks base method.
+func (m *MockProjectService) DeleteProjectMilestone(ctx context.Context, projectID, milestoneID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProjectMilestone", ctx, projectID, milestoneID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProjectMilestone indicates an expected call of DeleteProjectMilestone.
+func (mr *MockProjectServiceMockRecorder) DeleteProjectMilestone(ctx, projectID, milestoneID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectMilestone", reflect.TypeOf((*MockProjectService)(nil).DeleteProjectMilestone), ctx, projectID, milestoneID)
+}
+
+// GetMostLikedProjects mocks base method.
+func (m *MockProjectService) GetMostLikedProjects(ctx context.Context, limit int) ([]service.ProjectLikes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMostLikedProjects", ctx, limit)
+	ret0, _ := ret[0].([]service.ProjectLikes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMostLikedProjects indicates an expected call of GetMostLikedProjects.
+func (mr *MockProjectServiceMockRecorder) GetMostLikedProjects(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMostLikedProjects", reflect.TypeOf((*MockProjectService)(nil).GetMostLikedProjects), ctx, limit)
+}
+
+// GetProject mocks base method.
+func (m *MockProjectService) GetProject(ctx context.Context, id uint, includeDrafts, includeArchived bool) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", ctx, id, includeDrafts, includeArchived)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockProjectServiceMockRecorder) GetProject(ctx, id, includeDrafts, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockProjectService)(nil).GetProject), ctx, id, includeDrafts, includeArchived)
+}
+
+// GetProjectSkills mocks base method.
+func (m *MockProjectService) GetProjectSkills(ctx context.Context, id uint) ([]models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectSkills", ctx, id)
+	ret0, _ := ret[0].([]models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectSkills indicates an expected call of GetProjectSkills.
+func (mr *MockProjectServiceMockRecorder) GetProjectSkills(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectSkills", reflect.TypeOf((*MockProjectService)(nil).GetProjectSkills), ctx, id)
+}
+
+// GetProjects mocks base method.
+func (m *MockProjectService) GetProjects(ctx context.Context, featured *bool, categoryID *uint, tag string, includeDrafts, includeArchived bool) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", ctx, featured, categoryID, tag, includeDrafts, includeArchived)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockProjectServiceMockRecorder) GetProjects(ctx, featured, categoryID, tag, includeDrafts, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockProjectService)(nil).GetProjects), ctx, featured, categoryID, tag, includeDrafts, includeArchived)
+}
+
+// GetProjectsBySkill mocks base method.
+func (m *MockProjectService) GetProjectsBySkill(ctx context.Context, skillID uint) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectsBySkill", ctx, skillID)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectsBySkill indicates an expected call of GetProjectsBySkill.
+func (mr *MockProjectServiceMockRecorder) GetProjectsBySkill(ctx, skillID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectsBySkill", reflect.TypeOf((*MockProjectService)(nil).GetProjectsBySkill), ctx, skillID)
+}
+
+// LikeProject mocks base method.
+func (m *MockProjectService) LikeProject(ctx context.Context, id uint, ipAddress, userAgent string) (*service.LikeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LikeProject", ctx, id, ipAddress, userAgent)
+	ret0, _ := ret[0].(*service.LikeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LikeProject indicates an expected call of LikeProject.
+func (mr *MockProjectServiceMockRecorder) LikeProject(ctx, id, ipAddress, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeProject", reflect.TypeOf((*MockProjectService)(nil).LikeProject), ctx, id, ipAddress, userAgent)
+}
+
+// ReorderFeaturedProjects mocks base method.
+func (m *MockProjectService) ReorderFeaturedProjects(ctx context.Context, req *service.ProjectReorderRequest) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderFeaturedProjects", ctx, req)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderFeaturedProjects indicates an expected call of ReorderFeaturedProjects.
+func (mr *MockProjectServiceMockRecorder) ReorderFeaturedProjects(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderFeaturedProjects", reflect.TypeOf((*MockProjectService)(nil).ReorderFeaturedProjects), ctx, req)
+}
+
+// ReorderProjectImages mocks base method.
+func (m *MockProjectService) ReorderProjectImages(ctx context.Context, projectID uint, req *service.ProjectImageReorderRequest) ([]models.ProjectImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderProjectImages", ctx, projectID, req)
+	ret0, _ := ret[0].([]models.ProjectImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderProjectImages indicates an expected call of ReorderProjectImages.
+func (mr *MockProjectServiceMockRecorder) ReorderProjectImages(ctx, projectID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderProjectImages", reflect.TypeOf((*MockProjectService)(nil).ReorderProjectImages), ctx, projectID, req)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProjectService) UpdateProject(ctx context.Context, id uint, username string, req *service.ProjectUpdateRequest) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, id, username, req)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectServiceMockRecorder) UpdateProject(ctx, id, username, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProjectService)(nil).UpdateProject), ctx, id, username, req)
+}
+
+// UpdateProjectMilestone mocks base method.
+func (m *MockProjectService) UpdateProjectMilestone(ctx context.Context, projectID, milestoneID uint, req *service.ProjectMilestoneRequest) (*models.ProjectMilestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectMilestone", ctx, projectID, milestoneID, req)
+	ret0, _ := ret[0].(*models.ProjectMilestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProjectMilestone indicates an expected call of UpdateProjectMilestone.
+func (mr *MockProjectServiceMockRecorder) UpdateProjectMilestone(ctx, projectID, milestoneID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectMilestone", reflect.TypeOf((*MockProjectService)(nil).UpdateProjectMilestone), ctx, projectID, milestoneID, req)
+}
+
+// MockContactService is a mock of ContactService interface.
+type MockContactService struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactServiceMockRecorder
+}
+
+// MockContactServiceMockRecorder is the mock recorder for MockContactService.
+type MockContactServiceMockRecorder struct {
+	mock *MockContactService
+}
+
+// NewMockContactService creates a new mock instance.
+func NewMockContactService(ctrl *gomock.Controller) *MockContactService {
+	mock := &MockContactService{ctrl: ctrl}
+	mock.recorder = &MockContactServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactService) EXPECT() *MockContactServiceMockRecorder {
+	return m.recorder
+}
+
+// AddNote mocks base method.
+func (m *MockContactService) AddNote(ctx context.Context, id, authorID uint, author string, req *service.ContactNoteRequest) (*models.ContactNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNote", ctx, id, authorID, author, req)
+	ret0, _ := ret[0].(*models.ContactNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNote indicates an expected call of AddNote.
+func (mr *MockContactServiceMockRecorder) AddNote(ctx, id, authorID, author, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNote", reflect.TypeOf((*MockContactService)(nil).AddNote), ctx, id, authorID, author, req)
+}
+
+// CreateContact mocks base method.
+func (m *MockContactService) CreateContact(ctx context.Context, req *service.ContactCreateRequest) (*models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContact", ctx, req)
+	ret0, _ := ret[0].(*models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContact indicates an expected call of CreateContact.
+func (mr *MockContactServiceMockRecorder) CreateContact(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContact", reflect.TypeOf((*MockContactService)(nil).CreateContact), ctx, req)
+}
+
+// ExportContactsCSV mocks base method.
+func (m *MockContactService) ExportContactsCSV(ctx context.Context, w io.Writer, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportContactsCSV", ctx, w, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportContactsCSV indicates an expected call of ExportContactsCSV.
+func (mr *MockContactServiceMockRecorder) ExportContactsCSV(ctx, w, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportContactsCSV", reflect.TypeOf((*MockContactService)(nil).ExportContactsCSV), ctx, w, status)
+}
+
+// GetContacts mocks base method.
+func (m *MockContactService) GetContacts(ctx context.Context, status, country, email string) ([]models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, status, country, email)
+	ret0, _ := ret[0].([]models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockContactServiceMockRecorder) GetContacts(ctx, status, country, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContactService)(nil).GetContacts), ctx, status, country, email)
+}
+
+// GetMessages mocks base method.
+func (m *MockContactService) GetMessages(ctx context.Context, id uint) ([]models.ContactMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", ctx, id)
+	ret0, _ := ret[0].([]models.ContactMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockContactServiceMockRecorder) GetMessages(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockContactService)(nil).GetMessages), ctx, id)
+}
+
+// GetNotes mocks base method.
+func (m *MockContactService) GetNotes(ctx context.Context, id uint) ([]models.ContactNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotes", ctx, id)
+	ret0, _ := ret[0].([]models.ContactNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotes indicates an expected call of GetNotes.
+func (mr *MockContactServiceMockRecorder) GetNotes(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotes", reflect.TypeOf((*MockContactService)(nil).GetNotes), ctx, id)
+}
+
+// MarkContactHam mocks base method.
+func (m *MockContactService) MarkContactHam(ctx context.Context, id uint) (*models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkContactHam", ctx, id)
+	ret0, _ := ret[0].(*models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkContactHam indicates an expected call of MarkContactHam.
+func (mr *MockContactServiceMockRecorder) MarkContactHam(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkContactHam", reflect.TypeOf((*MockContactService)(nil).MarkContactHam), ctx, id)
+}
+
+// MarkContactSpam mocks base method.
+func (m *MockContactService) MarkContactSpam(ctx context.Context, id uint) (*models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkContactSpam", ctx, id)
+	ret0, _ := ret[0].(*models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkContactSpam indicates an expected call of MarkContactSpam.
+func (mr *MockContactServiceMockRecorder) MarkContactSpam(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkContactSpam", reflect.TypeOf((*MockContactService)(nil).MarkContactSpam), ctx, id)
+}
+
+// ReplyToContact mocks base method.
+func (m *MockContactService) ReplyToContact(ctx context.Context, id, authorID uint, req *service.ContactReplyRequest) (*models.ContactMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToContact", ctx, id, authorID, req)
+	ret0, _ := ret[0].(*models.ContactMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplyToContact indicates an expected call of ReplyToContact.
+func (mr *MockContactServiceMockRecorder) ReplyToContact(ctx, id, authorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToContact", reflect.TypeOf((*MockContactService)(nil).ReplyToContact), ctx, id, authorID, req)
+}
+
+// UpdateContactStatus mocks base method.
+func (m *MockContactService) UpdateContactStatus(ctx context.Context, id uint, status string) (*models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactStatus", ctx, id, status)
+	ret0, _ := ret[0].(*models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContactStatus indicates an expected call of UpdateContactStatus.
+func (mr *MockContactServiceMockRecorder) UpdateContactStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactStatus", reflect.TypeOf((*MockContactService)(nil).UpdateContactStatus), ctx, id, status)
+}
@@ -0,0 +1,351 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiPath returns the path of the API version and tenant a request came in
+// on, given the part of the request path below it
+func apiPath(c *gin.Context, suffix string) string {
+	return strings.TrimSuffix(c.Request.URL.Path, suffix)
+}
+
+// SubscribeNewsletter signs an address up for the newsletter
+// @Summary Subscribe to the newsletter
+// @Description Signs an address up and emails it a confirmation link. The subscription starts once the link is opened.
+// @Tags newsletter
+// @Accept json
+// @Produce json
+// @Param subscription body service.NewsletterSubscribeRequest true "Subscriber"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /newsletter/subscribe [post]
+func (h *Handlers) SubscribeNewsletter(c *gin.Context) {
+	var req service.NewsletterSubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	req.IPAddress = c.ClientIP()
+
+	if err := h.newsletterService.Subscribe(c.Request.Context(), &req, apiPath(c, "/newsletter/subscribe")); err != nil {
+		switch err.Error() {
+		case "rate limit exceeded":
+			respondError(c, http.StatusTooManyRequests, "Too many signups, try again later")
+		case "site URL not configured":
+			respondError(c, http.StatusServiceUnavailable, "Newsletter signups are not available")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to subscribe")
+		}
+		return
+	}
+
+	respond(c, http.StatusAccepted, gin.H{"message": "Check your inbox to confirm the subscription"})
+}
+
+// ConfirmNewsletter confirms a newsletter subscription
+// @Summary Confirm newsletter subscription
+// @Description Starts the subscription the token was emailed for
+// @Tags newsletter
+// @Produce json
+// @Param token query string true "Token from the confirmation email"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /newsletter/confirm [get]
+func (h *Handlers) ConfirmNewsletter(c *gin.Context) {
+	if err := h.newsletterService.Confirm(c.Request.Context(), c.Query("token")); err != nil {
+		if err.Error() == "subscriber not found" {
+			respondError(c, http.StatusNotFound, "Subscription not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to confirm subscription")
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "Subscription confirmed"})
+}
+
+// UnsubscribeNewsletter ends a newsletter subscription
+// @Summary Unsubscribe from the newsletter
+// @Description Ends the subscription of the token's address. POST serves one-click unsubscribing from mail clients (RFC 8058).
+// @Tags newsletter
+// @Produce json
+// @Param token query string true "Token from a newsletter email"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /newsletter/unsubscribe [get]
+// @Router /newsletter/unsubscribe [post]
+func (h *Handlers) UnsubscribeNewsletter(c *gin.Context) {
+	if err := h.newsletterService.Unsubscribe(c.Request.Context(), c.Query("token")); err != nil {
+		if err.Error() == "subscriber not found" {
+			respondError(c, http.StatusNotFound, "Subscription not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to unsubscribe")
+		return
+	}
+
+	respond(c, http.StatusOK, gin.H{"message": "Unsubscribed"})
+}
+
+// GetSubscribers returns the newsletter subscribers (admin only)
+// @Summary Get newsletter subscribers
+// @Description Returns every subscriber including pending and unsubscribed ones, newest first (admin only)
+// @Tags newsletter
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Subscriber
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/newsletter/subscribers [get]
+func (h *Handlers) GetSubscribers(c *gin.Context) {
+	subscribers, err := h.newsletterService.GetSubscribers(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get subscribers")
+		return
+	}
+	respondList(c, subscribers)
+}
+
+// GetCampaigns returns the newsletter campaigns (admin only)
+// @Summary Get newsletter campaigns
+// @Description Returns every campaign with its delivery counts by status, newest first (admin only)
+// @Tags newsletter
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Campaign
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/newsletter/campaigns [get]
+func (h *Handlers) GetCampaigns(c *gin.Context) {
+	campaigns, err := h.newsletterService.GetCampaigns(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get campaigns")
+		return
+	}
+	respondList(c, campaigns)
+}
+
+// GetCampaign returns a newsletter campaign (admin only)
+// @Summary Get newsletter campaign
+// @Description Returns a campaign with its delivery counts by status (admin only)
+// @Tags newsletter
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} models.Campaign
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/newsletter/campaigns/{id} [get]
+func (h *Handlers) GetCampaign(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid campaign ID")
+		return
+	}
+
+	campaign, err := h.newsletterService.GetCampaign(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "campaign not found" {
+			respondError(c, http.StatusNotFound, "Campaign not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get campaign")
+		return
+	}
+
+	respond(c, http.StatusOK, campaign)
+}
+
+// CreateCampaign composes a newsletter campaign (admin only)
+// @Summary Create newsletter campaign
+// @Description Saves a draft campaign. The Markdown body may use {{.Name}}, {{.Email}} and {{.UnsubscribeURL}} (admin only)
+// @Tags newsletter
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign body service.CampaignRequest true "Campaign"
+// @Success 201 {object} models.Campaign
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/newsletter/campaigns [post]
+func (h *Handlers) CreateCampaign(c *gin.Context) {
+	var req service.CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	campaign, err := h.newsletterService.CreateCampaign(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "invalid template" {
+			respondError(c, http.StatusBadRequest, "Invalid template in body")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create campaign")
+		return
+	}
+
+	respond(c, http.StatusCreated, campaign)
+}
+
+// UpdateCampaign changes a draft newsletter campaign (admin only)
+// @Summary Update newsletter campaign
+// @Description Changes the subject and body of a campaign that has not been sent (admin only)
+// @Tags newsletter
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Param campaign body service.CampaignRequest true "Campaign"
+// @Success 200 {object} models.Campaign
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/newsletter/campaigns/{id} [put]
+func (h *Handlers) UpdateCampaign(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid campaign ID")
+		return
+	}
+
+	var req service.CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	campaign, err := h.newsletterService.UpdateCampaign(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "campaign not found":
+			respondError(c, http.StatusNotFound, "Campaign not found")
+		case "campaign already sent":
+			respondError(c, http.StatusConflict, "Campaign already sent")
+		case "invalid template":
+			respondError(c, http.StatusBadRequest, "Invalid template in body")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update campaign")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, campaign)
+}
+
+// DeleteCampaign deletes a draft newsletter campaign (admin only)
+// @Summary Delete newsletter campaign
+// @Description Deletes a campaign that has not been sent (admin only)
+// @Tags newsletter
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/newsletter/campaigns/{id} [delete]
+func (h *Handlers) DeleteCampaign(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid campaign ID")
+		return
+	}
+
+	if err := h.newsletterService.DeleteCampaign(c.Request.Context(), uint(id)); err != nil {
+		switch err.Error() {
+		case "campaign not found":
+			respondError(c, http.StatusNotFound, "Campaign not found")
+		case "campaign already sent":
+			respondError(c, http.StatusConflict, "Campaign already sent")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to delete campaign")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SendCampaign sends a newsletter campaign (admin only)
+// @Summary Send newsletter campaign
+// @Description Queues a draft for every confirmed subscriber. Emails go out in the background at NEWSLETTER_RATE_PER_MINUTE (admin only)
+// @Tags newsletter
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Success 202 {object} models.Campaign
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /admin/newsletter/campaigns/{id}/send [post]
+func (h *Handlers) SendCampaign(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid campaign ID")
+		return
+	}
+
+	path := apiPath(c, "/admin/newsletter/campaigns/"+c.Param("id")+"/send")
+	campaign, err := h.newsletterService.SendCampaign(c.Request.Context(), uint(id), path)
+	if err != nil {
+		switch err.Error() {
+		case "campaign not found":
+			respondError(c, http.StatusNotFound, "Campaign not found")
+		case "campaign already sent":
+			respondError(c, http.StatusConflict, "Campaign already sent")
+		case "mailer not configured":
+			respondError(c, http.StatusServiceUnavailable, "Email is not configured")
+		case "site URL not configured":
+			respondError(c, http.StatusServiceUnavailable, "SITE_URL is not configured")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to send campaign")
+		}
+		return
+	}
+
+	respond(c, http.StatusAccepted, campaign)
+}
+
+// GetCampaignDeliveries returns the deliveries of a newsletter campaign (admin only)
+// @Summary Get newsletter campaign deliveries
+// @Description Returns the delivery status of a campaign for every recipient (admin only)
+// @Tags newsletter
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Success 200 {array} models.CampaignDelivery
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/newsletter/campaigns/{id}/deliveries [get]
+func (h *Handlers) GetCampaignDeliveries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid campaign ID")
+		return
+	}
+
+	deliveries, err := h.newsletterService.GetCampaignDeliveries(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "campaign not found" {
+			respondError(c, http.StatusNotFound, "Campaign not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get campaign deliveries")
+		return
+	}
+
+	respondList(c, deliveries)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetServices returns all services
+// @Summary Get services
+// @Description Returns the consulting and freelance services on offer
+// @Tags services
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Service
+// @Router /services [get]
+func (h *Handlers) GetServices(c *gin.Context) {
+	offerings, err := h.offeringService.GetServices(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get services")
+		return
+	}
+	respondList(c, offerings)
+}
+
+// CreateService creates a new service entry
+// @Summary Create service
+// @Description Creates a new service entry (admin only)
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param offering body service.ServiceCreateRequest true "Service data"
+// @Success 201 {object} models.Service
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/services [post]
+func (h *Handlers) CreateService(c *gin.Context) {
+	var req service.ServiceCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	offering, err := h.offeringService.CreateService(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to create service")
+		return
+	}
+
+	respond(c, http.StatusCreated, offering)
+}
+
+// UpdateService updates an existing service entry
+// @Summary Update service
+// @Description Updates an existing service entry (admin only)
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Service ID"
+// @Param offering body service.ServiceUpdateRequest true "Service data"
+// @Success 200 {object} models.Service
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/services/{id} [put]
+func (h *Handlers) UpdateService(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid service ID")
+		return
+	}
+
+	var req service.ServiceUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	offering, err := h.offeringService.UpdateService(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "service not found" {
+			respondError(c, http.StatusNotFound, "Service not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update service")
+		return
+	}
+
+	respond(c, http.StatusOK, offering)
+}
+
+// DeleteService deletes a service entry
+// @Summary Delete service
+// @Description Deletes a service entry (admin only)
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Service ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/services/{id} [delete]
+func (h *Handlers) DeleteService(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid service ID")
+		return
+	}
+
+	err = h.offeringService.DeleteService(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "service not found" {
+			respondError(c, http.StatusNotFound, "Service not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete service")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
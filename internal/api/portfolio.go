@@ -0,0 +1,164 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/qr"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPortfolio returns the whole portfolio in one response
+// @Summary Get portfolio
+// @Description Returns the profile together with experiences, education, skills (also grouped by category), projects (also the featured ones by rank), certifications, publications, awards and approved testimonials as one cached document
+// @Tags portfolio
+// @Accept json
+// @Produce json
+// @Param render query string false "Set to html to add summary_html and long_description_html, rendered from Markdown to sanitized HTML"
+// @Success 200 {object} service.Portfolio
+// @Router /portfolio [get]
+func (h *Handlers) GetPortfolio(c *gin.Context) {
+	portfolio, err := h.portfolioService.GetPortfolio(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get portfolio")
+		return
+	}
+	if renderHTML(c) {
+		portfolio = renderPortfolio(portfolio)
+	}
+	if !isAdmin(c) {
+		stripped := *portfolio
+		stripped.Experiences = withoutAttribution(portfolio.Experiences, clearExperience)
+		stripped.Skills = withoutAttribution(portfolio.Skills, clearSkill)
+		stripped.SkillGroups = make([]service.SkillGroup, len(portfolio.SkillGroups))
+		for i, group := range portfolio.SkillGroups {
+			group.Skills = withoutAttribution(group.Skills, clearSkill)
+			stripped.SkillGroups[i] = group
+		}
+		stripped.Projects = withoutAttribution(portfolio.Projects, clearProject)
+		stripped.FeaturedProjects = withoutAttribution(portfolio.FeaturedProjects, clearProject)
+		portfolio = &stripped
+	}
+	respond(c, http.StatusOK, portfolio)
+}
+
+// GetTimeline returns the portfolio timeline
+// @Summary Get timeline
+// @Description Returns experiences, education, certifications, publications, awards, talks and featured project launches as one dated list, newest first. Each entry's type says which content it links to
+// @Tags portfolio
+// @Accept json
+// @Produce json
+// @Param types query string false "Comma-separated entry types to include: experience, education, certification, publication, award, talk, project (default all)"
+// @Success 200 {array} service.TimelineEntry
+// @Failure 400 {object} map[string]interface{}
+// @Router /timeline [get]
+func (h *Handlers) GetTimeline(c *gin.Context) {
+	var types []string
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			types = append(types, strings.TrimSpace(t))
+		}
+	}
+
+	timeline, err := h.portfolioService.GetTimeline(c.Request.Context(), types)
+	if err != nil {
+		if err.Error() == "invalid type" {
+			respondError(c, http.StatusBadRequest, "types must list entry types from: "+strings.Join(service.TimelineTypes, ", "))
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get timeline")
+		return
+	}
+	respondList(c, timeline)
+}
+
+// GetProjectOpenGraph returns social card metadata for a project
+// @Summary Get project OpenGraph metadata
+// @Description Returns the title, description, absolute image URL and canonical URL of a published project for og:* meta tags, so server-side renderers need no formatting logic of their own. The description falls back to the long description as plain text and is cut to 200 characters
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} service.OpenGraph
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /projects/{id}/og [get]
+func (h *Handlers) GetProjectOpenGraph(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	og, err := h.portfolioService.GetProjectOpenGraph(c.Request.Context(), uint(id), requestBaseURL(c))
+	if err != nil {
+		if err.Error() == "project not found" {
+			respondError(c, http.StatusNotFound, "Project not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get project metadata")
+		return
+	}
+
+	respond(c, http.StatusOK, og)
+}
+
+// GetProfileQRPNG returns a QR code for the profile as PNG
+// @Summary Get profile QR code (PNG)
+// @Description Returns a QR code encoding the profile as a vCard, which phones offer to save as a contact, or with content=url the portfolio site URL. For slides and printed resumes
+// @Tags profile
+// @Produce png
+// @Param content query string false "vcard (default) or url"
+// @Param size query int false "Width and height in pixels (64-2048, default 512)"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]interface{}
+// @Router /profile/qr.png [get]
+func (h *Handlers) GetProfileQRPNG(c *gin.Context) {
+	h.writeProfileQR(c, "image/png", qr.PNG)
+}
+
+// GetProfileQRSVG returns a QR code for the profile as SVG
+// @Summary Get profile QR code (SVG)
+// @Description Same as /profile/qr.png as a scalable SVG, which stays sharp at any print size
+// @Tags profile
+// @Produce image/svg+xml
+// @Param content query string false "vcard (default) or url"
+// @Param size query int false "Width and height in pixels (64-2048, default 512)"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]interface{}
+// @Router /profile/qr.svg [get]
+func (h *Handlers) GetProfileQRSVG(c *gin.Context) {
+	h.writeProfileQR(c, "image/svg+xml", qr.SVG)
+}
+
+func (h *Handlers) writeProfileQR(c *gin.Context, contentType string, encode func(string, int) ([]byte, error)) {
+	size := qr.DefaultSize
+	if raw := c.Query("size"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < qr.MinSize || n > qr.MaxSize {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("size must be between %d and %d", qr.MinSize, qr.MaxSize))
+			return
+		}
+		size = n
+	}
+
+	content, err := h.portfolioService.GetProfileQRContent(c.Request.Context(), c.DefaultQuery("content", service.ProfileQRVCard), requestBaseURL(c))
+	if err != nil {
+		if err.Error() == "invalid content" {
+			respondError(c, http.StatusBadRequest, "content must be vcard or url")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get profile")
+		return
+	}
+
+	image, err := encode(content, size)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to generate QR code")
+		return
+	}
+	c.Data(http.StatusOK, contentType, image)
+}
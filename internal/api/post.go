@@ -0,0 +1,214 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPosts returns published blog posts
+// @Summary Get blog posts
+// @Description Returns published blog posts, newest first; admins may add include_drafts=true
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param tag query string false "Filter by tag slug"
+// @Param include_drafts query bool false "Include unpublished and scheduled posts (admin only)"
+// @Param render query string false "Set to html to add body_html, rendered from Markdown to sanitized HTML"
+// @Success 200 {array} models.Post
+// @Failure 401 {object} map[string]interface{}
+// @Router /posts [get]
+func (h *Handlers) GetPosts(c *gin.Context) {
+	drafts, ok := includeDrafts(c)
+	if !ok {
+		return
+	}
+
+	posts, err := h.postService.GetPosts(c.Request.Context(), c.Query("tag"), drafts)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get posts")
+		return
+	}
+	if renderHTML(c) {
+		posts = renderPosts(posts)
+	}
+	if !isAdmin(c) {
+		posts = withoutAttribution(posts, clearPost)
+	}
+	respondList(c, posts)
+}
+
+// GetPost returns a published blog post by slug
+// @Summary Get blog post
+// @Description Returns a single published blog post by its slug; admins may add include_drafts=true
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Param include_drafts query bool false "Allow unpublished and scheduled posts (admin only)"
+// @Param render query string false "Set to html to add body_html, rendered from Markdown to sanitized HTML"
+// @Success 200 {object} models.Post
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /posts/{slug} [get]
+func (h *Handlers) GetPost(c *gin.Context) {
+	drafts, ok := includeDrafts(c)
+	if !ok {
+		return
+	}
+
+	post, err := h.postService.GetPost(c.Request.Context(), c.Param("slug"), drafts)
+	if err != nil {
+		if err.Error() == "post not found" {
+			respondError(c, http.StatusNotFound, "Post not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get post")
+		return
+	}
+	if renderHTML(c) {
+		post = renderPost(post)
+	}
+	if !isAdmin(c) {
+		stripped := *post
+		clearPost(&stripped)
+		post = &stripped
+	}
+	respond(c, http.StatusOK, post)
+}
+
+// GetAllPosts returns all blog posts including drafts (admin only)
+// @Summary Get all blog posts
+// @Description Returns every blog post including drafts and scheduled posts (admin only)
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Post
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/posts [get]
+func (h *Handlers) GetAllPosts(c *gin.Context) {
+	posts, err := h.postService.GetPosts(c.Request.Context(), "", true)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get posts")
+		return
+	}
+	respondList(c, posts)
+}
+
+// CreatePost creates a new blog post (admin only)
+// @Summary Create blog post
+// @Description Creates a new blog post; the slug is derived from the title when omitted (admin only)
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param post body service.PostCreateRequest true "Post data"
+// @Success 201 {object} models.Post
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/posts [post]
+func (h *Handlers) CreatePost(c *gin.Context) {
+	var req service.PostCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	post, err := h.postService.CreatePost(c.Request.Context(), c.GetString("username"), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, err.Error())
+		case "slug already exists":
+			respondError(c, http.StatusConflict, "Slug already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create post")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, post)
+}
+
+// UpdatePost updates a blog post (admin only)
+// @Summary Update blog post
+// @Description Updates an existing blog post (admin only)
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Post ID"
+// @Param post body service.PostUpdateRequest true "Post data"
+// @Success 200 {object} models.Post
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/posts/{id} [put]
+func (h *Handlers) UpdatePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	var req service.PostUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	post, err := h.postService.UpdatePost(c.Request.Context(), uint(id), c.GetString("username"), &req)
+	if err != nil {
+		switch err.Error() {
+		case "post not found":
+			respondError(c, http.StatusNotFound, "Post not found")
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, err.Error())
+		case "slug already exists":
+			respondError(c, http.StatusConflict, "Slug already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update post")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, post)
+}
+
+// DeletePost deletes a blog post (admin only)
+// @Summary Delete blog post
+// @Description Deletes a blog post (admin only)
+// @Tags posts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Post ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/posts/{id} [delete]
+func (h *Handlers) DeletePost(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	err = h.postService.DeletePost(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "post not found" {
+			respondError(c, http.StatusNotFound, "Post not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete post")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddProjectImage adds an image to a project's gallery
+// @Summary Add project image
+// @Description Appends an image to the end of a project's gallery. Images uploaded through /admin/media carry their resized variants (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param image body service.ProjectImageRequest true "Image"
+// @Success 201 {object} models.ProjectImage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/images [post]
+func (h *Handlers) AddProjectImage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req service.ProjectImageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	image, err := h.projectService.AddProjectImage(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "project not found" {
+			respondError(c, http.StatusNotFound, "Project not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to add image")
+		return
+	}
+
+	respond(c, http.StatusCreated, image)
+}
+
+// ReorderProjectImages orders a project's gallery
+// @Summary Reorder project images
+// @Description Orders a project's gallery from a list of every image ID of the project; the first image becomes the cover (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param order body service.ProjectImageReorderRequest true "Image IDs in display order"
+// @Success 200 {array} models.ProjectImage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/images/reorder [put]
+func (h *Handlers) ReorderProjectImages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req service.ProjectImageReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	images, err := h.projectService.ReorderProjectImages(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "project not found":
+			respondError(c, http.StatusNotFound, "Project not found")
+		case "invalid order":
+			respondError(c, http.StatusBadRequest, "Order must list every image of the project exactly once")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to reorder images")
+		}
+		return
+	}
+
+	respondList(c, images)
+}
+
+// DeleteProjectImage removes an image from a project's gallery
+// @Summary Delete project image
+// @Description Removes an image from a project's gallery; the uploaded file itself is kept (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param image_id path int true "Image ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/images/{image_id} [delete]
+func (h *Handlers) DeleteProjectImage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid image ID")
+		return
+	}
+
+	err = h.projectService.DeleteProjectImage(c.Request.Context(), uint(id), uint(imageID))
+	if err != nil {
+		if err.Error() == "image not found" {
+			respondError(c, http.StatusNotFound, "Image not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete image")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateProjectMilestone adds a milestone to a project
+// @Summary Create project milestone
+// @Description Adds a dated milestone, such as a release, to a project's history; project responses list milestones newest first (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param milestone body service.ProjectMilestoneRequest true "Milestone"
+// @Success 201 {object} models.ProjectMilestone
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/milestones [post]
+func (h *Handlers) CreateProjectMilestone(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req service.ProjectMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	milestone, err := h.projectService.CreateProjectMilestone(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "project not found" {
+			respondError(c, http.StatusNotFound, "Project not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create milestone")
+		return
+	}
+
+	respond(c, http.StatusCreated, milestone)
+}
+
+// UpdateProjectMilestone updates a milestone of a project
+// @Summary Update project milestone
+// @Description Replaces a milestone of a project (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param milestone_id path int true "Milestone ID"
+// @Param milestone body service.ProjectMilestoneRequest true "Milestone"
+// @Success 200 {object} models.ProjectMilestone
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/milestones/{milestone_id} [put]
+func (h *Handlers) UpdateProjectMilestone(c *gin.Context) {
+	id, milestoneID, ok := milestonePath(c)
+	if !ok {
+		return
+	}
+
+	var req service.ProjectMilestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	milestone, err := h.projectService.UpdateProjectMilestone(c.Request.Context(), id, milestoneID, &req)
+	if err != nil {
+		if err.Error() == "milestone not found" {
+			respondError(c, http.StatusNotFound, "Milestone not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update milestone")
+		return
+	}
+
+	respond(c, http.StatusOK, milestone)
+}
+
+// DeleteProjectMilestone removes a milestone from a project
+// @Summary Delete project milestone
+// @Description Removes a milestone from a project's history (admin only)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param milestone_id path int true "Milestone ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/projects/{id}/milestones/{milestone_id} [delete]
+func (h *Handlers) DeleteProjectMilestone(c *gin.Context) {
+	id, milestoneID, ok := milestonePath(c)
+	if !ok {
+		return
+	}
+
+	err := h.projectService.DeleteProjectMilestone(c.Request.Context(), id, milestoneID)
+	if err != nil {
+		if err.Error() == "milestone not found" {
+			respondError(c, http.StatusNotFound, "Milestone not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete milestone")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// milestonePath parses the project and milestone IDs in the request path,
+// reporting an invalid one
+func milestonePath(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid project ID")
+		return 0, 0, false
+	}
+	milestoneID, err := strconv.ParseUint(c.Param("milestone_id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid milestone ID")
+		return 0, 0, false
+	}
+	return uint(id), uint(milestoneID), true
+}
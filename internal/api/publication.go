@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPublications returns all publications
+// @Summary Get publications
+// @Description Returns all publications, newest first
+// @Tags publications
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Publication
+// @Router /publications [get]
+func (h *Handlers) GetPublications(c *gin.Context) {
+	publications, err := h.publicationService.GetPublications(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get publications")
+		return
+	}
+	respondList(c, publications)
+}
+
+// CreatePublication creates a new publication entry
+// @Summary Create publication
+// @Description Creates a new publication entry (admin only)
+// @Tags publications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param publication body service.PublicationCreateRequest true "Publication data"
+// @Success 201 {object} models.Publication
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/publications [post]
+func (h *Handlers) CreatePublication(c *gin.Context) {
+	var req service.PublicationCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	publication, err := h.publicationService.CreatePublication(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to create publication")
+		return
+	}
+
+	respond(c, http.StatusCreated, publication)
+}
+
+// UpdatePublication updates an existing publication entry
+// @Summary Update publication
+// @Description Updates an existing publication entry (admin only)
+// @Tags publications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Param publication body service.PublicationUpdateRequest true "Publication data"
+// @Success 200 {object} models.Publication
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/publications/{id} [put]
+func (h *Handlers) UpdatePublication(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid publication ID")
+		return
+	}
+
+	var req service.PublicationUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	publication, err := h.publicationService.UpdatePublication(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "publication not found" {
+			respondError(c, http.StatusNotFound, "Publication not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update publication")
+		return
+	}
+
+	respond(c, http.StatusOK, publication)
+}
+
+// DeletePublication deletes a publication entry
+// @Summary Delete publication
+// @Description Deletes a publication entry (admin only)
+// @Tags publications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Publication ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/publications/{id} [delete]
+func (h *Handlers) DeletePublication(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid publication ID")
+		return
+	}
+
+	err = h.publicationService.DeletePublication(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "publication not found" {
+			respondError(c, http.StatusNotFound, "Publication not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete publication")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/middleware"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Supported API versions
+const (
+	V1 = 1
+	V2 = 2
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// Envelope wraps every v2 response body
+type Envelope struct {
+	Data interface{} `json:"data"`
+	Meta Meta        `json:"meta"`
+}
+
+// Meta carries response metadata for v2 responses
+type Meta struct {
+	APIVersion string      `json:"api_version"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes the page of a v2 list response
+type Pagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// Problem is an RFC 7807 problem details document returned by v2 on errors
+type Problem = middleware.Problem
+
+// apiVersion returns the version negotiated for the request, defaulting to v1
+func apiVersion(c *gin.Context) int {
+	return middleware.Version(c)
+}
+
+// respond writes a successful response in the shape of the negotiated version
+func respond(c *gin.Context, status int, data interface{}) {
+	if apiVersion(c) < V2 {
+		c.JSON(status, data)
+		return
+	}
+
+	c.JSON(status, Envelope{
+		Data: data,
+		Meta: Meta{APIVersion: "v" + strconv.Itoa(apiVersion(c))},
+	})
+}
+
+// respondList writes a list response. v1 returns the full list as a bare
+// array; v2 paginates it with ?page= and ?per_page= and reports the totals.
+func respondList[T any](c *gin.Context, items []T) {
+	if apiVersion(c) < V2 {
+		c.JSON(http.StatusOK, items)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", strconv.Itoa(defaultPerPage)))
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	total := len(items)
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, Envelope{
+		Data: items[start:end],
+		Meta: Meta{
+			APIVersion: "v" + strconv.Itoa(apiVersion(c)),
+			Pagination: &Pagination{
+				Page:       page,
+				PerPage:    perPage,
+				Total:      total,
+				TotalPages: (total + perPage - 1) / perPage,
+			},
+		},
+	})
+}
+
+// respondError writes an error response. v1 keeps the {"error": "..."} body,
+// v2 returns an application/problem+json document. A server error caused by
+// the request deadline passing is reported as 504.
+func respondError(c *gin.Context, status int, detail string) {
+	if status >= http.StatusInternalServerError && errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		status, detail = http.StatusGatewayTimeout, "Request timed out"
+	}
+	writeError(c, status, detail, nil)
+}
+
+// writeError writes an error response in the shape of the negotiated
+// version, listing any failed fields under "errors"
+func writeError(c *gin.Context, status int, detail string, fields []FieldError) {
+	// Server errors are attached to the request for the logs and Sentry
+	if status >= http.StatusInternalServerError {
+		c.Error(errors.New(detail))
+	}
+	middleware.Error(c, status, detail, fields)
+}
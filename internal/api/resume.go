@@ -0,0 +1,192 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportResume returns the portfolio as a JSON Resume document
+// @Summary Export JSON Resume
+// @Description Returns the portfolio as a JSON Resume (jsonresume.org) document. variant picks a resume variant by slug, which replaces the label and summary and keeps only the projects with its tags; without it the default variant is used, or the whole portfolio when there is none
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Param variant query string false "Resume variant slug"
+// @Success 200 {object} service.Resume
+// @Failure 404 {object} map[string]interface{}
+// @Router /resume [get]
+func (h *Handlers) ExportResume(c *gin.Context) {
+	resume, err := h.resumeService.Export(c.Request.Context(), c.Query("variant"))
+	if err != nil {
+		if err.Error() == "resume not found" {
+			respondError(c, http.StatusNotFound, "Resume variant not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to export resume")
+		return
+	}
+	respond(c, http.StatusOK, resume)
+}
+
+// ImportResume imports a JSON Resume document (admin only)
+// @Summary Import JSON Resume
+// @Description Replaces the profile from basics and appends work, education, skills, projects, awards, certificates and publications. Entries that cannot be mapped are reported as skipped (admin only)
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param resume body service.Resume true "JSON Resume document"
+// @Success 200 {object} service.ResumeImportResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/resume/import [post]
+func (h *Handlers) ImportResume(c *gin.Context) {
+	var resume service.Resume
+	if err := c.ShouldBindJSON(&resume); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.resumeService.Import(c.Request.Context(), c.GetString("username"), &resume)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to import resume")
+		return
+	}
+
+	respond(c, http.StatusOK, result)
+}
+
+// GetResumes returns the resume variants
+// @Summary Get resume variants
+// @Description Returns the resume variants, the default first, then by name
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Resume
+// @Router /resumes [get]
+func (h *Handlers) GetResumes(c *gin.Context) {
+	resumes, err := h.resumeService.GetResumes(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get resumes")
+		return
+	}
+	respondList(c, resumes)
+}
+
+// CreateResume creates a resume variant
+// @Summary Create resume variant
+// @Description Creates a resume variant; the slug is derived from the name when omitted. A default variant takes the flag from the previous one (admin only)
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param resume body service.ResumeRequest true "Resume variant data"
+// @Success 201 {object} models.Resume
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/resumes [post]
+func (h *Handlers) CreateResume(c *gin.Context) {
+	var req service.ResumeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	resume, err := h.resumeService.CreateResume(c.Request.Context(), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, "Resume name or slug must contain letters or digits")
+		case "resume already exists":
+			respondError(c, http.StatusConflict, "Resume already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create resume")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, resume)
+}
+
+// UpdateResume updates a resume variant
+// @Summary Update resume variant
+// @Description Replaces a resume variant (admin only)
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Resume ID"
+// @Param resume body service.ResumeRequest true "Resume variant data"
+// @Success 200 {object} models.Resume
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/resumes/{id} [put]
+func (h *Handlers) UpdateResume(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid resume ID")
+		return
+	}
+
+	var req service.ResumeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	resume, err := h.resumeService.UpdateResume(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, "Resume name or slug must contain letters or digits")
+		case "resume not found":
+			respondError(c, http.StatusNotFound, "Resume not found")
+		case "resume already exists":
+			respondError(c, http.StatusConflict, "Resume already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update resume")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, resume)
+}
+
+// DeleteResume deletes a resume variant
+// @Summary Delete resume variant
+// @Description Deletes a resume variant (admin only)
+// @Tags resume
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Resume ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/resumes/{id} [delete]
+func (h *Handlers) DeleteResume(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid resume ID")
+		return
+	}
+
+	err = h.resumeService.DeleteResume(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "resume not found" {
+			respondError(c, http.StatusNotFound, "Resume not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete resume")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
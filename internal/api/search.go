@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Search result page sizes
+const (
+	defaultSearchResults = 20
+	maxSearchResults     = 50
+)
+
+// Search finds published content by name
+// @Summary Search
+// @Description Finds published projects, skills and experiences whose project name, skill name or company resembles the query, best match first. Matching tolerates typos, so misspelled queries such as "kuberentes" still match. Uses the external search engine when one is configured and the database otherwise
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param types query string false "Comma-separated types to search: project, skill, experience (default all)"
+// @Param limit query int false "Number of results, at most 50" default(20)
+// @Success 200 {array} repository.SearchMatch
+// @Failure 400 {object} map[string]interface{}
+// @Router /search [get]
+func (h *Handlers) Search(c *gin.Context) {
+	var types []string
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			types = append(types, strings.TrimSpace(t))
+		}
+	}
+
+	limit := defaultSearchResults
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSearchResults {
+			respondError(c, http.StatusBadRequest, "Limit must be between 1 and 50")
+			return
+		}
+		limit = n
+	}
+
+	matches, err := h.searchService.Search(c.Request.Context(), c.Query("q"), types, limit)
+	if err != nil {
+		switch err.Error() {
+		case "query required":
+			respondError(c, http.StatusBadRequest, "Query parameter q is required")
+		case "invalid type":
+			respondError(c, http.StatusBadRequest, "types must list types from: "+strings.Join(repository.SearchTypes, ", "))
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to search")
+		}
+		return
+	}
+	respondList(c, matches)
+}
+
+// ReindexSearch rebuilds the search engine index
+// @Summary Rebuild search index
+// @Description Queues a rebuild of the external search engine's index from the content of every portfolio. Content changes are indexed as they happen; this repairs an index that was changed or lost outside the server (default tenant admins only)
+// @Tags search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 202
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/search/reindex [post]
+func (h *Handlers) ReindexSearch(c *gin.Context) {
+	if err := h.searchService.Reindex(c.Request.Context()); err != nil {
+		if err.Error() == "search engine not configured" {
+			respondError(c, http.StatusNotFound, "No search engine is configured")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to queue reindex")
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// SemanticSearch finds published content by meaning
+// @Summary Semantic search
+// @Description Finds published projects, experiences and posts whose content is closest in meaning to the query, best match first, so "distributed systems" also finds a project about Kafka pipelines. Scores are cosine similarities of embeddings. Requires an embedding model to be configured
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param types query string false "Comma-separated types to search: project, experience, post (default all)"
+// @Param limit query int false "Number of results, at most 50" default(20)
+// @Success 200 {array} repository.SearchMatch
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 502 {object} map[string]interface{}
+// @Router /search/semantic [get]
+func (h *Handlers) SemanticSearch(c *gin.Context) {
+	var types []string
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			types = append(types, strings.TrimSpace(t))
+		}
+	}
+
+	limit := defaultSearchResults
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxSearchResults {
+			respondError(c, http.StatusBadRequest, "Limit must be between 1 and 50")
+			return
+		}
+		limit = n
+	}
+
+	matches, err := h.embeddingService.SemanticSearch(c.Request.Context(), c.Query("q"), types, limit)
+	if err != nil {
+		switch err.Error() {
+		case "semantic search not configured":
+			respondError(c, http.StatusNotFound, "Semantic search is not configured")
+		case "query required":
+			respondError(c, http.StatusBadRequest, "Query parameter q is required")
+		case "invalid type":
+			respondError(c, http.StatusBadRequest, "types must list types from: "+strings.Join(repository.SemanticSearchTypes, ", "))
+		case "semantic search unavailable":
+			respondError(c, http.StatusBadGateway, "Semantic search is unavailable")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to search")
+		}
+		return
+	}
+	respondList(c, matches)
+}
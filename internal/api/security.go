@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Login history page sizes
+const (
+	defaultLoginEvents = 100
+	maxLoginEvents     = 500
+)
+
+// GetLoginEvents returns the login history (admin only)
+// @Summary Get login history
+// @Description Returns recent successful and failed login attempts, newest first, with the address, browser and location they came from. new_ip and new_country flag the first successful login of a user from an address or country (admin only)
+// @Tags security
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param username query string false "Only attempts with this username"
+// @Param success query bool false "Only successful (true) or failed (false) attempts"
+// @Param limit query int false "Number of attempts, at most 500" default(100)
+// @Success 200 {array} models.LoginEvent
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/security/logins [get]
+func (h *Handlers) GetLoginEvents(c *gin.Context) {
+	var success *bool
+	if value := c.Query("success"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid success filter")
+			return
+		}
+		success = &parsed
+	}
+
+	limit := defaultLoginEvents
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLoginEvents {
+			respondError(c, http.StatusBadRequest, "Limit must be between 1 and 500")
+			return
+		}
+		limit = n
+	}
+
+	events, err := h.authService.GetLoginEvents(c.Request.Context(), c.Query("username"), success, limit)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get login history")
+		return
+	}
+	respondList(c, events)
+}
@@ -0,0 +1,91 @@
+package api
+
+//go:generate mockgen -source=services.go -destination=mocks/services.go -package=mocks
+
+import (
+	"context"
+	"io"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/service"
+)
+
+// The handlers depend on these interfaces rather than on the concrete
+// services, so they can be tested with the generated mocks in
+// internal/api/mocks and httptest without Redis or a database. Each
+// interface only lists what the handlers use.
+
+// ProfileService serves the profile
+type ProfileService interface {
+	GetProfile(ctx context.Context) (*models.Profile, error)
+	UpdateProfile(ctx context.Context, req *service.ProfileUpdateRequest) (*models.Profile, error)
+	UploadAvatar(ctx context.Context, filename string, r io.Reader, crop service.AvatarCrop) (*models.Profile, error)
+}
+
+// ExperienceService serves work experiences
+type ExperienceService interface {
+	GetExperiences(ctx context.Context, includeDrafts bool) ([]models.Experience, error)
+	CreateExperience(ctx context.Context, username string, req *service.ExperienceCreateRequest) (*models.Experience, error)
+	UpdateExperience(ctx context.Context, id uint, username string, req *service.ExperienceUpdateRequest) (*models.Experience, error)
+	ReorderExperiences(ctx context.Context, req *service.ExperienceReorderRequest) ([]models.Experience, error)
+	DeleteExperience(ctx context.Context, id uint) error
+}
+
+// SkillService serves skills and endorsements
+type SkillService interface {
+	GetSkills(ctx context.Context) ([]models.Skill, error)
+	GetSkillGroups(ctx context.Context) ([]service.SkillGroup, error)
+	CreateSkill(ctx context.Context, username string, req *service.SkillCreateRequest) (*models.Skill, error)
+	UpdateSkill(ctx context.Context, id uint, username string, req *service.SkillUpdateRequest) (*models.Skill, error)
+	ReorderSkills(ctx context.Context, req *service.SkillReorderRequest) ([]models.Skill, error)
+	GetSkillCategories(ctx context.Context) ([]service.SkillCategory, error)
+	RenameSkillCategory(ctx context.Context, username string, req *service.SkillCategoryRenameRequest) ([]service.SkillCategory, error)
+	MergeSkillCategories(ctx context.Context, username string, req *service.SkillCategoryMergeRequest) ([]service.SkillCategory, error)
+	MergeSkills(ctx context.Context, username string, req *service.SkillMergeRequest) (*models.Skill, error)
+	DeleteSkill(ctx context.Context, id uint) error
+	EndorseSkill(ctx context.Context, id uint, ipAddress, userAgent string) (*service.EndorsementResult, error)
+	GetSkillSuggestions(ctx context.Context) ([]service.SkillSuggestion, error)
+	AcceptSkillSuggestions(ctx context.Context, username string, req *service.SkillSuggestionAcceptRequest) (*service.SkillSuggestionResult, error)
+}
+
+// ProjectService serves projects
+type ProjectService interface {
+	GetProjects(ctx context.Context, featured *bool, categoryID *uint, tag string, includeDrafts, includeArchived bool) ([]models.Project, error)
+	GetProject(ctx context.Context, id uint, includeDrafts, includeArchived bool) (*models.Project, error)
+	GetProjectSkills(ctx context.Context, id uint) ([]models.Skill, error)
+	GetProjectsBySkill(ctx context.Context, skillID uint) ([]models.Project, error)
+	CreateProject(ctx context.Context, username string, req *service.ProjectCreateRequest) (*models.Project, error)
+	UpdateProject(ctx context.Context, id uint, username string, req *service.ProjectUpdateRequest) (*models.Project, error)
+	DeleteProject(ctx context.Context, id uint) error
+	ArchiveProject(ctx context.Context, id uint, username string, archived bool) (*models.Project, error)
+	ReorderFeaturedProjects(ctx context.Context, req *service.ProjectReorderRequest) ([]models.Project, error)
+	AddProjectImage(ctx context.Context, projectID uint, req *service.ProjectImageRequest) (*models.ProjectImage, error)
+	DeleteProjectImage(ctx context.Context, projectID, imageID uint) error
+	ReorderProjectImages(ctx context.Context, projectID uint, req *service.ProjectImageReorderRequest) ([]models.ProjectImage, error)
+	CreateProjectMilestone(ctx context.Context, projectID uint, req *service.ProjectMilestoneRequest) (*models.ProjectMilestone, error)
+	UpdateProjectMilestone(ctx context.Context, projectID, milestoneID uint, req *service.ProjectMilestoneRequest) (*models.ProjectMilestone, error)
+	DeleteProjectMilestone(ctx context.Context, projectID, milestoneID uint) error
+	LikeProject(ctx context.Context, id uint, ipAddress, userAgent string) (*service.LikeResult, error)
+	GetMostLikedProjects(ctx context.Context, limit int) ([]service.ProjectLikes, error)
+}
+
+// ContactService serves contact submissions, replies and notes
+type ContactService interface {
+	CreateContact(ctx context.Context, req *service.ContactCreateRequest) (*models.Contact, error)
+	GetContacts(ctx context.Context, status, country, email string) ([]models.Contact, error)
+	UpdateContactStatus(ctx context.Context, id uint, status string) (*models.Contact, error)
+	MarkContactSpam(ctx context.Context, id uint) (*models.Contact, error)
+	MarkContactHam(ctx context.Context, id uint) (*models.Contact, error)
+	ExportContactsCSV(ctx context.Context, w io.Writer, status string) error
+	ReplyToContact(ctx context.Context, id, authorID uint, req *service.ContactReplyRequest) (*models.ContactMessage, error)
+	GetMessages(ctx context.Context, id uint) ([]models.ContactMessage, error)
+	AddNote(ctx context.Context, id, authorID uint, author string, req *service.ContactNoteRequest) (*models.ContactNote, error)
+	GetNotes(ctx context.Context, id uint) ([]models.ContactNote, error)
+}
+
+var (
+	_ ProfileService    = (*service.ProfileService)(nil)
+	_ ExperienceService = (*service.ExperienceService)(nil)
+	_ SkillService      = (*service.SkillService)(nil)
+	_ ProjectService    = (*service.ProjectService)(nil)
+	_ ContactService    = (*service.ContactService)(nil)
+)
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FollowShortLink redirects to the target of a short link
+// @Summary Follow short link
+// @Description Counts the visit and redirects to the link's target with 302, so the target can be changed later. utm_* query parameters are passed on to the target
+// @Tags short-links
+// @Param code path string true "Link code"
+// @Success 302
+// @Failure 404 {object} map[string]interface{}
+// @Router /l/{code} [get]
+func (h *Handlers) FollowShortLink(c *gin.Context) {
+	target, err := h.shortLinkService.Resolve(c.Request.Context(), c.Param("code"), c.Request.URL.Query())
+	if err != nil {
+		if err.Error() == "short link not found" {
+			respondError(c, http.StatusNotFound, "Short link not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to follow short link")
+		return
+	}
+
+	c.Redirect(http.StatusFound, target)
+}
+
+// GetShortLinks returns all short links with their click counts
+// @Summary Get short links
+// @Description Returns all short links ordered by code, with how often and when last each was followed (admin only)
+// @Tags short-links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.ShortLink
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/short-links [get]
+func (h *Handlers) GetShortLinks(c *gin.Context) {
+	links, err := h.shortLinkService.GetLinks(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get short links")
+		return
+	}
+	respondList(c, links)
+}
+
+// CreateShortLink creates a new short link
+// @Summary Create short link
+// @Description Creates a short link served at /l/{code}; a random code is generated when omitted. Targets are absolute http(s) URLs or paths on this host (admin only)
+// @Tags short-links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param link body service.ShortLinkCreateRequest true "Link data"
+// @Success 201 {object} models.ShortLink
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/short-links [post]
+func (h *Handlers) CreateShortLink(c *gin.Context) {
+	var req service.ShortLinkCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	link, err := h.shortLinkService.CreateLink(c.Request.Context(), &req)
+	if err != nil {
+		respondShortLinkError(c, err, "Failed to create short link")
+		return
+	}
+
+	respond(c, http.StatusCreated, link)
+}
+
+// UpdateShortLink updates an existing short link
+// @Summary Update short link
+// @Description Changes the code, target or note of a short link, or deactivates it. Click counts are kept (admin only)
+// @Tags short-links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Short link ID"
+// @Param link body service.ShortLinkUpdateRequest true "Link data"
+// @Success 200 {object} models.ShortLink
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/short-links/{id} [put]
+func (h *Handlers) UpdateShortLink(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid short link ID")
+		return
+	}
+
+	var req service.ShortLinkUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	link, err := h.shortLinkService.UpdateLink(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		respondShortLinkError(c, err, "Failed to update short link")
+		return
+	}
+
+	respond(c, http.StatusOK, link)
+}
+
+// DeleteShortLink deletes a short link
+// @Summary Delete short link
+// @Description Deletes a short link; its code answers 404 afterwards (admin only)
+// @Tags short-links
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Short link ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/short-links/{id} [delete]
+func (h *Handlers) DeleteShortLink(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid short link ID")
+		return
+	}
+
+	err = h.shortLinkService.DeleteLink(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "short link not found" {
+			respondError(c, http.StatusNotFound, "Short link not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete short link")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondShortLinkError(c *gin.Context, err error, fallback string) {
+	switch err.Error() {
+	case "short link not found":
+		respondError(c, http.StatusNotFound, "Short link not found")
+	case "invalid code":
+		respondError(c, http.StatusBadRequest, "Code must be lowercase letters, digits and single dashes")
+	case "invalid target":
+		respondError(c, http.StatusBadRequest, "Target must be an http(s) URL or a path starting with /")
+	case "short link already exists":
+		respondError(c, http.StatusConflict, "Short link code is already in use")
+	default:
+		respondError(c, http.StatusInternalServerError, fallback)
+	}
+}
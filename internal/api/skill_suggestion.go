@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSkillSuggestions proposes skills for experiences (admin only)
+// @Summary Get skill suggestions
+// @Description Scans the technologies, description and achievements of every experience, drafts included, for the names of existing skills and of well-known technologies, and lists the experience-skill links that are missing. A suggestion without skill_id names a skill that accepting it creates (admin only)
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} service.SkillSuggestion
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/skills/suggestions [get]
+func (h *Handlers) GetSkillSuggestions(c *gin.Context) {
+	suggestions, err := h.skillService.GetSkillSuggestions(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get skill suggestions")
+		return
+	}
+	respondList(c, suggestions)
+}
+
+// AcceptSkillSuggestions links experiences to skills in bulk (admin only)
+// @Summary Accept skill suggestions
+// @Description Links experiences to skills in one transaction. Each link names an existing skill by skill_id, or a skill by name and category, which is created unless a skill has that name regardless of case. Suggestions can be sent back as they were listed (admin only)
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param links body service.SkillSuggestionAcceptRequest true "Links to add"
+// @Success 200 {object} service.SkillSuggestionResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/skills/suggestions/accept [post]
+func (h *Handlers) AcceptSkillSuggestions(c *gin.Context) {
+	var req service.SkillSuggestionAcceptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.skillService.AcceptSkillSuggestions(c.Request.Context(), c.GetString("username"), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid skill":
+			respondError(c, http.StatusBadRequest, "New skills need a name and a category")
+		case "skill not found":
+			respondError(c, http.StatusNotFound, "Skill not found")
+		case "experience not found":
+			respondError(c, http.StatusNotFound, "Experience not found")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to accept skill suggestions")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, result)
+}
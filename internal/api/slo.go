@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetSLOReport returns per-endpoint SLO compliance (admin only)
+// @Summary Get SLO report
+// @Description Returns per-endpoint success rates, burn rates over rolling windows and remaining error budget (admin only)
+// @Tags slo
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} slo.EndpointReport
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/slo [get]
+func (h *Handlers) GetSLOReport(c *gin.Context) {
+	report, err := h.sloTracker.Report(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get SLO report")
+		return
+	}
+	respondList(c, report)
+}
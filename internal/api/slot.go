@@ -0,0 +1,204 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAvailableSlots returns bookable interview slots
+// @Summary Get available interview slots
+// @Description Returns upcoming, unbooked occurrences of the weekly availability slots
+// @Tags slots
+// @Accept json
+// @Produce json
+// @Param days query int false "Number of days to look ahead (default 14, max 60)"
+// @Success 200 {array} models.SlotOccurrence
+// @Router /slots [get]
+func (h *Handlers) GetAvailableSlots(c *gin.Context) {
+	days, _ := strconv.Atoi(c.Query("days"))
+
+	slots, err := h.slotService.GetAvailableSlots(c.Request.Context(), days)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get slots")
+		return
+	}
+	respondList(c, slots)
+}
+
+// BookSlot books an occurrence of an interview slot
+// @Summary Book interview slot
+// @Description Books an occurrence of a slot, creates a meeting contact and emails a calendar invite
+// @Tags slots
+// @Accept json
+// @Produce json
+// @Param id path int true "Slot ID"
+// @Param booking body service.SlotBookingRequest true "Booking data"
+// @Success 201 {object} models.Booking
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /slots/{id}/book [post]
+func (h *Handlers) BookSlot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid slot ID")
+		return
+	}
+
+	var req service.SlotBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	// Add client information
+	req.IPAddress = c.ClientIP()
+	req.UserAgent = c.GetHeader("User-Agent")
+
+	booking, err := h.slotService.BookSlot(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "slot not found":
+			respondError(c, http.StatusNotFound, "Slot not found")
+		case "slot already booked":
+			respondError(c, http.StatusConflict, "Slot already booked")
+		case "invalid slot time":
+			respondError(c, http.StatusBadRequest, "Requested time does not match an available slot")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to book slot")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, booking)
+}
+
+// GetSlots returns all slot definitions (admin only)
+// @Summary Get slot definitions
+// @Description Returns all weekly availability slots, including inactive ones (admin only)
+// @Tags slots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Slot
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/slots [get]
+func (h *Handlers) GetSlots(c *gin.Context) {
+	slots, err := h.slotService.GetSlots(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get slots")
+		return
+	}
+	respondList(c, slots)
+}
+
+// CreateSlot creates a weekly availability slot
+// @Summary Create slot
+// @Description Creates a weekly availability slot (admin only)
+// @Tags slots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param slot body service.SlotCreateRequest true "Slot data"
+// @Success 201 {object} models.Slot
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/slots [post]
+func (h *Handlers) CreateSlot(c *gin.Context) {
+	var req service.SlotCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	slot, err := h.slotService.CreateSlot(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "invalid start time" || err.Error() == "invalid timezone" {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create slot")
+		return
+	}
+
+	respond(c, http.StatusCreated, slot)
+}
+
+// UpdateSlot updates a weekly availability slot
+// @Summary Update slot
+// @Description Updates a weekly availability slot (admin only)
+// @Tags slots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Slot ID"
+// @Param slot body service.SlotUpdateRequest true "Slot data"
+// @Success 200 {object} models.Slot
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/slots/{id} [put]
+func (h *Handlers) UpdateSlot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid slot ID")
+		return
+	}
+
+	var req service.SlotUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	slot, err := h.slotService.UpdateSlot(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "slot not found":
+			respondError(c, http.StatusNotFound, "Slot not found")
+		case "invalid start time", "invalid timezone":
+			respondError(c, http.StatusBadRequest, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update slot")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, slot)
+}
+
+// DeleteSlot deletes a weekly availability slot
+// @Summary Delete slot
+// @Description Deletes a weekly availability slot (admin only)
+// @Tags slots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Slot ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/slots/{id} [delete]
+func (h *Handlers) DeleteSlot(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid slot ID")
+		return
+	}
+
+	err = h.slotService.DeleteSlot(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "slot not found" {
+			respondError(c, http.StatusNotFound, "Slot not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete slot")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTags returns all tags with usage counts
+// @Summary Get tags
+// @Description Returns all tags ordered by name with the number of published projects, posts and talks carrying each
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.TagUsage
+// @Router /tags [get]
+func (h *Handlers) GetTags(c *gin.Context) {
+	tags, err := h.tagService.GetTags(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get tags")
+		return
+	}
+	respondList(c, tags)
+}
+
+// CreateTag creates a new tag
+// @Summary Create tag
+// @Description Creates a new tag; the slug is derived from the name when omitted (admin only)
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tag body service.TagCreateRequest true "Tag data"
+// @Success 201 {object} models.Tag
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/tags [post]
+func (h *Handlers) CreateTag(c *gin.Context) {
+	var req service.TagCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(c.Request.Context(), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, err.Error())
+		case "tag already exists":
+			respondError(c, http.StatusConflict, "Tag already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create tag")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, tag)
+}
+
+// UpdateTag updates an existing tag
+// @Summary Update tag
+// @Description Renames an existing tag everywhere it is used (admin only)
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Param tag body service.TagUpdateRequest true "Tag data"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/tags/{id} [put]
+func (h *Handlers) UpdateTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	var req service.TagUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tag, err := h.tagService.UpdateTag(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "tag not found":
+			respondError(c, http.StatusNotFound, "Tag not found")
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, err.Error())
+		case "tag already exists":
+			respondError(c, http.StatusConflict, "Tag already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update tag")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, tag)
+}
+
+// DeleteTag deletes a tag
+// @Summary Delete tag
+// @Description Deletes a tag and removes it from every project, post and talk (admin only)
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/tags/{id} [delete]
+func (h *Handlers) DeleteTag(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	err = h.tagService.DeleteTag(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "tag not found" {
+			respondError(c, http.StatusNotFound, "Tag not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete tag")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTalks returns all talks
+// @Summary Get talks
+// @Description Returns all talks and speaking engagements, newest first
+// @Tags talks
+// @Accept json
+// @Produce json
+// @Param tag query string false "Filter by tag slug"
+// @Success 200 {array} models.Talk
+// @Router /talks [get]
+func (h *Handlers) GetTalks(c *gin.Context) {
+	talks, err := h.talkService.GetTalks(c.Request.Context(), c.Query("tag"))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get talks")
+		return
+	}
+	respondList(c, talks)
+}
+
+// CreateTalk creates a new talk entry
+// @Summary Create talk
+// @Description Creates a new talk entry (admin only)
+// @Tags talks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param talk body service.TalkCreateRequest true "Talk data"
+// @Success 201 {object} models.Talk
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/talks [post]
+func (h *Handlers) CreateTalk(c *gin.Context) {
+	var req service.TalkCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	talk, err := h.talkService.CreateTalk(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to create talk")
+		return
+	}
+
+	respond(c, http.StatusCreated, talk)
+}
+
+// UpdateTalk updates an existing talk entry
+// @Summary Update talk
+// @Description Updates an existing talk entry (admin only)
+// @Tags talks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Talk ID"
+// @Param talk body service.TalkUpdateRequest true "Talk data"
+// @Success 200 {object} models.Talk
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/talks/{id} [put]
+func (h *Handlers) UpdateTalk(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid talk ID")
+		return
+	}
+
+	var req service.TalkUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	talk, err := h.talkService.UpdateTalk(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		if err.Error() == "talk not found" {
+			respondError(c, http.StatusNotFound, "Talk not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to update talk")
+		return
+	}
+
+	respond(c, http.StatusOK, talk)
+}
+
+// DeleteTalk deletes a talk entry
+// @Summary Delete talk
+// @Description Deletes a talk entry (admin only)
+// @Tags talks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Talk ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/talks/{id} [delete]
+func (h *Handlers) DeleteTalk(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid talk ID")
+		return
+	}
+
+	err = h.talkService.DeleteTalk(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "talk not found" {
+			respondError(c, http.StatusNotFound, "Talk not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete talk")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
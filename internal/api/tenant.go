@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTenants returns the portfolios hosted by the deployment
+// @Summary Get tenants
+// @Description Returns every tenant with the slug and domains it is served under (default tenant admins only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Tenant
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /admin/tenants [get]
+func (h *Handlers) GetTenants(c *gin.Context) {
+	tenants, err := h.tenantService.GetTenants(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get tenants")
+		return
+	}
+	respondList(c, tenants)
+}
+
+// CreateTenant adds a portfolio to the deployment
+// @Summary Create tenant
+// @Description Creates a tenant together with the admin user that manages its content. The tenant is served under /t/{slug} and on its domains (default tenant admins only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant body service.TenantCreateRequest true "Tenant data"
+// @Success 201 {object} models.Tenant
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/tenants [post]
+func (h *Handlers) CreateTenant(c *gin.Context) {
+	var req service.TenantCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tenant, err := h.tenantService.CreateTenant(c.Request.Context(), &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, "Slug may only contain lowercase letters, digits and hyphens")
+		case "invalid domain":
+			respondError(c, http.StatusBadRequest, "Invalid domain")
+		case "slug already exists":
+			respondError(c, http.StatusConflict, "Slug already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to create tenant")
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, tenant)
+}
+
+// UpdateTenant updates a tenant's slug, name and domains
+// @Summary Update tenant
+// @Description Updates an existing tenant (default tenant admins only)
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tenant ID"
+// @Param tenant body service.TenantUpdateRequest true "Tenant data"
+// @Success 200 {object} models.Tenant
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /admin/tenants/{id} [put]
+func (h *Handlers) UpdateTenant(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid tenant ID")
+		return
+	}
+
+	var req service.TenantUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tenant, err := h.tenantService.UpdateTenant(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "tenant not found":
+			respondError(c, http.StatusNotFound, "Tenant not found")
+		case "invalid slug":
+			respondError(c, http.StatusBadRequest, "Slug may only contain lowercase letters, digits and hyphens")
+		case "invalid domain":
+			respondError(c, http.StatusBadRequest, "Invalid domain")
+		case "slug already exists":
+			respondError(c, http.StatusConflict, "Slug already exists")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update tenant")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, tenant)
+}
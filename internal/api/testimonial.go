@@ -0,0 +1,207 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTestimonials returns approved testimonials
+// @Summary Get testimonials
+// @Description Returns approved testimonials, newest first
+// @Tags testimonials
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Testimonial
+// @Router /testimonials [get]
+func (h *Handlers) GetTestimonials(c *gin.Context) {
+	testimonials, err := h.testimonialService.GetTestimonials(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get testimonials")
+		return
+	}
+	respondList(c, testimonials)
+}
+
+// GetAllTestimonials returns all testimonials including unapproved ones (admin only)
+// @Summary Get all testimonials
+// @Description Returns every testimonial including those awaiting moderation (admin only)
+// @Tags testimonials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Testimonial
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/testimonials [get]
+func (h *Handlers) GetAllTestimonials(c *gin.Context) {
+	testimonials, err := h.testimonialService.GetAllTestimonials(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get testimonials")
+		return
+	}
+	respondList(c, testimonials)
+}
+
+// CreateTestimonial creates a new testimonial
+// @Summary Create testimonial
+// @Description Creates a new testimonial entry (admin only)
+// @Tags testimonials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param testimonial body service.TestimonialCreateRequest true "Testimonial data"
+// @Success 201 {object} models.Testimonial
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/testimonials [post]
+func (h *Handlers) CreateTestimonial(c *gin.Context) {
+	var req service.TestimonialCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	testimonial, err := h.testimonialService.CreateTestimonial(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "company not found" {
+			respondError(c, http.StatusBadRequest, "Company not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create testimonial")
+		return
+	}
+
+	respond(c, http.StatusCreated, testimonial)
+}
+
+// UpdateTestimonial updates an existing testimonial
+// @Summary Update testimonial
+// @Description Updates an existing testimonial entry (admin only)
+// @Tags testimonials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Testimonial ID"
+// @Param testimonial body service.TestimonialUpdateRequest true "Testimonial data"
+// @Success 200 {object} models.Testimonial
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/testimonials/{id} [put]
+func (h *Handlers) UpdateTestimonial(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid testimonial ID")
+		return
+	}
+
+	var req service.TestimonialUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	testimonial, err := h.testimonialService.UpdateTestimonial(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "testimonial not found":
+			respondError(c, http.StatusNotFound, "Testimonial not found")
+		case "company not found":
+			respondError(c, http.StatusBadRequest, "Company not found")
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update testimonial")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, testimonial)
+}
+
+// ApproveTestimonial publishes a testimonial
+// @Summary Approve testimonial
+// @Description Approves a testimonial so it appears in the public listing (admin only)
+// @Tags testimonials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Testimonial ID"
+// @Success 200 {object} models.Testimonial
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/testimonials/{id}/approve [put]
+func (h *Handlers) ApproveTestimonial(c *gin.Context) {
+	h.moderateTestimonial(c, true)
+}
+
+// RejectTestimonial hides a testimonial from the public listing
+// @Summary Reject testimonial
+// @Description Rejects a testimonial so it is hidden from the public listing (admin only)
+// @Tags testimonials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Testimonial ID"
+// @Success 200 {object} models.Testimonial
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/testimonials/{id}/reject [put]
+func (h *Handlers) RejectTestimonial(c *gin.Context) {
+	h.moderateTestimonial(c, false)
+}
+
+func (h *Handlers) moderateTestimonial(c *gin.Context, approved bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid testimonial ID")
+		return
+	}
+
+	testimonial, err := h.testimonialService.ModerateTestimonial(c.Request.Context(), uint(id), approved)
+	if err != nil {
+		if err.Error() == "testimonial not found" {
+			respondError(c, http.StatusNotFound, "Testimonial not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to moderate testimonial")
+		return
+	}
+
+	respond(c, http.StatusOK, testimonial)
+}
+
+// DeleteTestimonial deletes a testimonial
+// @Summary Delete testimonial
+// @Description Deletes a testimonial entry (admin only)
+// @Tags testimonials
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Testimonial ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/testimonials/{id} [delete]
+func (h *Handlers) DeleteTestimonial(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid testimonial ID")
+		return
+	}
+
+	err = h.testimonialService.DeleteTestimonial(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "testimonial not found" {
+			respondError(c, http.StatusNotFound, "Testimonial not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete testimonial")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
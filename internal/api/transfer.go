@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportPortfolio returns the whole portfolio as one document (admin only)
+// @Summary Export portfolio
+// @Description Returns the profile, experiences, skills, categories and projects, drafts included, as a single JSON document (admin only)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} service.PortfolioExport
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/export [get]
+func (h *Handlers) ExportPortfolio(c *gin.Context) {
+	export, err := h.transferService.Export(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to export portfolio")
+		return
+	}
+	respond(c, http.StatusOK, export)
+}
+
+// ImportPortfolio restores a portfolio export (admin only)
+// @Summary Import portfolio
+// @Description Replaces the profile, experiences, skills, categories and projects with an export in one transaction; dry_run=true checks the import and rolls it back (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param dry_run query bool false "Validate the import without applying it"
+// @Param export body service.PortfolioExport true "Portfolio export"
+// @Success 200 {object} service.PortfolioImportResult
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/import [post]
+func (h *Handlers) ImportPortfolio(c *gin.Context) {
+	var doc service.PortfolioExport
+	if err := c.ShouldBindJSON(&doc); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.transferService.Import(c.Request.Context(), &doc, c.Query("dry_run") == "true")
+	if err != nil {
+		if err.Error() == "unsupported export version" || strings.HasPrefix(err.Error(), "invalid import") {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to import portfolio")
+		return
+	}
+
+	respond(c, http.StatusOK, result)
+}
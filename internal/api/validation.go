@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"stackwhiz-portfolio-backend/internal/middleware"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes one request field that failed validation
+type FieldError = middleware.FieldError
+
+func init() {
+	// Report fields by the names clients send rather than the Go names
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// requestFieldName returns the JSON or form name of a request field
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// respondBindError writes a 400 response for a request that failed to bind.
+// Validation failures and mistyped JSON values are listed per field under
+// "errors"; malformed bodies keep the decoder's message.
+func respondBindError(c *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrors):
+		fields := make([]FieldError, len(validationErrors))
+		for i, fe := range validationErrors {
+			fields[i] = FieldError{
+				Field:   fieldPath(fe.Namespace()),
+				Rule:    fe.Tag(),
+				Message: fieldMessage(fe),
+			}
+		}
+		writeError(c, http.StatusBadRequest, "Validation failed", fields)
+	case errors.As(err, &typeError) && typeError.Field != "":
+		writeError(c, http.StatusBadRequest, "Validation failed", []FieldError{{
+			Field:   typeError.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be of type %s", typeError.Field, jsonType(typeError.Type)),
+		}})
+	default:
+		respondError(c, http.StatusBadRequest, err.Error())
+	}
+}
+
+// fieldPath drops the request type from a validator namespace such as
+// ProjectCreateRequest.links[0].url
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// fieldMessage explains a failed rule in words a form can show next to the
+// field
+func fieldMessage(fe validator.FieldError) string {
+	field := fieldPath(fe.Namespace())
+	param := fe.Param()
+
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required", "required_without":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email address"
+	case "url", "http_url":
+		return field + " must be a valid URL"
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s%s", field, param, unit)
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s%s", field, param, unit)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s%s", field, param, unit)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s%s", field, param, unit)
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s%s", field, param, unit)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.Join(strings.Fields(param), ", "))
+	default:
+		return fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+	}
+}
+
+// jsonType names a Go type the way the JSON in a request would spell it
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
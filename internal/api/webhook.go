@@ -0,0 +1,169 @@
+package api
+
+import (
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWebhooks returns all webhook subscriptions (admin only)
+// @Summary Get webhook subscriptions
+// @Description Returns all outbound webhook subscriptions (admin only)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/webhooks [get]
+func (h *Handlers) GetWebhooks(c *gin.Context) {
+	subscriptions, err := h.webhookService.GetSubscriptions(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "Failed to get webhooks")
+		return
+	}
+	respondList(c, subscriptions)
+}
+
+// CreateWebhook creates a webhook subscription (admin only)
+// @Summary Create webhook subscription
+// @Description Subscribes a URL to content change events; a signing secret is generated when omitted (admin only)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook body service.WebhookCreateRequest true "Webhook data"
+// @Success 201 {object} models.WebhookSubscription
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/webhooks [post]
+func (h *Handlers) CreateWebhook(c *gin.Context) {
+	var req service.WebhookCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.Request.Context(), &req)
+	if err != nil {
+		if err.Error() == "invalid webhook url" || err.Error() == "invalid event type" {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to create webhook")
+		return
+	}
+
+	respond(c, http.StatusCreated, subscription)
+}
+
+// UpdateWebhook updates a webhook subscription (admin only)
+// @Summary Update webhook subscription
+// @Description Updates a webhook subscription (admin only)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param webhook body service.WebhookUpdateRequest true "Webhook data"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/webhooks/{id} [put]
+func (h *Handlers) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	var req service.WebhookUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "webhook not found":
+			respondError(c, http.StatusNotFound, "Webhook not found")
+		case "invalid webhook url", "invalid event type":
+			respondError(c, http.StatusBadRequest, err.Error())
+		default:
+			respondError(c, http.StatusInternalServerError, "Failed to update webhook")
+		}
+		return
+	}
+
+	respond(c, http.StatusOK, subscription)
+}
+
+// DeleteWebhook deletes a webhook subscription (admin only)
+// @Summary Delete webhook subscription
+// @Description Deletes a webhook subscription and its delivery history (admin only)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/webhooks/{id} [delete]
+func (h *Handlers) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	err = h.webhookService.DeleteSubscription(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "webhook not found" {
+			respondError(c, http.StatusNotFound, "Webhook not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to delete webhook")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries returns recent deliveries of a webhook (admin only)
+// @Summary Get webhook deliveries
+// @Description Returns the most recent delivery attempts for a webhook subscription (admin only)
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *Handlers) GetWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), uint(id))
+	if err != nil {
+		if err.Error() == "webhook not found" {
+			respondError(c, http.StatusNotFound, "Webhook not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, "Failed to get webhook deliveries")
+		return
+	}
+
+	respondList(c, deliveries)
+}
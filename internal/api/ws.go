@@ -0,0 +1,23 @@
+package api
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminWebSocket opens the admin notification channel (admin only)
+// @Summary Admin notification WebSocket
+// @Description Upgrades to a WebSocket that receives every event (new contacts, content updates, logins from new IPs) as JSON messages. Browsers may pass the admin token as ?token= since they cannot set headers on WebSocket requests.
+// @Tags notifications
+// @Security BearerAuth
+// @Param token query string false "Admin token (alternative to the Authorization header)"
+// @Success 101
+// @Failure 401 {object} map[string]interface{}
+// @Router /admin/ws [get]
+func (h *Handlers) AdminWebSocket(c *gin.Context) {
+	// The upgrader writes its own error response on failure
+	if err := h.hub.ServeWS(c.Writer, c.Request); err != nil {
+		log.Printf("Warning: websocket upgrade failed: %v", err)
+	}
+}
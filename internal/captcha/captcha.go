@@ -0,0 +1,96 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Verification endpoints of the supported providers. Both accept the same
+// form-encoded request and return the same JSON shape.
+var providerURLs = map[string]string{
+	"recaptcha": "https://www.google.com/recaptcha/api/siteverify",
+	"hcaptcha":  "https://api.hcaptcha.com/siteverify",
+}
+
+var (
+	// ErrMissingToken is returned when no token was submitted
+	ErrMissingToken = errors.New("captcha token required")
+	// ErrRejected is returned when the provider does not accept the token
+	ErrRejected = errors.New("captcha verification failed")
+)
+
+// Verifier checks captcha tokens against a provider's siteverify API
+type Verifier struct {
+	endpoint string
+	secret   string
+	client   *http.Client
+}
+
+// NewVerifier returns a verifier for "recaptcha" or "hcaptcha"
+func NewVerifier(provider, secret string) (*Verifier, error) {
+	endpoint, ok := providerURLs[strings.ToLower(provider)]
+	if !ok {
+		return nil, fmt.Errorf("unknown captcha provider %q", provider)
+	}
+	if secret == "" {
+		return nil, errors.New("captcha secret is required")
+	}
+	return &Verifier{
+		endpoint: endpoint,
+		secret:   secret,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// Verify asks the provider whether token is valid for a visitor at remoteIP.
+// It returns ErrMissingToken or ErrRejected for bad tokens and a wrapped
+// error when the provider cannot be reached.
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return ErrMissingToken
+	}
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha: provider returned %s", resp.Status)
+	}
+
+	var result verifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("captcha: invalid provider response: %w", err)
+	}
+	if !result.Success {
+		return ErrRejected
+	}
+	return nil
+}
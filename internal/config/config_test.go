@@ -0,0 +1,23 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrustedProxyList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{" , ", nil},
+		{"10.0.0.1, 172.16.0.0/12", []string{"10.0.0.1", "172.16.0.0/12"}},
+	}
+	for _, tt := range tests {
+		cfg := &Config{TrustedProxies: tt.value}
+		if got := cfg.TrustedProxyList(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("TrustedProxyList(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
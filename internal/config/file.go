@@ -0,0 +1,159 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultConfigFile = "config.yaml"
+
+// loader resolves settings from environment variables first, then from the
+// config file, then from the built-in default. Malformed values are collected
+// in problems instead of silently falling back to the default.
+type loader struct {
+	path     string
+	file     map[string]string // Keyed by the upper-cased variable name
+	used     map[string]bool
+	values   map[string]string // Resolved value of every setting read
+	problems []string
+}
+
+// newLoader reads the YAML config file at path. The file is optional unless
+// its path was set explicitly through CONFIG_FILE.
+func newLoader(path string) (*loader, error) {
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigFile
+	}
+
+	l := &loader{
+		path:   path,
+		file:   map[string]string{},
+		used:   map[string]bool{},
+		values: map[string]string{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return l, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	for key, value := range values {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			l.problemf("%s: config file values must be plain strings, numbers or booleans", key)
+		case nil:
+			// An empty value leaves the setting at its default
+		default:
+			l.file[strings.ToUpper(key)] = fmt.Sprint(value)
+		}
+	}
+	return l, nil
+}
+
+func (l *loader) problemf(format string, args ...interface{}) {
+	l.problems = append(l.problems, fmt.Sprintf(format, args...))
+}
+
+// lookup returns the raw value for key and whether one was configured
+func (l *loader) lookup(key string) (string, bool) {
+	l.used[key] = true
+	if value := os.Getenv(key); value != "" {
+		return value, true
+	}
+	value, ok := l.file[key]
+	return value, ok && value != ""
+}
+
+func (l *loader) getString(key, defaultValue string) string {
+	value, ok := l.lookup(key)
+	if !ok {
+		value = defaultValue
+	}
+	l.values[key] = value
+	return value
+}
+
+func (l *loader) getInt(key string, defaultValue int) int {
+	value, ok := l.lookup(key)
+	if !ok {
+		l.values[key] = fmt.Sprint(defaultValue)
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		l.problemf("%s: %q is not a whole number", key, value)
+		return defaultValue
+	}
+	l.values[key] = value
+	return intValue
+}
+
+func (l *loader) getFloat(key string, defaultValue float64) float64 {
+	value, ok := l.lookup(key)
+	if !ok {
+		l.values[key] = fmt.Sprint(defaultValue)
+		return defaultValue
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		l.problemf("%s: %q is not a number", key, value)
+		return defaultValue
+	}
+	l.values[key] = value
+	return floatValue
+}
+
+func (l *loader) getBool(key string, defaultValue bool) bool {
+	value, ok := l.lookup(key)
+	if !ok {
+		l.values[key] = fmt.Sprint(defaultValue)
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		l.problemf("%s: %q is not true or false", key, value)
+		return defaultValue
+	}
+	l.values[key] = value
+	return boolValue
+}
+
+// checkUnknownKeys reports config file keys that no setting read, which are
+// usually typos
+func (l *loader) checkUnknownKeys() {
+	var unknown []string
+	for key := range l.file {
+		if !l.used[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		l.problemf("%s: unknown setting in config file", strings.ToLower(key))
+	}
+}
+
+// ValidationError lists every problem found while loading the configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Features lists the feature flags and whether each is on by default
+var Features = map[string]bool{
+	"contact_form":    true,
+	"comments":        true,
+	"bookings":        true,
+	"endorsements":    true,
+	"likes":           true,
+	"widgets":         true,
+	"github_activity": true,
+	"newsletter":      true,
+}
+
+// runtimeSettings are the variables applied again when the config file
+// changes; everything else needs a restart
+var runtimeSettings = []string{"RATE_LIMIT", "CORS_ORIGINS", "CACHE_TTL_SECONDS", "FEATURE_FLAGS"}
+
+// Settings whose names end in one of secretSuffixes are shown redacted by
+// Live.Snapshot, so new credentials are covered without being listed here.
+// Webhook URLs carry their credentials in the path.
+var secretSuffixes = []string{"_KEY", "_SECRET", "_TOKEN", "_PASSWORD", "_DSN", "_WEBHOOK_URL"}
+
+// urlSettings may carry credentials in their user info
+var urlSettings = []string{"DATABASE_URL", "DATABASE_REPLICA_URL", "REDIS_URL"}
+
+// Runtime holds the settings that can change while the server runs
+type Runtime struct {
+	RateLimit       int             `json:"rate_limit"`
+	CORSOrigins     []string        `json:"cors_origins"`
+	CacheTTLSeconds int             `json:"cache_ttl_seconds"`
+	Features        map[string]bool `json:"features"`
+}
+
+// Runtime returns the settings of c that can change while the server runs
+func (c *Config) Runtime() *Runtime {
+	// Load has already rejected malformed flags
+	features, _ := parseFeatureFlags(c.FeatureFlags)
+
+	return &Runtime{
+		RateLimit:       c.RateLimit,
+		CORSOrigins:     splitList(c.CORSOrigins),
+		CacheTTLSeconds: c.CacheTTLSeconds,
+		Features:        features,
+	}
+}
+
+// parseFeatureFlags parses "name=bool" pairs separated by "," on top of the
+// defaults in Features
+func parseFeatureFlags(s string) (map[string]bool, error) {
+	flags := make(map[string]bool, len(Features))
+	for name, enabled := range Features {
+		flags[name] = enabled
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, fmt.Errorf("%q is not name=true or name=false", pair)
+		}
+		if _, known := Features[name]; !known {
+			return nil, fmt.Errorf("unknown feature %q", name)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not name=true or name=false", pair)
+		}
+		flags[name] = enabled
+	}
+	return flags, nil
+}
+
+// Live serves the runtime settings and reloads them when the config file
+// changes. Settings from environment variables still take precedence, so
+// only values that come from the file or their defaults can change.
+type Live struct {
+	mu         sync.RWMutex
+	runtime    *Runtime
+	settings   map[string]string
+	file       string
+	reloadedAt time.Time
+
+	interval time.Duration
+	onChange []func(*Runtime)
+}
+
+func NewLive(cfg *Config) *Live {
+	return &Live{
+		runtime:    cfg.Runtime(),
+		settings:   cfg.settings,
+		file:       cfg.File,
+		reloadedAt: time.Now().UTC(),
+		interval:   time.Duration(cfg.ConfigReloadSeconds) * time.Second,
+	}
+}
+
+// OnChange registers fn to be called with the current runtime settings now
+// and again after every reload. Register hooks before calling Run.
+func (l *Live) OnChange(fn func(*Runtime)) {
+	l.onChange = append(l.onChange, fn)
+	fn(l.Runtime())
+}
+
+// Runtime returns the runtime settings in effect
+func (l *Live) Runtime() *Runtime {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.runtime
+}
+
+func (l *Live) RateLimit() int {
+	return l.Runtime().RateLimit
+}
+
+func (l *Live) CORSOrigins() []string {
+	return l.Runtime().CORSOrigins
+}
+
+// CacheTTL returns how long cached content lives
+func (l *Live) CacheTTL() time.Duration {
+	return time.Duration(l.Runtime().CacheTTLSeconds) * time.Second
+}
+
+// Enabled reports whether the named feature flag is on
+func (l *Live) Enabled(feature string) bool {
+	return l.Runtime().Features[feature]
+}
+
+// Snapshot is the effective configuration reported to admins
+type Snapshot struct {
+	File       string            `json:"file"`
+	ReloadedAt time.Time         `json:"reloaded_at"`
+	Runtime    *Runtime          `json:"runtime"`
+	Settings   map[string]string `json:"settings"` // Keyed by lower-cased variable name; secrets redacted
+}
+
+// Snapshot returns the configuration in effect with secrets redacted
+func (l *Live) Snapshot() *Snapshot {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	settings := make(map[string]string, len(l.settings))
+	for key, value := range l.settings {
+		settings[strings.ToLower(key)] = redact(key, value)
+	}
+
+	return &Snapshot{
+		File:       l.file,
+		ReloadedAt: l.reloadedAt,
+		Runtime:    l.runtime,
+		Settings:   settings,
+	}
+}
+
+// Run checks the config file for changes every reload interval until the
+// context is cancelled. An invalid file is logged and the previous settings
+// stay in effect.
+func (l *Live) Run(ctx context.Context) {
+	if l.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	last := fileVersion(l.file)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		version := fileVersion(l.file)
+		if version == last {
+			continue
+		}
+		last = version
+
+		if err := l.reload(); err != nil {
+			log.Printf("Warning: ignoring config file change: %v", err)
+			continue
+		}
+		log.Printf("Configuration reloaded from %s", l.file)
+	}
+}
+
+func (l *Live) reload() error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	runtime := cfg.Runtime()
+
+	l.mu.Lock()
+	settings := make(map[string]string, len(l.settings))
+	for key, value := range l.settings {
+		settings[key] = value
+	}
+	for _, key := range runtimeSettings {
+		settings[key] = cfg.settings[key]
+	}
+	l.runtime = runtime
+	l.settings = settings
+	l.reloadedAt = time.Now().UTC()
+	l.mu.Unlock()
+
+	for _, fn := range l.onChange {
+		fn(runtime)
+	}
+	return nil
+}
+
+// fileVersion identifies the content of the config file cheaply; a missing
+// file has an empty version
+func fileVersion(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ""
+		}
+		return err.Error()
+	}
+	return fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size())
+}
+
+func redact(key, value string) string {
+	if value == "" {
+		return value
+	}
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return "********"
+		}
+	}
+	for _, setting := range urlSettings {
+		if key == setting {
+			if u, err := url.Parse(value); err == nil {
+				return u.Redacted()
+			}
+			return "********"
+		}
+	}
+	return value
+}
@@ -0,0 +1,23 @@
+package config
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"JWT_SECRET", "s3cret", "********"},
+		{"GCS_ACCESS_KEY", "GOOG1E", "********"},
+		{"SLO_ALERT_WEBHOOK_URL", "https://hooks.slack.com/services/T0/B0/x", "********"},
+		{"SENTRY_DSN", "https://key@sentry.io/1", "********"},
+		{"DATABASE_URL", "postgres://app:pw@db/portfolio", "postgres://app:xxxxx@db/portfolio"},
+		{"SITE_URL", "https://example.com", "https://example.com"},
+		{"RATE_LIMIT", "100", "100"},
+		{"SMTP_PASSWORD", "", ""},
+	}
+	for _, tt := range tests {
+		if got := redact(tt.key, tt.value); got != tt.want {
+			t.Errorf("redact(%s) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
@@ -2,26 +2,37 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/tenant"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
-// Initialize sets up the database connection and runs migrations
-func Initialize(databaseURL string) (*gorm.DB, error) {
+// Postgres opens the PostgreSQL database at databaseURL
+func Postgres(databaseURL string) gorm.Dialector {
+	return postgres.Open(databaseURL)
+}
+
+// Initialize sets up the database connection, runs migrations and seeds an
+// empty database from seedFile. When replicaURL is set, read queries are
+// routed to that replica while writes and transactions stay on the primary.
+// The plugins are registered before seeding, so they also apply to the seed.
+func Initialize(dialector gorm.Dialector, replicaURL, seedFile string, plugins ...gorm.Plugin) (*gorm.DB, error) {
 	// Configure GORM logger
 	config := &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	}
 
 	// Connect to database
-	db, err := gorm.Open(postgres.Open(databaseURL), config)
+	db, err := gorm.Open(dialector, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -36,16 +47,46 @@ func Initialize(databaseURL string) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
+	// Limit every query on content tables to the tenant in its context
+	if err := db.Use(tenant.Plugin{}); err != nil {
+		return nil, fmt.Errorf("failed to register tenant plugin: %w", err)
+	}
+	for _, plugin := range plugins {
+		if err := db.Use(plugin); err != nil {
+			return nil, fmt.Errorf("failed to register %s plugin: %w", plugin.Name(), err)
+		}
+	}
+
+	// Content that predates tenants, and the seed, belong to the default one
+	defaultTenant := db.WithContext(tenant.WithID(context.Background(), tenant.DefaultID))
+
 	// Run migrations
-	if err := runMigrations(db); err != nil {
+	if err := runMigrations(defaultTenant); err != nil {
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
 	// Seed initial data if needed
-	if err := seedInitialData(db); err != nil {
+	if err := seedInitialData(defaultTenant, seedFile); err != nil {
 		log.Printf("Warning: failed to seed initial data: %v", err)
 	}
 
+	// Route reads to the replica only after migrating and seeding, which
+	// must read what they write
+	if replicaURL != "" {
+		resolver := dbresolver.Register(dbresolver.Config{
+			Replicas: []gorm.Dialector{postgres.Open(replicaURL)},
+		}).
+			SetMaxIdleConns(10).
+			SetMaxOpenConns(100).
+			SetConnMaxLifetime(time.Hour)
+		if err := db.Use(resolver); err != nil {
+			return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+		}
+		if err := readPrimaryInContext(db); err != nil {
+			return nil, fmt.Errorf("failed to set up primary reads: %w", err)
+		}
+	}
+
 	return db, nil
 }
 
@@ -74,215 +115,359 @@ func InitializeRedis(redisURL string) *redis.Client {
 
 // runMigrations runs database migrations
 func runMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(
+	// Company names were free text before companies existed
+	linkCompanies := !db.Migrator().HasTable(&models.Company{})
+	// Contacts were not threaded by sender before contact groups existed
+	groupContacts := !db.Migrator().HasColumn(&models.Contact{}, "contact_group")
+
+	err := db.AutoMigrate(
 		&models.Profile{},
+		&models.Company{},
 		&models.Experience{},
 		&models.Skill{},
+		&models.Category{},
+		&models.Tag{},
 		&models.Project{},
+		&models.ProjectImage{},
+		&models.ProjectMilestone{},
 		&models.Contact{},
+		&models.ContactMessage{},
+		&models.ContactNote{},
 		&models.User{},
+		&models.LoginEvent{},
+		&models.Slot{},
+		&models.Booking{},
+		&models.EmbedToken{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.Post{},
+		&models.Testimonial{},
+		&models.Comment{},
+		&models.Education{},
+		&models.Certification{},
+		&models.Publication{},
+		&models.Award{},
+		&models.Service{},
+		&models.Talk{},
+		&models.Media{},
+		&models.MediaVariant{},
+		&models.Tenant{},
+		&models.IPRule{},
+		&models.ShortLink{},
+		&models.Resume{},
+		&models.Embedding{},
+		&models.Subscriber{},
+		&models.Campaign{},
+		&models.CampaignDelivery{},
 	)
+	if err != nil {
+		return err
+	}
+
+	if err := createDefaultTenant(db); err != nil {
+		return err
+	}
+	if err := dropGlobalUniqueIndexes(db); err != nil {
+		return err
+	}
+	if err := migrateProjectCategories(db); err != nil {
+		return err
+	}
+	if err := migratePostTags(db); err != nil {
+		return err
+	}
+	if err := migrateProjectImages(db); err != nil {
+		return err
+	}
+	if linkCompanies {
+		if err := migrateCompanies(db); err != nil {
+			return err
+		}
+	}
+	if groupContacts {
+		if err := migrateContactGroups(db); err != nil {
+			return err
+		}
+	}
+	if err := createTrigramIndexes(db); err != nil {
+		return err
+	}
+	enableVectorExtension(db)
+	return nil
 }
 
-// seedInitialData seeds the database with initial data
-func seedInitialData(db *gorm.DB) error {
-	// Check if profile already exists
+// createDefaultTenant adds the tenant that existing content belongs to. On
+// an empty table it is numbered 1, matching the column default.
+func createDefaultTenant(db *gorm.DB) error {
 	var count int64
-	db.Model(&models.Profile{}).Count(&count)
+	if err := db.Model(&models.Tenant{}).Count(&count).Error; err != nil {
+		return err
+	}
 	if count > 0 {
-		return nil // Data already exists
+		return nil
 	}
+	return db.Create(&models.Tenant{Slug: "default", Name: "Default", Domains: []string{}}).Error
+}
 
-	// Create initial profile
-	profile := &models.Profile{
-		Name:     "Your name",
-		Title:    "title",
-		Location: "location",
-		Email:    "email@gmail.com",
-		Phone:    "+123456789",
-		Telegram: "@telegram",
-		GitHub:   "github.com/StackWhiz",
-		Summary:  `summary.`,
-	}
+// globalUniqueIndexes were unique across the whole table before tenants;
+// they are now unique per tenant
+var globalUniqueIndexes = map[string]interface{}{
+	"idx_profiles_email":  &models.Profile{},
+	"idx_skills_name":     &models.Skill{},
+	"idx_categories_name": &models.Category{},
+	"idx_tags_slug":       &models.Tag{},
+	"idx_posts_slug":      &models.Post{},
+	"idx_users_username":  &models.User{},
+	"idx_users_email":     &models.User{},
+}
 
-	if err := db.Create(profile).Error; err != nil {
-		return fmt.Errorf("failed to create initial profile: %w", err)
+// dropGlobalUniqueIndexes removes the indexes that would stop two tenants
+// from using the same slug or name
+func dropGlobalUniqueIndexes(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for name, model := range globalUniqueIndexes {
+		if !migrator.HasIndex(model, name) {
+			continue
+		}
+		if err := migrator.DropIndex(model, name); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Create initial experiences
-	experiences := []models.Experience{
-		{
-			Company:     "Company1",
-			Position:    "Position",
-			Location:    "Remote",
-			StartDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-			Current:     true,
-			Description: "Description",
-			Achievements: []string{
-				"Architected and led backend services in Rust and Go, scaling APIs and microservices to handle millions of daily requests",
-				"Implemented PoS consensus logic and validator services in Rust, enhancing block finality and network reliability",
-				"Built Kafka + Postgres + ClickHouse pipelines processing 50k+ blockchain events per second",
-				"Developed and audited Solidity & Anchor smart contracts for staking, governance, token bridging, and liquidity pools",
-				"Designed DDoS protection strategies (rate-limiting, WAF, caching, load balancing) securing validator RPCs and public APIs",
-				"Containerized workloads with Docker and deployed to Kubernetes (GKE) with Helm, Prometheus/Grafana, and ELK logging",
-				"Established CI/CD pipelines (GitHub Actions + GitLab CI) automating builds, tests, and deployments",
-				"Led and mentored 6 engineers, introducing best practices in distributed systems, DevOps, and blockchain protocol design",
-			},
-			Technologies: []string{"Rust", "Go", "Kafka", "PostgreSQL", "ClickHouse", "Solidity", "Anchor", "Docker", "Kubernetes", "Helm", "Prometheus", "Grafana"},
-		},
-		{
-			Company:     "Company2",
-			Position:    "Position",
-			Location:    "Remote",
-			StartDate:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:     &[]time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}[0],
-			Current:     false,
-			Description: "Developed high-performance trading systems and secure wallet infrastructure",
-			Achievements: []string{
-				"Developed and optimized a Go-based matching engine sustaining 10k+ TPS with <50ms latency",
-				"Designed and deployed trading APIs (REST, WebSocket, gRPC) serving 50k+ concurrent users",
-				"Built secure wallet microservices in Rust with multi-sig and HSM integrations",
-				"Architected DDoS-resistant API gateways with throttling, reverse proxies, and auto-scaling clusters",
-				"Optimized PostgreSQL sharding and Redis caching, boosting performance by 35%",
-				"Automated deployments with CI/CD pipelines (Docker + GitLab CI), reducing release times by 60%",
-				"Delivered 99.99% uptime SLA across multi-region Kubernetes clusters (AWS & GCP)",
-				"Contributed to MEV-resistant order execution logic, mitigating front-running attacks",
-			},
-			Technologies: []string{"Go", "Rust", "PostgreSQL", "Redis", "Docker", "Kubernetes", "AWS", "GCP", "gRPC", "WebSocket"},
-		},
-		{
-			Company:     "Company3",
-			Position:    "Position",
-			Location:    "Remote",
-			StartDate:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:     &[]time.Time{time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}[0],
-			Current:     false,
-			Description: "Built blockchain analytics and transaction indexing systems",
-			Achievements: []string{
-				"Built Rust & Go-based microservices for transaction indexing and real-time blockchain analytics",
-				"Implemented fraud/anomaly detection modules with Kafka + ClickHouse, improving detection accuracy by 20%",
-				"Developed GraphQL + REST APIs serving blockchain insights to enterprise clients",
-				"Designed streaming architectures with Kafka, ClickHouse, and Redis, enabling <1s latency dashboards",
-				"Enhanced node protocols for mempool data capture and transaction propagation, improving throughput by 30%",
-				"Containerized applications with Docker and set up automated pipelines for staging/production",
-			},
-			Technologies: []string{"Rust", "Go", "Kafka", "ClickHouse", "Redis", "GraphQL", "Docker"},
-		},
-		{
-			Company:     "Company4",
-			Position:    "Position",
-			Location:    "Remote",
-			StartDate:   time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
-			EndDate:     &[]time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}[0],
-			Current:     false,
-			Description: "Developed financial transaction processing systems",
-			Achievements: []string{
-				"Developed Go microservices handling 100k+ daily financial transactions",
-				"Integrated ISO8583 and SWIFT protocols, ensuring compliance with global banking standards",
-				"Built fraud detection engines using Redis + Postgres triggers, reducing fraudulent cases by 25%",
-				"Designed secure API gateways with JWT auth, rate-limiting, and RBAC",
-				"Implemented DDoS protection layers with load balancing and request filtering",
-				"Automated compliance reporting workflows, cutting audit effort by 40%",
-			},
-			Technologies: []string{"Go", "PostgreSQL", "Redis", "JWT", "ISO8583", "SWIFT"},
-		},
-	}
+// trigramIndexes are the columns search matches with pg_trgm, by table
+var trigramIndexes = map[string]string{
+	"projects":    "name",
+	"skills":      "name",
+	"experiences": "company",
+}
 
-	for _, exp := range experiences {
-		if err := db.Create(&exp).Error; err != nil {
-			return fmt.Errorf("failed to create experience: %w", err)
+// createTrigramIndexes enables pg_trgm and indexes the searched columns so
+// that typo-tolerant matching does not scan whole tables. Other databases
+// are searched without it.
+func createTrigramIndexes(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return fmt.Errorf("failed to enable pg_trgm: %w", err)
+	}
+	for table, column := range trigramIndexes {
+		err := db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_%s_trgm ON %s USING gin (%s gin_trgm_ops)", table, column, table, column)).Error
+		if err != nil {
+			return err
 		}
 	}
+	return nil
+}
+
+// enableVectorExtension installs pgvector so semantic search ranks
+// embeddings in the database. PostgreSQL servers without the extension
+// keep working, with embeddings compared in Go instead.
+func enableVectorExtension(db *gorm.DB) {
+	if db.Dialector.Name() != "postgres" {
+		return
+	}
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS vector").Error; err != nil {
+		log.Printf("Warning: pgvector is not available, semantic search compares embeddings in Go: %v", err)
+	}
+}
 
-	// Create initial skills
-	skills := []models.Skill{
-		// Languages
-		{Name: "Rust", Category: "Languages", Level: 9, Description: "Systems programming, blockchain development", Icon: "🦀"},
-		{Name: "Go", Category: "Languages", Level: 9, Description: "Backend services, microservices", Icon: "🐹"},
-		{Name: "JavaScript/TypeScript", Category: "Languages", Level: 8, Description: "Full-stack development", Icon: "🟨"},
-		{Name: "Python", Category: "Languages", Level: 7, Description: "Data processing, automation", Icon: "🐍"},
-		{Name: "Solidity", Category: "Languages", Level: 8, Description: "Smart contract development", Icon: "⛓️"},
-
-		// Frameworks
-		{Name: "Actix", Category: "Frameworks", Level: 8, Description: "Rust web framework", Icon: "⚡"},
-		{Name: "Axum", Category: "Frameworks", Level: 7, Description: "Rust async web framework", Icon: "🪶"},
-		{Name: "Echo", Category: "Frameworks", Level: 8, Description: "Go web framework", Icon: "🌊"},
-		{Name: "Gin", Category: "Frameworks", Level: 8, Description: "Go HTTP web framework", Icon: "🍸"},
-		{Name: "Express.js", Category: "Frameworks", Level: 7, Description: "Node.js web framework", Icon: "🚀"},
-		{Name: "NestJS", Category: "Frameworks", Level: 7, Description: "Node.js enterprise framework", Icon: "🏗️"},
-
-		// Blockchain
-		{Name: "Consensus Algorithms", Category: "Blockchain", Level: 9, Description: "PoS, BFT consensus implementation", Icon: "🔗"},
-		{Name: "Validator Nodes", Category: "Blockchain", Level: 9, Description: "Blockchain validator infrastructure", Icon: "⚖️"},
-		{Name: "MEV & DeFi", Category: "Blockchain", Level: 8, Description: "MEV infrastructure, DeFi protocols", Icon: "💰"},
-		{Name: "P2P Networking", Category: "Blockchain", Level: 8, Description: "Distributed network protocols", Icon: "🌐"},
-
-		// DevOps
-		{Name: "Docker", Category: "DevOps", Level: 9, Description: "Containerization", Icon: "🐳"},
-		{Name: "Kubernetes", Category: "DevOps", Level: 8, Description: "Container orchestration", Icon: "☸️"},
-		{Name: "Helm", Category: "DevOps", Level: 7, Description: "Kubernetes package manager", Icon: "⛵"},
-		{Name: "AWS", Category: "DevOps", Level: 8, Description: "Cloud infrastructure", Icon: "☁️"},
-		{Name: "Azure", Category: "DevOps", Level: 7, Description: "Microsoft cloud platform", Icon: "🔷"},
-
-		// Databases
-		{Name: "PostgreSQL", Category: "Databases", Level: 9, Description: "Relational database", Icon: "🐘"},
-		{Name: "Redis", Category: "Databases", Level: 8, Description: "In-memory data store", Icon: "🔴"},
-		{Name: "ClickHouse", Category: "Databases", Level: 7, Description: "Analytical database", Icon: "📊"},
-		{Name: "MongoDB", Category: "Databases", Level: 6, Description: "NoSQL document database", Icon: "🍃"},
-		{Name: "Cassandra", Category: "Databases", Level: 6, Description: "Distributed NoSQL database", Icon: "🗃️"},
+// migrateProjectCategories moves the free-text project category column onto
+// Category rows, merging names that only differ by case, and then drops it.
+// It does nothing once the column is gone.
+func migrateProjectCategories(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Project{}, "category") {
+		return nil
 	}
 
-	for _, skill := range skills {
-		if err := db.Create(&skill).Error; err != nil {
-			return fmt.Errorf("failed to create skill: %w", err)
+	return db.Transaction(func(tx *gorm.DB) error {
+		var names []string
+		err := tx.Model(&models.Project{}).
+			Where("category IS NOT NULL AND TRIM(category) <> ''").
+			Distinct().
+			Pluck("TRIM(category)", &names).Error
+		if err != nil {
+			return err
 		}
-	}
 
-	// Create initial projects
-	projects := []models.Project{
-		{
-			Name:            "High-Performance Trading Engine",
-			Description:     "Go-based matching engine sustaining 10k+ TPS with <50ms latency",
-			LongDescription: "Built a high-frequency trading engine using Go with custom data structures and memory optimization techniques. Implemented order matching algorithms, real-time market data distribution, and risk management systems.",
-			Technologies:    []string{"Go", "Redis", "PostgreSQL", "WebSocket", "gRPC"},
-			Category:        "Backend",
-			Featured:        true,
-			Status:          "completed",
-		},
-		{
-			Name:            "Blockchain Validator Infrastructure",
-			Description:     "Rust-based validator services with PoS consensus implementation",
-			LongDescription: "Developed and deployed blockchain validator infrastructure using Rust. Implemented custom consensus algorithms, P2P networking protocols, and monitoring systems for high availability.",
-			Technologies:    []string{"Rust", "Docker", "Kubernetes", "Prometheus", "Grafana"},
-			Category:        "Blockchain",
-			Featured:        true,
-			Status:          "completed",
-		},
-		{
-			Name:            "Real-time Analytics Pipeline",
-			Description:     "Kafka + ClickHouse pipeline processing 50k+ blockchain events per second",
-			LongDescription: "Architected a real-time data processing pipeline for blockchain analytics. Built streaming data ingestion, real-time aggregation, and dashboard systems for enterprise clients.",
-			Technologies:    []string{"Kafka", "ClickHouse", "Rust", "Go", "Redis"},
-			Category:        "Backend",
-			Featured:        true,
-			Status:          "completed",
-		},
-		{
-			Name:            "Smart Contract Suite",
-			Description:     "Solidity & Anchor smart contracts for DeFi protocols",
-			LongDescription: "Developed comprehensive smart contract suite including staking mechanisms, governance systems, token bridging protocols, and liquidity pools with security audits.",
-			Technologies:    []string{"Solidity", "Anchor", "Rust", "TypeScript"},
-			Category:        "Blockchain",
-			Featured:        true,
-			Status:          "completed",
-		},
+		for _, name := range names {
+			var category models.Category
+			err := tx.Where("LOWER(name) = LOWER(?)", name).
+				Attrs(models.Category{Name: name}).
+				FirstOrCreate(&category).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Model(&models.Project{}).
+				Where("category_id IS NULL AND LOWER(TRIM(category)) = LOWER(?)", name).
+				Update("category_id", category.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Migrator().DropColumn(&models.Project{}, "category")
+	})
+}
+
+// migratePostTags moves the tag names stored as JSON on posts onto Tag rows
+// and then drops the column. It does nothing once the column is gone.
+func migratePostTags(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Post{}, "tags") {
+		return nil
 	}
 
-	for _, project := range projects {
-		if err := db.Create(&project).Error; err != nil {
-			return fmt.Errorf("failed to create project: %w", err)
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []struct {
+			ID   uint
+			Tags string
+		}
+		err := tx.Table("posts").Select("id, tags").Where("tags IS NOT NULL").Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			var names []string
+			if err := json.Unmarshal([]byte(row.Tags), &names); err != nil {
+				log.Printf("Warning: skipping unreadable tags on post %d: %v", row.ID, err)
+				continue
+			}
+
+			for _, name := range names {
+				slug := models.Slugify(name)
+				if slug == "" {
+					continue
+				}
+
+				var tag models.Tag
+				err := tx.Where("slug = ?", slug).
+					Attrs(models.Tag{Name: name, Slug: slug}).
+					FirstOrCreate(&tag).Error
+				if err != nil {
+					return err
+				}
+
+				err = tx.Exec("INSERT INTO post_tags (post_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", row.ID, tag.ID).Error
+				if err != nil {
+					return err
+				}
+			}
 		}
+
+		return tx.Migrator().DropColumn(&models.Post{}, "tags")
+	})
+}
+
+// migrateCompanies creates a company for every company name on the
+// experiences and testimonials of each tenant and links the rows to it.
+// Names differing only by case share one company, named as first seen.
+func migrateCompanies(db *gorm.DB) error {
+	db = db.WithContext(tenant.All(db.Statement.Context))
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.Experience{}, &models.Testimonial{}} {
+			var rows []struct {
+				TenantID uint
+				Name     string
+			}
+			err := tx.Model(model).
+				Select("DISTINCT tenant_id, TRIM(company) AS name").
+				Where("company_id IS NULL AND company IS NOT NULL AND TRIM(company) <> ''").
+				Order("tenant_id, name").
+				Scan(&rows).Error
+			if err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				var company models.Company
+				err := tx.Where("tenant_id = ? AND LOWER(name) = LOWER(?)", row.TenantID, row.Name).
+					Attrs(models.Company{TenantID: row.TenantID, Name: row.Name}).
+					FirstOrCreate(&company).Error
+				if err != nil {
+					return err
+				}
+
+				err = tx.Model(model).
+					Where("tenant_id = ? AND company_id IS NULL AND LOWER(TRIM(company)) = LOWER(?)", row.TenantID, row.Name).
+					Update("company_id", company.ID).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// migrateContactGroups threads the contacts of every tenant by email
+// address, ignoring case, naming each thread after its first submission
+// and flagging the later ones as repeats
+func migrateContactGroups(db *gorm.DB) error {
+	db = db.WithContext(tenant.All(db.Statement.Context))
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Contact{}).Where("1 = 1").
+			UpdateColumn("contact_group", gorm.Expr("(SELECT MIN(earliest.id) FROM contacts AS earliest "+
+				"WHERE earliest.tenant_id = contacts.tenant_id AND LOWER(earliest.email) = LOWER(contacts.email))")).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.Contact{}).Where("id <> contact_group").UpdateColumn("repeat", true).Error
+	})
+}
+
+// migrateProjectImages moves the single image of each project into its
+// gallery, together with the image's variants, and then drops the image
+// columns. It does nothing once the columns are gone.
+func migrateProjectImages(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Project{}, "image_url") {
+		return nil
 	}
 
-	return nil
+	// Projects of every tenant move, each image keeping its project's tenant
+	db = db.WithContext(tenant.All(db.Statement.Context))
+	return db.Transaction(func(tx *gorm.DB) error {
+		var rows []struct {
+			ID            uint
+			TenantID      uint
+			ImageURL      string
+			ImageVariants string
+		}
+		err := tx.Table("projects").
+			Select("id, tenant_id, image_url, image_variants").
+			Where("image_url IS NOT NULL AND image_url <> ''").
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		// SQLite drops a column by rebuilding the table, which would cascade
+		// to images already pointing at it
+		for _, column := range []string{"image_url", "image_variants"} {
+			if err := tx.Migrator().DropColumn(&models.Project{}, column); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range rows {
+			image := models.ProjectImage{
+				TenantID:  row.TenantID,
+				ProjectID: row.ID,
+				URL:       row.ImageURL,
+				Position:  1,
+			}
+			if row.ImageVariants != "" {
+				if err := json.Unmarshal([]byte(row.ImageVariants), &image.Variants); err != nil {
+					log.Printf("Warning: dropping unreadable image variants of project %d: %v", row.ID, err)
+				}
+			}
+			if err := tx.Create(&image).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
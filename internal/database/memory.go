@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// InitializeMemoryRedis starts an in-process Redis replacement for embedded
+// mode and returns a client connected to it. Its content is lost when the
+// server stops, which suits the caches, locks and counters kept there.
+func InitializeMemoryRedis(ctx context.Context) (*redis.Client, error) {
+	server := miniredis.NewMiniRedis()
+	if err := server.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start in-memory Redis: %w", err)
+	}
+
+	// The in-memory server only expires keys when its clock is advanced
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				server.Close()
+				return
+			case <-ticker.C:
+				server.FastForward(time.Second)
+			}
+		}
+	}()
+
+	return redis.NewClient(&redis.Options{Addr: server.Addr()}), nil
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type primaryKey struct{}
+
+// Primary returns a copy of ctx whose queries read from the primary even
+// when a replica is configured, for reads that must see a write just made,
+// such as refilling caches after it. A lagging replica would put the data
+// from before the write back in the cache for its whole lifetime.
+func Primary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// readPrimaryInContext sends the reads of contexts made by Primary to the
+// primary. The resolver has already picked a connection by then, so it is
+// run again with the write mode set.
+func readPrimaryInContext(db *gorm.DB) error {
+	readPrimary := func(db *gorm.DB) {
+		if db.Statement.Context != nil && db.Statement.Context.Value(primaryKey{}) == true {
+			dbresolver.Write.ModifyStatement(db.Statement)
+		}
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("database:read_primary", readPrimary); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("database:read_primary", readPrimary); err != nil {
+		return err
+	}
+	return db.Callback().Raw().Before("gorm:raw").Register("database:read_primary", readPrimary)
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type note struct {
+	ID   uint
+	Text string
+}
+
+func TestPrimaryReadsSkipReplica(t *testing.T) {
+	dir := t.TempDir()
+	open := func(name string) gorm.Dialector {
+		dialector, err := SQLite(filepath.Join(dir, name))
+		if err != nil {
+			t.Skip(err)
+		}
+		return dialector
+	}
+
+	// The replica has the table but not the row just written, as if lagging
+	replica, err := gorm.Open(open("replica.db"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := replica.AutoMigrate(&note{}); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open(open("primary.db"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&note{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: []gorm.Dialector{open("replica.db")}})); err != nil {
+		t.Fatal(err)
+	}
+	if err := readPrimaryInContext(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&note{Text: "written"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var count int64
+	db.WithContext(context.Background()).Model(&note{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("plain read found %d rows, want the replica's 0", count)
+	}
+	var notes []note
+	if err := db.WithContext(Primary(context.Background())).Find(&notes).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 1 {
+		t.Errorf("primary read found %d rows, want 1", len(notes))
+	}
+}
@@ -0,0 +1,265 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+const seedDateLayout = "2006-01-02"
+
+// SeedData is the content of a seed file
+type SeedData struct {
+	Profile     SeedProfile      `yaml:"profile" json:"profile" validate:"required"`
+	Experiences []SeedExperience `yaml:"experiences" json:"experiences" validate:"dive"`
+	Skills      []SeedSkill      `yaml:"skills" json:"skills" validate:"dive"`
+	Categories  []SeedCategory   `yaml:"categories" json:"categories" validate:"dive"`
+	Projects    []SeedProject    `yaml:"projects" json:"projects" validate:"dive"`
+}
+
+type SeedProfile struct {
+	Name      string `yaml:"name" json:"name" validate:"required"`
+	Title     string `yaml:"title" json:"title" validate:"required"`
+	Location  string `yaml:"location" json:"location"`
+	Email     string `yaml:"email" json:"email" validate:"required,email"`
+	Phone     string `yaml:"phone" json:"phone"`
+	Telegram  string `yaml:"telegram" json:"telegram"`
+	GitHub    string `yaml:"github" json:"github"`
+	LinkedIn  string `yaml:"linkedin" json:"linkedin"`
+	Summary   string `yaml:"summary" json:"summary"`
+	Avatar    string `yaml:"avatar" json:"avatar"`
+	ResumeURL string `yaml:"resume_url" json:"resume_url"`
+}
+
+type SeedExperience struct {
+	Company      string   `yaml:"company" json:"company" validate:"required"`
+	Position     string   `yaml:"position" json:"position" validate:"required"`
+	Location     string   `yaml:"location" json:"location"`
+	StartDate    string   `yaml:"start_date" json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string   `yaml:"end_date" json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	Current      bool     `yaml:"current" json:"current"`
+	Description  string   `yaml:"description" json:"description"`
+	Achievements []string `yaml:"achievements" json:"achievements"`
+	Technologies []string `yaml:"technologies" json:"technologies"`
+}
+
+type SeedSkill struct {
+	Name        string `yaml:"name" json:"name" validate:"required"`
+	Category    string `yaml:"category" json:"category" validate:"required"`
+	Level       int    `yaml:"level" json:"level" validate:"min=1,max=10"`
+	Description string `yaml:"description" json:"description"`
+	Icon        string `yaml:"icon" json:"icon"`
+}
+
+type SeedCategory struct {
+	Name        string `yaml:"name" json:"name" validate:"required"`
+	Description string `yaml:"description" json:"description"`
+}
+
+type SeedProject struct {
+	Name            string   `yaml:"name" json:"name" validate:"required"`
+	Description     string   `yaml:"description" json:"description" validate:"required"`
+	LongDescription string   `yaml:"long_description" json:"long_description"`
+	Technologies    []string `yaml:"technologies" json:"technologies"`
+	GitHubURL       string   `yaml:"github_url" json:"github_url" validate:"omitempty,url"`
+	LiveURL         string   `yaml:"live_url" json:"live_url" validate:"omitempty,url"`
+	ImageURL        string   `yaml:"image_url" json:"image_url"` // Becomes the first gallery image
+	Category        string   `yaml:"category" json:"category"`   // Name of one of the seeded categories
+	Featured        bool     `yaml:"featured" json:"featured"`
+	Status          string   `yaml:"status" json:"status" validate:"omitempty,oneof=completed in-progress planned"`
+}
+
+// LoadSeedFile reads and validates a seed file. Files ending in .json are
+// parsed as JSON, anything else as YAML.
+func LoadSeedFile(path string) (*SeedData, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var data SeedData
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(raw, &data)
+	} else {
+		err = yaml.Unmarshal(raw, &data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := data.validate(); err != nil {
+		return nil, fmt.Errorf("invalid seed file %s: %w", path, err)
+	}
+	return &data, nil
+}
+
+// validate checks field rules and the references between entries
+func (d *SeedData) validate() error {
+	if err := validator.New().Struct(d); err != nil {
+		return err
+	}
+
+	skills := make(map[string]bool, len(d.Skills))
+	for _, skill := range d.Skills {
+		key := strings.ToLower(skill.Name)
+		if skills[key] {
+			return fmt.Errorf("skill %q is listed twice", skill.Name)
+		}
+		skills[key] = true
+	}
+
+	categories := make(map[string]bool, len(d.Categories))
+	for _, category := range d.Categories {
+		categories[category.Name] = true
+	}
+	for _, project := range d.Projects {
+		if project.Category != "" && !categories[project.Category] {
+			return fmt.Errorf("project %q uses unknown category %q", project.Name, project.Category)
+		}
+	}
+
+	for _, experience := range d.Experiences {
+		if experience.EndDate != "" && experience.EndDate < experience.StartDate {
+			return fmt.Errorf("experience at %q ends before it starts", experience.Company)
+		}
+	}
+	return nil
+}
+
+// seedInitialData fills an empty database from the seed file. Nothing is
+// inserted when a profile already exists or no seed file is available.
+func seedInitialData(db *gorm.DB, seedFile string) error {
+	// Check if profile already exists
+	var count int64
+	db.Model(&models.Profile{}).Count(&count)
+	if count > 0 {
+		return nil // Data already exists
+	}
+
+	if seedFile == "" {
+		return nil
+	}
+	data, err := LoadSeedFile(seedFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("seed file %s not found, starting with an empty portfolio", seedFile)
+		}
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return data.insert(tx)
+	})
+}
+
+func (d *SeedData) insert(tx *gorm.DB) error {
+	profile := &models.Profile{
+		Name:      d.Profile.Name,
+		Title:     d.Profile.Title,
+		Location:  d.Profile.Location,
+		Email:     d.Profile.Email,
+		Phone:     d.Profile.Phone,
+		Telegram:  d.Profile.Telegram,
+		GitHub:    d.Profile.GitHub,
+		LinkedIn:  d.Profile.LinkedIn,
+		Summary:   d.Profile.Summary,
+		Avatar:    d.Profile.Avatar,
+		ResumeURL: d.Profile.ResumeURL,
+	}
+	if err := tx.Create(profile).Error; err != nil {
+		return fmt.Errorf("failed to create initial profile: %w", err)
+	}
+
+	for _, seed := range d.Experiences {
+		// Dates were checked by validate
+		startDate, _ := time.Parse(seedDateLayout, seed.StartDate)
+		var endDate *time.Time
+		if seed.EndDate != "" {
+			date, _ := time.Parse(seedDateLayout, seed.EndDate)
+			endDate = &date
+		}
+
+		var company models.Company
+		err := tx.Where("LOWER(name) = LOWER(?)", seed.Company).
+			Attrs(models.Company{Name: seed.Company}).
+			FirstOrCreate(&company).Error
+		if err != nil {
+			return fmt.Errorf("failed to create company: %w", err)
+		}
+
+		experience := &models.Experience{
+			Company:      company.Name,
+			CompanyID:    &company.ID,
+			Position:     seed.Position,
+			Location:     seed.Location,
+			StartDate:    startDate,
+			EndDate:      endDate,
+			Current:      seed.Current,
+			Description:  seed.Description,
+			Achievements: seed.Achievements,
+			Technologies: seed.Technologies,
+		}
+		if err := tx.Create(experience).Error; err != nil {
+			return fmt.Errorf("failed to create experience: %w", err)
+		}
+	}
+
+	for i, seed := range d.Skills {
+		skill := &models.Skill{
+			Name:        seed.Name,
+			Category:    seed.Category,
+			Level:       seed.Level,
+			Description: seed.Description,
+			Icon:        seed.Icon,
+			Position:    i + 1,
+		}
+		if err := tx.Create(skill).Error; err != nil {
+			return fmt.Errorf("failed to create skill: %w", err)
+		}
+	}
+
+	categoryIDs := make(map[string]uint, len(d.Categories))
+	for _, seed := range d.Categories {
+		category := &models.Category{Name: seed.Name, Description: seed.Description}
+		if err := tx.Create(category).Error; err != nil {
+			return fmt.Errorf("failed to create category: %w", err)
+		}
+		categoryIDs[seed.Name] = category.ID
+	}
+
+	for _, seed := range d.Projects {
+		project := &models.Project{
+			Name:            seed.Name,
+			Description:     seed.Description,
+			LongDescription: seed.LongDescription,
+			Technologies:    seed.Technologies,
+			GitHubURL:       seed.GitHubURL,
+			LiveURL:         seed.LiveURL,
+			Featured:        seed.Featured,
+			Status:          seed.Status,
+		}
+		if id, ok := categoryIDs[seed.Category]; ok {
+			project.CategoryID = &id
+		}
+		if seed.ImageURL != "" {
+			project.Images = []models.ProjectImage{{URL: seed.ImageURL, Position: 1}}
+		}
+		if project.Status == "" {
+			project.Status = "completed"
+		}
+		if err := tx.Create(project).Error; err != nil {
+			return fmt.Errorf("failed to create project: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build cgo
+
+package database
+
+import (
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// SQLite opens the database file at path for embedded mode. Write-ahead
+// logging lets readers continue while a write is in progress, and writers
+// wait for each other instead of failing with "database is locked".
+func SQLite(path string) (gorm.Dialector, error) {
+	return sqlite.Open(path + "?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on"), nil
+}
@@ -0,0 +1,14 @@
+//go:build !cgo
+
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// SQLite is unavailable without cgo, which the SQLite driver needs
+func SQLite(path string) (gorm.Dialector, error) {
+	return nil, errors.New("embedded mode needs a build with CGO_ENABLED=1")
+}
@@ -0,0 +1,174 @@
+package events
+
+import (
+	"context"
+	"log"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types published by the services
+const (
+	ProfileUpdated       = "profile.updated"
+	ExperienceCreated    = "experience.created"
+	ExperienceUpdated    = "experience.updated"
+	ExperienceDeleted    = "experience.deleted"
+	ExperiencesReordered = "experience.reordered"
+	SkillCreated         = "skill.created"
+	SkillUpdated         = "skill.updated"
+	SkillDeleted         = "skill.deleted"
+	SkillsReordered      = "skill.reordered"
+	SkillsRecategorized  = "skill.recategorized"
+	ProjectCreated       = "project.created"
+	ProjectUpdated       = "project.updated"
+	ProjectDeleted       = "project.deleted"
+	ProjectsReordered    = "project.reordered"
+	PostCreated          = "post.created"
+	PostUpdated          = "post.updated"
+	PostDeleted          = "post.deleted"
+	TestimonialCreated   = "testimonial.created"
+	TestimonialUpdated   = "testimonial.updated"
+	TestimonialDeleted   = "testimonial.deleted"
+	EducationCreated     = "education.created"
+	EducationUpdated     = "education.updated"
+	EducationDeleted     = "education.deleted"
+	CertificationCreated = "certification.created"
+	CertificationUpdated = "certification.updated"
+	CertificationDeleted = "certification.deleted"
+	PublicationCreated   = "publication.created"
+	PublicationUpdated   = "publication.updated"
+	PublicationDeleted   = "publication.deleted"
+	AwardCreated         = "award.created"
+	AwardUpdated         = "award.updated"
+	AwardDeleted         = "award.deleted"
+	ServiceCreated       = "service.created"
+	ServiceUpdated       = "service.updated"
+	ServiceDeleted       = "service.deleted"
+	TalkCreated          = "talk.created"
+	TalkUpdated          = "talk.updated"
+	TalkDeleted          = "talk.deleted"
+	CategoryCreated      = "category.created"
+	CategoryUpdated      = "category.updated"
+	CategoryDeleted      = "category.deleted"
+	CompanyCreated       = "company.created"
+	CompanyUpdated       = "company.updated"
+	CompanyDeleted       = "company.deleted"
+	TagCreated           = "tag.created"
+	TagUpdated           = "tag.updated"
+	TagDeleted           = "tag.deleted"
+	ContactCreated       = "contact.created"
+	CommentCreated       = "comment.created"
+	CommentUpdated       = "comment.updated"
+	CommentDeleted       = "comment.deleted"
+	ResumeCreated        = "resume.created"
+	ResumeUpdated        = "resume.updated"
+	ResumeDeleted        = "resume.deleted"
+
+	// LoginFromNewIP is a security notification for admins and is not
+	// offered to webhook subscribers
+	LoginFromNewIP = "auth.login_new_ip"
+)
+
+// Types lists every event type that can be subscribed to
+var Types = []string{
+	ProfileUpdated,
+	ExperienceCreated, ExperienceUpdated, ExperienceDeleted, ExperiencesReordered,
+	SkillCreated, SkillUpdated, SkillDeleted, SkillsReordered, SkillsRecategorized,
+	ProjectCreated, ProjectUpdated, ProjectDeleted, ProjectsReordered,
+	PostCreated, PostUpdated, PostDeleted,
+	TestimonialCreated, TestimonialUpdated, TestimonialDeleted,
+	EducationCreated, EducationUpdated, EducationDeleted,
+	CertificationCreated, CertificationUpdated, CertificationDeleted,
+	PublicationCreated, PublicationUpdated, PublicationDeleted,
+	AwardCreated, AwardUpdated, AwardDeleted,
+	ServiceCreated, ServiceUpdated, ServiceDeleted,
+	TalkCreated, TalkUpdated, TalkDeleted,
+	CategoryCreated, CategoryUpdated, CategoryDeleted,
+	CompanyCreated, CompanyUpdated, CompanyDeleted,
+	TagCreated, TagUpdated, TagDeleted,
+	ContactCreated,
+	CommentCreated, CommentUpdated, CommentDeleted,
+	ResumeCreated, ResumeUpdated, ResumeDeleted,
+}
+
+// Event describes a change to the portfolio content
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	TenantID   uint        `json:"tenant_id"` // Portfolio the change was made to
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Context returns a background context scoped to the event's tenant, for
+// subscribers that read or write the tenant's content
+func (e Event) Context() context.Context {
+	return tenant.WithID(context.Background(), e.TenantID)
+}
+
+// Handler consumes published events. Handlers run synchronously on the
+// publishing goroutine and should hand off slow work.
+type Handler func(Event)
+
+// Bus fans events out to in-process subscribers
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for all events
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish delivers an event to every subscriber, tagged with the tenant in
+// ctx. A panicking subscriber is logged and does not affect the others or
+// the caller.
+func (b *Bus) Publish(ctx context.Context, eventType string, data interface{}) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		tenantID = tenant.DefaultID
+	}
+
+	event := Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		TenantID:   tenantID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Warning: event handler panicked on %s: %v", event.Type, r)
+				}
+			}()
+			handler(event)
+		}()
+	}
+}
+
+// IsValidType reports whether eventType is a known event type
+func IsValidType(eventType string) bool {
+	for _, t := range Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const apiURL = "https://api.github.com"
+
+// Client talks to the GitHub REST API. A token is optional but raises the
+// rate limit from 60 to 5000 requests per hour.
+type Client struct {
+	token string
+	http  *http.Client
+}
+
+func NewClient(token string) *Client {
+	return &Client{
+		token: token,
+		http:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Repository holds the repository statistics shown on projects
+type Repository struct {
+	Stars      int       `json:"stargazers_count"`
+	Forks      int       `json:"forks_count"`
+	OpenIssues int       `json:"open_issues_count"`
+	PushedAt   time.Time `json:"pushed_at"`
+}
+
+// GetRepository fetches a public repository
+func (c *Client) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
+	var repo Repository
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+	if err := c.get(ctx, path, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// GetReadme returns the text of a public repository's README
+func (c *Client) GetReadme(ctx context.Context, owner, name string) (string, error) {
+	var readme struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name) + "/readme"
+	if err := c.get(ctx, path, &readme); err != nil {
+		return "", err
+	}
+	if readme.Encoding != "base64" {
+		return readme.Content, nil
+	}
+	// GitHub wraps the encoded content every 60 characters
+	content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(readme.Content, "\n", ""))
+	if err != nil {
+		return "", fmt.Errorf("github: invalid README for %s/%s: %w", owner, name, err)
+	}
+	return string(content), nil
+}
+
+// Event is a public GitHub event such as a push or a new pull request
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetPublicEvents returns the user's most recent public events
+func (c *Client) GetPublicEvents(ctx context.Context, username string, limit int) ([]Event, error) {
+	var events []Event
+	path := fmt.Sprintf("/users/%s/events/public?per_page=%d", url.PathEscape(username), limit)
+	if err := c.get(ctx, path, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ContributionCalendar is the contribution graph shown on a GitHub profile
+type ContributionCalendar struct {
+	TotalContributions int                `json:"total_contributions"`
+	Weeks              []ContributionWeek `json:"weeks"`
+}
+
+type ContributionWeek struct {
+	Days []ContributionDay `json:"days"`
+}
+
+type ContributionDay struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+	Color string `json:"color"`
+}
+
+const contributionQuery = `query($login: String!) {
+  user(login: $login) {
+    contributionsCollection {
+      contributionCalendar {
+        totalContributions
+        weeks { contributionDays { date contributionCount color } }
+      }
+    }
+  }
+}`
+
+// GetContributionCalendar fetches the user's contribution calendar for the
+// past year. It uses the GraphQL API, which requires a token.
+func (c *Client) GetContributionCalendar(ctx context.Context, username string) (*ContributionCalendar, error) {
+	if c.token == "" {
+		return nil, errors.New("github: a token is required for the contribution calendar")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     contributionQuery,
+		"variables": map[string]string{"login": username},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+"/graphql", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		Data struct {
+			User *struct {
+				ContributionsCollection struct {
+					ContributionCalendar struct {
+						TotalContributions int `json:"totalContributions"`
+						Weeks              []struct {
+							ContributionDays []struct {
+								Date              string `json:"date"`
+								ContributionCount int    `json:"contributionCount"`
+								Color             string `json:"color"`
+							} `json:"contributionDays"`
+						} `json:"weeks"`
+					} `json:"contributionCalendar"`
+				} `json:"contributionsCollection"`
+			} `json:"user"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := c.do(req, "/graphql", &result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("github: %s", result.Errors[0].Message)
+	}
+	if result.Data.User == nil {
+		return nil, fmt.Errorf("github: user %q not found", username)
+	}
+
+	source := result.Data.User.ContributionsCollection.ContributionCalendar
+	calendar := &ContributionCalendar{
+		TotalContributions: source.TotalContributions,
+		Weeks:              make([]ContributionWeek, 0, len(source.Weeks)),
+	}
+	for _, week := range source.Weeks {
+		days := make([]ContributionDay, 0, len(week.ContributionDays))
+		for _, day := range week.ContributionDays {
+			days = append(days, ContributionDay{Date: day.Date, Count: day.ContributionCount, Color: day.Color})
+		}
+		calendar.Weeks = append(calendar.Weeks, ContributionWeek{Days: days})
+	}
+	return calendar, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, path, out)
+}
+
+func (c *Client) do(req *http.Request, path string, out interface{}) error {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("github: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github: %s %s returned %s", req.Method, path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("github: invalid response for %s: %w", path, err)
+	}
+	return nil
+}
+
+// ParseRepoURL extracts the owner and repository name from a github.com URL
+// such as https://github.com/owner/repo or https://github.com/owner/repo.git
+func ParseRepoURL(rawURL string) (owner, name string, ok bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || !strings.EqualFold(strings.TrimPrefix(u.Host, "www."), "github.com") {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
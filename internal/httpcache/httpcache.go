@@ -0,0 +1,150 @@
+// Package httpcache caches the serialized responses of public GET routes in
+// Redis, so repeat requests skip the handler and JSON encoding altogether.
+// Responses are grouped by the resource they show and dropped together with
+// the service caches of that resource.
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"stackwhiz-portfolio-backend/internal/metrics"
+	"stackwhiz-portfolio-backend/internal/middleware"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// Key returns the Redis hash holding the cached responses of a resource,
+// such as "skills". Callers namespace it by tenant.
+func Key(resource string) string {
+	return "response:" + resource
+}
+
+// entry is a cached response
+type entry struct {
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+	StoredAt    time.Time `json:"stored_at"`
+}
+
+// recorder keeps a copy of the body the handler writes
+type recorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *recorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Middleware answers GET requests for resource from the cache and stores
+// successful responses for ttl. Responses vary by path, the query params
+// the handler reads, negotiated API version and Accept-Language; other
+// query parameters cannot split the cache. Admins may see drafts and
+// attribution, so their requests bypass the cache. A Redis failure falls
+// through to the handler.
+func Middleware(client *redis.Client, resource string, ttl func() time.Duration, params ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || c.GetString("user_role") == "admin" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key := tenant.CacheKey(ctx, Key(resource))
+		field := variant(c, params)
+
+		if cached, ok := lookup(ctx, client, resource, key, field, ttl()); ok {
+			c.Header("X-Cache", "HIT")
+			c.Data(http.StatusOK, cached.ContentType, cached.Body)
+			c.Abort()
+			return
+		}
+
+		c.Header("X-Cache", "MISS")
+		rec := &recorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+		c.Writer = rec.ResponseWriter
+
+		if rec.Status() != http.StatusOK || c.IsAborted() {
+			return
+		}
+		data, err := json.Marshal(entry{
+			ContentType: rec.Header().Get("Content-Type"),
+			Body:        rec.body.Bytes(),
+			StoredAt:    time.Now(),
+		})
+		if err != nil {
+			return
+		}
+
+		// The hash expires ttl after its first variant was stored, so
+		// frequent misses cannot keep it alive; later entries carry their
+		// own age
+		storeCtx := context.WithoutCancel(ctx)
+		pipe := client.TxPipeline()
+		pipe.HSet(storeCtx, key, field, data)
+		pipe.ExpireNX(storeCtx, key, ttl())
+		pipe.Exec(storeCtx)
+	}
+}
+
+// variant identifies the response within its resource's hash. Only params
+// are kept from the query, sorted by name. The v1 and v2 groups each serve
+// both envelopes, so the version negotiated from the Accept header is part
+// of it.
+func variant(c *gin.Context, params []string) string {
+	query := url.Values{}
+	for _, param := range params {
+		if values, ok := c.Request.URL.Query()[param]; ok {
+			query[param] = values
+		}
+	}
+	return c.Request.URL.Path + "?" + query.Encode() +
+		"|v" + strconv.Itoa(c.GetInt(middleware.APIVersionKey)) +
+		"|" + strings.TrimSpace(c.GetHeader("Accept-Language"))
+}
+
+func lookup(ctx context.Context, client *redis.Client, resource, key, field string, ttl time.Duration) (*entry, bool) {
+	start := time.Now()
+	result := metrics.Hit
+	defer func() {
+		metrics.ObserveCacheLookup("response:"+resource, result, time.Since(start))
+	}()
+
+	data, err := client.HGet(ctx, key, field).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		result = metrics.Miss
+		return nil, false
+	case err != nil:
+		result = metrics.Error
+		return nil, false
+	}
+
+	var cached entry
+	if err := json.Unmarshal(data, &cached); err != nil {
+		result = metrics.Stale
+		return nil, false
+	}
+	if time.Since(cached.StoredAt) > ttl {
+		result = metrics.Miss
+		return nil, false
+	}
+	return &cached, true
+}
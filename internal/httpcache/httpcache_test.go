@@ -0,0 +1,85 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"stackwhiz-portfolio-backend/internal/middleware"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMiddlewareVariesByAPIVersion(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.APIVersion(1, 1, 2))
+	router.GET("/projects", Middleware(client, "projects", func() time.Duration { return time.Minute }), func(c *gin.Context) {
+		if c.GetInt(middleware.APIVersionKey) == 2 {
+			c.JSON(http.StatusOK, gin.H{"data": []string{}})
+			return
+		}
+		c.JSON(http.StatusOK, []string{})
+	})
+
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	get("application/json")
+	v2 := get("application/vnd.portfolio.v2+json")
+	if v2.Header().Get("X-Cache") != "MISS" || v2.Body.String() != `{"data":[]}` {
+		t.Errorf("v2 got %s %q, want a miss with the v2 envelope", v2.Header().Get("X-Cache"), v2.Body.String())
+	}
+	v1 := get("application/json")
+	if v1.Header().Get("X-Cache") != "HIT" || v1.Body.String() != `[]` {
+		t.Errorf("v1 got %s %q, want a hit with the v1 body", v1.Header().Get("X-Cache"), v1.Body.String())
+	}
+}
+
+func TestMiddlewareKeysOnReadParams(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/projects", Middleware(client, "projects", func() time.Duration { return time.Minute }, "tag", "order"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"tag": c.Query("tag"), "order": c.Query("order")})
+	})
+
+	get := func(target string) string {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec.Header().Get("X-Cache")
+	}
+
+	if got := get("/projects?tag=go&order=created_at"); got != "MISS" {
+		t.Fatalf("first request: X-Cache = %s", got)
+	}
+	ttl := server.TTL("response:projects")
+	server.FastForward(30 * time.Second)
+
+	for _, target := range []string{"/projects?order=created_at&tag=go", "/projects?tag=go&order=created_at&utm_source=x&_=123"} {
+		if got := get(target); got != "HIT" {
+			t.Errorf("%s: X-Cache = %s, want HIT", target, got)
+		}
+	}
+	if got := get("/projects?tag=rust"); got != "MISS" {
+		t.Errorf("other tag: X-Cache = %s, want MISS", got)
+	}
+	if got := server.TTL("response:projects"); got != ttl-30*time.Second {
+		t.Errorf("TTL after a second variant = %v, want %v left of the first", got, ttl-30*time.Second)
+	}
+	if fields, _ := server.HKeys("response:projects"); len(fields) != 2 {
+		t.Errorf("hash holds variants %q, want 2", fields)
+	}
+}
@@ -0,0 +1,74 @@
+package imaging
+
+import (
+	"image"
+	_ "image/gif" // Register decoders for the accepted upload types
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const jpegQuality = 85
+
+// Decode reads an image in any of the supported formats
+func Decode(r io.Reader) (image.Image, error) {
+	img, _, err := image.Decode(r)
+	return img, err
+}
+
+// Fit scales img down so that neither side exceeds maxSize, keeping the
+// aspect ratio. Images that already fit are returned unchanged.
+func Fit(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSize && height <= maxSize {
+		return img
+	}
+
+	if width >= height {
+		height = height * maxSize / width
+		width = maxSize
+	} else {
+		width = width * maxSize / height
+		height = maxSize
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// Crop copies the part of img inside rect, trimmed evenly on its longer
+// side to a square. rect is in the coordinates of img and must lie within
+// its bounds.
+func Crop(img image.Image, rect image.Rectangle) image.Image {
+	if d := rect.Dx() - rect.Dy(); d > 0 {
+		rect.Min.X += d / 2
+		rect.Max.X = rect.Min.X + rect.Dy()
+	} else if d < 0 {
+		rect.Min.Y += -d / 2
+		rect.Max.Y = rect.Min.Y + rect.Dx()
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Copy(dst, image.Point{}, img, rect, draw.Src, nil)
+	return dst
+}
+
+// Encode writes img as PNG when transparency must be preserved and as JPEG
+// otherwise, returning the MIME type used
+func Encode(w io.Writer, img image.Image, keepAlpha bool) (string, error) {
+	if keepAlpha {
+		return "image/png", png.Encode(w, img)
+	}
+	return "image/jpeg", jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+}
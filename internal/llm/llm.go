@@ -0,0 +1,297 @@
+// Package llm asks a large language model for text through the chat APIs of
+// OpenAI, or of servers compatible with it, and Anthropic, and turns text
+// into embeddings through OpenAI's embeddings API
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Providers
+const (
+	OpenAI    = "openai"
+	Anthropic = "anthropic"
+)
+
+const (
+	openAIURL        = "https://api.openai.com/v1"
+	anthropicURL     = "https://api.anthropic.com/v1"
+	anthropicVersion = "2023-06-01"
+)
+
+// Message is one turn of a conversation
+type Message struct {
+	Role    string `json:"role"` // user or assistant
+	Content string `json:"content"`
+}
+
+// Client calls one model of one provider
+type Client struct {
+	provider string
+	key      string
+	model    string
+	baseURL  string
+	http     *http.Client
+}
+
+// NewClient returns a client for the provider's model. An empty baseURL uses
+// the provider's own API; with OpenAI it may point at any server speaking
+// the same protocol, such as a local Ollama.
+func NewClient(provider, key, model, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = openAIURL
+		if provider == Anthropic {
+			baseURL = anthropicURL
+		}
+	}
+	return &Client{
+		provider: provider,
+		key:      key,
+		model:    model,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		http:     &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Model returns the name of the model answering
+func (c *Client) Model() string {
+	return c.model
+}
+
+// Complete sends the system prompt and the conversation and returns the
+// model's answer, at most maxTokens long
+func (c *Client) Complete(ctx context.Context, system string, messages []Message, maxTokens int) (string, error) {
+	if c.provider == Anthropic {
+		return c.completeAnthropic(ctx, system, messages, maxTokens)
+	}
+	return c.completeOpenAI(ctx, system, messages, maxTokens)
+}
+
+func (c *Client) completeOpenAI(ctx context.Context, system string, messages []Message, maxTokens int) (string, error) {
+	body := map[string]interface{}{
+		"model":      c.model,
+		"messages":   append([]Message{{Role: "system", Content: system}}, messages...),
+		"max_tokens": maxTokens,
+	}
+	var result struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+	}
+	if err := c.post(ctx, "/chat/completions", body, &result); err != nil {
+		return "", err
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("llm: %s returned no answer", c.provider)
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
+
+func (c *Client) completeAnthropic(ctx context.Context, system string, messages []Message, maxTokens int) (string, error) {
+	body := map[string]interface{}{
+		"model":      c.model,
+		"system":     system,
+		"messages":   messages,
+		"max_tokens": maxTokens,
+	}
+	var result struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := c.post(ctx, "/messages", body, &result); err != nil {
+		return "", err
+	}
+	var answer strings.Builder
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			answer.WriteString(block.Text)
+		}
+	}
+	if answer.Len() == 0 {
+		return "", fmt.Errorf("llm: %s returned no answer", c.provider)
+	}
+	return strings.TrimSpace(answer.String()), nil
+}
+
+// Stream is like Complete but passes the answer to onText piece by piece as
+// the model writes it. It stops with onText's error if onText fails.
+func (c *Client) Stream(ctx context.Context, system string, messages []Message, maxTokens int, onText func(string) error) error {
+	path := "/chat/completions"
+	body := map[string]interface{}{
+		"model":      c.model,
+		"messages":   append([]Message{{Role: "system", Content: system}}, messages...),
+		"max_tokens": maxTokens,
+		"stream":     true,
+	}
+	if c.provider == Anthropic {
+		path = "/messages"
+		body = map[string]interface{}{
+			"model":      c.model,
+			"system":     system,
+			"messages":   messages,
+			"max_tokens": maxTokens,
+			"stream":     true,
+		}
+	}
+
+	resp, err := c.send(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Both providers answer with Server-Sent Events whose data is JSON
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			break
+		}
+		text, err := c.streamedText(data)
+		if err != nil {
+			return err
+		}
+		if text != "" {
+			if err := onText(text); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("llm: reading %s stream: %w", c.provider, err)
+	}
+	return nil
+}
+
+// streamedText returns the text added by one streamed event
+func (c *Client) streamedText(data string) (string, error) {
+	if c.provider == Anthropic {
+		var event struct {
+			Type  string `json:"type"`
+			Delta struct {
+				Text string `json:"text"`
+			} `json:"delta"`
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return "", fmt.Errorf("llm: invalid %s stream event: %w", c.provider, err)
+		}
+		if event.Type == "error" {
+			return "", fmt.Errorf("llm: %s stream failed: %s", c.provider, event.Error.Message)
+		}
+		if event.Type != "content_block_delta" {
+			return "", nil
+		}
+		return event.Delta.Text, nil
+	}
+
+	var chunk struct {
+		Choices []struct {
+			Delta struct {
+				Content string `json:"content"`
+			} `json:"delta"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return "", fmt.Errorf("llm: invalid %s stream event: %w", c.provider, err)
+	}
+	if len(chunk.Choices) == 0 {
+		return "", nil
+	}
+	return chunk.Choices[0].Delta.Content, nil
+}
+
+// Embed returns an embedding vector for each text, in order. Only OpenAI
+// and compatible servers offer embeddings.
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if c.provider == Anthropic {
+		return nil, fmt.Errorf("llm: %s has no embeddings API", c.provider)
+	}
+	body := map[string]interface{}{
+		"model": c.model,
+		"input": texts,
+	}
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := c.post(ctx, "/embeddings", body, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Data) != len(texts) {
+		return nil, fmt.Errorf("llm: %s returned %d embeddings for %d texts", c.provider, len(result.Data), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, item := range result.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, fmt.Errorf("llm: %s returned an embedding for unknown input %d", c.provider, item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
+
+// post sends a JSON request to an API path and decodes the response
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	resp, err := c.send(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("llm: invalid response for %s: %w", path, err)
+	}
+	return nil
+}
+
+// send posts a JSON request to an API path and returns the successful
+// response, whose body the caller closes
+func (c *Client) send(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.provider == Anthropic {
+		req.Header.Set("x-api-key", c.key)
+		req.Header.Set("anthropic-version", anthropicVersion)
+	} else if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("llm: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("llm: %s %s returned %s: %s", c.provider, path, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CalendarEvent describes a meeting invite delivered as an ICS attachment
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	Organizer   string
+	Attendees   []string
+}
+
+const icsTimeFormat = "20060102T150405Z"
+
+// ICS renders the event as an iCalendar (RFC 5545) REQUEST
+func (e *CalendarEvent) ICS() []byte {
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(fmt.Sprintf(format, args...))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//StackWhiz//Portfolio Backend//EN")
+	line("METHOD:REQUEST")
+	line("BEGIN:VEVENT")
+	line("UID:%s", e.UID)
+	line("DTSTAMP:%s", time.Now().UTC().Format(icsTimeFormat))
+	line("DTSTART:%s", e.Start.UTC().Format(icsTimeFormat))
+	line("DTEND:%s", e.End.UTC().Format(icsTimeFormat))
+	line("SUMMARY:%s", escapeICS(e.Summary))
+	if e.Description != "" {
+		line("DESCRIPTION:%s", escapeICS(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION:%s", escapeICS(e.Location))
+	}
+	if e.Organizer != "" {
+		line("ORGANIZER:mailto:%s", e.Organizer)
+	}
+	for _, attendee := range e.Attendees {
+		line("ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=TRUE:mailto:%s", attendee)
+	}
+	line("STATUS:CONFIRMED")
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// Attachment wraps the event as a text/calendar email attachment
+func (e *CalendarEvent) Attachment() Attachment {
+	return Attachment{
+		Filename:    "invite.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=REQUEST",
+		Data:        e.ICS(),
+	}
+}
+
+func escapeICS(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
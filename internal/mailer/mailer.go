@@ -0,0 +1,281 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"stackwhiz-portfolio-backend/internal/config"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Attachment represents a file attached to an outgoing email
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+// Message represents an outgoing email
+type Message struct {
+	To          []string          `json:"to"`
+	ReplyTo     string            `json:"reply_to,omitempty"`
+	Subject     string            `json:"subject"`
+	Body        string            `json:"body"`
+	HTML        string            `json:"html,omitempty"`    // HTML version of Body, sent as an alternative
+	Headers     map[string]string `json:"headers,omitempty"` // Extra headers such as Message-ID or In-Reply-To
+	Attachments []Attachment      `json:"attachments,omitempty"`
+}
+
+// Mailer sends emails through the configured SMTP server
+type Mailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewMailer(cfg *config.Config) *Mailer {
+	return &Mailer{
+		host:     cfg.SMTPHost,
+		port:     cfg.SMTPPort,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		from:     cfg.MailFrom,
+	}
+}
+
+// Enabled reports whether an SMTP server has been configured
+func (m *Mailer) Enabled() bool {
+	return m.host != ""
+}
+
+// NewMessageID returns a unique Message-ID in the sender's domain
+func (m *Mailer) NewMessageID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+
+	domain := "localhost"
+	if at := strings.LastIndex(m.from, "@"); at >= 0 {
+		domain = strings.Trim(m.from[at+1:], "<> ")
+	}
+	return "<" + hex.EncodeToString(buf) + "@" + domain + ">"
+}
+
+// Send delivers a message. When no SMTP server is configured the message is
+// logged and dropped so that development setups keep working.
+func (m *Mailer) Send(msg *Message) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("mailer: message has no recipients")
+	}
+
+	if !m.Enabled() {
+		log.Printf("Mailer disabled, dropping email to %s: %s", strings.Join(msg.To, ", "), msg.Subject)
+		return nil
+	}
+
+	body, err := m.build(msg)
+	if err != nil {
+		return fmt.Errorf("mailer: failed to build message: %w", err)
+	}
+	// The envelope takes bare addresses, without display names
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("mailer: invalid sender: %w", err)
+	}
+	recipients, err := parseAddresses(msg.To)
+	if err != nil {
+		return fmt.Errorf("mailer: %w", err)
+	}
+	to := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		to[i] = recipient.Address
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	addr := m.host + ":" + strconv.Itoa(m.port)
+	if err := smtp.SendMail(addr, auth, from.Address, to, body); err != nil {
+		return fmt.Errorf("mailer: failed to send email: %w", err)
+	}
+	return nil
+}
+
+func (m *Mailer) build(msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	from, err := parseAddresses([]string{m.from})
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseAddresses(msg.To)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := textproto.MIMEHeader{}
+	headers.Set("From", formatAddresses(from))
+	headers.Set("To", formatAddresses(to))
+	headers.Set("Subject", msg.Subject)
+	headers.Set("Date", time.Now().Format(time.RFC1123Z))
+	headers.Set("MIME-Version", "1.0")
+	if msg.ReplyTo != "" {
+		replyTo, err := parseAddresses([]string{msg.ReplyTo})
+		if err != nil {
+			return nil, err
+		}
+		headers.Set("Reply-To", formatAddresses(replyTo))
+	}
+	for key, value := range msg.Headers {
+		headers.Set(key, value)
+	}
+
+	if len(msg.Attachments) == 0 && msg.HTML == "" {
+		headers.Set("Content-Type", "text/plain; charset=UTF-8")
+		writeHeaders(&buf, headers)
+		buf.WriteString(msg.Body)
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	if len(msg.Attachments) == 0 {
+		headers.Set("Content-Type", "multipart/alternative; boundary="+writer.Boundary())
+		if err := writeAlternatives(writer, msg); err != nil {
+			return nil, err
+		}
+	} else {
+		headers.Set("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+		if err := writeText(writer, msg); err != nil {
+			return nil, err
+		}
+		for _, attachment := range msg.Attachments {
+			part, err := writer.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {attachment.ContentType},
+				"Content-Transfer-Encoding": {"base64"},
+				"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", attachment.Filename)},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if _, err := part.Write([]byte(wrapBase64(attachment.Data))); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	writeHeaders(&out, headers)
+	out.Write(buf.Bytes())
+	return out.Bytes(), nil
+}
+
+// writeText adds the text of a message with attachments: the plain text
+// part, or a nested multipart/alternative when there is an HTML version
+func writeText(writer *multipart.Writer, msg *Message) error {
+	if msg.HTML == "" {
+		return writePart(writer, "text/plain; charset=UTF-8", msg.Body)
+	}
+
+	var nested bytes.Buffer
+	alternatives := multipart.NewWriter(&nested)
+	if err := writeAlternatives(alternatives, msg); err != nil {
+		return err
+	}
+	if err := alternatives.Close(); err != nil {
+		return err
+	}
+	return writePart(writer, "multipart/alternative; boundary="+alternatives.Boundary(), nested.String())
+}
+
+// writeAlternatives adds the plain text and HTML versions, least preferred
+// first as RFC 2046 asks
+func writeAlternatives(writer *multipart.Writer, msg *Message) error {
+	if err := writePart(writer, "text/plain; charset=UTF-8", msg.Body); err != nil {
+		return err
+	}
+	return writePart(writer, "text/html; charset=UTF-8", msg.HTML)
+}
+
+func writePart(writer *multipart.Writer, contentType, content string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {contentType}})
+	if err != nil {
+		return err
+	}
+	_, err = part.Write([]byte(content))
+	return err
+}
+
+func writeHeaders(buf *bytes.Buffer, headers textproto.MIMEHeader) {
+	for key, values := range headers {
+		for _, value := range values {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, headerValue(key, value))
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+// headerValue makes a header value safe to write: line breaks, which would
+// let text from a visitor such as a contact form subject start headers of
+// its own, become spaces. Non-ASCII text of the Subject is encoded as RFC
+// 2047 words; other headers are structured and must keep their syntax, so
+// addresses are formatted by formatAddresses instead.
+func headerValue(key, value string) string {
+	value = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+	if key != "Subject" {
+		return value
+	}
+	return mime.QEncoding.Encode("utf-8", value)
+}
+
+// parseAddresses parses mailboxes such as "Ada <ada@example.com>"
+func parseAddresses(values []string) ([]*mail.Address, error) {
+	addresses := make([]*mail.Address, len(values))
+	for i, value := range values {
+		address, err := mail.ParseAddress(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", value, err)
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
+
+// formatAddresses writes mailboxes for an address header, with non-ASCII
+// display names encoded as RFC 2047 words
+func formatAddresses(addresses []*mail.Address) string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address.String()
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// wrapBase64 encodes data as base64 split into 76 character lines (RFC 2045)
+func wrapBase64(data []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	return b.String()
+}
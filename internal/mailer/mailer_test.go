@@ -0,0 +1,126 @@
+package mailer
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+)
+
+func TestBuildKeepsSubjectOnOneHeader(t *testing.T) {
+	m := &Mailer{from: "site@example.com"}
+	raw, err := m.build(&Message{
+		To:      []string{"owner@example.com"},
+		Subject: "Hello\r\nBcc: attacker@example.com\r\n\r\nInjected body",
+		Body:    "Body",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bcc := msg.Header.Get("Bcc"); bcc != "" {
+		t.Errorf("Bcc header was injected: %q", bcc)
+	}
+	if subject := msg.Header.Get("Subject"); subject != "Hello Bcc: attacker@example.com  Injected body" {
+		t.Errorf("Subject = %q", subject)
+	}
+}
+
+func TestBuildEncodesNonASCIIHeaders(t *testing.T) {
+	m := &Mailer{from: "site@example.com"}
+	raw, err := m.build(&Message{To: []string{"owner@example.com"}, Subject: "Invitation: Café chat", Body: "Body"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded := msg.Header.Get("Subject")
+	if encoded != "=?utf-8?q?Invitation:_Caf=C3=A9_chat?=" {
+		t.Errorf("Subject = %q", encoded)
+	}
+	decoded, err := new(mime.WordDecoder).DecodeHeader(encoded)
+	if err != nil || decoded != "Invitation: Café chat" {
+		t.Errorf("decoded Subject = %q, %v", decoded, err)
+	}
+}
+
+func TestBuildFormatsAddressHeaders(t *testing.T) {
+	m := &Mailer{from: "José's Portfolio <no-reply@localhost>"}
+	raw, err := m.build(&Message{
+		To:      []string{"Zoë Müller <zoe@example.com>", "ada@example.com"},
+		ReplyTo: "\"Renée, visitor\" <renee@example.com>",
+		Subject: "Hi",
+		Body:    "Body",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Name != "José's Portfolio" || from[0].Address != "no-reply@localhost" {
+		t.Errorf("From = %q parsed as %v, %v", msg.Header.Get("From"), from, err)
+	}
+	to, err := msg.Header.AddressList("To")
+	if err != nil || len(to) != 2 || to[0].Name != "Zoë Müller" || to[0].Address != "zoe@example.com" || to[1].Address != "ada@example.com" {
+		t.Errorf("To = %q parsed as %v, %v", msg.Header.Get("To"), to, err)
+	}
+	replyTo, err := msg.Header.AddressList("Reply-To")
+	if err != nil || len(replyTo) != 1 || replyTo[0].Name != "Renée, visitor" || replyTo[0].Address != "renee@example.com" {
+		t.Errorf("Reply-To = %q parsed as %v, %v", msg.Header.Get("Reply-To"), replyTo, err)
+	}
+
+	if _, err := m.build(&Message{To: []string{"not an address"}, Subject: "Hi", Body: "Body"}); err == nil {
+		t.Error("build accepted an invalid recipient")
+	}
+}
+
+func TestBuildSendsHTMLAsAlternative(t *testing.T) {
+	m := &Mailer{from: "site@example.com"}
+	raw, err := m.build(&Message{To: []string{"owner@example.com"}, Subject: "News", Body: "**Hi**", HTML: "<p><strong>Hi</strong></p>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", msg.Header.Get("Content-Type"), err)
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var types, bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		types = append(types, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+	if len(types) != 2 || types[0] != "text/plain; charset=UTF-8" || types[1] != "text/html; charset=UTF-8" {
+		t.Fatalf("parts = %q, want plain text then HTML", types)
+	}
+	if bodies[0] != "**Hi**" || bodies[1] != "<p><strong>Hi</strong></p>" {
+		t.Errorf("bodies = %q", bodies)
+	}
+}
@@ -0,0 +1,122 @@
+// Package markdown renders Markdown fields to sanitized HTML, so every
+// frontend shows them the same way.
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	renderer = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+	policy = newPolicy()
+	strict = bluemonday.StrictPolicy()
+)
+
+// newPolicy allows the markup of user-generated content plus the heading
+// IDs and the language-* classes of fenced code blocks, which client-side
+// highlighters such as highlight.js and Prism pick up
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\w-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	return p
+}
+
+// ToHTML renders GitHub Flavored Markdown and sanitizes the result. Raw
+// HTML in the source is dropped rather than escaped.
+func ToHTML(src string) string {
+	if src == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(src), &buf); err != nil {
+		return ""
+	}
+	return policy.Sanitize(buf.String())
+}
+
+// ToText renders Markdown to plain text on a single line, for places that
+// cannot show markup such as link previews
+func ToText(src string) string {
+	if src == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(src), &buf); err != nil {
+		return ""
+	}
+	// Block ends become spaces so words of adjacent paragraphs stay apart
+	text := html.UnescapeString(strict.Sanitize(strings.ReplaceAll(buf.String(), "\n", " ")))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// StripHTML removes raw HTML from Markdown source and leaves everything
+// else, code included, as written. Removing a tag can join the pieces of
+// another, so it repeats until nothing changes.
+func StripHTML(src string) string {
+	for {
+		stripped := stripHTMLOnce(src)
+		if stripped == src {
+			return src
+		}
+		src = stripped
+	}
+}
+
+func stripHTMLOnce(src string) string {
+	source := []byte(src)
+	var cuts []text.Segment
+	doc := renderer.Parser().Parse(text.NewReader(source))
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.HTMLBlock:
+			for i := 0; i < n.Lines().Len(); i++ {
+				cuts = append(cuts, n.Lines().At(i))
+			}
+			if n.HasClosure() {
+				cuts = append(cuts, n.ClosureLine)
+			}
+		case *ast.RawHTML:
+			for i := 0; i < n.Segments.Len(); i++ {
+				cuts = append(cuts, n.Segments.At(i))
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	if len(cuts) == 0 {
+		return src
+	}
+
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].Start < cuts[j].Start })
+	var b strings.Builder
+	last := 0
+	for _, cut := range cuts {
+		if cut.Start >= last {
+			b.Write(source[last:cut.Start])
+		}
+		if cut.Stop > last {
+			last = cut.Stop
+		}
+	}
+	b.Write(source[last:])
+	return b.String()
+}
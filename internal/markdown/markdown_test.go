@@ -0,0 +1,21 @@
+package markdown
+
+import "testing"
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Tom & Jerry's a<b", "Tom & Jerry's a<b"},
+		{"Hi <b onclick=\"x()\">there</b>", "Hi there"},
+		{"<script>\nalert(1)\n</script>\n\n> quote", "\n> quote"},
+		{"<scr<b></b>ipt>alert(1)</script>", ""},
+		{"Use `<div>` here\n\n```html\n<p>kept</p>\n```\n", "Use `<div>` here\n\n```html\n<p>kept</p>\n```\n"},
+		{"See <https://example.com><!-- note -->.", "See <https://example.com>."},
+	}
+	for _, tt := range tests {
+		if got := StripHTML(tt.in); got != tt.want {
+			t.Errorf("StripHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
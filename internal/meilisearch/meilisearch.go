@@ -0,0 +1,123 @@
+// Package meilisearch is a small client for the parts of the Meilisearch
+// REST API the portfolio search uses
+package meilisearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client talks to one Meilisearch instance
+type Client struct {
+	url    string
+	key    string
+	client *http.Client
+}
+
+// NewClient returns a client for the instance at baseURL. key is the API
+// key sent with every request; it may be empty for an unprotected instance.
+func NewClient(baseURL, key string) *Client {
+	return &Client{
+		url:    strings.TrimRight(baseURL, "/"),
+		key:    key,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Settings configure how an index treats the fields of its documents
+type Settings struct {
+	SearchableAttributes []string `json:"searchableAttributes"`
+	FilterableAttributes []string `json:"filterableAttributes"`
+}
+
+// SearchRequest is a query against one index
+type SearchRequest struct {
+	Query            string `json:"q"`
+	Filter           string `json:"filter,omitempty"`
+	Limit            int    `json:"limit,omitempty"`
+	ShowRankingScore bool   `json:"showRankingScore,omitempty"` // Adds _rankingScore to every hit
+}
+
+// Writes are applied asynchronously by Meilisearch, so the methods below
+// return once the change has been queued, not once it is searchable.
+
+// UpdateSettings applies settings to an index, creating it if needed
+func (c *Client) UpdateSettings(ctx context.Context, index string, settings *Settings) error {
+	return c.do(ctx, http.MethodPatch, "/indexes/"+url.PathEscape(index)+"/settings", settings, nil)
+}
+
+// AddDocuments adds documents to an index, replacing those with the same
+// value of primaryKey
+func (c *Client) AddDocuments(ctx context.Context, index, primaryKey string, documents interface{}) error {
+	path := "/indexes/" + url.PathEscape(index) + "/documents?primaryKey=" + url.QueryEscape(primaryKey)
+	return c.do(ctx, http.MethodPost, path, documents, nil)
+}
+
+// DeleteDocument removes one document from an index
+func (c *Client) DeleteDocument(ctx context.Context, index, id string) error {
+	return c.do(ctx, http.MethodDelete, "/indexes/"+url.PathEscape(index)+"/documents/"+url.PathEscape(id), nil, nil)
+}
+
+// DeleteAllDocuments empties an index
+func (c *Client) DeleteAllDocuments(ctx context.Context, index string) error {
+	return c.do(ctx, http.MethodDelete, "/indexes/"+url.PathEscape(index)+"/documents", nil, nil)
+}
+
+// Search runs a query and decodes the matching documents into hits, which
+// must point to a slice
+func (c *Client) Search(ctx context.Context, index string, req *SearchRequest, hits interface{}) error {
+	result := struct {
+		Hits interface{} `json:"hits"`
+	}{Hits: hits}
+	return c.do(ctx, http.MethodPost, "/indexes/"+url.PathEscape(index)+"/search", req, &result)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("meilisearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(io.LimitReader(resp.Body, 1<<10)).Decode(&apiErr)
+		return fmt.Errorf("meilisearch: %s %s returned %s: %s", method, strings.SplitN(path, "?", 2)[0], resp.Status, apiErr.Message)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("meilisearch: invalid response: %w", err)
+	}
+	return nil
+}
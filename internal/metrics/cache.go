@@ -0,0 +1,94 @@
+// Package metrics records how the Redis cache in front of the database
+// performs, both as Prometheus metrics and as an in-process summary.
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Cache lookup results
+const (
+	Hit   = "hit"   // The value was cached and decoded
+	Miss  = "miss"  // Nothing was cached under the key
+	Stale = "stale" // A value was cached but no longer decodes, e.g. after a model change
+	Error = "error" // Redis failed; the lookup falls through to the database
+)
+
+var (
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "portfolio_cache_lookups_total",
+		Help: "Cache lookups by cache and result (hit, miss, stale, error).",
+	}, []string{"cache", "result"})
+
+	cacheLookupSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "portfolio_cache_lookup_duration_seconds",
+		Help:    "Time taken to read and decode a cached value, by cache and result.",
+		Buckets: []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25},
+	}, []string{"cache", "result"})
+)
+
+// CacheStats summarizes the lookups of one cache since the process started
+type CacheStats struct {
+	Cache           string  `json:"cache"`
+	Hits            int64   `json:"hits"`
+	Misses          int64   `json:"misses"`
+	Stale           int64   `json:"stale"`
+	Errors          int64   `json:"errors"`
+	HitRatio        float64 `json:"hit_ratio"`         // Hits over all lookups
+	AvgLookupMillis float64 `json:"avg_lookup_millis"` // Mean time of a lookup of any result
+}
+
+type cacheCounts struct {
+	results map[string]int64
+	total   time.Duration
+}
+
+var (
+	mu     sync.Mutex
+	caches = map[string]*cacheCounts{}
+)
+
+// ObserveCacheLookup records a lookup in the named cache
+func ObserveCacheLookup(cache, result string, elapsed time.Duration) {
+	cacheLookups.WithLabelValues(cache, result).Inc()
+	cacheLookupSeconds.WithLabelValues(cache, result).Observe(elapsed.Seconds())
+
+	mu.Lock()
+	defer mu.Unlock()
+	counts, ok := caches[cache]
+	if !ok {
+		counts = &cacheCounts{results: map[string]int64{}}
+		caches[cache] = counts
+	}
+	counts.results[result]++
+	counts.total += elapsed
+}
+
+// CacheSummary returns the stats of every cache looked up so far, by name
+func CacheSummary() []CacheStats {
+	mu.Lock()
+	defer mu.Unlock()
+
+	stats := make([]CacheStats, 0, len(caches))
+	for name, counts := range caches {
+		s := CacheStats{
+			Cache:  name,
+			Hits:   counts.results[Hit],
+			Misses: counts.results[Miss],
+			Stale:  counts.results[Stale],
+			Errors: counts.results[Error],
+		}
+		if lookups := s.Hits + s.Misses + s.Stale + s.Errors; lookups > 0 {
+			s.HitRatio = float64(s.Hits) / float64(lookups)
+			s.AvgLookupMillis = float64(counts.total.Microseconds()) / 1000 / float64(lookups)
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Cache < stats[j].Cache })
+	return stats
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"stackwhiz-portfolio-backend/internal/token"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthMiddlewareChecksTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.WithID(c.Request.Context(), 2))
+	})
+	router.GET("/admin", AuthMiddleware("secret"), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("username"))
+	})
+
+	ownTenant, err := token.Issue("secret", token.Claims{UserID: 4, Username: "ada", Role: "admin", TenantID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherTenant, err := token.Issue("secret", token.Claims{UserID: 1, Username: "eve", Role: "admin", TenantID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		bearer string
+		status int
+	}{
+		{"token of the tenant", ownTenant, http.StatusOK},
+		{"token of another tenant", otherTenant, http.StatusUnauthorized},
+		{"forged demo token", "demo-jwt-token-eve@2", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.bearer)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.status)
+		}
+	}
+}
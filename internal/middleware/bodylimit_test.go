@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBodyLimitChecksDepthOfEveryBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(BodyLimit(1<<10, 4))
+	router.POST("/contact", func(c *gin.Context) {
+		var body map[string]interface{}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	deep := strings.Repeat(`{"a":`, 10) + "1" + strings.Repeat("}", 10)
+	tests := []struct {
+		contentType string
+		body        string
+		status      int
+	}{
+		{"application/json", `{"a":{"b":1}}`, http.StatusOK},
+		{"application/json", deep, http.StatusRequestEntityTooLarge},
+		{"application/merge-patch+json", deep, http.StatusRequestEntityTooLarge},
+		{"text/plain", deep, http.StatusRequestEntityTooLarge},
+		{"", deep, http.StatusRequestEntityTooLarge},
+		{"text/plain", `{"a":{"b":1}}`, http.StatusOK},
+		{"application/json", `{"a":"` + strings.Repeat("x", 2<<10) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/contact", strings.NewReader(tt.body))
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%q body of %d bytes: status = %d, want %d", tt.contentType, len(tt.body), rec.Code, tt.status)
+		}
+	}
+}
+
+func TestBodyLimitStreamsMultipart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(BodyLimit(1<<10, 4))
+	router.POST("/media", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil || len(data) != 100 {
+			t.Errorf("handler read %d bytes, %v", len(data), err)
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/media", strings.NewReader(strings.Repeat("[", 100)))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
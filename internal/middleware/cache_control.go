@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy decides the caching headers of public responses
+type CachePolicy struct {
+	Default string            // Cache-Control of successful responses, e.g. "public, max-age=60, s-maxage=300"
+	Vary    string            // Request headers responses vary by, added to any Vary already set
+	Routes  map[string]string // Cache-Control by "METHOD /route", overriding Default and the handler's own
+}
+
+// ParseCacheRoutes parses "GET /api/v1/profile=public, max-age=300;GET
+// /api/v1/posts/:slug=no-cache". Routes are written as registered, without
+// the /t/:tenant prefix.
+func ParseCacheRoutes(value string) (map[string]string, error) {
+	routes := map[string]string{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		endpoint, directives, ok := strings.Cut(entry, "=")
+		fields := strings.Fields(endpoint)
+		directives = strings.TrimSpace(directives)
+		if !ok || directives == "" || len(fields) != 2 || !strings.HasPrefix(fields[1], "/") {
+			return nil, fmt.Errorf("invalid cache route %q", entry)
+		}
+		routes[strings.ToUpper(fields[0])+" "+fields[1]] = directives
+	}
+	return routes, nil
+}
+
+// cacheWriter sets Cache-Control once the status is known, just before the
+// headers go out
+type cacheWriter struct {
+	gin.ResponseWriter
+	decide func(status int)
+}
+
+func (w *cacheWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.decide(w.Status())
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// CacheControl lets browsers and CDNs cache successful GET responses of
+// public routes as the policy says. Responses to requests carrying
+// credentials may include drafts or attribution and are never stored, and
+// neither are errors, so a passing outage is not cached.
+func CacheControl(policy CachePolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method != http.MethodGet && method != http.MethodHead {
+			c.Header("Cache-Control", "no-store")
+			c.Next()
+			return
+		}
+
+		route := strings.TrimPrefix(c.FullPath(), "/t/:tenant")
+		override, overridden := policy.Routes[http.MethodGet+" "+route]
+		private := c.GetHeader("Authorization") != "" || c.GetString("user_role") != ""
+
+		c.Writer = &cacheWriter{ResponseWriter: c.Writer, decide: func(status int) {
+			header := c.Writer.Header()
+			switch {
+			case private || status < 200 || status >= 300:
+				header.Set("Cache-Control", "private, no-store")
+			case overridden:
+				header.Set("Cache-Control", override)
+			case header.Get("Cache-Control") == "" && policy.Default != "":
+				header.Set("Cache-Control", policy.Default)
+			}
+			if policy.Vary != "" && !private {
+				header.Add("Vary", policy.Vary)
+			}
+		}}
+		c.Next()
+	}
+}
+
+// NoStore keeps admin and auth responses out of every cache. Handlers may
+// still set their own Cache-Control.
+func NoStore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Problem is an RFC 7807 problem details document returned by v2 on errors
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"` // Fields that failed validation
+}
+
+// FieldError describes one request field that failed validation
+type FieldError struct {
+	Field   string `json:"field"` // Path in the request, e.g. links[0].url
+	Rule    string `json:"rule"`  // Validation rule that failed, e.g. required or email
+	Message string `json:"message"`
+}
+
+var pathVersion = regexp.MustCompile(`/api/v(\d+)(/|$)`)
+
+// Version returns the API version negotiated for the request. Middleware
+// running before APIVersion gets the version in the request path; requests
+// outside the API are v1.
+func Version(c *gin.Context) int {
+	if version, ok := c.Get(APIVersionKey); ok {
+		if v, ok := version.(int); ok {
+			return v
+		}
+	}
+	if match := pathVersion.FindStringSubmatch(c.Request.URL.Path); match != nil {
+		if v, err := strconv.Atoi(match[1]); err == nil {
+			return v
+		}
+	}
+	return 1
+}
+
+// Error writes an error response in the shape of the request's version:
+// {"error": detail} on v1 and problem details on v2, listing any failed
+// fields under "errors"
+func Error(c *gin.Context, status int, detail string, fields []FieldError) {
+	if Version(c) < 2 {
+		body := gin.H{"error": detail}
+		if len(fields) > 0 {
+			body["errors"] = fields
+		}
+		c.JSON(status, body)
+		return
+	}
+
+	// gin keeps an explicitly set Content-Type when rendering JSON
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(status, Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Errors:   fields,
+	})
+}
+
+// abortWithError writes an error response and stops the chain
+func abortWithError(c *gin.Context, status int, detail string) {
+	Error(c, status, detail, nil)
+	c.Abort()
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorsFollowAPIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Runs before the groups record their version, like the global limits
+	router.Use(RateLimit(func() int { return 100 }), BodyLimit(8, 4))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/v1/admin", APIVersion(1, 1, 2), AuthMiddleware("secret"), ok)
+	router.GET("/api/v2/admin", APIVersion(2, 1, 2), AuthMiddleware("secret"), ok)
+	router.GET("/api/v2/likes", APIVersion(2, 1, 2), Feature("likes", func(string) bool { return false }), ok)
+	router.POST("/api/v2/contact", ok)
+
+	tests := []struct {
+		method, path string
+		status       int
+		problem      bool
+	}{
+		{http.MethodGet, "/api/v1/admin", http.StatusUnauthorized, false},
+		{http.MethodGet, "/api/v2/admin", http.StatusUnauthorized, true},
+		{http.MethodGet, "/api/v2/likes", http.StatusNotFound, true},
+		{http.MethodPost, "/api/v2/contact", http.StatusRequestEntityTooLarge, true},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.method == http.MethodPost {
+			req.ContentLength = 64
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.path, rec.Code, tt.status)
+			continue
+		}
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if tt.problem {
+			if rec.Header().Get("Content-Type") != "application/problem+json" || body["status"] != float64(tt.status) || body["instance"] != tt.path {
+				t.Errorf("%s: got %s %v, want problem details", tt.path, rec.Header().Get("Content-Type"), body)
+			}
+		} else if _, ok := body["error"]; !ok {
+			t.Errorf("%s: got %v, want an error field", tt.path, body)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newProxyRouter sets up trusted proxies the way the server does; an empty
+// TRUSTED_PROXIES gives no proxies at all
+func newProxyRouter(t *testing.T, trustedProxies []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func TestForwardedForIgnoredWithoutTrustedProxies(t *testing.T) {
+	blocked := func(ctx context.Context, ip string) (bool, error) { return ip == "203.0.113.9", nil }
+	adminAllowed := func(ctx context.Context, ip string) (bool, error) { return ip == "10.0.0.1", nil }
+
+	// Requests per client address, as the comment and signup limits count them
+	counts := map[string]int{}
+	router := newProxyRouter(t, nil)
+	router.Use(DenyIPs(blocked))
+	router.POST("/comments", func(c *gin.Context) {
+		counts[c.ClientIP()]++
+		c.Status(http.StatusOK)
+	})
+	router.GET("/admin", AllowIPs(adminAllowed), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(method, path, remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	tests := []struct {
+		name                     string
+		method, path             string
+		remoteAddr, forwardedFor string
+		status                   int
+	}{
+		{"blocked peer claims another address", http.MethodPost, "/comments", "203.0.113.9:4000", "198.51.100.7", http.StatusForbidden},
+		{"peer claims a blocked address", http.MethodPost, "/comments", "198.51.100.7:4000", "203.0.113.9", http.StatusOK},
+		{"peer claims an admin address", http.MethodGet, "/admin", "203.0.113.50:4000", "10.0.0.1", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		if got := request(tt.method, tt.path, tt.remoteAddr, tt.forwardedFor); got != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, got, tt.status)
+		}
+	}
+
+	for _, forwardedFor := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		request(http.MethodPost, "/comments", "198.51.100.7:4000", forwardedFor)
+	}
+	if counts["198.51.100.7"] != 4 || len(counts) != 1 {
+		t.Errorf("requests counted per address %v, want all 4 under the peer", counts)
+	}
+}
+
+func TestForwardedForUsedFromTrustedProxies(t *testing.T) {
+	blocked := func(ctx context.Context, ip string) (bool, error) { return ip == "203.0.113.9", nil }
+	router := newProxyRouter(t, []string{"198.51.100.7"})
+	router.Use(DenyIPs(blocked))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "198.51.100.7:4000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want the client behind the proxy to be blocked", rec.Code)
+	}
+}
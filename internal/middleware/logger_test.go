@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLoggerRedactsQueryToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var out bytes.Buffer
+	defaultWriter := gin.DefaultWriter
+	gin.DefaultWriter = &out
+	defer func() { gin.DefaultWriter = defaultWriter }()
+
+	router := gin.New()
+	router.Use(Logger())
+	router.GET("/admin/ws", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/admin/ws?lang=en&token=eyJhbGciOi.secret&to%6Ben=again", nil))
+	line := out.String()
+	if strings.Contains(line, "secret") || strings.Contains(line, "again") {
+		t.Errorf("log line leaks the token: %s", line)
+	}
+	if !strings.Contains(line, `"/admin/ws?lang=en&token=REDACTED&to%6Ben=REDACTED"`) {
+		t.Errorf("log line = %s", line)
+	}
+}
@@ -1,7 +1,21 @@
 package middleware
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"regexp"
+	"stackwhiz-portfolio-backend/internal/slo"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"stackwhiz-portfolio-backend/internal/token"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,10 +23,59 @@ import (
 	"golang.org/x/time/rate"
 )
 
-// CORS middleware
-func CORS() gin.HandlerFunc {
+// Logger logs requests in gin's default format with the value of ?token=
+// masked. Admin WebSockets and newsletter links carry credentials there.
+func Logger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		var statusColor, methodColor, resetColor string
+		if param.IsOutputColor() {
+			statusColor = param.StatusCodeColor()
+			methodColor = param.MethodColor()
+			resetColor = param.ResetColor()
+		}
+		if param.Latency > time.Minute {
+			param.Latency = param.Latency.Truncate(time.Second)
+		}
+		return fmt.Sprintf("[GIN] %v |%s %3d %s| %13v | %15s |%s %-7s %s %#v\n%s",
+			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			statusColor, param.StatusCode, resetColor,
+			param.Latency,
+			param.ClientIP,
+			methodColor, param.Method, resetColor,
+			redactToken(param.Path),
+			param.ErrorMessage,
+		)
+	})
+}
+
+// redactToken replaces the value of every token parameter in the query of
+// path, leaving the rest as it was sent
+func redactToken(path string) string {
+	base, query, ok := strings.Cut(path, "?")
+	if !ok {
+		return path
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil && unescaped == "token" {
+			params[i] = key + "=REDACTED"
+		}
+	}
+	return base + "?" + strings.Join(params, "&")
+}
+
+// CORS allows cross-origin requests from the origins returned by origins,
+// where "*" allows any. The list is read on every request so it can change
+// while the server runs.
+func CORS(origins func() []string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		if origin := allowedOrigin(origins(), c.GetHeader("Origin")); origin != "" {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			if origin != "*" {
+				c.Writer.Header().Add("Vary", "Origin")
+			}
+		}
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
@@ -26,16 +89,44 @@ func CORS() gin.HandlerFunc {
 	}
 }
 
-// Rate limiting middleware
-var limiter = rate.NewLimiter(rate.Every(time.Second), 10) // 10 requests per second
+// allowedOrigin returns the Access-Control-Allow-Origin value for origin, or
+// an empty string when it is not allowed
+func allowedOrigin(allowed []string, origin string) string {
+	for _, candidate := range allowed {
+		if candidate == "*" {
+			return "*"
+		}
+		if origin != "" && strings.EqualFold(candidate, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// RateLimit allows limit() requests per second across all clients. The limit
+// is read on every request so it can change while the server runs.
+func RateLimit(limit func() int) gin.HandlerFunc {
+	limiter := rate.NewLimiter(rate.Limit(limit()), limit())
 
-func RateLimit() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if n := limit(); n != limiter.Burst() {
+			limiter.SetLimit(rate.Limit(n))
+			limiter.SetBurst(n)
+		}
+
 		if !limiter.Allow() {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "Rate limit exceeded",
-			})
-			c.Abort()
+			abortWithError(c, http.StatusTooManyRequests, "Rate limit exceeded")
+			return
+		}
+		c.Next()
+	}
+}
+
+// Feature hides a route while the named feature flag is off
+func Feature(name string, enabled func(string) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled(name) {
+			abortWithError(c, http.StatusNotFound, "Feature is disabled")
 			return
 		}
 		c.Next()
@@ -54,64 +145,331 @@ func SecurityHeaders() gin.HandlerFunc {
 	}
 }
 
+// Timeout gives each request a deadline. Handlers pass the request context
+// down to the database and Redis, so their work stops once it passes or the
+// client goes away, and report it as 504. A handler that wrote nothing by
+// the deadline gets a 504 here. WebSocket upgrades and event streams stay
+// open as long as the client wants and are left alone, as are runtime
+// profiles, which record for as long as they are asked to. Streams are
+// recognized by their Accept header or by the route being one of streams,
+// given below the API version, under any version and tenant prefix. This
+// covers clients that do not send the header.
+func Timeout(d time.Duration, streams ...string) gin.HandlerFunc {
+	streamRoute := streamRoutes(streams)
+	return func(c *gin.Context) {
+		if d <= 0 || c.GetHeader("Upgrade") != "" || strings.Contains(c.GetHeader("Accept"), "text/event-stream") ||
+			strings.HasPrefix(c.Request.URL.Path, "/debug/pprof/") || (streamRoute != nil && streamRoute.MatchString(c.FullPath())) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if ctx.Err() == context.DeadlineExceeded && !c.Writer.Written() {
+			Error(c, http.StatusGatewayTimeout, "Request timed out", nil)
+		}
+	}
+}
+
+// streamRoutes matches routes ending in /api/v{N} followed by one of
+// streams, or nil when there are none
+func streamRoutes(streams []string) *regexp.Regexp {
+	if len(streams) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(streams))
+	for i, stream := range streams {
+		quoted[i] = regexp.QuoteMeta(stream)
+	}
+	return regexp.MustCompile(`/api/v\d+(` + strings.Join(quoted, "|") + `)$`)
+}
+
+// DenyIPs rejects requests from client addresses covered by a deny rule.
+// When the rules cannot be loaded the request is let through, so an outage
+// of the rule store does not take the whole site down.
+func DenyIPs(blocked func(ctx context.Context, ip string) (bool, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deny, err := blocked(c.Request.Context(), c.ClientIP())
+		if err != nil {
+			log.Printf("Warning: failed to check IP deny rules: %v", err)
+		}
+		if deny {
+			abortWithError(c, http.StatusForbidden, "Access denied")
+			return
+		}
+		c.Next()
+	}
+}
+
+// AllowIPs keeps a route group to the client addresses covered by the allow
+// rules, if there are any. Unlike DenyIPs it fails closed.
+func AllowIPs(allowed func(ctx context.Context, ip string) (bool, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allow, err := allowed(c.Request.Context(), c.ClientIP())
+		if err != nil {
+			abortWithError(c, http.StatusServiceUnavailable, "Failed to check IP allow rules")
+			return
+		}
+		if !allow {
+			abortWithError(c, http.StatusForbidden, "Access denied")
+			return
+		}
+		c.Next()
+	}
+}
+
+// Tenant scopes the request to the tenant named by the /t/:tenant path
+// prefix or, without one, to the tenant serving the request's host. It must
+// run before the authentication middleware.
+func Tenant(resolve func(ctx context.Context, host, slug string) (uint, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := resolve(c.Request.Context(), c.Request.Host, c.Param("tenant"))
+		if err != nil {
+			if err.Error() == "tenant not found" {
+				Error(c, http.StatusNotFound, "Tenant not found", nil)
+			} else {
+				Error(c, http.StatusInternalServerError, "Failed to resolve tenant", nil)
+			}
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenant.WithID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// DefaultTenantOnly keeps routes that affect the whole deployment, such as
+// backups and tenant management, to the admins of the default tenant
+func DefaultTenantOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if requestTenant(c) != tenant.DefaultID {
+			abortWithError(c, http.StatusForbidden, "Only available to the default tenant")
+			return
+		}
+		c.Next()
+	}
+}
+
+// requestTenant returns the tenant the request was scoped to by Tenant
+func requestTenant(c *gin.Context) uint {
+	if id, ok := tenant.FromContext(c.Request.Context()); ok {
+		return id
+	}
+	return tenant.DefaultID
+}
+
+// bodySourceKey holds the request body as it was before the first BodyLimit
+// ran, so a later, route-specific limit replaces a group-wide one instead of
+// being capped by it
+const bodySourceKey = "body_source"
+
+// BodyLimit rejects request bodies larger than maxBytes with 413. Bodies
+// are read up front and also rejected with 413 when they hold JSON nesting
+// deeper than maxDepth, whatever their declared content type, since
+// handlers bind JSON without looking at it. Multipart uploads are only
+// capped. When several limits apply to a route, the last one wins; a body
+// read by an earlier limit can only be narrowed.
+func BodyLimit(maxBytes int64, maxDepth int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			abortWithError(c, http.StatusRequestEntityTooLarge, "Request body too large")
+			return
+		}
+
+		var body io.ReadCloser
+		source, _ := c.Get(bodySourceKey)
+		switch source := source.(type) {
+		case []byte:
+			body = io.NopCloser(bytes.NewReader(source))
+		case io.ReadCloser:
+			body = source
+		default:
+			body = c.Request.Body
+			c.Set(bodySourceKey, body)
+		}
+		body = http.MaxBytesReader(c.Writer, body, maxBytes)
+
+		if strings.HasPrefix(c.ContentType(), "multipart/") {
+			c.Request.Body = body
+			c.Next()
+			return
+		}
+
+		data, err := io.ReadAll(body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				abortWithError(c, http.StatusRequestEntityTooLarge, "Request body too large")
+				return
+			}
+			abortWithError(c, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+		if jsonDepth(data) > maxDepth {
+			abortWithError(c, http.StatusRequestEntityTooLarge, "JSON nested too deeply")
+			return
+		}
+
+		c.Set(bodySourceKey, data)
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+		c.Next()
+	}
+}
+
+// jsonDepth returns how deeply the objects and arrays in data nest. It stops
+// at the first syntax error and leaves reporting it to the handler.
+func jsonDepth(data []byte) int {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	depth, deepest := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return deepest
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			if depth > deepest {
+				deepest = depth
+			}
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
+
 // Auth middleware for JWT authentication
 func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header required",
-			})
-			c.Abort()
+			abortWithError(c, http.StatusUnauthorized, "Authorization header required")
 			return
 		}
 
 		// Check if the header starts with "Bearer "
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid authorization header format",
-			})
-			c.Abort()
+			abortWithError(c, http.StatusUnauthorized, "Invalid authorization header format")
 			return
 		}
 
 		// Extract the token
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Token required",
-			})
-			c.Abort()
+		bearer := strings.TrimPrefix(authHeader, "Bearer ")
+		if bearer == "" {
+			abortWithError(c, http.StatusUnauthorized, "Token required")
 			return
 		}
 
-		// Validate the token (simplified implementation)
-		// In a real application, you would use a proper JWT library
-		if !isValidToken(token, jwtSecret) {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid token",
-			})
-			c.Abort()
+		// Tokens are only valid for the tenant they were issued in
+		claims, err := token.Parse(jwtSecret, bearer)
+		if err != nil || claims.TenantID != requestTenant(c) {
+			abortWithError(c, http.StatusUnauthorized, "Invalid token")
 			return
 		}
 
-		// Set user context (simplified)
-		c.Set("user_id", 1)
-		c.Set("user_role", "admin")
+		setUser(c, claims)
+		c.Next()
+	}
+}
 
+// OptionalAuth sets the user context when a valid bearer token is present
+// but lets anonymous requests through, for public routes that reveal more to
+// admins
+func OptionalAuth(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		bearer := strings.TrimPrefix(authHeader, "Bearer ")
+		if strings.HasPrefix(authHeader, "Bearer ") && bearer != "" {
+			if claims, err := token.Parse(jwtSecret, bearer); err == nil && claims.TenantID == requestTenant(c) {
+				setUser(c, claims)
+			}
+		}
+		c.Next()
+	}
+}
+
+// StaticToken requires the bearer token given, for endpoints such as
+// /metrics that are read by machines rather than admins. An empty token lets
+// every request through.
+func StaticToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.Next()
+			return
+		}
+		given := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			abortWithError(c, http.StatusUnauthorized, "Invalid token")
+			return
+		}
 		c.Next()
 	}
 }
 
-// Simple token validation (for demo purposes)
-func isValidToken(token, secret string) bool {
-	// This is a simplified implementation
-	// In a real application, you would:
-	// 1. Parse the JWT token
-	// 2. Verify the signature
-	// 3. Check expiration
-	// 4. Validate claims
+// QueryToken lets clients that cannot set headers, such as browser
+// WebSockets, pass the bearer token as ?token=. It must run before
+// AuthMiddleware.
+func QueryToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
 
-	// For demo purposes, accept any token that starts with "demo-jwt-token-"
-	return strings.HasPrefix(token, "demo-jwt-token-")
+// setUser records the admin a verified token was issued to
+func setUser(c *gin.Context, claims *token.Claims) {
+	c.Set("user_id", int(claims.UserID))
+	c.Set("username", claims.Username)
+	c.Set("user_role", claims.Role)
+}
+
+// APIVersionKey is the context key holding the negotiated API version
+const APIVersionKey = "api_version"
+
+var vendorMediaType = regexp.MustCompile(`application/vnd\.portfolio\.v(\d+)\+json`)
+
+// APIVersion records the API version served by a route group. Clients may
+// also negotiate a version through the Accept header using the vendor media
+// type application/vnd.portfolio.v{N}+json, as long as it is supported.
+func APIVersion(version int, supported ...int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		negotiated := version
+		if match := vendorMediaType.FindStringSubmatch(c.GetHeader("Accept")); match != nil {
+			if requested, err := strconv.Atoi(match[1]); err == nil {
+				for _, v := range supported {
+					if v == requested {
+						negotiated = requested
+						break
+					}
+				}
+			}
+		}
+
+		c.Set(APIVersionKey, negotiated)
+		c.Header("X-API-Version", "v"+strconv.Itoa(negotiated))
+		c.Next()
+	}
+}
+
+// SLO records the outcome of every routed request for SLO tracking
+func SLO(tracker *slo.Tracker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// Unmatched routes would create an unbounded set of endpoints
+		if c.FullPath() == "" {
+			return
+		}
+
+		tracker.Record(c.Request.Method+" "+c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
 }
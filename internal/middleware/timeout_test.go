@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTimeoutLeavesStreamsAlone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout(10*time.Millisecond, "/chat"))
+	slow := func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(50 * time.Millisecond):
+			c.String(http.StatusOK, "done")
+		}
+	}
+	router.POST("/api/v1/chat", slow)
+	router.POST("/t/:tenant/api/v1/chat", slow)
+	router.POST("/api/v2/chat", slow)
+	router.POST("/api/v2/admin/chat", slow)
+	router.POST("/api/v1/contact", slow)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/api/v1/chat", http.StatusOK},
+		{"/t/acme/api/v1/chat", http.StatusOK},
+		{"/api/v2/chat", http.StatusOK},
+		{"/api/v2/admin/chat", http.StatusGatewayTimeout},
+		{"/api/v1/contact", http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.path, rec.Code, tt.status)
+		}
+	}
+}
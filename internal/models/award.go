@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Award represents awards and honors
+type Award struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	TenantID    uint      `json:"-" gorm:"not null;default:1;index"`
+	Title       string    `json:"title" gorm:"not null"`
+	Issuer      string    `json:"issuer"`
+	Date        time.Time `json:"date" gorm:"not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	Link        string    `json:"link"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
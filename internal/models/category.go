@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Category groups projects under a curated, consistently named heading
+type Category struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	TenantID    uint      `json:"-" gorm:"not null;default:1;uniqueIndex:idx_categories_tenant_name"`
+	Name        string    `json:"name" gorm:"not null;uniqueIndex:idx_categories_tenant_name"` // Backend, Blockchain, Full-stack, etc.
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Certification represents professional certifications
+type Certification struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	TenantID        uint       `json:"-" gorm:"not null;default:1;index"`
+	Name            string     `json:"name" gorm:"not null"`
+	Issuer          string     `json:"issuer" gorm:"not null"`
+	IssueDate       time.Time  `json:"issue_date" gorm:"not null"`
+	ExpiryDate      *time.Time `json:"expiry_date"`
+	Expired         bool       `json:"expired" gorm:"-"` // Whether expiry_date has passed, set when certifications are listed
+	ReminderSentAt  *time.Time `json:"-"`                // When the owner was reminded of the coming expiry
+	CredentialID    string     `json:"credential_id"`
+	VerificationURL string     `json:"verification_url"`
+	BadgeImage      string     `json:"badge_image"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// What a comment can be posted on
+const (
+	CommentOnProject = "project"
+	CommentOnPost    = "post"
+)
+
+// Comment is a visitor's comment on a project or blog post. Comments are
+// held for moderation and shown once approved; the email, address and user
+// agent are for moderators only.
+type Comment struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	TenantID    uint      `json:"-" gorm:"not null;default:1;index"`
+	TargetType  string    `json:"target_type" gorm:"size:20;not null;index:idx_comments_target"` // project or post
+	TargetID    uint      `json:"target_id" gorm:"not null;index:idx_comments_target"`
+	Author      string    `json:"author" gorm:"not null"`
+	Email       string    `json:"email,omitempty"`
+	Body        string    `json:"body" gorm:"type:text;not null"`
+	Status      string    `json:"status" gorm:"size:20;default:'pending';index"` // pending, approved, rejected, spam
+	SpamScore   int       `json:"spam_score,omitempty" gorm:"default:0"`
+	SpamVerdict string    `json:"spam_verdict,omitempty"` // ham, spam or blatant from Akismet; empty when not checked
+	IPAddress   string    `json:"ip_address,omitempty"`
+	UserAgent   string    `json:"user_agent,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Company is an employer or client that experiences and testimonials can
+// reference, so it is named and shown with the same logo wherever it appears
+type Company struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;uniqueIndex:idx_companies_tenant_name"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex:idx_companies_tenant_name"`
+	Logo      string    `json:"logo"` // Usually the url of an upload from /admin/media
+	URL       string    `json:"url"`
+	Industry  string    `json:"industry"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
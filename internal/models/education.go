@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Education represents academic history entries
+type Education struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	TenantID    uint       `json:"-" gorm:"not null;default:1;index"`
+	Institution string     `json:"institution" gorm:"not null"`
+	Degree      string     `json:"degree" gorm:"not null"`
+	Field       string     `json:"field"`
+	StartDate   time.Time  `json:"start_date" gorm:"not null"`
+	EndDate     *time.Time `json:"end_date"`
+	Description string     `json:"description" gorm:"type:text"`
+	Position    int        `json:"position" gorm:"default:0"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// EmbedToken grants third-party sites read access to the embeddable widgets.
+// Requests must originate from one of AllowedOrigins ("*" allows any origin).
+type EmbedToken struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	TenantID       uint      `json:"-" gorm:"not null;default:1;index"`
+	Name           string    `json:"name" gorm:"not null"`
+	Token          string    `json:"token" gorm:"uniqueIndex;not null"`
+	AllowedOrigins []string  `json:"allowed_origins" gorm:"type:json;serializer:json"`
+	Active         bool      `json:"active" gorm:"default:true"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
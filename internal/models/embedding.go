@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Embedding is the vector semantic search compares queries with for one
+// published project, experience or post. It is computed again when the
+// item's text or the embedding model changes.
+type Embedding struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	TenantID    uint       `json:"-" gorm:"not null;default:1;uniqueIndex:idx_embeddings_tenant_item"`
+	Type        string     `json:"type" gorm:"not null;uniqueIndex:idx_embeddings_tenant_item"` // project, experience or post
+	ItemID      uint       `json:"item_id" gorm:"not null;uniqueIndex:idx_embeddings_tenant_item"`
+	Title       string     `json:"title"`
+	Subtitle    string     `json:"subtitle"`
+	PublishedAt *time.Time `json:"published_at"` // Set on scheduled items, which are only found from then on
+	Model       string     `json:"model" gorm:"not null"`
+	Checksum    string     `json:"checksum" gorm:"not null"` // SHA-256 of the text the vector was computed from
+	Vector      Vector     `json:"-" gorm:"type:text;not null"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Vector is stored as text in pgvector's format, such as [0.1,-0.2], so it
+// can be cast to vector where the extension is installed and read back
+// anywhere else
+type Vector []float32
+
+func (v Vector) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, x := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(x), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String(), nil
+}
+
+func (v *Vector) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	case nil:
+		*v = nil
+		return nil
+	default:
+		return fmt.Errorf("vector: cannot scan %T", src)
+	}
+
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+		return fmt.Errorf("vector: invalid value %q", text)
+	}
+	text = strings.TrimSpace(text[1 : len(text)-1])
+	if text == "" {
+		*v = Vector{}
+		return nil
+	}
+	parts := strings.Split(text, ",")
+	vector := make(Vector, len(parts))
+	for i, part := range parts {
+		x, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return fmt.Errorf("vector: invalid value: %w", err)
+		}
+		vector[i] = float32(x)
+	}
+	*v = vector
+	return nil
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IPRule blocks or admits a range of client addresses. Deny rules apply to
+// every request; once any allow rule exists, only the addresses it covers
+// may reach the admin API. Rules apply to the whole deployment rather than
+// to one tenant.
+type IPRule struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CIDR      string    `json:"cidr" gorm:"not null"`   // A single address is stored as /32 or /128
+	Action    string    `json:"action" gorm:"not null"` // allow, deny
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
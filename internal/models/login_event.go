@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// LoginEvent records an attempt to log in to the admin, successful or not
+type LoginEvent struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	TenantID   uint      `json:"-" gorm:"not null;default:1;index"`
+	Username   string    `json:"username" gorm:"size:100;index"` // As typed, so failed attempts may name unknown users
+	Success    bool      `json:"success" gorm:"index"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Country    string    `json:"country,omitempty"` // Located from IPAddress when geolocation is enabled
+	City       string    `json:"city,omitempty"`
+	NewIP      bool      `json:"new_ip"`      // First successful login of the user from this address
+	NewCountry bool      `json:"new_country"` // First successful login of the user from this country
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Media represents an uploaded file
+type Media struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	TenantID   uint           `json:"-" gorm:"not null;default:1;index"`
+	Filename   string         `json:"filename" gorm:"not null"`                // Original name of the uploaded file
+	StoredName string         `json:"stored_name" gorm:"uniqueIndex;not null"` // Name in the storage backend
+	Size       int64          `json:"size"`
+	MimeType   string         `json:"mime_type"`
+	Checksum   string         `json:"checksum" gorm:"index"` // Hex SHA-256 of the contents
+	URL        string         `json:"url" gorm:"index"`
+	Private    bool           `json:"private" gorm:"not null;default:false"` // Served only through signed URLs
+	Variants   []MediaVariant `json:"variants" gorm:"foreignKey:MediaID"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// MediaVariant is a resized copy of an uploaded image
+type MediaVariant struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	TenantID   uint      `json:"-" gorm:"not null;default:1;index"`
+	MediaID    uint      `json:"media_id" gorm:"not null;uniqueIndex:idx_media_variant"`
+	Name       string    `json:"name" gorm:"not null;uniqueIndex:idx_media_variant"` // thumb, card, full
+	StoredName string    `json:"stored_name" gorm:"uniqueIndex;not null"`
+	URL        string    `json:"url"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Size       int64     `json:"size"`
+	MimeType   string    `json:"mime_type"`
+	Checksum   string    `json:"checksum"`
+	CreatedAt  time.Time `json:"created_at"`
+}
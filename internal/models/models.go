@@ -8,77 +8,216 @@ import (
 
 // Profile represents the main profile information
 type Profile struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name" gorm:"not null"`
-	Title     string    `json:"title" gorm:"not null"`
-	Location  string    `json:"location"`
-	Email     string    `json:"email" gorm:"uniqueIndex"`
-	Phone     string    `json:"phone"`
-	Telegram  string    `json:"telegram"`
-	GitHub    string    `json:"github"`
-	LinkedIn  string    `json:"linkedin"`
-	Summary   string    `json:"summary" gorm:"type:text"`
-	Avatar    string    `json:"avatar"`
-	ResumeURL string    `json:"resume_url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uint              `json:"id" gorm:"primaryKey"`
+	TenantID       uint              `json:"-" gorm:"not null;default:1;uniqueIndex:idx_profiles_tenant_email"`
+	Name           string            `json:"name" gorm:"not null"`
+	Title          string            `json:"title" gorm:"not null"`
+	Location       string            `json:"location"`
+	Email          string            `json:"email" gorm:"uniqueIndex:idx_profiles_tenant_email"`
+	Phone          string            `json:"phone"`
+	Telegram       string            `json:"telegram"`
+	GitHub         string            `json:"github"`
+	LinkedIn       string            `json:"linkedin"`
+	Summary        string            `json:"summary" gorm:"type:text"`        // Markdown
+	SummaryHTML    string            `json:"summary_html,omitempty" gorm:"-"` // Rendered on request with ?render=html
+	Avatar         string            `json:"avatar"`
+	AvatarVariants map[string]string `json:"avatar_variants" gorm:"type:json;serializer:json"` // Square copies of an uploaded avatar by variant name
+	ResumeURL      string            `json:"resume_url"`
+	Version        uint              `json:"version" gorm:"not null;default:1"` // Incremented on every update; send it back to detect conflicting edits
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// Attribution records the admins who created and last changed an entry, by
+// username. Public responses leave it out.
+type Attribution struct {
+	CreatedBy string `json:"created_by,omitempty" gorm:"size:100"`
+	UpdatedBy string `json:"updated_by,omitempty" gorm:"size:100"`
 }
 
 // Experience represents work experience entries
 type Experience struct {
-	ID           uint       `json:"id" gorm:"primaryKey"`
-	Company      string     `json:"company" gorm:"not null"`
-	Position     string     `json:"position" gorm:"not null"`
-	Location     string     `json:"location"`
-	StartDate    time.Time  `json:"start_date" gorm:"not null"`
-	EndDate      *time.Time `json:"end_date"`
-	Current      bool       `json:"current" gorm:"default:false"`
-	Description  string     `json:"description" gorm:"type:text"`
-	Achievements []string   `json:"achievements" gorm:"type:json"`
-	Technologies []string   `json:"technologies" gorm:"type:json"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	TenantID       uint       `json:"-" gorm:"not null;default:1;index"`
+	Company        string     `json:"company" gorm:"not null"` // Name of the linked company when company_id is set
+	CompanyID      *uint      `json:"company_id" gorm:"index"`
+	CompanyDetails *Company   `json:"company_details,omitempty" gorm:"foreignKey:CompanyID;constraint:OnDelete:SET NULL"`
+	Position       string     `json:"position" gorm:"not null"`
+	Location       string     `json:"location"`
+	StartDate      time.Time  `json:"start_date" gorm:"not null"`
+	EndDate        *time.Time `json:"end_date"`
+	Current        bool       `json:"current" gorm:"default:false"`
+	Description    string     `json:"description" gorm:"type:text"`
+	Achievements   []string   `json:"achievements" gorm:"type:json;serializer:json"`
+	Technologies   []string   `json:"technologies" gorm:"type:json;serializer:json"`
+	Skills         []Skill    `json:"skills" gorm:"many2many:experience_skills"` // Skill rows backing the free-form technologies
+	Pinned         bool       `json:"pinned" gorm:"default:false"`
+	SortOrder      int        `json:"sort_order" gorm:"default:0"` // Set via reorder; 0 falls back to start date
+	Published      bool       `json:"published" gorm:"default:true"`
+	PublishedAt    *time.Time `json:"published_at"`
+	Attribution
+	Version   uint      `json:"version" gorm:"not null;default:1"` // Incremented on every update
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // Skill represents technical skills
 type Skill struct {
+	ID           uint    `json:"id" gorm:"primaryKey"`
+	TenantID     uint    `json:"-" gorm:"not null;default:1;uniqueIndex:idx_skills_tenant_name"`
+	Name         string  `json:"name" gorm:"not null;uniqueIndex:idx_skills_tenant_name"`
+	Category     string  `json:"category" gorm:"not null"` // Languages, Frameworks, Tools, etc.
+	Level        int     `json:"level" gorm:"default:5"`   // 1-10 scale
+	Description  string  `json:"description"`
+	Icon         string  `json:"icon"`
+	Position     int     `json:"position" gorm:"default:0"` // Display order set via reorder
+	YearsOfUse   float64 `json:"years_of_use" gorm:"-"`     // Computed from linked published experiences
+	Endorsements int64   `json:"endorsements" gorm:"default:0"`
+	Attribution
+	Version   uint      `json:"version" gorm:"not null;default:1"` // Incremented on every update
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Project represents portfolio projects
+type Project struct {
+	ID                  uint               `json:"id" gorm:"primaryKey"`
+	TenantID            uint               `json:"-" gorm:"not null;default:1;index"`
+	Name                string             `json:"name" gorm:"not null"`
+	Description         string             `json:"description" gorm:"type:text"`
+	LongDescription     string             `json:"long_description" gorm:"type:text"`        // Markdown
+	LongDescriptionHTML string             `json:"long_description_html,omitempty" gorm:"-"` // Rendered on request with ?render=html
+	Technologies        []string           `json:"technologies" gorm:"type:json;serializer:json"`
+	GitHubURL           string             `json:"github_url"`
+	GitHub              GitHubStats        `json:"github" gorm:"embedded;embeddedPrefix:github_"` // Refreshed in the background from GitHubURL
+	LiveURL             string             `json:"live_url"`
+	Images              []ProjectImage     `json:"images" gorm:"constraint:OnDelete:CASCADE"`     // Gallery in display order; the first image is the cover
+	DemoVideoURL        string             `json:"demo_video_url"`                                // YouTube or Vimeo link, or a video file
+	Demo                *DemoVideo         `json:"demo,omitempty" gorm:"-"`                       // How to play DemoVideoURL, added when projects are loaded
+	Milestones          []ProjectMilestone `json:"milestones" gorm:"constraint:OnDelete:CASCADE"` // Progress history, newest first
+	Featured            bool               `json:"featured" gorm:"default:false"`
+	FeaturedRank        int                `json:"featured_rank" gorm:"not null;default:0"` // Position among featured projects from 1; 0 is unranked
+	CategoryID          *uint              `json:"category_id" gorm:"index"`
+	Category            *Category          `json:"category,omitempty" gorm:"constraint:OnDelete:SET NULL"`
+	Tags                []Tag              `json:"tags" gorm:"many2many:project_tags"`
+	Skills              []Skill            `json:"skills" gorm:"many2many:project_skills"`       // Skill rows backing the free-form technologies
+	Status              string             `json:"status" gorm:"default:'completed'"`            // completed, in-progress, planned
+	Archived            bool               `json:"archived" gorm:"not null;default:false;index"` // Hidden from listings without deleting the project
+	ArchivedAt          *time.Time         `json:"archived_at"`
+	LikeCount           int64              `json:"like_count" gorm:"not null;default:0"`
+	Published           bool               `json:"published" gorm:"default:true"`
+	PublishedAt         *time.Time         `json:"published_at"`
+	Attribution
+	Version   uint      `json:"version" gorm:"not null;default:1"` // Incremented on every update
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProjectImage is a screenshot in a project's gallery
+type ProjectImage struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	TenantID  uint              `json:"-" gorm:"not null;default:1;index"`
+	ProjectID uint              `json:"-" gorm:"not null;index"`
+	URL       string            `json:"url" gorm:"not null"`
+	Variants  map[string]string `json:"variants" gorm:"type:json;serializer:json"` // Resized copies of an uploaded image by variant name
+	Caption   string            `json:"caption"`
+	Position  int               `json:"position" gorm:"not null;default:0"` // Display order within the gallery from 1
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// ProjectMilestone is a dated step in a project's history, such as a
+// release or a launch
+type ProjectMilestone struct {
 	ID          uint      `json:"id" gorm:"primaryKey"`
-	Name        string    `json:"name" gorm:"not null;uniqueIndex"`
-	Category    string    `json:"category" gorm:"not null"` // Languages, Frameworks, Tools, etc.
-	Level       int       `json:"level" gorm:"default:5"`   // 1-10 scale
-	Description string    `json:"description"`
-	Icon        string    `json:"icon"`
+	TenantID    uint      `json:"-" gorm:"not null;default:1;index"`
+	ProjectID   uint      `json:"-" gorm:"not null;index"`
+	Date        time.Time `json:"date" gorm:"not null"`
+	Title       string    `json:"title" gorm:"not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	Link        string    `json:"link"` // Release notes, changelog or announcement
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-// Project represents portfolio projects
-type Project struct {
-	ID              uint      `json:"id" gorm:"primaryKey"`
-	Name            string    `json:"name" gorm:"not null"`
-	Description     string    `json:"description" gorm:"type:text"`
-	LongDescription string    `json:"long_description" gorm:"type:text"`
-	Technologies    []string  `json:"technologies" gorm:"type:json"`
-	GitHubURL       string    `json:"github_url"`
-	LiveURL         string    `json:"live_url"`
-	ImageURL        string    `json:"image_url"`
-	Featured        bool      `json:"featured" gorm:"default:false"`
-	Category        string    `json:"category"`                          // Blockchain, Backend, Full-stack, etc.
-	Status          string    `json:"status" gorm:"default:'completed'"` // completed, in-progress, planned
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+// DemoVideo tells clients how to play a project's demo video. The title,
+// author, thumbnail and size come from the provider and are left out when
+// it could not be reached or the video is self-hosted.
+type DemoVideo struct {
+	Provider     string `json:"provider"`  // youtube, vimeo or self-hosted
+	EmbedURL     string `json:"embed_url"` // Player address for an iframe, or the file for a video element
+	Title        string `json:"title,omitempty"`
+	AuthorName   string `json:"author_name,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+}
+
+// Cover returns the URL of the project's first image, preferring its full
+// size variant, or "" when the gallery is empty
+func (p *Project) Cover() string {
+	if len(p.Images) == 0 {
+		return ""
+	}
+	if full := p.Images[0].Variants["full"]; full != "" {
+		return full
+	}
+	return p.Images[0].URL
+}
+
+// GitHubStats are repository statistics fetched from the GitHub API
+type GitHubStats struct {
+	Stars      int        `json:"stars"`
+	Forks      int        `json:"forks"`
+	OpenIssues int        `json:"open_issues"`
+	PushedAt   *time.Time `json:"pushed_at"`
+	SyncedAt   *time.Time `json:"synced_at"` // When the figures were fetched; null until the first sync
 }
 
 // Contact represents contact form submissions
 type Contact struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	TenantID     uint      `json:"-" gorm:"not null;default:1;index"`
+	Name         string    `json:"name" gorm:"not null"`
+	Email        string    `json:"email" gorm:"not null"`
+	Subject      string    `json:"subject"`
+	Message      string    `json:"message" gorm:"type:text;not null"`
+	Status       string    `json:"status" gorm:"default:'new'"` // new, read, replied, spam
+	SpamScore    int       `json:"spam_score" gorm:"default:0"`
+	SpamVerdict  string    `json:"spam_verdict,omitempty"`            // ham, spam or blatant from Akismet; empty when not checked
+	ServiceID    *uint     `json:"service_id,omitempty" gorm:"index"` // Set when sent from a service's call to action
+	RepliedAuto  bool      `json:"replied_auto" gorm:"default:false"` // Set once the acknowledgment email has been sent
+	ContactGroup uint      `json:"contact_group" gorm:"index"`        // ID of the first submission from the same email address
+	Repeat       bool      `json:"repeat" gorm:"default:false"`       // Set when the sender had written before
+	IPAddress    string    `json:"ip_address"`
+	Country      string    `json:"country" gorm:"size:2;index"` // ISO 3166-1 alpha-2 code looked up from IPAddress
+	City         string    `json:"city"`
+	UserAgent    string    `json:"user_agent"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ContactMessage is a reply emailed to a contact submitter. Replies are kept
+// in order so that later ones can reference earlier ones for threading.
+type ContactMessage struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;index"`
+	ContactID uint      `json:"contact_id" gorm:"index;not null"`
+	AuthorID  uint      `json:"author_id"`
+	Subject   string    `json:"subject" gorm:"not null"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	MessageID string    `json:"message_id"` // Message-ID header of the sent email
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ContactNote is an internal note admins keep on a contact submission. Notes
+// are never shown to the submitter.
+type ContactNote struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name" gorm:"not null"`
-	Email     string    `json:"email" gorm:"not null"`
-	Subject   string    `json:"subject"`
-	Message   string    `json:"message" gorm:"type:text;not null"`
-	Status    string    `json:"status" gorm:"default:'new'"` // new, read, replied
-	IPAddress string    `json:"ip_address"`
-	UserAgent string    `json:"user_agent"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;index"`
+	ContactID uint      `json:"contact_id" gorm:"index;not null"`
+	AuthorID  uint      `json:"author_id"`
+	Author    string    `json:"author"` // Username of the admin who wrote the note
+	Body      string    `json:"body" gorm:"type:text;not null"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -86,8 +225,9 @@ type Contact struct {
 // User represents admin users
 type User struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
-	Username  string    `json:"username" gorm:"uniqueIndex;not null"`
-	Email     string    `json:"email" gorm:"uniqueIndex;not null"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;uniqueIndex:idx_users_tenant_username;uniqueIndex:idx_users_tenant_email"`
+	Username  string    `json:"username" gorm:"uniqueIndex:idx_users_tenant_username;not null"`
+	Email     string    `json:"email" gorm:"uniqueIndex:idx_users_tenant_email;not null"`
 	Password  string    `json:"-" gorm:"not null"` // Hidden from JSON
 	Role      string    `json:"role" gorm:"default:'admin'"`
 	Active    bool      `json:"active" gorm:"default:true"`
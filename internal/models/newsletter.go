@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Subscriber is an email address signed up for the newsletter. Signups stay
+// pending until the address is confirmed through the link mailed to it.
+type Subscriber struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	TenantID       uint       `json:"-" gorm:"not null;default:1;uniqueIndex:idx_subscribers_tenant_email"`
+	Email          string     `json:"email" gorm:"not null;uniqueIndex:idx_subscribers_tenant_email"` // Stored lowercased
+	Name           string     `json:"name"`
+	Status         string     `json:"status" gorm:"not null;default:'pending';index"` // pending, subscribed, unsubscribed
+	Token          string     `json:"-" gorm:"uniqueIndex;not null"`                  // Confirms and unsubscribes the address
+	ConfirmedAt    *time.Time `json:"confirmed_at"`
+	UnsubscribedAt *time.Time `json:"unsubscribed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// Campaign is a newsletter issue. The body is Markdown and a text/template
+// rendered for every subscriber.
+type Campaign struct {
+	ID        uint             `json:"id" gorm:"primaryKey"`
+	TenantID  uint             `json:"-" gorm:"not null;default:1;index"`
+	Subject   string           `json:"subject" gorm:"not null"`
+	Body      string           `json:"body" gorm:"type:text;not null"`
+	Status    string           `json:"status" gorm:"not null;default:'draft';index"` // draft, sending, sent
+	Stats     map[string]int64 `json:"stats" gorm:"-"`                               // Deliveries by status
+	QueuedAt  *time.Time       `json:"queued_at"`
+	SentAt    *time.Time       `json:"sent_at"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// CampaignDelivery tracks the email of a campaign to one subscriber
+type CampaignDelivery struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	TenantID     uint       `json:"-" gorm:"not null;default:1;index"`
+	CampaignID   uint       `json:"campaign_id" gorm:"not null;index"`
+	SubscriberID uint       `json:"subscriber_id" gorm:"not null;index"`
+	Email        string     `json:"email" gorm:"not null"`
+	Status       string     `json:"status" gorm:"not null;default:'pending';index"` // pending, sent, failed, skipped
+	Attempts     int        `json:"attempts" gorm:"not null;default:0"`
+	LastError    string     `json:"last_error"`
+	SentAt       *time.Time `json:"sent_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
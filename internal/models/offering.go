@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Service represents a consulting or freelance offering
+type Service struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	TenantID     uint      `json:"-" gorm:"not null;default:1;index"`
+	Name         string    `json:"name" gorm:"not null"`
+	Description  string    `json:"description" gorm:"type:text"`
+	PricingHint  string    `json:"pricing_hint"`
+	Availability string    `json:"availability" gorm:"default:'available'"` // available, limited, unavailable
+	Icon         string    `json:"icon"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
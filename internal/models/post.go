@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Post represents a blog article written in Markdown
+type Post struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	TenantID    uint       `json:"-" gorm:"not null;default:1;uniqueIndex:idx_posts_tenant_slug"`
+	Title       string     `json:"title" gorm:"not null"`
+	Slug        string     `json:"slug" gorm:"uniqueIndex:idx_posts_tenant_slug;not null"`
+	Summary     string     `json:"summary" gorm:"type:text"`
+	Body        string     `json:"body" gorm:"type:text"`        // Markdown
+	BodyHTML    string     `json:"body_html,omitempty" gorm:"-"` // Rendered on request with ?render=html
+	Tags        []Tag      `json:"tags" gorm:"many2many:post_tags"`
+	CoverImage  string     `json:"cover_image"`
+	Published   bool       `json:"published" gorm:"default:false"`
+	PublishedAt *time.Time `json:"published_at"`
+	Attribution
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Publication represents papers, articles and whitepapers
+type Publication struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;index"`
+	Title     string    `json:"title" gorm:"not null"`
+	Venue     string    `json:"venue"`
+	Date      time.Time `json:"date" gorm:"not null"`
+	CoAuthors []string  `json:"co_authors" gorm:"type:json;serializer:json"`
+	DOI       string    `json:"doi"`
+	URL       string    `json:"url"`
+	Abstract  string    `json:"abstract" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Resume is a variant of the resume aimed at one kind of role, such as
+// Backend or Blockchain. It is rendered from the portfolio like the full
+// resume, with its own label and summary and only the projects carrying its
+// tags.
+type Resume struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	TenantID   uint      `json:"-" gorm:"not null;default:1;uniqueIndex:idx_resumes_tenant_slug"`
+	Name       string    `json:"name" gorm:"not null"`
+	Slug       string    `json:"slug" gorm:"not null;uniqueIndex:idx_resumes_tenant_slug"` // Picks the variant in GET /resume?variant=
+	TargetRole string    `json:"target_role"`                                              // Replaces the profile title
+	Summary    string    `json:"summary" gorm:"type:text"`                                 // Replaces the profile summary when set
+	FileURL    string    `json:"file_url"`                                                 // Prepared document, usually a PDF uploaded to /admin/media
+	Tags       []string  `json:"tags" gorm:"type:json;serializer:json"`                    // Slugs of the tags choosing the projects; empty keeps them all
+	IsDefault  bool      `json:"default" gorm:"not null;default:false"`                    // Served when no variant is asked for; at most one per tenant
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ShortLink redirects /l/{code} to a target and counts the visits, so links
+// shared on social media or printed on a CV can be tracked and repointed
+// without changing
+type ShortLink struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	TenantID      uint       `json:"-" gorm:"not null;default:1;uniqueIndex:idx_short_links_tenant_code"`
+	Code          string     `json:"code" gorm:"not null;uniqueIndex:idx_short_links_tenant_code"`
+	TargetURL     string     `json:"target_url" gorm:"not null"` // Absolute http(s) URL or a path on this host
+	Note          string     `json:"note"`
+	Active        bool       `json:"active" gorm:"default:true"`
+	Clicks        int64      `json:"clicks" gorm:"not null;default:0"`
+	LastClickedAt *time.Time `json:"last_clicked_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Slot represents a recurring weekly availability window for interviews
+type Slot struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	TenantID        uint      `json:"-" gorm:"not null;default:1;index"`
+	Weekday         int       `json:"weekday" gorm:"not null"`    // 0 = Sunday ... 6 = Saturday
+	StartTime       string    `json:"start_time" gorm:"not null"` // HH:MM in the slot's timezone
+	DurationMinutes int       `json:"duration_minutes" gorm:"default:30"`
+	Timezone        string    `json:"timezone" gorm:"default:'UTC'"`
+	Title           string    `json:"title"`
+	Location        string    `json:"location"` // Meeting link or address
+	Active          bool      `json:"active" gorm:"default:true"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Booking represents a reserved occurrence of a slot
+type Booking struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;index"`
+	SlotID    uint      `json:"slot_id" gorm:"not null;uniqueIndex:idx_booking_slot_start"`
+	StartsAt  time.Time `json:"starts_at" gorm:"not null;uniqueIndex:idx_booking_slot_start"`
+	EndsAt    time.Time `json:"ends_at" gorm:"not null"`
+	ContactID uint      `json:"contact_id"`
+	Name      string    `json:"name" gorm:"not null"`
+	Email     string    `json:"email" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SlotOccurrence is a concrete, bookable instance of a weekly slot
+type SlotOccurrence struct {
+	SlotID   uint      `json:"slot_id"`
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Timezone string    `json:"timezone"`
+}
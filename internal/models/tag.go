@@ -0,0 +1,32 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Tag labels projects, posts and talks with a shared vocabulary
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;uniqueIndex:idx_tags_tenant_slug"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex:idx_tags_tenant_slug;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TagUsage is a tag with the number of published items carrying it
+type TagUsage struct {
+	Tag      `gorm:"embedded"`
+	Projects int64 `json:"projects"`
+	Posts    int64 `json:"posts"`
+	Talks    int64 `json:"talks"`
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify lowercases s and joins its letters and digits with dashes
+func Slugify(s string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
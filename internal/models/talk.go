@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Talk represents a talk or speaking engagement
+type Talk struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  uint      `json:"-" gorm:"not null;default:1;index"`
+	Title     string    `json:"title" gorm:"not null"`
+	Event     string    `json:"event" gorm:"not null"`
+	Date      time.Time `json:"date" gorm:"not null"`
+	Location  string    `json:"location"`
+	SlidesURL string    `json:"slides_url"`
+	VideoURL  string    `json:"video_url"`
+	Abstract  string    `json:"abstract" gorm:"type:text"`
+	Tags      []Tag     `json:"tags" gorm:"many2many:talk_tags"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Tenant is one portfolio hosted by the deployment. Every content row
+// carries the ID of the tenant it belongs to.
+type Tenant struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex;not null"`         // Served under /t/{slug}/api/...
+	Name      string    `json:"name" gorm:"not null"`                     // Display name for admins
+	Domains   []string  `json:"domains" gorm:"type:json;serializer:json"` // Hostnames that serve this tenant
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
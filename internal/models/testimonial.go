@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Testimonial represents a recommendation shown once approved by an admin
+type Testimonial struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	TenantID       uint      `json:"-" gorm:"not null;default:1;index"`
+	Author         string    `json:"author" gorm:"not null"`
+	Role           string    `json:"role"`
+	Company        string    `json:"company"` // Name of the linked company when company_id is set
+	CompanyID      *uint     `json:"company_id" gorm:"index"`
+	CompanyDetails *Company  `json:"company_details,omitempty" gorm:"foreignKey:CompanyID;constraint:OnDelete:SET NULL"`
+	Text           string    `json:"text" gorm:"type:text;not null"`
+	Avatar         string    `json:"avatar"`
+	Approved       bool      `json:"approved" gorm:"default:false"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
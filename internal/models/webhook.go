@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// WebhookSubscription is an admin-managed endpoint notified of content events
+type WebhookSubscription struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	TenantID   uint      `json:"-" gorm:"not null;default:1;index"`
+	URL        string    `json:"url" gorm:"not null"`
+	Secret     string    `json:"secret" gorm:"not null"` // Used to sign payloads (HMAC-SHA256)
+	EventTypes []string  `json:"event_types" gorm:"type:json;serializer:json"`
+	Active     bool      `json:"active" gorm:"default:true"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Subscribes reports whether the subscription wants events of the given type
+func (w *WebhookSubscription) Subscribes(eventType string) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType || t == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery tracks delivery attempts of one event to one subscription
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	TenantID       uint       `json:"-" gorm:"not null;default:1;index"`
+	SubscriptionID uint       `json:"subscription_id" gorm:"not null;index"`
+	EventID        string     `json:"event_id" gorm:"not null"`
+	EventType      string     `json:"event_type" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"type:text"`
+	Status         string     `json:"status" gorm:"default:'pending';index"` // pending, delivering, succeeded, failed
+	Attempts       int        `json:"attempts" gorm:"default:0"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `json:"last_error"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,159 @@
+// Package oembed recognizes demo video URLs and fetches their metadata from
+// the providers' oEmbed endpoints
+package oembed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Video providers
+const (
+	YouTube    = "youtube"
+	Vimeo      = "vimeo"
+	SelfHosted = "self-hosted" // A video file played by the browser
+)
+
+// videoExtensions are the file types browsers play in a video element
+var videoExtensions = map[string]bool{".mp4": true, ".m4v": true, ".webm": true, ".ogv": true, ".mov": true}
+
+var (
+	youTubeID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoID   = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Video is a recognized demo video
+type Video struct {
+	Provider string
+	EmbedURL string // Player address for an iframe, or the file itself when self-hosted
+	PageURL  string // Canonical address the provider's oEmbed endpoint is asked about; empty when self-hosted
+}
+
+// Detect recognizes YouTube and Vimeo links and links to video files, which
+// may be absolute or, for uploads, start with /
+func Detect(rawURL string) (*Video, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, errors.New("unsupported video")
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."), "m.")
+	if u.Host != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.New("unsupported video")
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch host {
+	case "youtube.com", "youtube-nocookie.com", "youtu.be":
+		var id string
+		switch {
+		case host == "youtu.be":
+			id = parts[0]
+		case len(parts) == 1 && parts[0] == "watch":
+			id = u.Query().Get("v")
+		case len(parts) == 2 && (parts[0] == "embed" || parts[0] == "shorts" || parts[0] == "live"):
+			id = parts[1]
+		}
+		if !youTubeID.MatchString(id) {
+			return nil, errors.New("unsupported video")
+		}
+		return &Video{
+			Provider: YouTube,
+			EmbedURL: "https://www.youtube-nocookie.com/embed/" + id,
+			PageURL:  "https://www.youtube.com/watch?v=" + id,
+		}, nil
+
+	case "vimeo.com", "player.vimeo.com":
+		// vimeo.com/ID, vimeo.com/ID/HASH for unlisted videos,
+		// vimeo.com/channels/NAME/ID and player.vimeo.com/video/ID?h=HASH
+		if len(parts) > 0 && (parts[0] == "video" || parts[0] == "channels") {
+			parts = parts[1:]
+			if len(parts) > 0 && !vimeoID.MatchString(parts[0]) {
+				parts = parts[1:]
+			}
+		}
+		if len(parts) == 0 || !vimeoID.MatchString(parts[0]) {
+			return nil, errors.New("unsupported video")
+		}
+		id, hash := parts[0], u.Query().Get("h")
+		if len(parts) > 1 {
+			hash = parts[1]
+		}
+		video := &Video{
+			Provider: Vimeo,
+			EmbedURL: "https://player.vimeo.com/video/" + id,
+			PageURL:  "https://vimeo.com/" + id,
+		}
+		if hash != "" {
+			video.EmbedURL += "?h=" + url.QueryEscape(hash)
+			video.PageURL += "/" + url.PathEscape(hash)
+		}
+		return video, nil
+	}
+
+	if (u.Host != "" || strings.HasPrefix(u.Path, "/")) && videoExtensions[strings.ToLower(path.Ext(u.Path))] {
+		return &Video{Provider: SelfHosted, EmbedURL: u.String()}, nil
+	}
+	return nil, errors.New("unsupported video")
+}
+
+// Metadata is what a provider tells about a video
+type Metadata struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// oEmbed endpoints by provider
+var endpoints = map[string]string{
+	YouTube: "https://www.youtube.com/oembed",
+	Vimeo:   "https://vimeo.com/api/oembed.json",
+}
+
+// Client fetches oEmbed metadata
+type Client struct {
+	http *http.Client
+}
+
+func NewClient() *Client {
+	return &Client{http: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// Fetch asks the video's provider about it. Self-hosted videos have no
+// provider to ask and get an error.
+func (c *Client) Fetch(ctx context.Context, video *Video) (*Metadata, error) {
+	endpoint, ok := endpoints[video.Provider]
+	if !ok {
+		return nil, fmt.Errorf("oembed: no endpoint for %s videos", video.Provider)
+	}
+
+	query := url.Values{"url": {video.PageURL}, "format": {"json"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oembed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oembed: %s returned %s for %s", video.Provider, resp.Status, video.PageURL)
+	}
+	var metadata Metadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("oembed: invalid response from %s: %w", video.Provider, err)
+	}
+	return &metadata, nil
+}
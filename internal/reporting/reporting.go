@@ -0,0 +1,184 @@
+// Package reporting sends panics and server errors to Sentry. Events carry
+// the request they happened in, with contact form personal data scrubbed
+// before anything leaves the process.
+package reporting
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gin-gonic/gin"
+)
+
+const redacted = "[redacted]"
+
+// Query parameters whose values never reach Sentry: tokens authenticate
+// event streams, the rest identify contact form submitters
+var sensitiveParams = map[string]bool{
+	"token":   true,
+	"email":   true,
+	"name":    true,
+	"phone":   true,
+	"ip":      true,
+	"country": true,
+	"city":    true,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	ipv4Pattern  = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+)
+
+// Init sets up the Sentry client. Without a DSN reporting stays off and the
+// middleware in this package does nothing. An empty release falls back to
+// the VCS revision the binary was built from.
+func Init(dsn, environment, release string) error {
+	if dsn == "" {
+		return nil
+	}
+	if release == "" {
+		release = buildRevision()
+	}
+
+	return sentry.Init(sentry.ClientOptions{
+		Dsn:              dsn,
+		Environment:      environment,
+		Release:          release,
+		AttachStacktrace: true,
+		BeforeSend:       scrub,
+	})
+}
+
+// Flush waits for queued events to be sent, up to timeout
+func Flush(timeout time.Duration) {
+	if sentry.CurrentHub().Client() != nil {
+		sentry.Flush(timeout)
+	}
+}
+
+// Recovery is a gin.RecoveryFunc that reports the panic, which gin has
+// already logged, before answering with 500
+func Recovery(c *gin.Context, recovered any) {
+	if hub := requestHub(c); hub != nil {
+		hub.RecoverWithContext(c.Request.Context(), recovered)
+	}
+	c.AbortWithStatus(http.StatusInternalServerError)
+}
+
+// Errors reports responses with a 5xx status. The errors attached to the
+// context with c.Error describe what went wrong; without any the event
+// names the status alone.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError {
+			return
+		}
+		hub := requestHub(c)
+		if hub == nil {
+			return
+		}
+
+		hub.Scope().SetTag("status", fmt.Sprint(status))
+		if len(c.Errors) == 0 {
+			hub.CaptureMessage(fmt.Sprintf("%s %s: %s", c.Request.Method, c.FullPath(), http.StatusText(status)))
+			return
+		}
+		for _, err := range c.Errors {
+			hub.CaptureException(err.Err)
+		}
+	}
+}
+
+// requestHub returns a hub scoped to the request, or nil when reporting is
+// off
+func requestHub(c *gin.Context) *sentry.Hub {
+	if sentry.CurrentHub().Client() == nil {
+		return nil
+	}
+
+	hub := sentry.CurrentHub().Clone()
+	scope := hub.Scope()
+	scope.SetRequest(c.Request)
+	scope.SetTag("method", c.Request.Method)
+	if route := c.FullPath(); route != "" {
+		scope.SetTag("route", route)
+	}
+	if username := c.GetString("username"); username != "" {
+		scope.SetUser(sentry.User{Username: username})
+	}
+	return hub
+}
+
+// scrub removes contact form personal data from an event: the request body
+// and client addresses are dropped, sensitive query parameters redacted and
+// email and IP addresses masked in messages, exceptions and breadcrumbs
+func scrub(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+	event.User.Email = ""
+	event.User.IPAddress = ""
+
+	if req := event.Request; req != nil {
+		req.Data = ""
+		req.Cookies = ""
+		req.Env = nil
+		req.QueryString = scrubQuery(req.QueryString)
+		for _, header := range []string{"X-Forwarded-For", "X-Real-Ip", "Forwarded", "Authorization", "Cookie"} {
+			delete(req.Headers, header)
+		}
+	}
+
+	event.Message = maskText(event.Message)
+	for i := range event.Exception {
+		event.Exception[i].Value = maskText(event.Exception[i].Value)
+	}
+	for _, crumb := range event.Breadcrumbs {
+		crumb.Message = maskText(crumb.Message)
+	}
+	return event
+}
+
+func scrubQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return redacted
+	}
+	for key, vals := range values {
+		for i := range vals {
+			if sensitiveParams[strings.ToLower(key)] {
+				vals[i] = redacted
+			} else {
+				vals[i] = maskText(vals[i])
+			}
+		}
+	}
+	return values.Encode()
+}
+
+func maskText(s string) string {
+	s = emailPattern.ReplaceAllString(s, redacted)
+	return ipv4Pattern.ReplaceAllString(s, redacted)
+}
+
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AwardRepository handles award data operations
+type AwardRepository struct {
+	db *gorm.DB
+}
+
+func NewAwardRepository(db *gorm.DB) *AwardRepository {
+	return &AwardRepository{db: db}
+}
+
+func (r *AwardRepository) GetAwards(ctx context.Context) ([]models.Award, error) {
+	db := r.db.WithContext(ctx)
+	var awards []models.Award
+	err := db.Order("date DESC").Find(&awards).Error
+	if err != nil {
+		return nil, err
+	}
+	return awards, nil
+}
+
+func (r *AwardRepository) CreateAward(ctx context.Context, award *models.Award) (*models.Award, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(award).Error
+	if err != nil {
+		return nil, err
+	}
+	return award, nil
+}
+
+func (r *AwardRepository) UpdateAward(ctx context.Context, id uint, award *models.Award) (*models.Award, error) {
+	db := r.db.WithContext(ctx)
+	var existingAward models.Award
+	err := primary(db).First(&existingAward, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("award not found")
+		}
+		return nil, err
+	}
+
+	award.ID = id
+	award.CreatedAt = existingAward.CreatedAt
+	err = db.Save(award).Error
+	if err != nil {
+		return nil, err
+	}
+	return award, nil
+}
+
+func (r *AwardRepository) DeleteAward(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var award models.Award
+	err := primary(db).First(&award, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("award not found")
+		}
+		return err
+	}
+
+	err = db.Delete(&award).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CategoryRepository handles project category data operations
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) GetCategories(ctx context.Context) ([]models.Category, error) {
+	db := r.db.WithContext(ctx)
+	var categories []models.Category
+	err := db.Order("name").Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *CategoryRepository) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.ensureUniqueName(db, category.Name, 0); err != nil {
+		return nil, err
+	}
+
+	err := db.Create(category).Error
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, id uint, category *models.Category) (*models.Category, error) {
+	db := r.db.WithContext(ctx)
+	var existingCategory models.Category
+	err := primary(db).First(&existingCategory, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("category not found")
+		}
+		return nil, err
+	}
+
+	if err := r.ensureUniqueName(db, category.Name, id); err != nil {
+		return nil, err
+	}
+
+	category.ID = id
+	category.CreatedAt = existingCategory.CreatedAt
+	err = db.Save(category).Error
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// DeleteCategory removes the category and leaves its projects uncategorized
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var category models.Category
+	err := primary(db).First(&category, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("category not found")
+		}
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Project{}).
+			Where("category_id = ?", id).
+			Update("category_id", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&category).Error
+	})
+}
+
+// ensureUniqueName rejects names that only differ by case from an existing
+// category, which is how free-text categories used to drift apart
+func (r *CategoryRepository) ensureUniqueName(db *gorm.DB, name string, excludeID uint) error {
+	var count int64
+	err := primary(db).Model(&models.Category{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("category already exists")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CertificationRepository handles certification data operations
+type CertificationRepository struct {
+	db *gorm.DB
+}
+
+func NewCertificationRepository(db *gorm.DB) *CertificationRepository {
+	return &CertificationRepository{db: db}
+}
+
+func (r *CertificationRepository) GetCertifications(ctx context.Context) ([]models.Certification, error) {
+	db := r.db.WithContext(ctx)
+	var certifications []models.Certification
+	err := db.Order("issue_date DESC").Find(&certifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return certifications, nil
+}
+
+func (r *CertificationRepository) CreateCertification(ctx context.Context, certification *models.Certification) (*models.Certification, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(certification).Error
+	if err != nil {
+		return nil, err
+	}
+	return certification, nil
+}
+
+func (r *CertificationRepository) UpdateCertification(ctx context.Context, id uint, certification *models.Certification) (*models.Certification, error) {
+	db := r.db.WithContext(ctx)
+	var existingCertification models.Certification
+	err := primary(db).First(&existingCertification, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("certification not found")
+		}
+		return nil, err
+	}
+
+	certification.ID = id
+	certification.CreatedAt = existingCertification.CreatedAt
+	if sameTime(certification.ExpiryDate, existingCertification.ExpiryDate) {
+		// A renewal moves the expiry date and earns a new reminder
+		certification.ReminderSentAt = existingCertification.ReminderSentAt
+	}
+	err = db.Save(certification).Error
+	if err != nil {
+		return nil, err
+	}
+	return certification, nil
+}
+
+func (r *CertificationRepository) DeleteCertification(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var certification models.Certification
+	err := primary(db).First(&certification, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("certification not found")
+		}
+		return err
+	}
+
+	err = db.Delete(&certification).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetExpiringCertifications returns the certifications of every tenant in
+// ctx that expire after from and no later than to, soonest first
+func (r *CertificationRepository) GetExpiringCertifications(ctx context.Context, from, to time.Time) ([]models.Certification, error) {
+	db := r.db.WithContext(ctx)
+	var certifications []models.Certification
+	err := db.Where("expiry_date > ? AND expiry_date <= ?", from, to).
+		Order("expiry_date, id").
+		Find(&certifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return certifications, nil
+}
+
+// ClaimReminder records that the owner is being reminded of a
+// certification's expiry and reports whether the reminder was still due,
+// so that of several replicas only one sends it
+func (r *CertificationRepository) ClaimReminder(ctx context.Context, id uint) (bool, error) {
+	db := r.db.WithContext(ctx)
+	result := db.Model(&models.Certification{}).
+		Where("id = ? AND reminder_sent_at IS NULL", id).
+		UpdateColumn("reminder_sent_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// sameTime reports whether two optional times are both unset or equal
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CommentRepository handles comment data operations
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// GetApprovedComments returns the approved comments on a project or post,
+// oldest first
+func (r *CommentRepository) GetApprovedComments(ctx context.Context, targetType string, targetID uint) ([]models.Comment, error) {
+	db := r.db.WithContext(ctx)
+	var comments []models.Comment
+	err := db.Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, "approved").
+		Order("created_at, id").Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetComments returns the comments with the given status, newest first
+func (r *CommentRepository) GetComments(ctx context.Context, status string) ([]models.Comment, error) {
+	db := r.db.WithContext(ctx)
+	var comments []models.Comment
+	err := db.Where("status = ?", status).Order("created_at DESC, id DESC").Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *CommentRepository) CreateComment(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(comment).Error
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// SetCommentStatus moves a comment through moderation
+func (r *CommentRepository) SetCommentStatus(ctx context.Context, id uint, status string) (*models.Comment, error) {
+	db := r.db.WithContext(ctx)
+	var comment models.Comment
+	err := primary(db).First(&comment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("comment not found")
+		}
+		return nil, err
+	}
+
+	err = db.Model(&comment).Update("status", status).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *CommentRepository) DeleteComment(ctx context.Context, id uint) (*models.Comment, error) {
+	db := r.db.WithContext(ctx)
+	var comment models.Comment
+	err := primary(db).First(&comment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("comment not found")
+		}
+		return nil, err
+	}
+
+	err = db.Delete(&comment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
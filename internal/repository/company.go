@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CompanyRepository handles company data operations
+type CompanyRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyRepository(db *gorm.DB) *CompanyRepository {
+	return &CompanyRepository{db: db}
+}
+
+func (r *CompanyRepository) GetCompanies(ctx context.Context) ([]models.Company, error) {
+	db := r.db.WithContext(ctx)
+	var companies []models.Company
+	err := db.Order("name").Find(&companies).Error
+	if err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+// CompanyHistory is a company with the roles held there and the
+// testimonials from its people
+type CompanyHistory struct {
+	models.Company
+	Experiences  []models.Experience  `json:"experiences"`
+	Testimonials []models.Testimonial `json:"testimonials"`
+}
+
+// GetCompanyHistory returns a company with its experiences, most recent
+// first, and testimonials, newest first. Unpublished and scheduled
+// experiences and unapproved testimonials are left out unless
+// includeDrafts is set.
+func (r *CompanyRepository) GetCompanyHistory(ctx context.Context, id uint, includeDrafts bool) (*CompanyHistory, error) {
+	db := r.db.WithContext(ctx)
+	var history CompanyHistory
+	err := db.First(&history.Company, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("company not found")
+		}
+		return nil, err
+	}
+
+	experiences := db.Preload("Skills", orderSkills).Where("company_id = ?", id).Order("start_date DESC")
+	testimonials := db.Where("company_id = ?", id).Order("created_at DESC")
+	if !includeDrafts {
+		experiences = experiences.Where("published = ? AND (published_at IS NULL OR published_at <= ?)", true, time.Now())
+		testimonials = testimonials.Where("approved = ?", true)
+	}
+	if err := experiences.Find(&history.Experiences).Error; err != nil {
+		return nil, err
+	}
+	if err := testimonials.Find(&history.Testimonials).Error; err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+func (r *CompanyRepository) CreateCompany(ctx context.Context, company *models.Company) (*models.Company, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.ensureUniqueName(db, company.Name, 0); err != nil {
+		return nil, err
+	}
+
+	err := db.Create(company).Error
+	if err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+// UpdateCompany updates the company and carries a new name over to the
+// experiences and testimonials linked to it
+func (r *CompanyRepository) UpdateCompany(ctx context.Context, id uint, company *models.Company) (*models.Company, error) {
+	db := r.db.WithContext(ctx)
+	var existingCompany models.Company
+	err := primary(db).First(&existingCompany, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("company not found")
+		}
+		return nil, err
+	}
+
+	if err := r.ensureUniqueName(db, company.Name, id); err != nil {
+		return nil, err
+	}
+
+	company.ID = id
+	company.CreatedAt = existingCompany.CreatedAt
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(company).Error; err != nil {
+			return err
+		}
+		if company.Name == existingCompany.Name {
+			return nil
+		}
+
+		// Renaming changes the experiences, so their versions move too
+		err := tx.Model(&models.Experience{}).
+			Where("company_id = ?", id).
+			Updates(map[string]interface{}{
+				"company": company.Name,
+				"version": gorm.Expr("version + 1"),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.Testimonial{}).
+			Where("company_id = ?", id).
+			Update("company", company.Name).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return company, nil
+}
+
+// DeleteCompany removes the company and unlinks its experiences and
+// testimonials, which keep the company's name
+func (r *CompanyRepository) DeleteCompany(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var company models.Company
+	err := primary(db).First(&company, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("company not found")
+		}
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.Experience{}, &models.Testimonial{}} {
+			err := tx.Model(model).
+				Where("company_id = ?", id).
+				Update("company_id", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&company).Error
+	})
+}
+
+// ensureUniqueName rejects names that only differ by case from an existing
+// company
+func (r *CompanyRepository) ensureUniqueName(db *gorm.DB, name string, excludeID uint) error {
+	var count int64
+	err := primary(db).Model(&models.Company{}).
+		Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("company already exists")
+	}
+	return nil
+}
+
+// companyName returns the name of the linked company, reporting an unknown
+// one, or name when no company is linked
+func companyName(db *gorm.DB, companyID *uint, name string) (string, error) {
+	if companyID == nil {
+		return name, nil
+	}
+
+	var company models.Company
+	err := primary(db).Select("name").First(&company, *companyID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("company not found")
+		}
+		return "", err
+	}
+	return company.Name, nil
+}
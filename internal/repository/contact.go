@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// filter narrows contacts to the given status, hiding spam when it is empty
+func (r *ContactRepository) filter(db *gorm.DB, status string) *gorm.DB {
+	if status == "" {
+		return db.Where("status <> ?", "spam")
+	}
+	return db.Where("status = ?", status)
+}
+
+// EachContactBatch passes contacts matching the status filter to fn in
+// batches, oldest first, without loading them all at once
+func (r *ContactRepository) EachContactBatch(ctx context.Context, status string, size int, fn func([]models.Contact) error) error {
+	db := r.db.WithContext(ctx)
+	var batch []models.Contact
+	return r.filter(db, status).FindInBatches(&batch, size, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
+
+// createGroupedContact stores a contact in the thread of the earlier
+// submissions from its email address, compared ignoring case, flagging it
+// as a repeat. A first submission starts a thread named by its own ID.
+func createGroupedContact(tx *gorm.DB, contact *models.Contact) error {
+	var first models.Contact
+	err := tx.Select("id, contact_group").Where("LOWER(email) = LOWER(?)", contact.Email).Order("id").Take(&first).Error
+	switch {
+	case err == nil:
+		contact.ContactGroup = first.ContactGroup
+		contact.Repeat = true
+		return tx.Create(contact).Error
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+
+	if err := tx.Create(contact).Error; err != nil {
+		return err
+	}
+	contact.ContactGroup = contact.ID
+	return tx.Model(contact).UpdateColumn("contact_group", contact.ID).Error
+}
+
+func (r *ContactRepository) GetContact(ctx context.Context, id uint) (*models.Contact, error) {
+	db := r.db.WithContext(ctx)
+	var contact models.Contact
+	err := db.First(&contact, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("contact not found")
+		}
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// GetMessages returns the replies sent to a contact, oldest first
+func (r *ContactRepository) GetMessages(ctx context.Context, contactID uint) ([]models.ContactMessage, error) {
+	db := r.db.WithContext(ctx)
+	var messages []models.ContactMessage
+	err := db.Where("contact_id = ?", contactID).Order("created_at, id").Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// GetNotes returns the internal notes on a contact, oldest first
+func (r *ContactRepository) GetNotes(ctx context.Context, contactID uint) ([]models.ContactNote, error) {
+	db := r.db.WithContext(ctx)
+	var notes []models.ContactNote
+	err := db.Where("contact_id = ?", contactID).Order("created_at, id").Find(&notes).Error
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+func (r *ContactRepository) CreateNote(ctx context.Context, note *models.ContactNote) (*models.ContactNote, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(note).Error
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// AddReply stores a sent reply and marks the contact as replied
+func (r *ContactRepository) AddReply(ctx context.Context, message *models.ContactMessage) (*models.ContactMessage, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Contact{}).Where("id = ?", message.ContactID).Update("status", "replied").Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
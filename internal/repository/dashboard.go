@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContentStats summarizes one kind of content
+type ContentStats struct {
+	Count       int64
+	LastUpdated *time.Time
+}
+
+// DashboardRepository reads aggregates for the admin dashboard
+type DashboardRepository struct {
+	db *gorm.DB
+}
+
+func NewDashboardRepository(db *gorm.DB) *DashboardRepository {
+	return &DashboardRepository{db: db}
+}
+
+// dashboardContent maps the names reported on the dashboard to their models
+var dashboardContent = map[string]interface{}{
+	"profile":        &models.Profile{},
+	"experiences":    &models.Experience{},
+	"skills":         &models.Skill{},
+	"projects":       &models.Project{},
+	"categories":     &models.Category{},
+	"tags":           &models.Tag{},
+	"posts":          &models.Post{},
+	"testimonials":   &models.Testimonial{},
+	"education":      &models.Education{},
+	"certifications": &models.Certification{},
+	"publications":   &models.Publication{},
+	"awards":         &models.Award{},
+	"services":       &models.Service{},
+	"talks":          &models.Talk{},
+	"media":          &models.Media{},
+}
+
+// GetContentStats returns the row count and latest change of every kind of
+// portfolio content
+func (r *DashboardRepository) GetContentStats(ctx context.Context) (map[string]ContentStats, error) {
+	db := r.db.WithContext(ctx)
+	stats := make(map[string]ContentStats, len(dashboardContent))
+	for name, model := range dashboardContent {
+		var row ContentStats
+		err := db.Model(model).
+			Select("COUNT(*) AS count, MAX(updated_at) AS last_updated").
+			Scan(&row).Error
+		if err != nil {
+			return nil, err
+		}
+		stats[name] = row
+	}
+	return stats, nil
+}
+
+// CountContacts returns the number of contact submissions that are not spam
+// in total and with the given status
+func (r *DashboardRepository) CountContacts(ctx context.Context, status string) (total int64, withStatus int64, err error) {
+	db := r.db.WithContext(ctx)
+	if err = db.Model(&models.Contact{}).Where("status <> ?", "spam").Count(&total).Error; err != nil {
+		return 0, 0, err
+	}
+	err = db.Model(&models.Contact{}).Where("status = ?", status).Count(&withStatus).Error
+	return total, withStatus, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// EducationRepository handles education data operations
+type EducationRepository struct {
+	db *gorm.DB
+}
+
+func NewEducationRepository(db *gorm.DB) *EducationRepository {
+	return &EducationRepository{db: db}
+}
+
+func (r *EducationRepository) GetEducation(ctx context.Context) ([]models.Education, error) {
+	db := r.db.WithContext(ctx)
+	var education []models.Education
+	err := db.Order("position, start_date DESC").Find(&education).Error
+	if err != nil {
+		return nil, err
+	}
+	return education, nil
+}
+
+func (r *EducationRepository) CreateEducation(ctx context.Context, education *models.Education) (*models.Education, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(education).Error
+	if err != nil {
+		return nil, err
+	}
+	return education, nil
+}
+
+func (r *EducationRepository) UpdateEducation(ctx context.Context, id uint, education *models.Education) (*models.Education, error) {
+	db := r.db.WithContext(ctx)
+	var existingEducation models.Education
+	err := primary(db).First(&existingEducation, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("education not found")
+		}
+		return nil, err
+	}
+
+	education.ID = id
+	education.CreatedAt = existingEducation.CreatedAt
+	err = db.Save(education).Error
+	if err != nil {
+		return nil, err
+	}
+	return education, nil
+}
+
+func (r *EducationRepository) DeleteEducation(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var education models.Education
+	err := primary(db).First(&education, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("education not found")
+		}
+		return err
+	}
+
+	err = db.Delete(&education).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
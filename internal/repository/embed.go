@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// EmbedTokenRepository handles embed token data operations
+type EmbedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewEmbedTokenRepository(db *gorm.DB) *EmbedTokenRepository {
+	return &EmbedTokenRepository{db: db}
+}
+
+func (r *EmbedTokenRepository) GetTokens(ctx context.Context) ([]models.EmbedToken, error) {
+	db := r.db.WithContext(ctx)
+	var tokens []models.EmbedToken
+	err := db.Order("created_at DESC").Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *EmbedTokenRepository) GetByToken(ctx context.Context, token string) (*models.EmbedToken, error) {
+	db := r.db.WithContext(ctx)
+	var embedToken models.EmbedToken
+	err := db.Where("token = ?", token).First(&embedToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("embed token not found")
+		}
+		return nil, err
+	}
+	return &embedToken, nil
+}
+
+func (r *EmbedTokenRepository) CreateToken(ctx context.Context, token *models.EmbedToken) (*models.EmbedToken, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(token).Error
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *EmbedTokenRepository) DeleteToken(ctx context.Context, id uint) (*models.EmbedToken, error) {
+	db := r.db.WithContext(ctx)
+	var token models.EmbedToken
+	err := primary(db).First(&token, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("embed token not found")
+		}
+		return nil, err
+	}
+
+	err = db.Delete(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
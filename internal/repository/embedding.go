@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sort"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SearchPosts is the type of blog posts, which only semantic search covers
+const SearchPosts = "post"
+
+// SemanticSearchTypes lists every kind of content semantic search covers
+var SemanticSearchTypes = []string{SearchProjects, SearchExperiences, SearchPosts}
+
+// EmbeddingSource is published content and the text its embedding is
+// computed from
+type EmbeddingSource struct {
+	TenantID    uint
+	Type        string
+	ID          uint
+	Title       string
+	Subtitle    string
+	PublishedAt *time.Time
+	Text        string
+}
+
+// EmbeddingRepository stores content embeddings and ranks them by
+// similarity to a query
+type EmbeddingRepository struct {
+	db *gorm.DB
+
+	pgvectorOnce sync.Once
+	pgvector     bool
+}
+
+func NewEmbeddingRepository(db *gorm.DB) *EmbeddingRepository {
+	return &EmbeddingRepository{db: db}
+}
+
+// GetEmbeddingSources returns the published, unarchived items of a type,
+// including those scheduled for later, or only the one with the given ID
+// when id is not 0. Pass a tenant.All context to cover every tenant.
+func (r *EmbeddingRepository) GetEmbeddingSources(ctx context.Context, kind string, id uint) ([]EmbeddingSource, error) {
+	db := r.db.WithContext(ctx).Order("id")
+	if id != 0 {
+		db = db.Where("id = ?", id)
+	}
+
+	var sources []EmbeddingSource
+	switch kind {
+	case SearchProjects:
+		var projects []models.Project
+		if err := db.Where("published = ? AND archived = ?", true, false).Find(&projects).Error; err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			sources = append(sources, EmbeddingSource{
+				TenantID:    project.TenantID,
+				Type:        kind,
+				ID:          project.ID,
+				Title:       project.Name,
+				Subtitle:    project.Description,
+				PublishedAt: project.PublishedAt,
+				Text:        joinText(project.Name, project.Description, project.LongDescription, strings.Join(project.Technologies, ", ")),
+			})
+		}
+	case SearchExperiences:
+		var experiences []models.Experience
+		if err := db.Where("published = ?", true).Find(&experiences).Error; err != nil {
+			return nil, err
+		}
+		for _, experience := range experiences {
+			sources = append(sources, EmbeddingSource{
+				TenantID:    experience.TenantID,
+				Type:        kind,
+				ID:          experience.ID,
+				Title:       experience.Company,
+				Subtitle:    experience.Position,
+				PublishedAt: experience.PublishedAt,
+				Text: joinText(experience.Position+" at "+experience.Company, experience.Description,
+					strings.Join(experience.Achievements, "\n"), strings.Join(experience.Technologies, ", ")),
+			})
+		}
+	case SearchPosts:
+		var posts []models.Post
+		if err := db.Where("published = ?", true).Find(&posts).Error; err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			sources = append(sources, EmbeddingSource{
+				TenantID:    post.TenantID,
+				Type:        kind,
+				ID:          post.ID,
+				Title:       post.Title,
+				Subtitle:    post.Summary,
+				PublishedAt: post.PublishedAt,
+				Text:        joinText(post.Title, post.Summary, post.Body),
+			})
+		}
+	}
+	return sources, nil
+}
+
+// joinText puts the non-empty parts of an item's text on separate lines
+func joinText(parts ...string) string {
+	kept := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// GetEmbeddings returns the stored embeddings of a type without their
+// vectors, or only the one of the item with the given ID when id is not 0.
+// Pass a tenant.All context to cover every tenant.
+func (r *EmbeddingRepository) GetEmbeddings(ctx context.Context, kind string, id uint) ([]models.Embedding, error) {
+	db := r.db.WithContext(ctx)
+	query := db.Omit("vector").Where("type = ?", kind)
+	if id != 0 {
+		query = query.Where("item_id = ?", id)
+	}
+
+	var embeddings []models.Embedding
+	if err := query.Find(&embeddings).Error; err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
+
+// SaveEmbedding stores an item's embedding, replacing the previous one
+func (r *EmbeddingRepository) SaveEmbedding(ctx context.Context, embedding *models.Embedding) error {
+	db := r.db.WithContext(ctx)
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("tenant_id = ? AND type = ? AND item_id = ?", embedding.TenantID, embedding.Type, embedding.ItemID).
+			Delete(&models.Embedding{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(embedding).Error
+	})
+}
+
+// DeleteEmbedding removes the embedding of an item of the tenant
+func (r *EmbeddingRepository) DeleteEmbedding(ctx context.Context, tenantID uint, kind string, id uint) error {
+	db := r.db.WithContext(ctx)
+	return db.Where("tenant_id = ? AND type = ? AND item_id = ?", tenantID, kind, id).
+		Delete(&models.Embedding{}).Error
+}
+
+// SearchEmbeddings returns up to limit items of the given types whose
+// embeddings from model are closest to vector, ranked by cosine similarity.
+// PostgreSQL with pgvector compares them in the database; elsewhere the
+// vectors are loaded and compared in Go.
+func (r *EmbeddingRepository) SearchEmbeddings(ctx context.Context, vector models.Vector, model string, types []string, limit int) ([]SearchMatch, error) {
+	db := r.db.WithContext(ctx)
+	query := db.Model(&models.Embedding{}).
+		Where("model = ? AND type IN ?", model, types).
+		Where("(published_at IS NULL OR published_at <= ?)", time.Now())
+
+	matches := []SearchMatch{}
+	if r.hasPgvector(db) {
+		literal, _ := vector.Value()
+		err := query.
+			Select("type, item_id AS id, title, subtitle, 1 - (vector::vector <=> ?::vector) AS score", literal).
+			Order("score DESC").
+			Limit(limit).
+			Scan(&matches).Error
+		return matches, err
+	}
+
+	var embeddings []models.Embedding
+	if err := query.Select("type, item_id, title, subtitle, vector").Find(&embeddings).Error; err != nil {
+		return nil, err
+	}
+	for _, embedding := range embeddings {
+		matches = append(matches, SearchMatch{
+			Type:     embedding.Type,
+			ID:       embedding.ItemID,
+			Title:    embedding.Title,
+			Subtitle: embedding.Subtitle,
+			Score:    cosineSimilarity(vector, embedding.Vector),
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// hasPgvector reports whether the database is PostgreSQL with the vector
+// extension, which the migrations install when the server offers it
+func (r *EmbeddingRepository) hasPgvector(db *gorm.DB) bool {
+	r.pgvectorOnce.Do(func() {
+		if db.Dialector.Name() != "postgres" {
+			return
+		}
+		err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'vector')").Scan(&r.pgvector).Error
+		if err != nil {
+			r.pgvector = false
+		}
+	})
+	return r.pgvector
+}
+
+// cosineSimilarity is 1 for vectors pointing the same way and 0 for
+// unrelated ones; vectors of different lengths, as from another model,
+// are not similar
+func cosineSimilarity(a, b models.Vector) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// IPRuleRepository handles IP allow and deny rule data operations
+type IPRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewIPRuleRepository(db *gorm.DB) *IPRuleRepository {
+	return &IPRuleRepository{db: db}
+}
+
+func (r *IPRuleRepository) GetRules(ctx context.Context) ([]models.IPRule, error) {
+	db := r.db.WithContext(ctx)
+	var rules []models.IPRule
+	err := db.Order("id").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *IPRuleRepository) CreateRule(ctx context.Context, rule *models.IPRule) (*models.IPRule, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *IPRuleRepository) UpdateRule(ctx context.Context, id uint, rule *models.IPRule) (*models.IPRule, error) {
+	db := r.db.WithContext(ctx)
+	var existingRule models.IPRule
+	err := primary(db).First(&existingRule, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("ip rule not found")
+		}
+		return nil, err
+	}
+
+	rule.ID = id
+	rule.CreatedAt = existingRule.CreatedAt
+	err = db.Save(rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *IPRuleRepository) DeleteRule(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var rule models.IPRule
+	err := primary(db).First(&rule, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("ip rule not found")
+		}
+		return err
+	}
+
+	return db.Delete(&rule).Error
+}
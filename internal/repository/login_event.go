@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// LoginEventRepository handles login history data operations
+type LoginEventRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginEventRepository(db *gorm.DB) *LoginEventRepository {
+	return &LoginEventRepository{db: db}
+}
+
+// GetLoginEvents returns the most recent login attempts, optionally only
+// those of one username or with one outcome
+func (r *LoginEventRepository) GetLoginEvents(ctx context.Context, username string, success *bool, limit int) ([]models.LoginEvent, error) {
+	db := r.db.WithContext(ctx)
+	query := db.Order("created_at DESC, id DESC").Limit(limit)
+	if username != "" {
+		query = query.Where("username = ?", username)
+	}
+	if success != nil {
+		query = query.Where("success = ?", *success)
+	}
+
+	var events []models.LoginEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// KnownLocations reports whether the user logged in successfully before at
+// all, from ip and from country. An empty country is never known.
+func (r *LoginEventRepository) KnownLocations(ctx context.Context, username, ip, country string) (hasHistory, knownIP, knownCountry bool, err error) {
+	db := primary(r.db.WithContext(ctx))
+	successful := func() *gorm.DB {
+		return db.Model(&models.LoginEvent{}).Where("username = ? AND success = ?", username, true)
+	}
+
+	var count int64
+	if err := successful().Count(&count).Error; err != nil {
+		return false, false, false, err
+	}
+	if count == 0 {
+		return false, false, false, nil
+	}
+
+	if err := successful().Where("ip_address = ?", ip).Count(&count).Error; err != nil {
+		return false, false, false, err
+	}
+	knownIP = count > 0
+
+	if country != "" {
+		if err := successful().Where("country = ?", country).Count(&count).Error; err != nil {
+			return false, false, false, err
+		}
+		knownCountry = count > 0
+	}
+	return true, knownIP, knownCountry, nil
+}
+
+func (r *LoginEventRepository) CreateLoginEvent(ctx context.Context, event *models.LoginEvent) error {
+	db := r.db.WithContext(ctx)
+	return db.Create(event).Error
+}
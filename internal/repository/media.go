@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MediaRepository handles uploaded media data operations
+type MediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+func (r *MediaRepository) GetMedia(ctx context.Context) ([]models.Media, error) {
+	db := r.db.WithContext(ctx)
+	var media []models.Media
+	err := db.Preload("Variants").Order("created_at DESC").Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *MediaRepository) GetMediaByID(ctx context.Context, id uint) (*models.Media, error) {
+	db := r.db.WithContext(ctx)
+	var media models.Media
+	err := db.Preload("Variants").First(&media, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("media not found")
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *MediaRepository) GetMediaByStoredName(ctx context.Context, storedName string) (*models.Media, error) {
+	db := r.db.WithContext(ctx)
+	var media models.Media
+	err := db.Where("stored_name = ?", storedName).First(&media).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("media not found")
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *MediaRepository) GetVariantByStoredName(ctx context.Context, storedName string) (*models.MediaVariant, error) {
+	db := r.db.WithContext(ctx)
+	var variant models.MediaVariant
+	err := db.Where("stored_name = ?", storedName).First(&variant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("media not found")
+		}
+		return nil, err
+	}
+	return &variant, nil
+}
+
+func (r *MediaRepository) CreateMedia(ctx context.Context, media *models.Media) (*models.Media, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *MediaRepository) CreateVariant(ctx context.Context, variant *models.MediaVariant) (*models.MediaVariant, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(variant).Error
+	if err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+// DeleteMedia removes the media and its variants, returning them so the
+// stored files can be cleaned up
+func (r *MediaRepository) DeleteMedia(ctx context.Context, id uint) (*models.Media, error) {
+	db := r.db.WithContext(ctx)
+	var media models.Media
+	err := primary(db).Preload("Variants").First(&media, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("media not found")
+		}
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("media_id = ?", id).Delete(&models.MediaVariant{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&media).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
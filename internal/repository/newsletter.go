@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NewsletterRepository handles newsletter subscriber and campaign data
+// operations
+type NewsletterRepository struct {
+	db *gorm.DB
+}
+
+func NewNewsletterRepository(db *gorm.DB) *NewsletterRepository {
+	return &NewsletterRepository{db: db}
+}
+
+// GetSubscribers returns every subscriber, newest first
+func (r *NewsletterRepository) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	db := r.db.WithContext(ctx)
+	var subscribers []models.Subscriber
+	err := db.Order("created_at DESC").Find(&subscribers).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscribers, nil
+}
+
+func (r *NewsletterRepository) GetSubscriber(ctx context.Context, id uint) (*models.Subscriber, error) {
+	return r.findSubscriber(ctx, "id = ?", id)
+}
+
+func (r *NewsletterRepository) GetSubscriberByEmail(ctx context.Context, email string) (*models.Subscriber, error) {
+	return r.findSubscriber(ctx, "email = ?", email)
+}
+
+func (r *NewsletterRepository) GetSubscriberByToken(ctx context.Context, token string) (*models.Subscriber, error) {
+	return r.findSubscriber(ctx, "token = ?", token)
+}
+
+func (r *NewsletterRepository) findSubscriber(ctx context.Context, query string, arg interface{}) (*models.Subscriber, error) {
+	db := r.db.WithContext(ctx)
+	var subscriber models.Subscriber
+	err := primary(db).Where(query, arg).First(&subscriber).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("subscriber not found")
+		}
+		return nil, err
+	}
+	return &subscriber, nil
+}
+
+// SaveSubscriber creates or updates a subscriber
+func (r *NewsletterRepository) SaveSubscriber(ctx context.Context, subscriber *models.Subscriber) error {
+	db := r.db.WithContext(ctx)
+	return db.Save(subscriber).Error
+}
+
+// GetCampaigns returns every campaign with its delivery counts, newest first
+func (r *NewsletterRepository) GetCampaigns(ctx context.Context) ([]models.Campaign, error) {
+	db := r.db.WithContext(ctx)
+	var campaigns []models.Campaign
+	err := db.Order("created_at DESC").Find(&campaigns).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadStats(db, campaigns); err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
+
+func (r *NewsletterRepository) GetCampaign(ctx context.Context, id uint) (*models.Campaign, error) {
+	db := r.db.WithContext(ctx)
+	var campaign models.Campaign
+	err := primary(db).First(&campaign, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("campaign not found")
+		}
+		return nil, err
+	}
+
+	campaigns := []models.Campaign{campaign}
+	if err := r.loadStats(primary(db), campaigns); err != nil {
+		return nil, err
+	}
+	return &campaigns[0], nil
+}
+
+// loadStats counts the deliveries of each campaign by status
+func (r *NewsletterRepository) loadStats(db *gorm.DB, campaigns []models.Campaign) error {
+	if len(campaigns) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(campaigns))
+	byID := make(map[uint]*models.Campaign, len(campaigns))
+	for i := range campaigns {
+		ids[i] = campaigns[i].ID
+		campaigns[i].Stats = map[string]int64{}
+		byID[campaigns[i].ID] = &campaigns[i]
+	}
+
+	var rows []struct {
+		CampaignID uint
+		Status     string
+		Count      int64
+	}
+	err := db.Model(&models.CampaignDelivery{}).
+		Select("campaign_id, status, COUNT(*) AS count").
+		Where("campaign_id IN ?", ids).
+		Group("campaign_id, status").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		byID[row.CampaignID].Stats[row.Status] = row.Count
+	}
+	return nil
+}
+
+func (r *NewsletterRepository) CreateCampaign(ctx context.Context, campaign *models.Campaign) (*models.Campaign, error) {
+	db := r.db.WithContext(ctx)
+	campaign.Status = "draft"
+	err := db.Create(campaign).Error
+	if err != nil {
+		return nil, err
+	}
+	campaign.Stats = map[string]int64{}
+	return campaign, nil
+}
+
+// UpdateCampaign changes the subject and body of a draft
+func (r *NewsletterRepository) UpdateCampaign(ctx context.Context, id uint, subject, body string) (*models.Campaign, error) {
+	db := r.db.WithContext(ctx)
+	campaign, err := r.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != "draft" {
+		return nil, errors.New("campaign already sent")
+	}
+
+	campaign.Subject = subject
+	campaign.Body = body
+	err = db.Model(campaign).Updates(map[string]interface{}{"subject": subject, "body": body}).Error
+	if err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// DeleteCampaign deletes a draft
+func (r *NewsletterRepository) DeleteCampaign(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	campaign, err := r.GetCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+	if campaign.Status != "draft" {
+		return errors.New("campaign already sent")
+	}
+	return db.Delete(campaign).Error
+}
+
+// QueueCampaign moves a draft to sending and creates a pending delivery for
+// every confirmed subscriber. Call it inside a unit of work so the campaign
+// and its deliveries are written together.
+func (r *NewsletterRepository) QueueCampaign(ctx context.Context, id uint) (*models.Campaign, error) {
+	db := r.db.WithContext(ctx)
+	now := time.Now()
+	result := db.Model(&models.Campaign{}).
+		Where("id = ? AND status = ?", id, "draft").
+		Updates(map[string]interface{}{"status": "sending", "queued_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetCampaign(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("campaign already sent")
+	}
+
+	var subscribers []models.Subscriber
+	err := primary(db).Where("status = ?", "subscribed").Order("id").Find(&subscribers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]models.CampaignDelivery, len(subscribers))
+	for i, subscriber := range subscribers {
+		deliveries[i] = models.CampaignDelivery{
+			CampaignID:   id,
+			SubscriberID: subscriber.ID,
+			Email:        subscriber.Email,
+			Status:       "pending",
+		}
+	}
+	if len(deliveries) > 0 {
+		if err := db.CreateInBatches(deliveries, 500).Error; err != nil {
+			return nil, err
+		}
+	}
+	return r.GetCampaign(ctx, id)
+}
+
+// MarkCampaignSent completes a campaign once no delivery is pending
+func (r *NewsletterRepository) MarkCampaignSent(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	return db.Model(&models.Campaign{}).
+		Where("id = ? AND status = ?", id, "sending").
+		Updates(map[string]interface{}{"status": "sent", "sent_at": time.Now()}).Error
+}
+
+// GetPendingDeliveries returns up to limit deliveries of a campaign still
+// to be sent, oldest first
+func (r *NewsletterRepository) GetPendingDeliveries(ctx context.Context, campaignID uint, limit int) ([]models.CampaignDelivery, error) {
+	db := r.db.WithContext(ctx)
+	var deliveries []models.CampaignDelivery
+	err := primary(db).Where("campaign_id = ? AND status = ?", campaignID, "pending").
+		Order("id").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetDeliveries returns every delivery of a campaign
+func (r *NewsletterRepository) GetDeliveries(ctx context.Context, campaignID uint) ([]models.CampaignDelivery, error) {
+	db := r.db.WithContext(ctx)
+	var deliveries []models.CampaignDelivery
+	err := db.Where("campaign_id = ?", campaignID).Order("id").Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *NewsletterRepository) SaveDelivery(ctx context.Context, delivery *models.CampaignDelivery) error {
+	db := r.db.WithContext(ctx)
+	return db.Save(delivery).Error
+}
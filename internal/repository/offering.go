@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ServiceRepository handles service data operations
+type ServiceRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceRepository(db *gorm.DB) *ServiceRepository {
+	return &ServiceRepository{db: db}
+}
+
+func (r *ServiceRepository) GetServices(ctx context.Context) ([]models.Service, error) {
+	db := r.db.WithContext(ctx)
+	var offerings []models.Service
+	err := db.Order("name").Find(&offerings).Error
+	if err != nil {
+		return nil, err
+	}
+	return offerings, nil
+}
+
+func (r *ServiceRepository) CreateService(ctx context.Context, offering *models.Service) (*models.Service, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(offering).Error
+	if err != nil {
+		return nil, err
+	}
+	return offering, nil
+}
+
+func (r *ServiceRepository) UpdateService(ctx context.Context, id uint, offering *models.Service) (*models.Service, error) {
+	db := r.db.WithContext(ctx)
+	var existingService models.Service
+	err := primary(db).First(&existingService, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("service not found")
+		}
+		return nil, err
+	}
+
+	offering.ID = id
+	offering.CreatedAt = existingService.CreatedAt
+	err = db.Save(offering).Error
+	if err != nil {
+		return nil, err
+	}
+	return offering, nil
+}
+
+func (r *ServiceRepository) DeleteService(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var offering models.Service
+	err := primary(db).First(&offering, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("service not found")
+		}
+		return err
+	}
+
+	err = db.Delete(&offering).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
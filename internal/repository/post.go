@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostRepository handles blog post data operations
+type PostRepository struct {
+	db *gorm.DB
+}
+
+func NewPostRepository(db *gorm.DB) *PostRepository {
+	return &PostRepository{db: db}
+}
+
+// GetPosts returns posts ordered by publication date. When publishedOnly is
+// set, unpublished posts and posts scheduled for the future are excluded.
+func (r *PostRepository) GetPosts(ctx context.Context, publishedOnly bool) ([]models.Post, error) {
+	db := r.db.WithContext(ctx)
+	var posts []models.Post
+	query := db.Preload("Tags").Order("published_at DESC NULLS LAST, created_at DESC")
+
+	if publishedOnly {
+		query = query.Where("published = ? AND published_at <= ?", true, time.Now())
+	}
+
+	err := query.Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (r *PostRepository) GetPostBySlug(ctx context.Context, slug string, publishedOnly bool) (*models.Post, error) {
+	db := r.db.WithContext(ctx)
+	var post models.Post
+	query := db.Preload("Tags").Where("slug = ?", slug)
+
+	if publishedOnly {
+		query = query.Where("published = ? AND published_at <= ?", true, time.Now())
+	}
+
+	err := query.First(&post).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (r *PostRepository) CreatePost(ctx context.Context, post *models.Post) (*models.Post, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.ensureUniqueSlug(db, post.Slug, 0); err != nil {
+		return nil, err
+	}
+
+	err := saveWithTags(db, post, post.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return post, primary(db).Preload("Tags").First(post, post.ID).Error
+}
+
+// UpdatePost saves the post and returns it together with its previous slug
+// so that callers can invalidate caches keyed by the old slug
+func (r *PostRepository) UpdatePost(ctx context.Context, id uint, post *models.Post) (*models.Post, string, error) {
+	db := r.db.WithContext(ctx)
+	var existingPost models.Post
+	err := primary(db).First(&existingPost, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errors.New("post not found")
+		}
+		return nil, "", err
+	}
+
+	if err := r.ensureUniqueSlug(db, post.Slug, id); err != nil {
+		return nil, "", err
+	}
+
+	post.ID = id
+	post.CreatedAt = existingPost.CreatedAt
+	post.CreatedBy = existingPost.CreatedBy
+	post.PublishedAt = keepPublicationDate(post.Published, post.PublishedAt, existingPost.PublishedAt)
+	err = saveWithTags(db, post, post.Tags)
+	if err != nil {
+		return nil, "", err
+	}
+	return post, existingPost.Slug, primary(db).Preload("Tags").First(post, id).Error
+}
+
+func (r *PostRepository) DeletePost(ctx context.Context, id uint) (*models.Post, error) {
+	db := r.db.WithContext(ctx)
+	var post models.Post
+	err := primary(db).First(&post, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+
+	err = db.Select("Tags").Delete(&post).Error
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (r *PostRepository) ensureUniqueSlug(db *gorm.DB, slug string, excludeID uint) error {
+	var count int64
+	err := primary(db).Model(&models.Post{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("slug already exists")
+	}
+	return nil
+}
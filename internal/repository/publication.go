@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PublicationRepository handles publication data operations
+type PublicationRepository struct {
+	db *gorm.DB
+}
+
+func NewPublicationRepository(db *gorm.DB) *PublicationRepository {
+	return &PublicationRepository{db: db}
+}
+
+func (r *PublicationRepository) GetPublications(ctx context.Context) ([]models.Publication, error) {
+	db := r.db.WithContext(ctx)
+	var publications []models.Publication
+	err := db.Order("date DESC").Find(&publications).Error
+	if err != nil {
+		return nil, err
+	}
+	return publications, nil
+}
+
+func (r *PublicationRepository) CreatePublication(ctx context.Context, publication *models.Publication) (*models.Publication, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(publication).Error
+	if err != nil {
+		return nil, err
+	}
+	return publication, nil
+}
+
+func (r *PublicationRepository) UpdatePublication(ctx context.Context, id uint, publication *models.Publication) (*models.Publication, error) {
+	db := r.db.WithContext(ctx)
+	var existingPublication models.Publication
+	err := primary(db).First(&existingPublication, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("publication not found")
+		}
+		return nil, err
+	}
+
+	publication.ID = id
+	publication.CreatedAt = existingPublication.CreatedAt
+	err = db.Save(publication).Error
+	if err != nil {
+		return nil, err
+	}
+	return publication, nil
+}
+
+func (r *PublicationRepository) DeletePublication(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var publication models.Publication
+	err := primary(db).First(&publication, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("publication not found")
+		}
+		return err
+	}
+
+	err = db.Delete(&publication).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,12 +1,23 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"stackwhiz-portfolio-backend/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
+// primary pins a query to the primary database. Reads are otherwise served
+// by the read replica when one is configured, which may lag behind, so reads
+// that guard a write or reload a row just written go through primary.
+func primary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write)
+}
+
 // ProfileRepository handles profile data operations
 type ProfileRepository struct {
 	db *gorm.DB
@@ -16,24 +27,80 @@ func NewProfileRepository(db *gorm.DB) *ProfileRepository {
 	return &ProfileRepository{db: db}
 }
 
-func (r *ProfileRepository) GetProfile() (*models.Profile, error) {
+func (r *ProfileRepository) GetProfile(ctx context.Context) (*models.Profile, error) {
+	db := r.db.WithContext(ctx)
 	var profile models.Profile
-	err := r.db.First(&profile).Error
+	err := db.First(&profile).Error
 	if err != nil {
 		return nil, err
 	}
 	return &profile, nil
 }
 
-func (r *ProfileRepository) UpdateProfile(profile *models.Profile) (*models.Profile, error) {
-	// Update or create profile
-	err := r.db.Save(profile).Error
+func (r *ProfileRepository) UpdateProfile(ctx context.Context, profile *models.Profile) (*models.Profile, error) {
+	db := r.db.WithContext(ctx)
+	// Update the existing profile, or create it if there is none yet
+	var existingProfile models.Profile
+	err := primary(db).First(&existingProfile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		profile.Version = 1
+		if err := db.Save(profile).Error; err != nil {
+			return nil, err
+		}
+		return profile, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profile.ID = existingProfile.ID
+	profile.CreatedAt = existingProfile.CreatedAt
+	// The variants belong to an uploaded avatar and go with it
+	if profile.Avatar == existingProfile.Avatar {
+		profile.AvatarVariants = existingProfile.AvatarVariants
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		version, err := nextVersion(tx, &models.Profile{}, profile.ID, profile.Version, existingProfile.Version)
+		if err != nil {
+			return err
+		}
+		profile.Version = version
+		return tx.Save(profile).Error
+	})
 	if err != nil {
 		return nil, err
 	}
 	return profile, nil
 }
 
+// UpdateAvatar points the profile at a new avatar, leaving the rest of it
+// untouched
+func (r *ProfileRepository) UpdateAvatar(ctx context.Context, avatar string, variants map[string]string) (*models.Profile, error) {
+	db := r.db.WithContext(ctx)
+	var profile models.Profile
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := primary(tx).First(&profile).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("profile not found")
+			}
+			return err
+		}
+
+		version, err := nextVersion(tx, &models.Profile{}, profile.ID, 0, profile.Version)
+		if err != nil {
+			return err
+		}
+		profile.Avatar = avatar
+		profile.AvatarVariants = variants
+		profile.Version = version
+		return tx.Model(&profile).Select("avatar", "avatar_variants", "updated_at").Updates(&profile).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
 // ExperienceRepository handles experience data operations
 type ExperienceRepository struct {
 	db *gorm.DB
@@ -43,26 +110,39 @@ func NewExperienceRepository(db *gorm.DB) *ExperienceRepository {
 	return &ExperienceRepository{db: db}
 }
 
-func (r *ExperienceRepository) GetExperiences() ([]models.Experience, error) {
+// GetExperiences returns experiences, excluding unpublished and scheduled ones
+// unless includeDrafts is set. Rows from before publishing existed have no
+// published_at and count as published. Pinned entries come first, then the
+// curated sort order; entries that were never reordered lead by start date.
+func (r *ExperienceRepository) GetExperiences(ctx context.Context, includeDrafts bool) ([]models.Experience, error) {
+	db := r.db.WithContext(ctx)
 	var experiences []models.Experience
-	err := r.db.Order("start_date DESC").Find(&experiences).Error
+	query := db.Preload("Skills", orderSkills).Preload("CompanyDetails").Order("pinned DESC, sort_order, start_date DESC")
+
+	if !includeDrafts {
+		query = query.Where("published = ? AND (published_at IS NULL OR published_at <= ?)", true, time.Now())
+	}
+
+	err := query.Find(&experiences).Error
 	if err != nil {
 		return nil, err
 	}
 	return experiences, nil
 }
 
-func (r *ExperienceRepository) CreateExperience(experience *models.Experience) (*models.Experience, error) {
-	err := r.db.Create(experience).Error
+func (r *ExperienceRepository) CreateExperience(ctx context.Context, experience *models.Experience) (*models.Experience, error) {
+	db := r.db.WithContext(ctx)
+	err := r.save(db, experience)
 	if err != nil {
 		return nil, err
 	}
-	return experience, nil
+	return experience, primary(db).Preload("Skills", orderSkills).Preload("CompanyDetails").First(experience, experience.ID).Error
 }
 
-func (r *ExperienceRepository) UpdateExperience(id uint, experience *models.Experience) (*models.Experience, error) {
+func (r *ExperienceRepository) UpdateExperience(ctx context.Context, id uint, experience *models.Experience) (*models.Experience, error) {
+	db := r.db.WithContext(ctx)
 	var existingExperience models.Experience
-	err := r.db.First(&existingExperience, id).Error
+	err := primary(db).First(&existingExperience, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("experience not found")
@@ -71,16 +151,54 @@ func (r *ExperienceRepository) UpdateExperience(id uint, experience *models.Expe
 	}
 
 	experience.ID = id
-	err = r.db.Save(experience).Error
+	experience.SortOrder = existingExperience.SortOrder
+	experience.CreatedBy = existingExperience.CreatedBy
+	experience.PublishedAt = keepPublicationDate(experience.Published, experience.PublishedAt, existingExperience.PublishedAt)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		version, err := nextVersion(tx, &models.Experience{}, id, experience.Version, existingExperience.Version)
+		if err != nil {
+			return err
+		}
+		experience.Version = version
+		return r.save(tx, experience)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return experience, nil
+	return experience, primary(db).Preload("Skills", orderSkills).Preload("CompanyDetails").First(experience, id).Error
+}
+
+// save stores the experience together with its skills, named after its
+// company when it links one
+func (r *ExperienceRepository) save(db *gorm.DB, experience *models.Experience) error {
+	skills, err := lookupSkills(primary(db), experience.Skills)
+	if err != nil {
+		return err
+	}
+	experience.Company, err = companyName(db, experience.CompanyID, experience.Company)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(experience).Error; err != nil {
+			return err
+		}
+		return tx.Model(experience).Association("Skills").Replace(skills)
+	})
+}
+
+// ReorderExperiences sets the sort order to match ids, which must list every
+// experience exactly once
+func (r *ExperienceRepository) ReorderExperiences(ctx context.Context, ids []uint) error {
+	db := r.db.WithContext(ctx)
+	return reorder(db, &models.Experience{}, "sort_order", ids)
 }
 
-func (r *ExperienceRepository) DeleteExperience(id uint) error {
+func (r *ExperienceRepository) DeleteExperience(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
 	var experience models.Experience
-	err := r.db.First(&experience, id).Error
+	err := primary(db).First(&experience, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("experience not found")
@@ -88,7 +206,7 @@ func (r *ExperienceRepository) DeleteExperience(id uint) error {
 		return err
 	}
 
-	err = r.db.Delete(&experience).Error
+	err = db.Select("Skills").Delete(&experience).Error
 	if err != nil {
 		return err
 	}
@@ -104,26 +222,37 @@ func NewSkillRepository(db *gorm.DB) *SkillRepository {
 	return &SkillRepository{db: db}
 }
 
-func (r *SkillRepository) GetSkills() ([]models.Skill, error) {
+func (r *SkillRepository) GetSkills(ctx context.Context) ([]models.Skill, error) {
+	db := r.db.WithContext(ctx)
 	var skills []models.Skill
-	err := r.db.Order("category, name").Find(&skills).Error
+	err := db.Order("position, category, name").Find(&skills).Error
 	if err != nil {
 		return nil, err
 	}
 	return skills, nil
 }
 
-func (r *SkillRepository) CreateSkill(skill *models.Skill) (*models.Skill, error) {
-	err := r.db.Create(skill).Error
+// CreateSkill appends the skill after the existing ones
+func (r *SkillRepository) CreateSkill(ctx context.Context, skill *models.Skill) (*models.Skill, error) {
+	db := r.db.WithContext(ctx)
+	var last int
+	err := primary(db).Model(&models.Skill{}).Select("COALESCE(MAX(position), 0)").Scan(&last).Error
+	if err != nil {
+		return nil, err
+	}
+
+	skill.Position = last + 1
+	err = db.Create(skill).Error
 	if err != nil {
 		return nil, err
 	}
 	return skill, nil
 }
 
-func (r *SkillRepository) UpdateSkill(id uint, skill *models.Skill) (*models.Skill, error) {
+func (r *SkillRepository) UpdateSkill(ctx context.Context, id uint, skill *models.Skill) (*models.Skill, error) {
+	db := r.db.WithContext(ctx)
 	var existingSkill models.Skill
-	err := r.db.First(&existingSkill, id).Error
+	err := primary(db).First(&existingSkill, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("skill not found")
@@ -132,16 +261,139 @@ func (r *SkillRepository) UpdateSkill(id uint, skill *models.Skill) (*models.Ski
 	}
 
 	skill.ID = id
-	err = r.db.Save(skill).Error
+	skill.Position = existingSkill.Position
+	skill.Endorsements = existingSkill.Endorsements
+	skill.CreatedAt = existingSkill.CreatedAt
+	skill.CreatedBy = existingSkill.CreatedBy
+	err = db.Transaction(func(tx *gorm.DB) error {
+		version, err := nextVersion(tx, &models.Skill{}, id, skill.Version, existingSkill.Version)
+		if err != nil {
+			return err
+		}
+		skill.Version = version
+		return tx.Save(skill).Error
+	})
 	if err != nil {
 		return nil, err
 	}
 	return skill, nil
 }
 
-func (r *SkillRepository) DeleteSkill(id uint) error {
+// SkillPeriod is the date range of a published experience using a skill
+type SkillPeriod struct {
+	SkillID   uint
+	StartDate time.Time
+	EndDate   *time.Time
+	Current   bool
+}
+
+// GetSkillPeriods returns the date ranges of the published experiences
+// linked to each skill
+func (r *SkillRepository) GetSkillPeriods(ctx context.Context) ([]SkillPeriod, error) {
+	db := r.db.WithContext(ctx)
+	var periods []SkillPeriod
+	err := db.Model(&models.Experience{}).
+		Select("experience_skills.skill_id, experiences.start_date, experiences.end_date, experiences.current").
+		Joins("JOIN experience_skills ON experience_skills.experience_id = experiences.id").
+		Where("experiences.published = ? AND (experiences.published_at IS NULL OR experiences.published_at <= ?)", true, time.Now()).
+		Order("experience_skills.skill_id, experiences.start_date").
+		Scan(&periods).Error
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// AddEndorsements adds the given counts to the skills' endorsements in one
+// transaction and returns the tenants the skills belong to
+func (r *SkillRepository) AddEndorsements(ctx context.Context, increments map[uint]int64) ([]uint, error) {
+	db := r.db.WithContext(ctx)
+	ids := make([]uint, 0, len(increments))
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for id, n := range increments {
+			err := tx.Model(&models.Skill{}).
+				Where("id = ?", id).
+				UpdateColumn("endorsements", gorm.Expr("endorsements + ?", n)).Error
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var tenants []uint
+	err = db.Model(&models.Skill{}).Where("id IN ?", ids).Distinct().Pluck("tenant_id", &tenants).Error
+	return tenants, err
+}
+
+// ReorderSkills sets the display order to match ids, which must list every
+// skill exactly once
+func (r *SkillRepository) ReorderSkills(ctx context.Context, ids []uint) error {
+	db := r.db.WithContext(ctx)
+	return reorder(db, &models.Skill{}, "position", ids)
+}
+
+// RenameSkillCategory moves every skill in category from to category to,
+// which must not be in use yet, and returns how many skills moved
+func (r *SkillRepository) RenameSkillCategory(ctx context.Context, from, to, username string) (int64, error) {
+	db := r.db.WithContext(ctx)
+	var moved int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		err := tx.Model(&models.Skill{}).Where("category = ?", to).Count(&taken).Error
+		if err != nil {
+			return err
+		}
+		if taken > 0 {
+			return errors.New("category already exists")
+		}
+
+		moved, err = recategorize(tx, []string{from}, to, username)
+		return err
+	})
+	return moved, err
+}
+
+// MergeSkillCategories moves every skill in one of the from categories to
+// category to, which may already be in use, and returns how many skills
+// moved
+func (r *SkillRepository) MergeSkillCategories(ctx context.Context, from []string, to, username string) (int64, error) {
+	db := r.db.WithContext(ctx)
+	var moved int64
+	err := db.Transaction(func(tx *gorm.DB) (err error) {
+		moved, err = recategorize(tx, from, to, username)
+		return err
+	})
+	return moved, err
+}
+
+// recategorize updates the skills of the from categories, bumping their
+// version like any other edit
+func recategorize(tx *gorm.DB, from []string, to, username string) (int64, error) {
+	result := tx.Model(&models.Skill{}).
+		Where("category IN ? AND category <> ?", from, to).
+		Updates(map[string]interface{}{
+			"category":   to,
+			"updated_by": username,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, errors.New("category not found")
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *SkillRepository) DeleteSkill(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
 	var skill models.Skill
-	err := r.db.First(&skill, id).Error
+	err := primary(db).First(&skill, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("skill not found")
@@ -149,11 +401,95 @@ func (r *SkillRepository) DeleteSkill(id uint) error {
 		return err
 	}
 
-	err = r.db.Delete(&skill).Error
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"project_skills", "experience_skills"} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE skill_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&skill).Error
+	})
+}
+
+// skillLinks are the join tables relating skills to other content, by the
+// model whose version changes with its skills
+var skillLinks = []struct {
+	table  string
+	column string
+	model  interface{}
+}{
+	{"project_skills", "project_id", &models.Project{}},
+	{"experience_skills", "experience_id", &models.Experience{}},
+}
+
+// MergeSkills folds the source skills into the target in one transaction:
+// projects and experiences using a source use the target instead, the
+// endorsements are added up and the sources are deleted. The target and
+// every project and experience whose skills changed get a new version.
+func (r *SkillRepository) MergeSkills(ctx context.Context, sourceIDs []uint, targetID uint, username string) (*models.Skill, error) {
+	db := r.db.WithContext(ctx)
+	var target models.Skill
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&target, targetID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("skill not found")
+			}
+			return err
+		}
+		var sources []models.Skill
+		if err := tx.Where("id IN ?", sourceIDs).Find(&sources).Error; err != nil {
+			return err
+		}
+		if len(sources) != len(sourceIDs) {
+			return errors.New("skill not found")
+		}
+
+		for _, link := range skillLinks {
+			var owners []uint
+			err := tx.Table(link.table).Where("skill_id IN ?", sourceIDs).Distinct().Pluck(link.column, &owners).Error
+			if err != nil {
+				return err
+			}
+			if len(owners) == 0 {
+				continue
+			}
+
+			err = tx.Exec("INSERT INTO "+link.table+" ("+link.column+", skill_id) SELECT DISTINCT "+link.column+", ? FROM "+link.table+
+				" WHERE skill_id IN ? AND "+link.column+" NOT IN (SELECT "+link.column+" FROM "+link.table+" WHERE skill_id = ?)",
+				targetID, sourceIDs, targetID).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM "+link.table+" WHERE skill_id IN ?", sourceIDs).Error; err != nil {
+				return err
+			}
+			err = tx.Model(link.model).Where("id IN ?", owners).Updates(map[string]interface{}{
+				"updated_by": username,
+				"version":    gorm.Expr("version + 1"),
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var endorsements int64
+		for _, source := range sources {
+			endorsements += source.Endorsements
+		}
+		err := tx.Model(&models.Skill{}).Where("id = ?", targetID).Updates(map[string]interface{}{
+			"endorsements": gorm.Expr("endorsements + ?", endorsements),
+			"updated_by":   username,
+			"version":      gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&models.Skill{}, sourceIDs).Error
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return &target, primary(db).First(&target, targetID).Error
 }
 
 // ProjectRepository handles project data operations
@@ -165,14 +501,27 @@ func NewProjectRepository(db *gorm.DB) *ProjectRepository {
 	return &ProjectRepository{db: db}
 }
 
-func (r *ProjectRepository) GetProjects(featured *bool) ([]models.Project, error) {
+func (r *ProjectRepository) GetProjects(ctx context.Context, featured *bool, categoryID *uint, includeDrafts, includeArchived bool) ([]models.Project, error) {
+	db := r.db.WithContext(ctx)
 	var projects []models.Project
-	query := r.db.Order("created_at DESC")
+	query := r.preload(db).Order("created_at DESC")
 
 	if featured != nil {
 		query = query.Where("featured = ?", *featured)
 	}
 
+	if categoryID != nil {
+		query = query.Where("category_id = ?", *categoryID)
+	}
+
+	if !includeDrafts {
+		query = query.Where("published = ? AND (published_at IS NULL OR published_at <= ?)", true, time.Now())
+	}
+
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
 	err := query.Find(&projects).Error
 	if err != nil {
 		return nil, err
@@ -180,17 +529,23 @@ func (r *ProjectRepository) GetProjects(featured *bool) ([]models.Project, error
 	return projects, nil
 }
 
-func (r *ProjectRepository) CreateProject(project *models.Project) (*models.Project, error) {
-	err := r.db.Create(project).Error
+func (r *ProjectRepository) CreateProject(ctx context.Context, project *models.Project) (*models.Project, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.checkCategory(db, project.CategoryID); err != nil {
+		return nil, err
+	}
+
+	err := r.save(db, project)
 	if err != nil {
 		return nil, err
 	}
-	return project, nil
+	return project, primary(r.preload(db)).First(project, project.ID).Error
 }
 
-func (r *ProjectRepository) UpdateProject(id uint, project *models.Project) (*models.Project, error) {
+func (r *ProjectRepository) UpdateProject(ctx context.Context, id uint, project *models.Project) (*models.Project, error) {
+	db := r.db.WithContext(ctx)
 	var existingProject models.Project
-	err := r.db.First(&existingProject, id).Error
+	err := primary(db).First(&existingProject, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("project not found")
@@ -198,17 +553,172 @@ func (r *ProjectRepository) UpdateProject(id uint, project *models.Project) (*mo
 		return nil, err
 	}
 
+	if err := r.checkCategory(db, project.CategoryID); err != nil {
+		return nil, err
+	}
+
 	project.ID = id
-	err = r.db.Save(project).Error
+	project.CreatedBy = existingProject.CreatedBy
+	// A project keeps its place among the featured ones until it is unfeatured
+	if project.Featured && existingProject.Featured {
+		project.FeaturedRank = existingProject.FeaturedRank
+	}
+	project.PublishedAt = keepPublicationDate(project.Published, project.PublishedAt, existingProject.PublishedAt)
+	// Statistics belong to the repository, so they only carry over while it is unchanged
+	if project.GitHubURL == existingProject.GitHubURL {
+		project.GitHub = existingProject.GitHub
+	}
+	project.LikeCount = existingProject.LikeCount
+	// Archiving has its own endpoints
+	project.Archived = existingProject.Archived
+	project.ArchivedAt = existingProject.ArchivedAt
+	err = db.Transaction(func(tx *gorm.DB) error {
+		version, err := nextVersion(tx, &models.Project{}, id, project.Version, existingProject.Version)
+		if err != nil {
+			return err
+		}
+		project.Version = version
+		return r.save(tx, project)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, primary(r.preload(db)).First(project, id).Error
+}
+
+// SetProjectArchived archives or restores a project, bumping its version.
+// Archiving again keeps the original archive date.
+func (r *ProjectRepository) SetProjectArchived(ctx context.Context, id uint, archived bool, username string) (*models.Project, error) {
+	db := r.db.WithContext(ctx)
+	var project models.Project
+	err := primary(db).First(&project, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("project not found")
+		}
+		return nil, err
+	}
+
+	if project.Archived != archived {
+		var archivedAt *time.Time
+		if archived {
+			now := time.Now()
+			archivedAt = &now
+		}
+		err = db.Model(&models.Project{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"archived":    archived,
+			"archived_at": archivedAt,
+			"updated_by":  username,
+			"version":     gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &project, primary(r.preload(db)).First(&project, id).Error
+}
+
+// ReorderFeaturedProjects ranks the featured projects to match ids, which
+// must list every featured project exactly once
+func (r *ProjectRepository) ReorderFeaturedProjects(ctx context.Context, ids []uint) error {
+	db := r.db.WithContext(ctx)
+	return reorder(db.Where("featured = ?", true), &models.Project{}, "featured_rank", ids)
+}
+
+// save stores the project together with its tags and skills
+func (r *ProjectRepository) save(db *gorm.DB, project *models.Project) error {
+	skills, err := lookupSkills(primary(db), project.Skills)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := saveWithTags(tx, project, project.Tags); err != nil {
+			return err
+		}
+		return tx.Model(project).Association("Skills").Replace(skills)
+	})
+}
+
+// preload loads everything a project response embeds
+func (r *ProjectRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Category").
+		Preload("Tags").
+		Preload("Skills", orderSkills).
+		Preload("Images", orderImages).
+		Preload("Milestones", orderMilestones)
+}
+
+// orderImages sorts preloaded gallery images in their display order
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// orderMilestones sorts preloaded milestones newest first
+func orderMilestones(db *gorm.DB) *gorm.DB {
+	return db.Order("date DESC, id DESC")
+}
+
+// orderSkills sorts preloaded skills in their display order
+func orderSkills(db *gorm.DB) *gorm.DB {
+	return db.Order("position, category, name")
+}
+
+// lookupSkills loads the referenced skills, failing if any does not exist
+func lookupSkills(db *gorm.DB, refs []models.Skill) ([]models.Skill, error) {
+	ids := make([]uint, 0, len(refs))
+	seen := make(map[uint]bool, len(refs))
+	for _, ref := range refs {
+		if !seen[ref.ID] {
+			seen[ref.ID] = true
+			ids = append(ids, ref.ID)
+		}
+	}
+
+	skills := []models.Skill{}
+	if len(ids) == 0 {
+		return skills, nil
+	}
+
+	err := db.Where("id IN ?", ids).Find(&skills).Error
 	if err != nil {
 		return nil, err
 	}
-	return project, nil
+	if len(skills) != len(ids) {
+		return nil, errors.New("skill not found")
+	}
+	return skills, nil
 }
 
-func (r *ProjectRepository) DeleteProject(id uint) error {
+// SkillExists reports whether the skill with the given ID exists
+func (r *ProjectRepository) SkillExists(ctx context.Context, id uint) (bool, error) {
+	db := r.db.WithContext(ctx)
+	var count int64
+	err := db.Model(&models.Skill{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// checkCategory reports an unknown category before it reaches the foreign key
+func (r *ProjectRepository) checkCategory(db *gorm.DB, categoryID *uint) error {
+	if categoryID == nil {
+		return nil
+	}
+
+	var count int64
+	err := primary(db).Model(&models.Category{}).Where("id = ?", *categoryID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("category not found")
+	}
+	return nil
+}
+
+func (r *ProjectRepository) DeleteProject(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
 	var project models.Project
-	err := r.db.First(&project, id).Error
+	err := primary(db).First(&project, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("project not found")
@@ -216,13 +726,235 @@ func (r *ProjectRepository) DeleteProject(id uint) error {
 		return err
 	}
 
-	err = r.db.Delete(&project).Error
+	err = db.Select("Tags", "Skills", "Images", "Milestones").Delete(&project).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// GetProject returns a project, drafts included
+func (r *ProjectRepository) GetProject(ctx context.Context, id uint) (*models.Project, error) {
+	db := r.db.WithContext(ctx)
+	var project models.Project
+	err := primary(r.preload(db)).First(&project, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("project not found")
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+// AddProjectImage appends an image to the end of a project's gallery
+func (r *ProjectRepository) AddProjectImage(ctx context.Context, projectID uint, image *models.ProjectImage) (*models.ProjectImage, error) {
+	db := r.db.WithContext(ctx)
+	variants, err := r.imageVariants(db, image.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := primary(tx).Model(&models.Project{}).Where("id = ?", projectID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("project not found")
+		}
+
+		var last int
+		err := primary(tx).Model(&models.ProjectImage{}).
+			Where("project_id = ?", projectID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		image.ID = 0
+		image.ProjectID = projectID
+		image.Variants = variants
+		image.Position = last + 1
+		return tx.Create(image).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// DeleteProjectImage removes an image from a project's gallery
+func (r *ProjectRepository) DeleteProjectImage(ctx context.Context, projectID, imageID uint) error {
+	db := r.db.WithContext(ctx)
+	result := db.Where("project_id = ?", projectID).Delete(&models.ProjectImage{}, imageID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("image not found")
+	}
+	return nil
+}
+
+// ReorderProjectImages puts a project's gallery in the order of ids, which
+// must list every image of the project exactly once, and returns it
+func (r *ProjectRepository) ReorderProjectImages(ctx context.Context, projectID uint, ids []uint) ([]models.ProjectImage, error) {
+	db := r.db.WithContext(ctx)
+	if err := reorder(db.Where("project_id = ?", projectID), &models.ProjectImage{}, "position", ids); err != nil {
+		return nil, err
+	}
+
+	var images []models.ProjectImage
+	err := orderImages(primary(db)).Where("project_id = ?", projectID).Find(&images).Error
+	return images, err
+}
+
+// RefreshImageVariants stores the current variants of an uploaded image on
+// every gallery image showing it
+func (r *ProjectRepository) RefreshImageVariants(ctx context.Context, imageURL string) (int64, error) {
+	db := r.db.WithContext(ctx)
+	variants, err := r.imageVariants(db, imageURL)
+	if err != nil || len(variants) == 0 {
+		return 0, err
+	}
+
+	result := db.Model(&models.ProjectImage{}).
+		Where("url = ?", imageURL).
+		Updates(models.ProjectImage{Variants: variants})
+	return result.RowsAffected, result.Error
+}
+
+// CreateProjectMilestone adds a milestone to a project's history
+func (r *ProjectRepository) CreateProjectMilestone(ctx context.Context, projectID uint, milestone *models.ProjectMilestone) (*models.ProjectMilestone, error) {
+	db := r.db.WithContext(ctx)
+	var count int64
+	if err := primary(db).Model(&models.Project{}).Where("id = ?", projectID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.New("project not found")
+	}
+
+	milestone.ID = 0
+	milestone.ProjectID = projectID
+	if err := db.Create(milestone).Error; err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// UpdateProjectMilestone replaces a milestone of a project
+func (r *ProjectRepository) UpdateProjectMilestone(ctx context.Context, projectID, milestoneID uint, milestone *models.ProjectMilestone) (*models.ProjectMilestone, error) {
+	db := r.db.WithContext(ctx)
+	var existing models.ProjectMilestone
+	err := primary(db).Where("project_id = ?", projectID).First(&existing, milestoneID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("milestone not found")
+		}
+		return nil, err
+	}
+
+	milestone.ID = milestoneID
+	milestone.ProjectID = projectID
+	milestone.CreatedAt = existing.CreatedAt
+	if err := db.Save(milestone).Error; err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// DeleteProjectMilestone removes a milestone from a project's history
+func (r *ProjectRepository) DeleteProjectMilestone(ctx context.Context, projectID, milestoneID uint) error {
+	db := r.db.WithContext(ctx)
+	result := db.Where("project_id = ?", projectID).Delete(&models.ProjectMilestone{}, milestoneID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("milestone not found")
+	}
+	return nil
+}
+
+// AddLikes adds the given counts to the projects' likes in one transaction
+// and returns the tenants the projects belong to. The content is unchanged,
+// so neither updated_at nor the version moves.
+func (r *ProjectRepository) AddLikes(ctx context.Context, increments map[uint]int64) ([]uint, error) {
+	db := r.db.WithContext(ctx)
+	ids := make([]uint, 0, len(increments))
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for id, n := range increments {
+			err := tx.Model(&models.Project{}).
+				Where("id = ?", id).
+				UpdateColumn("like_count", gorm.Expr("like_count + ?", n)).Error
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var tenants []uint
+	err = db.Model(&models.Project{}).Where("id IN ?", ids).Distinct().Pluck("tenant_id", &tenants).Error
+	return tenants, err
+}
+
+// GetGitHubProjects returns every project linked to a GitHub repository.
+// The background sync passes a tenant.All context to cover every tenant.
+func (r *ProjectRepository) GetGitHubProjects(ctx context.Context) ([]models.Project, error) {
+	db := r.db.WithContext(ctx)
+	var projects []models.Project
+	err := db.Where("git_hub_url <> ''").Order("id").Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// UpdateGitHubStats stores freshly fetched repository statistics without
+// touching updated_at, since the project content itself has not changed
+func (r *ProjectRepository) UpdateGitHubStats(ctx context.Context, id uint, stats models.GitHubStats) error {
+	db := r.db.WithContext(ctx)
+	return db.Model(&models.Project{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"github_stars":       stats.Stars,
+		"github_forks":       stats.Forks,
+		"github_open_issues": stats.OpenIssues,
+		"github_pushed_at":   stats.PushedAt,
+		"github_synced_at":   stats.SyncedAt,
+	}).Error
+}
+
+// imageVariants looks up the resized copies of an uploaded image by URL
+func (r *ProjectRepository) imageVariants(db *gorm.DB, imageURL string) (map[string]string, error) {
+	if imageURL == "" {
+		return nil, nil
+	}
+
+	var variants []models.MediaVariant
+	err := primary(db).Joins("JOIN media ON media.id = media_variants.media_id").
+		Where("media.url = ?", imageURL).
+		Find(&variants).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	urls := make(map[string]string, len(variants))
+	for _, variant := range variants {
+		urls[variant.Name] = variant.URL
+	}
+	return urls, nil
+}
+
 // ContactRepository handles contact data operations
 type ContactRepository struct {
 	db *gorm.DB
@@ -232,26 +964,45 @@ func NewContactRepository(db *gorm.DB) *ContactRepository {
 	return &ContactRepository{db: db}
 }
 
-func (r *ContactRepository) CreateContact(contact *models.Contact) (*models.Contact, error) {
-	err := r.db.Create(contact).Error
+func (r *ContactRepository) CreateContact(ctx context.Context, contact *models.Contact) (*models.Contact, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return createGroupedContact(tx, contact)
+	})
 	if err != nil {
 		return nil, err
 	}
 	return contact, nil
 }
 
-func (r *ContactRepository) GetContacts() ([]models.Contact, error) {
+// GetContacts returns the contacts matching the status filter, newest
+// first, optionally narrowed to those sent from the given country. Given an
+// email address it returns that sender's submissions, spam included unless
+// status asks for another one.
+func (r *ContactRepository) GetContacts(ctx context.Context, status, country, email string) ([]models.Contact, error) {
+	db := r.db.WithContext(ctx)
 	var contacts []models.Contact
-	err := r.db.Order("created_at DESC").Find(&contacts).Error
+	query := r.filter(db, status)
+	if email != "" {
+		query = db.Where("LOWER(email) = LOWER(?)", email)
+		if status != "" {
+			query = query.Where("status = ?", status)
+		}
+	}
+	if country != "" {
+		query = query.Where("country = ?", country)
+	}
+	err := query.Order("created_at DESC").Find(&contacts).Error
 	if err != nil {
 		return nil, err
 	}
 	return contacts, nil
 }
 
-func (r *ContactRepository) UpdateContactStatus(id uint, status string) (*models.Contact, error) {
+func (r *ContactRepository) UpdateContactStatus(ctx context.Context, id uint, status string) (*models.Contact, error) {
+	db := r.db.WithContext(ctx)
 	var contact models.Contact
-	err := r.db.First(&contact, id).Error
+	err := primary(db).First(&contact, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("contact not found")
@@ -260,9 +1011,82 @@ func (r *ContactRepository) UpdateContactStatus(id uint, status string) (*models
 	}
 
 	contact.Status = status
-	err = r.db.Save(&contact).Error
+	err = db.Save(&contact).Error
 	if err != nil {
 		return nil, err
 	}
 	return &contact, nil
 }
+
+// MarkAutoReplied records that the submitter received the acknowledgment email
+func (r *ContactRepository) MarkAutoReplied(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	return db.Model(&models.Contact{}).Where("id = ?", id).Update("replied_auto", true).Error
+}
+
+// keepPublicationDate returns the publication date to store when content is
+// updated: an explicit date wins, otherwise published content keeps its
+// original date or is stamped now when first published
+func keepPublicationDate(published bool, publishedAt, existing *time.Time) *time.Time {
+	if publishedAt != nil || !published {
+		return publishedAt
+	}
+	if existing != nil {
+		return existing
+	}
+	now := time.Now()
+	return &now
+}
+
+// nextVersion moves the row with the given id in model's table to its next
+// version inside tx and returns it. The row must still be at expected, the
+// version the client edited, or at current when the client sent none;
+// otherwise someone else changed it since and "version conflict" is returned.
+// The update holds the row's lock until tx ends, so concurrent writers
+// cannot both pass the check.
+func nextVersion(tx *gorm.DB, model interface{}, id, expected, current uint) (uint, error) {
+	if expected == 0 {
+		expected = current
+	}
+	result := tx.Model(model).Where("id = ? AND version = ?", id, expected).
+		UpdateColumn("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, errors.New("version conflict")
+	}
+	return expected + 1, nil
+}
+
+// reorder numbers the rows of model's table from 1 in the order given by ids,
+// storing the number in column. It runs in a transaction and fails without
+// changes unless ids lists every row exactly once.
+func reorder(db *gorm.DB, model interface{}, column string, ids []uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(model).Count(&count).Error; err != nil {
+			return err
+		}
+		if int64(len(ids)) != count {
+			return errors.New("invalid order")
+		}
+
+		seen := make(map[uint]bool, len(ids))
+		for i, id := range ids {
+			if seen[id] {
+				return errors.New("invalid order")
+			}
+			seen[id] = true
+
+			result := tx.Model(model).Where("id = ?", id).Update(column, i+1)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("invalid order")
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ResumeRepository handles resume variant data operations
+type ResumeRepository struct {
+	db *gorm.DB
+}
+
+func NewResumeRepository(db *gorm.DB) *ResumeRepository {
+	return &ResumeRepository{db: db}
+}
+
+// GetResumes returns the resume variants, the default first, then by name
+func (r *ResumeRepository) GetResumes(ctx context.Context) ([]models.Resume, error) {
+	db := r.db.WithContext(ctx)
+	var resumes []models.Resume
+	err := db.Order("is_default DESC, name").Find(&resumes).Error
+	if err != nil {
+		return nil, err
+	}
+	return resumes, nil
+}
+
+func (r *ResumeRepository) CreateResume(ctx context.Context, resume *models.Resume) (*models.Resume, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.ensureUniqueSlug(db, resume.Slug, 0); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(resume).Error; err != nil {
+			return err
+		}
+		return r.keepSingleDefault(tx, resume)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resume, nil
+}
+
+func (r *ResumeRepository) UpdateResume(ctx context.Context, id uint, resume *models.Resume) (*models.Resume, error) {
+	db := r.db.WithContext(ctx)
+	var existingResume models.Resume
+	err := primary(db).First(&existingResume, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("resume not found")
+		}
+		return nil, err
+	}
+
+	if err := r.ensureUniqueSlug(db, resume.Slug, id); err != nil {
+		return nil, err
+	}
+
+	resume.ID = id
+	resume.CreatedAt = existingResume.CreatedAt
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(resume).Error; err != nil {
+			return err
+		}
+		return r.keepSingleDefault(tx, resume)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resume, nil
+}
+
+func (r *ResumeRepository) DeleteResume(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var resume models.Resume
+	err := primary(db).First(&resume, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("resume not found")
+		}
+		return err
+	}
+
+	return db.Delete(&resume).Error
+}
+
+// keepSingleDefault takes the default flag from the other variants when
+// resume has it
+func (r *ResumeRepository) keepSingleDefault(tx *gorm.DB, resume *models.Resume) error {
+	if !resume.IsDefault {
+		return nil
+	}
+	return tx.Model(&models.Resume{}).
+		Where("id <> ? AND is_default = ?", resume.ID, true).
+		Update("is_default", false).Error
+}
+
+// ensureUniqueSlug rejects a slug another variant already uses
+func (r *ResumeRepository) ensureUniqueSlug(db *gorm.DB, slug string, excludeID uint) error {
+	var count int64
+	err := primary(db).Model(&models.Resume{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("resume already exists")
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// What search can find
+const (
+	SearchProjects    = "project"
+	SearchSkills      = "skill"
+	SearchExperiences = "experience"
+)
+
+// SearchTypes lists every kind of content search covers
+var SearchTypes = []string{SearchProjects, SearchSkills, SearchExperiences}
+
+// pg_trgm's default thresholds for the % and <% operators, which the
+// fallback matcher applies too
+const (
+	similarityThreshold     = 0.3
+	wordSimilarityThreshold = 0.6
+)
+
+// SearchMatch is published content whose name resembles the query
+type SearchMatch struct {
+	Type     string  `json:"type"`
+	ID       uint    `json:"id"`
+	Title    string  `json:"title"`    // Project or skill name, or company
+	Subtitle string  `json:"subtitle"` // Project description, skill category or position
+	Score    float64 `json:"score"`    // Relevance from 0 to 1
+}
+
+// searchSource describes where one type of content is searched
+type searchSource struct {
+	model       interface{}
+	column      string // Matched against the query
+	subtitle    string
+	publishable bool // Whether drafts and scheduled items must be left out
+	archivable  bool // Whether archived items must be left out
+}
+
+// scope limits a query to the items visitors can see now
+func (s searchSource) scope(db *gorm.DB) *gorm.DB {
+	if s.archivable {
+		db = db.Where("archived = ?", false)
+	}
+	if !s.publishable {
+		return db
+	}
+	return db.Where("published = ? AND (published_at IS NULL OR published_at <= ?)", true, time.Now())
+}
+
+var searchSources = map[string]searchSource{
+	SearchProjects:    {&models.Project{}, "name", "description", true, true},
+	SearchSkills:      {&models.Skill{}, "name", "category", false, false},
+	SearchExperiences: {&models.Experience{}, "company", "position", true, false},
+}
+
+// SearchRepository finds content by name, tolerating typos
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search returns up to limit items of the given types whose names resemble
+// query, best match first. PostgreSQL ranks them with pg_trgm, using the
+// trigram indexes created by the migrations; other databases load the names
+// and compare them the same way in Go.
+func (r *SearchRepository) Search(ctx context.Context, query string, types []string, limit int) ([]SearchMatch, error) {
+	db := r.db.WithContext(ctx)
+	matches := []SearchMatch{}
+	for _, kind := range types {
+		source := searchSources[kind]
+		var found []SearchMatch
+		var err error
+		if db.Dialector.Name() == "postgres" {
+			found, err = r.searchTrigrams(db, source, query, limit)
+		} else {
+			found, err = r.searchNames(db, source, query)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range found {
+			found[i].Type = kind
+		}
+		matches = append(matches, found...)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// searchTrigrams matches with pg_trgm's operators, which the indexes serve.
+// % compares whole names and <% finds the query within longer ones.
+func (r *SearchRepository) searchTrigrams(db *gorm.DB, source searchSource, query string, limit int) ([]SearchMatch, error) {
+	var matches []SearchMatch
+	err := source.scope(db.Model(source.model)).
+		Select("id, "+source.column+" AS title, "+source.subtitle+" AS subtitle, "+
+			"GREATEST(similarity("+source.column+", ?), word_similarity(?, "+source.column+")) AS score", query, query).
+		Where("("+source.column+" % ? OR ? <% "+source.column+")", query, query).
+		Order("score DESC").
+		Limit(limit).
+		Scan(&matches).Error
+	return matches, err
+}
+
+// searchNames loads every name and keeps those pg_trgm would match
+func (r *SearchRepository) searchNames(db *gorm.DB, source searchSource, query string) ([]SearchMatch, error) {
+	var rows []SearchMatch
+	err := source.scope(db.Model(source.model)).
+		Select("id, " + source.column + " AS title, " + source.subtitle + " AS subtitle").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	queryTrigrams := trigrams(query)
+	matches := []SearchMatch{}
+	for _, row := range rows {
+		similar := similarity(queryTrigrams, trigrams(row.Title))
+		word := wordSimilarity(queryTrigrams, row.Title)
+		if similar < similarityThreshold && word < wordSimilarityThreshold {
+			continue
+		}
+		row.Score = max(similar, word)
+		matches = append(matches, row)
+	}
+	return matches, nil
+}
+
+// trigrams returns the set of trigrams of s the way pg_trgm extracts them:
+// from each lower-cased word, padded with two spaces in front and one
+// behind
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range words(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// similarity is the share of trigrams two strings have in common
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range a {
+		if b[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// wordSimilarity approximates pg_trgm's word_similarity: the best
+// similarity between the query and any run of consecutive words in s
+func wordSimilarity(query map[string]bool, s string) float64 {
+	words := words(s)
+	best := 0.0
+	for i := range words {
+		for j := i + 1; j <= len(words); j++ {
+			best = max(best, similarity(query, trigrams(strings.Join(words[i:j], " "))))
+		}
+	}
+	return best
+}
+
+// SearchItem is content as handed to an external search engine
+type SearchItem struct {
+	TenantID    uint
+	ID          uint
+	Title       string
+	Subtitle    string
+	PublishedAt *time.Time // Set on scheduled items, which are only shown from then on
+}
+
+// GetSearchItems returns the published, unarchived items of a type,
+// including those scheduled for later, or only the one with the given ID
+// when id is not 0. Pass a tenant.All context to cover every tenant.
+func (r *SearchRepository) GetSearchItems(ctx context.Context, kind string, id uint) ([]SearchItem, error) {
+	db := r.db.WithContext(ctx)
+	source := searchSources[kind]
+	columns := "tenant_id, id, " + source.column + " AS title, " + source.subtitle + " AS subtitle"
+	query := db.Model(source.model).Order("id")
+	if source.publishable {
+		columns += ", published_at"
+		query = query.Where("published = ?", true)
+	}
+	if source.archivable {
+		query = query.Where("archived = ?", false)
+	}
+	if id != 0 {
+		query = query.Where("id = ?", id)
+	}
+
+	var items []SearchItem
+	if err := query.Select(columns).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
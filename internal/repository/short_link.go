@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShortLinkRepository handles short link data operations
+type ShortLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewShortLinkRepository(db *gorm.DB) *ShortLinkRepository {
+	return &ShortLinkRepository{db: db}
+}
+
+func (r *ShortLinkRepository) GetLinks(ctx context.Context) ([]models.ShortLink, error) {
+	db := r.db.WithContext(ctx)
+	var links []models.ShortLink
+	err := db.Order("code").Find(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *ShortLinkRepository) GetByCode(ctx context.Context, code string) (*models.ShortLink, error) {
+	db := r.db.WithContext(ctx)
+	var link models.ShortLink
+	err := db.Where("code = ?", code).First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("short link not found")
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ShortLinkRepository) CreateLink(ctx context.Context, link *models.ShortLink) (*models.ShortLink, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.ensureUniqueCode(db, link.Code, 0); err != nil {
+		return nil, err
+	}
+
+	err := db.Create(link).Error
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// UpdateLink saves the link and returns it together with the code it had
+// before, whose cached lookup is now stale
+func (r *ShortLinkRepository) UpdateLink(ctx context.Context, id uint, link *models.ShortLink) (*models.ShortLink, string, error) {
+	db := r.db.WithContext(ctx)
+	var existingLink models.ShortLink
+	err := primary(db).First(&existingLink, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", errors.New("short link not found")
+		}
+		return nil, "", err
+	}
+
+	if err := r.ensureUniqueCode(db, link.Code, id); err != nil {
+		return nil, "", err
+	}
+
+	link.ID = id
+	link.Clicks = existingLink.Clicks
+	link.LastClickedAt = existingLink.LastClickedAt
+	link.CreatedAt = existingLink.CreatedAt
+	err = db.Save(link).Error
+	if err != nil {
+		return nil, "", err
+	}
+	return link, existingLink.Code, nil
+}
+
+// RecordClick counts a visit of the link
+func (r *ShortLinkRepository) RecordClick(ctx context.Context, id uint, at time.Time) error {
+	db := r.db.WithContext(ctx)
+	return db.Model(&models.ShortLink{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"clicks":          gorm.Expr("clicks + 1"),
+			"last_clicked_at": at,
+		}).Error
+}
+
+func (r *ShortLinkRepository) DeleteLink(ctx context.Context, id uint) (*models.ShortLink, error) {
+	db := r.db.WithContext(ctx)
+	var link models.ShortLink
+	err := primary(db).First(&link, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("short link not found")
+		}
+		return nil, err
+	}
+
+	err = db.Delete(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ShortLinkRepository) ensureUniqueCode(db *gorm.DB, code string, excludeID uint) error {
+	var count int64
+	err := primary(db).Model(&models.ShortLink{}).
+		Where("code = ? AND id <> ?", code, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("short link already exists")
+	}
+	return nil
+}
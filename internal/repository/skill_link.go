@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SkillLink links an experience to a skill. Without a SkillID the skill is
+// looked up by name, ignoring case, and created in Category when missing.
+type SkillLink struct {
+	ExperienceID uint
+	SkillID      *uint
+	Name         string
+	Category     string
+}
+
+// LinkExperienceSkills adds the links in one transaction, keeping those
+// already in place, and bumps the version of every experience that gained
+// a skill. It returns the skills it created and the changed experiences.
+func (r *SkillRepository) LinkExperienceSkills(ctx context.Context, links []SkillLink, username string) ([]models.Skill, []models.Experience, error) {
+	db := r.db.WithContext(ctx)
+	created := []models.Skill{}
+	var changed []uint
+	err := db.Transaction(func(tx *gorm.DB) error {
+		byName := make(map[string]*models.Skill)
+		additions := make(map[uint][]models.Skill)
+		for _, link := range links {
+			skill, err := r.linkedSkill(tx, link, username, byName, &created)
+			if err != nil {
+				return err
+			}
+			if _, ok := additions[link.ExperienceID]; !ok {
+				changed = append(changed, link.ExperienceID)
+			}
+			additions[link.ExperienceID] = append(additions[link.ExperienceID], *skill)
+		}
+
+		for _, id := range changed {
+			var experience models.Experience
+			if err := tx.First(&experience, id).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errors.New("experience not found")
+				}
+				return err
+			}
+			if err := tx.Model(&experience).Association("Skills").Append(additions[id]); err != nil {
+				return err
+			}
+			err := tx.Model(&models.Experience{}).Where("id = ?", id).Updates(map[string]interface{}{
+				"updated_by": username,
+				"version":    gorm.Expr("version + 1"),
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	experiences := []models.Experience{}
+	err = primary(db).Preload("Skills", orderSkills).Preload("CompanyDetails").
+		Where("id IN ?", changed).Order("pinned DESC, sort_order, start_date DESC").Find(&experiences).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	return created, experiences, nil
+}
+
+// linkedSkill finds or creates the skill a link names. Skills found or
+// created by name are remembered in byName so a skill proposed for several
+// experiences is created once.
+func (r *SkillRepository) linkedSkill(tx *gorm.DB, link SkillLink, username string, byName map[string]*models.Skill, created *[]models.Skill) (*models.Skill, error) {
+	var skill models.Skill
+	if link.SkillID != nil {
+		if err := tx.First(&skill, *link.SkillID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("skill not found")
+			}
+			return nil, err
+		}
+		return &skill, nil
+	}
+
+	name := strings.TrimSpace(link.Name)
+	key := strings.ToLower(name)
+	if found, ok := byName[key]; ok {
+		return found, nil
+	}
+	err := tx.Where("LOWER(name) = ?", key).First(&skill).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		var last int
+		if err := tx.Model(&models.Skill{}).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return nil, err
+		}
+		skill = models.Skill{
+			Name:        name,
+			Category:    strings.TrimSpace(link.Category),
+			Level:       5,
+			Position:    last + 1,
+			Attribution: models.Attribution{CreatedBy: username, UpdatedBy: username},
+		}
+		if err := tx.Create(&skill).Error; err != nil {
+			return nil, err
+		}
+		*created = append(*created, skill)
+	case err != nil:
+		return nil, err
+	}
+	byName[key] = &skill
+	return &skill, nil
+}
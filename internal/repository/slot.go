@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SlotRepository handles availability slot and booking data operations
+type SlotRepository struct {
+	db *gorm.DB
+}
+
+func NewSlotRepository(db *gorm.DB) *SlotRepository {
+	return &SlotRepository{db: db}
+}
+
+func (r *SlotRepository) GetSlots(ctx context.Context, activeOnly bool) ([]models.Slot, error) {
+	db := r.db.WithContext(ctx)
+	var slots []models.Slot
+	query := db.Order("weekday, start_time")
+
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+
+	err := query.Find(&slots).Error
+	if err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+func (r *SlotRepository) GetSlot(ctx context.Context, id uint) (*models.Slot, error) {
+	db := r.db.WithContext(ctx)
+	var slot models.Slot
+	err := db.First(&slot, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("slot not found")
+		}
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (r *SlotRepository) CreateSlot(ctx context.Context, slot *models.Slot) (*models.Slot, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(slot).Error
+	if err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+func (r *SlotRepository) UpdateSlot(ctx context.Context, id uint, slot *models.Slot) (*models.Slot, error) {
+	db := r.db.WithContext(ctx)
+	var existingSlot models.Slot
+	err := primary(db).First(&existingSlot, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("slot not found")
+		}
+		return nil, err
+	}
+
+	slot.ID = id
+	err = db.Save(slot).Error
+	if err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+func (r *SlotRepository) DeleteSlot(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var slot models.Slot
+	err := primary(db).First(&slot, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("slot not found")
+		}
+		return err
+	}
+
+	err = db.Delete(&slot).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetBookingsBetween returns bookings starting within [from, to)
+func (r *SlotRepository) GetBookingsBetween(ctx context.Context, from, to time.Time) ([]models.Booking, error) {
+	db := r.db.WithContext(ctx)
+	var bookings []models.Booking
+	err := db.Where("starts_at >= ? AND starts_at < ?", from, to).
+		Order("starts_at").
+		Find(&bookings).Error
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (r *SlotRepository) IsBooked(ctx context.Context, slotID uint, startsAt time.Time) (bool, error) {
+	db := r.db.WithContext(ctx)
+	var count int64
+	err := primary(db).Model(&models.Booking{}).
+		Where("slot_id = ? AND starts_at = ?", slotID, startsAt).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateBooking stores the meeting contact and the booking together
+func (r *SlotRepository) CreateBooking(ctx context.Context, booking *models.Booking, contact *models.Contact) (*models.Booking, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := createGroupedContact(tx, contact); err != nil {
+			return err
+		}
+		booking.ContactID = contact.ID
+		return tx.Create(booking).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository handles tag data operations
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// GetTagUsage returns every tag with how many published projects, posts and
+// talks carry it, leaving out archived projects
+func (r *TagRepository) GetTagUsage(ctx context.Context) ([]models.TagUsage, error) {
+	db := r.db.WithContext(ctx)
+	now := time.Now()
+	var usage []models.TagUsage
+	err := db.Model(&models.Tag{}).
+		Select(`tags.*,
+			(SELECT COUNT(*) FROM project_tags JOIN projects ON projects.id = project_tags.project_id
+				WHERE project_tags.tag_id = tags.id AND projects.published = ? AND projects.archived = ?
+				AND (projects.published_at IS NULL OR projects.published_at <= ?)) AS projects,
+			(SELECT COUNT(*) FROM post_tags JOIN posts ON posts.id = post_tags.post_id
+				WHERE post_tags.tag_id = tags.id AND posts.published = ? AND posts.published_at <= ?) AS posts,
+			(SELECT COUNT(*) FROM talk_tags WHERE talk_tags.tag_id = tags.id) AS talks`,
+			true, false, now, true, now).
+		Order("tags.name").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (r *TagRepository) CreateTag(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.ensureUniqueSlug(db, tag.Slug, 0); err != nil {
+		return nil, err
+	}
+
+	err := db.Create(tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (r *TagRepository) UpdateTag(ctx context.Context, id uint, tag *models.Tag) (*models.Tag, error) {
+	db := r.db.WithContext(ctx)
+	var existingTag models.Tag
+	err := primary(db).First(&existingTag, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tag not found")
+		}
+		return nil, err
+	}
+
+	if err := r.ensureUniqueSlug(db, tag.Slug, id); err != nil {
+		return nil, err
+	}
+
+	tag.ID = id
+	tag.CreatedAt = existingTag.CreatedAt
+	err = db.Save(tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag removes the tag from everything carrying it and then deletes it
+func (r *TagRepository) DeleteTag(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var tag models.Tag
+	err := primary(db).First(&tag, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("tag not found")
+		}
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"project_tags", "post_tags", "talk_tags"} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE tag_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&tag).Error
+	})
+}
+
+func (r *TagRepository) ensureUniqueSlug(db *gorm.DB, slug string, excludeID uint) error {
+	var count int64
+	err := primary(db).Model(&models.Tag{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("tag already exists")
+	}
+	return nil
+}
+
+// resolveTags looks the given tags up by slug, creating the missing ones, and
+// drops blanks and duplicates
+func resolveTags(db *gorm.DB, tags []models.Tag) ([]models.Tag, error) {
+	resolved := []models.Tag{}
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if tag.Slug == "" || seen[tag.Slug] {
+			continue
+		}
+		seen[tag.Slug] = true
+
+		var found models.Tag
+		err := db.Where("slug = ?", tag.Slug).
+			Attrs(models.Tag{Name: tag.Name, Slug: tag.Slug}).
+			FirstOrCreate(&found).Error
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, found)
+	}
+	return resolved, nil
+}
+
+// saveWithTags saves model, which must have a Tags association, and replaces
+// its tags with the given ones in one transaction. Other associations are
+// left to the caller.
+func saveWithTags(db *gorm.DB, model interface{}, tags []models.Tag) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		resolved, err := resolveTags(tx, tags)
+		if err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(model).Error; err != nil {
+			return err
+		}
+		return tx.Model(model).Association("Tags").Replace(resolved)
+	})
+}
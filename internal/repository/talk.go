@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// TalkRepository handles talk data operations
+type TalkRepository struct {
+	db *gorm.DB
+}
+
+func NewTalkRepository(db *gorm.DB) *TalkRepository {
+	return &TalkRepository{db: db}
+}
+
+func (r *TalkRepository) GetTalks(ctx context.Context) ([]models.Talk, error) {
+	db := r.db.WithContext(ctx)
+	var talks []models.Talk
+	err := db.Preload("Tags").Order("date DESC").Find(&talks).Error
+	if err != nil {
+		return nil, err
+	}
+	return talks, nil
+}
+
+func (r *TalkRepository) CreateTalk(ctx context.Context, talk *models.Talk) (*models.Talk, error) {
+	db := r.db.WithContext(ctx)
+	err := saveWithTags(db, talk, talk.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return talk, primary(db).Preload("Tags").First(talk, talk.ID).Error
+}
+
+func (r *TalkRepository) UpdateTalk(ctx context.Context, id uint, talk *models.Talk) (*models.Talk, error) {
+	db := r.db.WithContext(ctx)
+	var existingTalk models.Talk
+	err := primary(db).First(&existingTalk, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("talk not found")
+		}
+		return nil, err
+	}
+
+	talk.ID = id
+	talk.CreatedAt = existingTalk.CreatedAt
+	err = saveWithTags(db, talk, talk.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return talk, primary(db).Preload("Tags").First(talk, id).Error
+}
+
+func (r *TalkRepository) DeleteTalk(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var talk models.Talk
+	err := primary(db).First(&talk, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("talk not found")
+		}
+		return err
+	}
+
+	err = db.Select("Tags").Delete(&talk).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/tenant"
+
+	"gorm.io/gorm"
+)
+
+// TenantRepository handles tenant data operations. Tenants themselves are
+// not owned by a tenant, so these queries are never scoped.
+type TenantRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantRepository(db *gorm.DB) *TenantRepository {
+	return &TenantRepository{db: db}
+}
+
+func (r *TenantRepository) GetTenants(ctx context.Context) ([]models.Tenant, error) {
+	db := r.db.WithContext(ctx)
+	var tenants []models.Tenant
+	err := db.Order("id").Find(&tenants).Error
+	if err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// CreateTenant adds the tenant together with its first admin user
+func (r *TenantRepository) CreateTenant(ctx context.Context, t *models.Tenant, admin *models.User) (*models.Tenant, error) {
+	db := r.db.WithContext(ctx)
+	if err := r.ensureUniqueSlug(db, t.Slug, 0); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(t).Error; err != nil {
+			return err
+		}
+		return tx.WithContext(tenant.WithID(ctx, t.ID)).Create(admin).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *TenantRepository) UpdateTenant(ctx context.Context, id uint, t *models.Tenant) (*models.Tenant, error) {
+	db := r.db.WithContext(ctx)
+	var existingTenant models.Tenant
+	err := primary(db).First(&existingTenant, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tenant not found")
+		}
+		return nil, err
+	}
+
+	if err := r.ensureUniqueSlug(db, t.Slug, id); err != nil {
+		return nil, err
+	}
+
+	t.ID = id
+	t.CreatedAt = existingTenant.CreatedAt
+	err = db.Save(t).Error
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *TenantRepository) ensureUniqueSlug(db *gorm.DB, slug string, excludeID uint) error {
+	var count int64
+	err := primary(db).Model(&models.Tenant{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("slug already exists")
+	}
+	return nil
+}
+
+// UserRepository handles admin user data operations for the tenant in ctx
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// CountUsers returns how many admin users the tenant has
+func (r *UserRepository) CountUsers(ctx context.Context) (int64, error) {
+	db := r.db.WithContext(ctx)
+	var count int64
+	err := db.Model(&models.User{}).Count(&count).Error
+	return count, err
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	db := r.db.WithContext(ctx)
+	return db.Create(user).Error
+}
+
+func (r *UserRepository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	db := r.db.WithContext(ctx)
+	var user models.User
+	err := db.Where("username = ?", username).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
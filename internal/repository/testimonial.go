@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// TestimonialRepository handles testimonial data operations
+type TestimonialRepository struct {
+	db *gorm.DB
+}
+
+func NewTestimonialRepository(db *gorm.DB) *TestimonialRepository {
+	return &TestimonialRepository{db: db}
+}
+
+// GetTestimonials returns testimonials, newest first. When approvedOnly is
+// set, entries awaiting moderation are excluded.
+func (r *TestimonialRepository) GetTestimonials(ctx context.Context, approvedOnly bool) ([]models.Testimonial, error) {
+	db := r.db.WithContext(ctx)
+	var testimonials []models.Testimonial
+	query := db.Preload("CompanyDetails").Order("created_at DESC")
+
+	if approvedOnly {
+		query = query.Where("approved = ?", true)
+	}
+
+	err := query.Find(&testimonials).Error
+	if err != nil {
+		return nil, err
+	}
+	return testimonials, nil
+}
+
+func (r *TestimonialRepository) CreateTestimonial(ctx context.Context, testimonial *models.Testimonial) (*models.Testimonial, error) {
+	db := r.db.WithContext(ctx)
+	var err error
+	testimonial.Company, err = companyName(db, testimonial.CompanyID, testimonial.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Create(testimonial).Error
+	if err != nil {
+		return nil, err
+	}
+	return testimonial, primary(db).Preload("CompanyDetails").First(testimonial, testimonial.ID).Error
+}
+
+func (r *TestimonialRepository) UpdateTestimonial(ctx context.Context, id uint, testimonial *models.Testimonial) (*models.Testimonial, error) {
+	db := r.db.WithContext(ctx)
+	var existingTestimonial models.Testimonial
+	err := primary(db).First(&existingTestimonial, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("testimonial not found")
+		}
+		return nil, err
+	}
+
+	testimonial.Company, err = companyName(db, testimonial.CompanyID, testimonial.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	testimonial.ID = id
+	testimonial.CreatedAt = existingTestimonial.CreatedAt
+	err = db.Save(testimonial).Error
+	if err != nil {
+		return nil, err
+	}
+	return testimonial, primary(db).Preload("CompanyDetails").First(testimonial, id).Error
+}
+
+// SetApproved changes the moderation state of a testimonial
+func (r *TestimonialRepository) SetApproved(ctx context.Context, id uint, approved bool) (*models.Testimonial, error) {
+	db := r.db.WithContext(ctx)
+	var testimonial models.Testimonial
+	err := primary(db).First(&testimonial, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("testimonial not found")
+		}
+		return nil, err
+	}
+
+	err = db.Model(&testimonial).Update("approved", approved).Error
+	if err != nil {
+		return nil, err
+	}
+	return &testimonial, nil
+}
+
+func (r *TestimonialRepository) DeleteTestimonial(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var testimonial models.Testimonial
+	err := primary(db).First(&testimonial, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("testimonial not found")
+		}
+		return err
+	}
+
+	err = db.Delete(&testimonial).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"stackwhiz-portfolio-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Snapshot is the portfolio content covered by export and import
+type Snapshot struct {
+	Profile     *models.Profile     `json:"profile"`
+	Experiences []models.Experience `json:"experiences"`
+	Skills      []models.Skill      `json:"skills"`
+	Categories  []models.Category   `json:"categories"`
+	Projects    []models.Project    `json:"projects"`
+}
+
+// snapshotJoins are the join tables emptied before a restore, each through
+// the rows owning its entries
+var snapshotJoins = []struct {
+	table  string
+	column string
+	owner  interface{}
+}{
+	{"experience_skills", "experience_id", &models.Experience{}},
+	{"project_skills", "project_id", &models.Project{}},
+	{"project_tags", "project_id", &models.Project{}},
+}
+
+// snapshotModels are emptied before a restore, after the join tables
+var snapshotModels = []interface{}{
+	&models.ProjectImage{}, &models.ProjectMilestone{}, &models.Project{}, &models.Experience{}, &models.Skill{}, &models.Category{}, &models.Profile{},
+}
+
+// TransferRepository reads and replaces the portfolio content as a whole
+type TransferRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+// Export loads every profile, experience, skill, category and project,
+// drafts included
+func (r *TransferRepository) Export(ctx context.Context) (*Snapshot, error) {
+	db := r.db.WithContext(ctx)
+	snapshot := &Snapshot{}
+
+	var profile models.Profile
+	err := db.First(&profile).Error
+	if err == nil {
+		snapshot.Profile = &profile
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err := db.Preload("Skills", orderSkills).Preload("CompanyDetails").Order("id").Find(&snapshot.Experiences).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Order("id").Find(&snapshot.Skills).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Order("id").Find(&snapshot.Categories).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Preload("Tags").Preload("Skills", orderSkills).Preload("Images", orderImages).Preload("Milestones", orderMilestones).Order("id").Find(&snapshot.Projects).Error; err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Restore replaces the content of the tenant in ctx with the snapshot. Rows
+// are stored under new IDs with the references between them carried over, so
+// a snapshot can be restored into any tenant. It deletes before it inserts,
+// so it must run inside UnitOfWork.WithTransaction.
+func (r *TransferRepository) Restore(ctx context.Context, snapshot *Snapshot) error {
+	db := r.db.WithContext(ctx)
+	if err := snapshot.check(); err != nil {
+		return err
+	}
+
+	// Join rows have no tenant of their own, so they go through their owners
+	for _, join := range snapshotJoins {
+		owners := db.Model(join.owner).Select("id")
+		if err := db.Exec("DELETE FROM "+join.table+" WHERE "+join.column+" IN (?)", owners).Error; err != nil {
+			return err
+		}
+	}
+	for _, model := range snapshotModels {
+		if err := db.Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	if snapshot.Profile != nil {
+		snapshot.Profile.ID = 0
+		if err := db.Create(snapshot.Profile).Error; err != nil {
+			return err
+		}
+	}
+
+	categoryIDs := make(map[uint]uint, len(snapshot.Categories))
+	for i := range snapshot.Categories {
+		category := &snapshot.Categories[i]
+		exportedID := category.ID
+		category.ID = 0
+		if err := db.Create(category).Error; err != nil {
+			return err
+		}
+		categoryIDs[exportedID] = category.ID
+	}
+
+	skillIDs := make(map[uint]uint, len(snapshot.Skills))
+	for i := range snapshot.Skills {
+		skill := &snapshot.Skills[i]
+		exportedID := skill.ID
+		skill.ID = 0
+		if err := db.Create(skill).Error; err != nil {
+			return err
+		}
+		skillIDs[exportedID] = skill.ID
+	}
+
+	for i := range snapshot.Experiences {
+		experience := &snapshot.Experiences[i]
+		experience.ID = 0
+
+		// Companies are shared with testimonials, so they are matched by name
+		company, err := resolveCompany(db, experience.CompanyDetails)
+		if err != nil {
+			return err
+		}
+		experience.CompanyID = company
+		if err := db.Omit(clause.Associations).Create(experience).Error; err != nil {
+			return err
+		}
+		for _, skill := range experience.Skills {
+			if err := db.Exec("INSERT INTO experience_skills (experience_id, skill_id) VALUES (?, ?)", experience.ID, skillIDs[skill.ID]).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range snapshot.Projects {
+		project := &snapshot.Projects[i]
+		project.ID = 0
+		project.Category = nil
+		if project.CategoryID != nil {
+			categoryID := categoryIDs[*project.CategoryID]
+			project.CategoryID = &categoryID
+		}
+		if err := db.Omit(clause.Associations).Create(project).Error; err != nil {
+			return err
+		}
+		for _, skill := range project.Skills {
+			if err := db.Exec("INSERT INTO project_skills (project_id, skill_id) VALUES (?, ?)", project.ID, skillIDs[skill.ID]).Error; err != nil {
+				return err
+			}
+		}
+		for j := range project.Images {
+			image := &project.Images[j]
+			image.ID = 0
+			image.ProjectID = project.ID
+			if err := db.Create(image).Error; err != nil {
+				return err
+			}
+		}
+		for j := range project.Milestones {
+			milestone := &project.Milestones[j]
+			milestone.ID = 0
+			milestone.ProjectID = project.ID
+			if err := db.Create(milestone).Error; err != nil {
+				return err
+			}
+		}
+
+		// Tags are shared with posts and talks, so they are matched by slug
+		tags, err := resolveTags(db, project.Tags)
+		if err != nil {
+			return err
+		}
+		if err := db.Model(project).Association("Tags").Replace(tags); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// check verifies that the snapshot only references entries it contains
+func (s *Snapshot) check() error {
+	skills := make(map[uint]bool, len(s.Skills))
+	for _, skill := range s.Skills {
+		if skill.ID == 0 {
+			return errors.New("invalid import: skill without id")
+		}
+		skills[skill.ID] = true
+	}
+
+	categories := make(map[uint]bool, len(s.Categories))
+	for _, category := range s.Categories {
+		if category.ID == 0 {
+			return errors.New("invalid import: category without id")
+		}
+		categories[category.ID] = true
+	}
+
+	for _, experience := range s.Experiences {
+		if experience.ID == 0 {
+			return errors.New("invalid import: experience without id")
+		}
+		for _, skill := range experience.Skills {
+			if !skills[skill.ID] {
+				return fmt.Errorf("invalid import: experience %d references unknown skill %d", experience.ID, skill.ID)
+			}
+		}
+	}
+
+	for _, project := range s.Projects {
+		if project.ID == 0 {
+			return errors.New("invalid import: project without id")
+		}
+		if project.CategoryID != nil && !categories[*project.CategoryID] {
+			return fmt.Errorf("invalid import: project %d references unknown category %d", project.ID, *project.CategoryID)
+		}
+		for _, skill := range project.Skills {
+			if !skills[skill.ID] {
+				return fmt.Errorf("invalid import: project %d references unknown skill %d", project.ID, skill.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveCompany returns the ID of the existing company with the exported
+// company's name, regardless of case, creating it when there is none. It
+// returns nil for an experience without a company.
+func resolveCompany(db *gorm.DB, exported *models.Company) (*uint, error) {
+	if exported == nil || exported.Name == "" {
+		return nil, nil
+	}
+
+	var company models.Company
+	err := db.Where("LOWER(name) = LOWER(?)", exported.Name).
+		Attrs(models.Company{Name: exported.Name, Logo: exported.Logo, URL: exported.URL, Industry: exported.Industry}).
+		FirstOrCreate(&company).Error
+	if err != nil {
+		return nil, err
+	}
+	return &company.ID, nil
+}
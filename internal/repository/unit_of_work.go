@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// Repositories groups the repositories that take part in a unit of work.
+// Inside WithTransaction every one of them is bound to the same transaction.
+type Repositories struct {
+	Profile       *ProfileRepository
+	Experience    *ExperienceRepository
+	Education     *EducationRepository
+	Skill         *SkillRepository
+	Project       *ProjectRepository
+	Certification *CertificationRepository
+	Publication   *PublicationRepository
+	Award         *AwardRepository
+	Category      *CategoryRepository
+	Tag           *TagRepository
+	Transfer      *TransferRepository
+	Newsletter    *NewsletterRepository
+}
+
+func newRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Profile:       NewProfileRepository(db),
+		Experience:    NewExperienceRepository(db),
+		Education:     NewEducationRepository(db),
+		Skill:         NewSkillRepository(db),
+		Project:       NewProjectRepository(db),
+		Certification: NewCertificationRepository(db),
+		Publication:   NewPublicationRepository(db),
+		Award:         NewAwardRepository(db),
+		Category:      NewCategoryRepository(db),
+		Tag:           NewTagRepository(db),
+		Transfer:      NewTransferRepository(db),
+		Newsletter:    NewNewsletterRepository(db),
+	}
+}
+
+// UnitOfWork runs operations spanning several repositories atomically
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// WithTransaction calls fn with repositories bound to a new transaction. The
+// transaction commits when fn returns nil and rolls back when it returns an
+// error or panics. Transactions always run on the primary database.
+func (u *UnitOfWork) WithTransaction(ctx context.Context, fn func(repos *Repositories) error, opts ...*sql.TxOptions) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(newRepositories(tx))
+	}, opts...)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository handles webhook subscription and delivery data operations
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	db := r.db.WithContext(ctx)
+	var subscriptions []models.WebhookSubscription
+	err := db.Order("created_at DESC").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) GetActiveSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	db := r.db.WithContext(ctx)
+	var subscriptions []models.WebhookSubscription
+	err := db.Where("active = ?", true).Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	db := r.db.WithContext(ctx)
+	var subscription models.WebhookSubscription
+	err := db.First(&subscription, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, id uint, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	db := r.db.WithContext(ctx)
+	var existingSubscription models.WebhookSubscription
+	err := primary(db).First(&existingSubscription, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook not found")
+		}
+		return nil, err
+	}
+
+	subscription.ID = id
+	subscription.CreatedAt = existingSubscription.CreatedAt
+	if subscription.Secret == "" {
+		subscription.Secret = existingSubscription.Secret
+	}
+	err = db.Save(subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id uint) error {
+	db := r.db.WithContext(ctx)
+	var subscription models.WebhookSubscription
+	err := primary(db).First(&subscription, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("webhook not found")
+		}
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&subscription).Error
+	})
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	db := r.db.WithContext(ctx)
+	err := db.Create(delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID uint, limit int) ([]models.WebhookDelivery, error) {
+	db := r.db.WithContext(ctx)
+	var deliveries []models.WebhookDelivery
+	err := db.Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetDueDeliveries returns deliveries whose next attempt is due, including
+// in-flight ones whose lease expired
+func (r *WebhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	db := r.db.WithContext(ctx)
+	var deliveries []models.WebhookDelivery
+	err := primary(db).Where("status IN ? AND next_attempt_at <= ?", []string{"pending", "delivering"}, now).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery marks a due delivery as in flight until leaseUntil, after
+// which it is due again. A delivery is due when pending or when the lease
+// of the worker delivering it expired. It returns false when another worker
+// (possibly on another replica) claimed it first.
+func (r *WebhookRepository) ClaimDelivery(ctx context.Context, id uint, now, leaseUntil time.Time) (bool, error) {
+	db := r.db.WithContext(ctx)
+	result := db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status IN ? AND next_attempt_at <= ?", id, []string{"pending", "delivering"}, now).
+		Updates(map[string]interface{}{"status": "delivering", "next_attempt_at": leaseUntil})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	db := r.db.WithContext(ctx)
+	return db.Save(delivery).Error
+}
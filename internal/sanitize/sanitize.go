@@ -0,0 +1,211 @@
+// Package sanitize cleans user-supplied rich text before it is stored, so
+// fields that clients may render as HTML cannot carry scripts or other
+// dangerous markup.
+package sanitize
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"stackwhiz-portfolio-backend/internal/markdown"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// DefaultPolicies assigns a policy to every text field that may be rendered
+// as HTML, keyed by "table.column". The profile summary, project long
+// descriptions and post bodies are Markdown, which HTML sanitizing would
+// mangle, so only the raw HTML is removed from them.
+var DefaultPolicies = map[string]string{
+	"profiles.summary":               "markdown",
+	"experiences.description":        "ugc",
+	"projects.description":           "ugc",
+	"projects.long_description":      "markdown",
+	"project_milestones.description": "ugc",
+	"contacts.message":               "strict",
+	"posts.summary":                  "ugc",
+	"posts.body":                     "markdown",
+	"testimonials.text":              "basic",
+	"educations.description":         "ugc",
+	"awards.description":             "ugc",
+	"services.description":           "ugc",
+	"publications.abstract":          "ugc",
+	"talks.abstract":                 "ugc",
+}
+
+// policies are the cleaners a field can use. "strict" is for plain text
+// and removes markup without escaping what is left, and "markdown" removes
+// raw HTML from Markdown source; "basic" and "ugc" keep the elements they
+// allow and escape the rest as HTML. "none" stores the value verbatim.
+var policies = map[string]func(string) string{
+	"strict":   stripTags,
+	"markdown": markdown.StripHTML,
+	"basic":    basicPolicy().Sanitize,
+	"ugc":      bluemonday.UGCPolicy().Sanitize,
+	"none":     nil,
+}
+
+// tag matches an HTML comment, or a tag or declaration. A "<" that starts
+// none of them, as in "a<b", is text.
+var tag = regexp.MustCompile(`<!--[\s\S]*?-->|<[!?/]?[a-zA-Z][^<>]*>`)
+
+// stripTags removes markup from plain text. Removing a tag can join the
+// pieces of another, as in "<scr<b>ipt>", so it repeats until nothing
+// changes.
+func stripTags(s string) string {
+	for {
+		stripped := tag.ReplaceAllString(s, "")
+		if stripped == s {
+			return s
+		}
+		s = stripped
+	}
+}
+
+// basicPolicy allows inline formatting, paragraphs, lists and links
+func basicPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "b", "strong", "i", "em", "u", "code", "ul", "ol", "li", "blockquote")
+	p.AllowStandardURLs()
+	p.AllowAttrs("href").OnElements("a")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// ParsePolicies applies "table.column=policy" pairs separated by "," on top
+// of DefaultPolicies
+func ParsePolicies(s string) (map[string]string, error) {
+	fields := make(map[string]string, len(DefaultPolicies))
+	for field, policy := range DefaultPolicies {
+		fields[field] = policy
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, policy, ok := strings.Cut(pair, "=")
+		field, policy = strings.TrimSpace(field), strings.ToLower(strings.TrimSpace(policy))
+		if !ok || !strings.Contains(field, ".") {
+			return nil, fmt.Errorf("%q is not table.column=policy", pair)
+		}
+		if _, known := policies[policy]; !known {
+			return nil, fmt.Errorf("unknown policy %q for %s; use %s", policy, field, policyNames())
+		}
+		fields[field] = policy
+	}
+	return fields, nil
+}
+
+func policyNames() string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Plugin sanitizes the configured string fields of rows as they are created
+// or saved, and of the values passed to Update and Updates
+type Plugin struct {
+	fields map[string]func(string) string // Keyed by "table.column"
+}
+
+// NewPlugin returns a plugin applying the given policy names, as returned by
+// ParsePolicies
+func NewPlugin(fields map[string]string) *Plugin {
+	p := &Plugin{fields: make(map[string]func(string) string, len(fields))}
+	for field, name := range fields {
+		if clean := policies[name]; clean != nil {
+			p.fields[field] = clean
+		}
+	}
+	return p
+}
+
+func (p *Plugin) Name() string {
+	return "sanitize"
+}
+
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("sanitize:create", p.sanitize); err != nil {
+		return err
+	}
+	return callbacks.Update().Before("gorm:update").Register("sanitize:update", p.sanitize)
+}
+
+func (p *Plugin) sanitize(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	table := db.Statement.Schema.Table
+
+	// Update and Updates with column names carry the new values in a map,
+	// and the model only holds what was loaded
+	if values, ok := db.Statement.Dest.(map[string]interface{}); ok {
+		for name, value := range values {
+			text, isString := value.(string)
+			field := db.Statement.Schema.LookUpField(name)
+			if !isString || field == nil {
+				continue
+			}
+			if clean := p.fields[table+"."+field.DBName]; clean != nil {
+				values[name] = clean(text)
+			}
+		}
+		return
+	}
+
+	var fields []*schema.Field
+	var cleaners []func(string) string
+	for _, field := range db.Statement.Schema.Fields {
+		clean := p.fields[table+"."+field.DBName]
+		if clean != nil && field.FieldType.Kind() == reflect.String {
+			fields = append(fields, field)
+			cleaners = append(cleaners, clean)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	clean := func(rv reflect.Value) {
+		rv = reflect.Indirect(rv)
+		if rv.Kind() != reflect.Struct || !rv.CanAddr() {
+			return
+		}
+		for i, field := range fields {
+			value, zero := field.ValueOf(db.Statement.Context, rv)
+			if zero {
+				continue
+			}
+			if err := field.Set(db.Statement.Context, rv, cleaners[i](value.(string))); err != nil {
+				db.AddError(err)
+			}
+		}
+	}
+	cleanAll := func(rv reflect.Value) {
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				clean(rv.Index(i))
+			}
+		case reflect.Struct:
+			clean(rv)
+		}
+	}
+
+	cleanAll(db.Statement.ReflectValue)
+	// Updates with a struct other than the model writes that struct's fields
+	if dest := reflect.ValueOf(db.Statement.Dest); dest.Kind() == reflect.Ptr && db.Statement.Dest != db.Statement.Model {
+		cleanAll(dest.Elem())
+	}
+}
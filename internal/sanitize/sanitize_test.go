@@ -0,0 +1,92 @@
+package sanitize
+
+import (
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/database"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Tom & Jerry's a<b", "Tom & Jerry's a<b"},
+		{"1 < 2 > 0", "1 < 2 > 0"},
+		{`Hi <b onclick="x()">there</b><br/>`, "Hi there"},
+		{"<script>alert(1)</script>", "alert(1)"},
+		{"<scr<b>ipt>alert(1)</script>", "alert(1)"},
+		{"before<!-- hidden <b> -->after", "beforeafter"},
+	}
+	for _, tt := range tests {
+		if got := stripTags(tt.in); got != tt.want {
+			t.Errorf("stripTags(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+type note struct {
+	ID      uint
+	Message string
+	Body    string
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	dialector, err := database.SQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(NewPlugin(map[string]string{"notes.message": "strict", "notes.body": "ugc"})); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&note{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestPluginCleansEveryWrite(t *testing.T) {
+	db := newTestDB(t)
+	load := func(id uint) note {
+		var n note
+		if err := db.First(&n, id).Error; err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	n := note{Message: "Tom & Jerry's a<b <i>x</i>", Body: `<p onclick="x()">Hi</p><script>alert(1)</script>`}
+	if err := db.Create(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got := load(n.ID); got.Message != "Tom & Jerry's a<b x" || got.Body != "<p>Hi</p>" {
+		t.Errorf("created %+v", got)
+	}
+
+	if err := db.Model(&note{}).Where("id = ?", n.ID).Update("message", "<b>bold</b> & done").Error; err != nil {
+		t.Fatal(err)
+	}
+	if got := load(n.ID); got.Message != "bold & done" {
+		t.Errorf("Update stored message %q", got.Message)
+	}
+
+	err := db.Model(&note{}).Where("id = ?", n.ID).Updates(map[string]interface{}{"Message": "<i>a</i>", "body": "<img src=x onerror=y>"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := load(n.ID); got.Message != "a" || got.Body != `<img src="x">` {
+		t.Errorf("Updates stored %+v", got)
+	}
+
+	if err := db.Model(&note{ID: n.ID}).Updates(&note{Message: "<u>u</u>"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if got := load(n.ID); got.Message != "u" {
+		t.Errorf("Updates with a struct stored message %q", got.Message)
+	}
+}
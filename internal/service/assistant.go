@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"stackwhiz-portfolio-backend/internal/github"
+	"stackwhiz-portfolio-backend/internal/llm"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"strings"
+)
+
+const (
+	// assistantReadmeLimit caps the README text sent to the model, in runes
+	assistantReadmeLimit = 12000
+	assistantMaxTokens   = 600
+)
+
+const projectDescriptionPrompt = `You write project descriptions for a software developer's portfolio.
+Using only facts from the README and project details you are given, write a description of one or two short paragraphs, at most 80 words, that says what the project does, how it is built and what stands out about it.
+Write plain text without headings, lists, links or Markdown. Do not invent features, numbers or users. Answer with the description only.`
+
+const profileSummaryPrompt = `You edit the professional summary on a software developer's portfolio.
+Tighten the summary you are given: remove filler and repetition, prefer concrete statements and keep it under 90 words. Keep every fact, the first person or third person voice it uses and any Markdown it uses, and add nothing that is not in it.
+Answer with the revised summary only.`
+
+// AssistantService asks a language model to draft portfolio copy. Drafts
+// are returned for review and never saved.
+type AssistantService struct {
+	projects *repository.ProjectRepository
+	profiles *repository.ProfileRepository
+	github   *github.Client
+	llm      *llm.Client // nil when no provider is configured
+}
+
+func NewAssistantService(projects *repository.ProjectRepository, profiles *repository.ProfileRepository, github *github.Client, llm *llm.Client) *AssistantService {
+	return &AssistantService{
+		projects: projects,
+		profiles: profiles,
+		github:   github,
+		llm:      llm,
+	}
+}
+
+type WritingRequest struct {
+	Instructions string `json:"instructions" binding:"max=500"` // Extra guidance such as tone or what to stress
+}
+
+// WritingSuggestion is a draft of one field, to be reviewed and saved
+// through the usual update endpoints
+type WritingSuggestion struct {
+	Field      string `json:"field"`   // Field the draft is for, e.g. description
+	Current    string `json:"current"` // Value of the field now
+	Suggestion string `json:"suggestion"`
+	Source     string `json:"source,omitempty"` // Where the facts came from, e.g. the repository
+	Model      string `json:"model"`
+}
+
+// SuggestProjectDescription drafts a project description from the README
+// of the project's GitHub repository
+func (s *AssistantService) SuggestProjectDescription(ctx context.Context, id uint, req *WritingRequest) (*WritingSuggestion, error) {
+	if s.llm == nil {
+		return nil, errors.New("assistant not configured")
+	}
+	project, err := s.projects.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	owner, name, ok := github.ParseRepoURL(project.GitHubURL)
+	if !ok {
+		return nil, errors.New("no github repository")
+	}
+
+	readme, err := s.github.GetReadme(ctx, owner, name)
+	if err != nil {
+		log.Printf("Warning: failed to fetch README of %s/%s: %v", owner, name, err)
+		return nil, errors.New("readme unavailable")
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Project: %s\n", project.Name)
+	if len(project.Technologies) > 0 {
+		fmt.Fprintf(&prompt, "Technologies: %s\n", strings.Join(project.Technologies, ", "))
+	}
+	if project.Description != "" {
+		fmt.Fprintf(&prompt, "Current description: %s\n", project.Description)
+	}
+	fmt.Fprintf(&prompt, "\nREADME:\n%s\n", truncateText(readme, assistantReadmeLimit))
+	writeInstructions(&prompt, req)
+
+	suggestion, err := s.complete(ctx, projectDescriptionPrompt, prompt.String())
+	if err != nil {
+		return nil, err
+	}
+	return &WritingSuggestion{
+		Field:      "description",
+		Current:    project.Description,
+		Suggestion: suggestion,
+		Source:     project.GitHubURL,
+		Model:      s.llm.Model(),
+	}, nil
+}
+
+// SuggestProfileSummary drafts a tighter version of the profile summary
+func (s *AssistantService) SuggestProfileSummary(ctx context.Context, req *WritingRequest) (*WritingSuggestion, error) {
+	if s.llm == nil {
+		return nil, errors.New("assistant not configured")
+	}
+	profile, err := s.profiles.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(profile.Summary) == "" {
+		return nil, errors.New("empty summary")
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Name: %s\nTitle: %s\n\nSummary:\n%s\n", profile.Name, profile.Title, profile.Summary)
+	writeInstructions(&prompt, req)
+
+	suggestion, err := s.complete(ctx, profileSummaryPrompt, prompt.String())
+	if err != nil {
+		return nil, err
+	}
+	return &WritingSuggestion{
+		Field:      "summary",
+		Current:    profile.Summary,
+		Suggestion: suggestion,
+		Model:      s.llm.Model(),
+	}, nil
+}
+
+func (s *AssistantService) complete(ctx context.Context, system, prompt string) (string, error) {
+	answer, err := s.llm.Complete(ctx, system, []llm.Message{{Role: "user", Content: prompt}}, assistantMaxTokens)
+	if err != nil {
+		log.Printf("Warning: language model request failed: %v", err)
+		return "", errors.New("assistant unavailable")
+	}
+	return answer, nil
+}
+
+// writeInstructions adds the admin's guidance to a prompt
+func writeInstructions(prompt *strings.Builder, req *WritingRequest) {
+	if instructions := strings.TrimSpace(req.Instructions); instructions != "" {
+		fmt.Fprintf(prompt, "\nAlso follow these instructions: %s\n", instructions)
+	}
+}
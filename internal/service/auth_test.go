@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"testing"
+)
+
+func newTestAuthService(t *testing.T) *AuthService {
+	dialector, err := database.SQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	db, err := database.Initialize(dialector, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs, client := newTestJobService(t)
+	return NewAuthService(repository.NewUserRepository(db), repository.NewLoginEventRepository(db), "secret", client, jobs, events.NewBus(), nil, LoginAlerts{})
+}
+
+func TestLoginNeedsAnAdminUser(t *testing.T) {
+	s := newTestAuthService(t)
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+
+	if _, err := s.Login(ctx, &LoginRequest{Username: "admin", Password: "anything"}); err == nil {
+		t.Fatal("login succeeded without any admin user")
+	}
+
+	hasAdmin, err := s.EnsureAdmin(ctx, "admin", "admin@localhost", "")
+	if err != nil || hasAdmin {
+		t.Fatalf("EnsureAdmin without a password = %v, %v; want no admin", hasAdmin, err)
+	}
+	if hasAdmin, err = s.EnsureAdmin(ctx, "admin", "admin@localhost", "correct horse"); err != nil || !hasAdmin {
+		t.Fatalf("EnsureAdmin = %v, %v; want an admin", hasAdmin, err)
+	}
+	// A later password does not replace the existing admin's
+	if hasAdmin, err = s.EnsureAdmin(ctx, "other", "other@localhost", "battery staple"); err != nil || !hasAdmin {
+		t.Fatalf("EnsureAdmin again = %v, %v; want an admin", hasAdmin, err)
+	}
+
+	if _, err := s.Login(ctx, &LoginRequest{Username: "admin", Password: "battery staple"}); err == nil {
+		t.Error("login succeeded with a wrong password")
+	}
+	if _, err := s.Login(ctx, &LoginRequest{Username: "other", Password: "battery staple"}); err == nil {
+		t.Error("login succeeded as a user that was never created")
+	}
+	resp, err := s.Login(ctx, &LoginRequest{Username: "admin", Password: "correct horse"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.User.Username != "admin" || resp.Token == "" {
+		t.Errorf("login returned %+v", resp)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/models"
+	"text/template"
+)
+
+// defaultAutoReplyTemplate is used when no template file is configured.
+// Templates are rendered with the stored models.Contact.
+const defaultAutoReplyTemplate = `Hi {{.Name}},
+
+Thanks for getting in touch{{if .Subject}} about "{{.Subject}}"{{end}}. This is an automatic confirmation that your message arrived; I'll get back to you personally as soon as I can.
+
+For reference, here is what you sent:
+
+{{.Message}}
+`
+
+// AutoReply renders the acknowledgment emailed to contact form submitters
+type AutoReply struct {
+	subject  string
+	template *template.Template
+}
+
+// NewAutoReply parses the template file at templatePath, falling back to the
+// built-in template when the path is empty
+func NewAutoReply(subject, templatePath string) (*AutoReply, error) {
+	text := defaultAutoReplyTemplate
+	if templatePath != "" {
+		data, err := os.ReadFile(templatePath)
+		if err != nil {
+			return nil, err
+		}
+		text = string(data)
+	}
+
+	tmpl, err := template.New("auto-reply").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &AutoReply{subject: subject, template: tmpl}, nil
+}
+
+func (a *AutoReply) message(contact *models.Contact) (*mailer.Message, error) {
+	var body bytes.Buffer
+	if err := a.template.Execute(&body, contact); err != nil {
+		return nil, fmt.Errorf("failed to render auto-reply: %w", err)
+	}
+	return &mailer.Message{
+		To:      []string{contact.Email},
+		Subject: a.subject,
+		Body:    body.String(),
+	}, nil
+}
+
+// autoReplyJob is the payload of an auto_reply job
+type autoReplyJob struct {
+	ContactID uint            `json:"contact_id"`
+	Message   *mailer.Message `json:"message"`
+}
+
+// sendAutoReply queues an acknowledgment of a submission. The job records
+// it on the contact once the mail server has accepted the message.
+func (s *ContactService) sendAutoReply(ctx context.Context, contact *models.Contact) {
+	if s.autoReply == nil {
+		return
+	}
+
+	msg, err := s.autoReply.message(contact)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+
+	if err := s.jobs.Enqueue(ctx, JobAutoReply, autoReplyJob{ContactID: contact.ID, Message: msg}); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+func (s *ContactService) runAutoReply(ctx context.Context, payload json.RawMessage) error {
+	var job autoReplyJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	if err := s.mailer.Send(job.Message); err != nil {
+		return err
+	}
+	// A disabled mailer drops the message, so nothing was sent
+	if !s.mailer.Enabled() {
+		return nil
+	}
+	if err := s.repo.MarkAutoReplied(ctx, job.ContactID); err != nil {
+		log.Printf("Warning: failed to flag auto-reply on contact %d: %v", job.ContactID, err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"io"
+	"log"
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/imaging"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+)
+
+// avatarVariants are the square copies made of a cropped avatar, keyed by
+// name with their side in pixels
+var avatarVariants = []struct {
+	name string
+	size int
+}{
+	{"small", 64},
+	{"medium", 256},
+	{"large", 512},
+}
+
+// AvatarCrop is the rectangle of an uploaded image kept for the avatar, in
+// pixels from its top left corner. A rectangle that is not square is
+// trimmed evenly on its longer side, and an empty one keeps the largest
+// centered square of the image.
+type AvatarCrop struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// within returns the crop in the coordinates of an image with the given
+// bounds
+func (c AvatarCrop) within(bounds image.Rectangle) (image.Rectangle, error) {
+	if c == (AvatarCrop{}) {
+		return bounds, nil
+	}
+	if c.X < 0 || c.Y < 0 || c.Width <= 0 || c.Height <= 0 {
+		return image.Rectangle{}, errors.New("invalid crop")
+	}
+	rect := image.Rect(c.X, c.Y, c.X+c.Width, c.Y+c.Height).Add(bounds.Min)
+	if !rect.In(bounds) {
+		return image.Rectangle{}, errors.New("invalid crop")
+	}
+	return rect, nil
+}
+
+// UploadAvatar stores an image as uploaded, together with square variants
+// of the cropped part. Unlike other uploads the variants are made before
+// returning, since the avatar points at them straight away.
+func (s *MediaService) UploadAvatar(ctx context.Context, filename string, r io.Reader, crop AvatarCrop) (*models.Media, error) {
+	// Read one byte past the limit so oversized uploads can be detected
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, errors.New("file too large")
+	}
+
+	mimeType := http.DetectContentType(data)
+	ext, ok := mediaExtensions[mimeType]
+	if !ok || !strings.HasPrefix(mimeType, "image/") {
+		return nil, errors.New("unsupported file type")
+	}
+
+	s.resizeSlots <- struct{}{}
+	defer func() { <-s.resizeSlots }()
+
+	src, err := imaging.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("invalid image")
+	}
+	rect, err := crop.within(src.Bounds())
+	if err != nil {
+		return nil, err
+	}
+	square := imaging.Crop(src, rect)
+
+	name, err := models.GenerateRandomString(16)
+	if err != nil {
+		return nil, err
+	}
+	storedName := name + ext
+	if err := s.storage.Put(storedName, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(data)
+	media, err := s.repo.CreateMedia(ctx, &models.Media{
+		Filename:   filename,
+		StoredName: storedName,
+		Size:       int64(len(data)),
+		MimeType:   mimeType,
+		Checksum:   hex.EncodeToString(checksum[:]),
+		URL:        "/media/" + storedName,
+	})
+	if err != nil {
+		s.removeObject(storedName)
+		return nil, err
+	}
+
+	keepAlpha := mimeType == "image/png" || mimeType == "image/gif"
+	for _, spec := range avatarVariants {
+		variant, err := s.storeVariant(ctx, media, spec.name, imaging.Fit(square, spec.size), keepAlpha)
+		if err != nil {
+			s.discard(ctx, media.ID)
+			return nil, err
+		}
+		media.Variants = append(media.Variants, *variant)
+	}
+	return media, nil
+}
+
+// discard deletes media that could not be put to use, logging failures
+func (s *MediaService) discard(ctx context.Context, id uint) {
+	if err := s.DeleteMedia(context.WithoutCancel(ctx), id); err != nil {
+		log.Printf("Warning: failed to discard media %d: %v", id, err)
+	}
+}
+
+// UploadAvatar crops an uploaded image into the avatar and points the
+// profile at it. Only the avatar columns change, so edits made to the rest
+// of the profile meanwhile are kept. The upload is discarded if the profile
+// cannot be updated.
+func (s *ProfileService) UploadAvatar(ctx context.Context, filename string, r io.Reader, crop AvatarCrop) (*models.Profile, error) {
+	media, err := s.media.UploadAvatar(ctx, filename, r, crop)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make(map[string]string, len(media.Variants))
+	for _, variant := range media.Variants {
+		variants[variant.Name] = variant.URL
+	}
+
+	updatedProfile, err := s.repo.UpdateAvatar(ctx, variants["large"], variants)
+	if err != nil {
+		s.media.discard(ctx, media.ID)
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "profile")
+
+	s.events.Publish(ctx, events.ProfileUpdated, updatedProfile)
+
+	return updatedProfile, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AwardService handles award-related operations
+type AwardService struct {
+	repo   *repository.AwardRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewAwardService(repo *repository.AwardRepository, redis *redis.Client, events *events.Bus) *AwardService {
+	return &AwardService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+func (s *AwardService) GetAwards(ctx context.Context) ([]models.Award, error) {
+	// Try to get from cache first
+	var awards []models.Award
+	if cacheGet(ctx, s.redis, "awards", tenant.CacheKey(ctx, "awards"), &awards) {
+		return awards, nil
+	}
+
+	// Get from database
+	awards, err := s.repo.GetAwards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	awardsJSON, _ := json.Marshal(awards)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "awards"), awardsJSON, contentCacheTTL())
+
+	return awards, nil
+}
+
+type AwardCreateRequest struct {
+	Title       string    `json:"title" binding:"required"`
+	Issuer      string    `json:"issuer"`
+	Date        time.Time `json:"date" binding:"required"`
+	Description string    `json:"description"`
+	Link        string    `json:"link" binding:"omitempty,url"`
+}
+
+func (s *AwardService) CreateAward(ctx context.Context, req *AwardCreateRequest) (*models.Award, error) {
+	createdAward, err := s.repo.CreateAward(ctx, newAward(req))
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "awards")
+
+	s.events.Publish(ctx, events.AwardCreated, createdAward)
+
+	return createdAward, nil
+}
+
+func newAward(req *AwardCreateRequest) *models.Award {
+	return &models.Award{
+		Title:       req.Title,
+		Issuer:      req.Issuer,
+		Date:        req.Date,
+		Description: req.Description,
+		Link:        req.Link,
+	}
+}
+
+type AwardUpdateRequest struct {
+	Title       string    `json:"title" binding:"required"`
+	Issuer      string    `json:"issuer"`
+	Date        time.Time `json:"date" binding:"required"`
+	Description string    `json:"description"`
+	Link        string    `json:"link" binding:"omitempty,url"`
+}
+
+func (s *AwardService) UpdateAward(ctx context.Context, id uint, req *AwardUpdateRequest) (*models.Award, error) {
+	award := &models.Award{
+		Title:       req.Title,
+		Issuer:      req.Issuer,
+		Date:        req.Date,
+		Description: req.Description,
+		Link:        req.Link,
+	}
+
+	updatedAward, err := s.repo.UpdateAward(ctx, id, award)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "awards")
+
+	s.events.Publish(ctx, events.AwardUpdated, updatedAward)
+
+	return updatedAward, nil
+}
+
+func (s *AwardService) DeleteAward(ctx context.Context, id uint) error {
+	err := s.repo.DeleteAward(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "awards")
+
+	s.events.Publish(ctx, events.AwardDeleted, map[string]uint{"id": id})
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/storage"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/robfig/cron/v3"
+	"gorm.io/gorm"
+)
+
+const (
+	backupPrefix    = "backups/"
+	backupStatusKey = "backups:status"
+	backupLockKey   = "backups:lock"
+	backupLockTTL   = 30 * time.Minute
+	backupTimeout   = 20 * time.Minute
+)
+
+// BackupStatus reports the backup schedule and the outcome of recent runs
+type BackupStatus struct {
+	Enabled       bool                 `json:"enabled"`
+	Schedule      string               `json:"schedule"`
+	Keep          int                  `json:"keep"`
+	NextRunAt     *time.Time           `json:"next_run_at"`
+	LastRunAt     *time.Time           `json:"last_run_at"`
+	LastSuccessAt *time.Time           `json:"last_success_at"`
+	LastError     string               `json:"last_error"`
+	LastBackup    string               `json:"last_backup"`
+	Backups       []storage.ObjectInfo `json:"backups"`
+}
+
+// BackupService dumps the database on a cron schedule, with pg_dump or, for
+// the embedded SQLite database, VACUUM INTO, compresses the dump and
+// uploads it to storage, keeping the most recent backups only
+type BackupService struct {
+	db          *gorm.DB
+	databaseURL string
+	store       storage.Storage // nil when backups are not configured
+	redis       *redis.Client
+	schedule    cron.Schedule
+	spec        string
+	keep        int
+}
+
+// NewBackupService parses the cron spec (standard five fields or
+// descriptors such as @daily). An empty spec or a nil store disables
+// backups.
+func NewBackupService(db *gorm.DB, databaseURL string, store storage.Storage, redis *redis.Client, spec string, keep int) (*BackupService, error) {
+	s := &BackupService{
+		db:          db,
+		databaseURL: databaseURL,
+		store:       store,
+		redis:       redis,
+		spec:        spec,
+		keep:        keep,
+	}
+	if spec == "" || store == nil {
+		s.store = nil
+		return s, nil
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, err
+	}
+	if keep < 1 {
+		return nil, errors.New("at least one backup must be kept")
+	}
+	s.schedule = schedule
+	return s, nil
+}
+
+func (s *BackupService) Enabled() bool {
+	return s.store != nil
+}
+
+// Run performs backups on schedule until the context is cancelled. With
+// several replicas running, a Redis lock lets only one of them back up.
+func (s *BackupService) Run(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+
+	for {
+		timer := time.NewTimer(time.Until(s.schedule.Next(time.Now())))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		locked, err := s.redis.SetNX(ctx, backupLockKey, 1, backupLockTTL).Result()
+		if err != nil || !locked {
+			continue
+		}
+		s.backup(ctx)
+		s.redis.Del(ctx, backupLockKey)
+	}
+}
+
+func (s *BackupService) backup(ctx context.Context) {
+	started := time.Now().UTC()
+	s.redis.HSet(ctx, backupStatusKey, "last_run_at", started.Format(time.RFC3339))
+
+	name, err := s.dumpAndUpload(ctx, started)
+	if err != nil {
+		log.Printf("Warning: database backup failed: %v", err)
+		s.redis.HSet(ctx, backupStatusKey, "last_error", err.Error())
+		return
+	}
+
+	s.redis.HSet(ctx, backupStatusKey,
+		"last_success_at", time.Now().UTC().Format(time.RFC3339),
+		"last_backup", name,
+		"last_error", "")
+
+	if err := s.rotate(); err != nil {
+		log.Printf("Warning: failed to rotate database backups: %v", err)
+	}
+}
+
+// dumpAndUpload streams the dump through gzip into storage, so the dump is
+// never held in memory as a whole
+func (s *BackupService) dumpAndUpload(ctx context.Context, started time.Time) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, backupTimeout)
+	defer cancel()
+
+	dump, extension := s.dumpPostgres, ".sql.gz"
+	if s.db.Dialector.Name() == "sqlite" {
+		dump, extension = s.dumpSQLite, ".sqlite.gz"
+	}
+
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		compressed := gzip.NewWriter(writer)
+		err := dump(ctx, compressed)
+		if err == nil {
+			err = compressed.Close()
+		}
+		// The upload fails with the dump's error instead of storing a
+		// truncated backup
+		writer.CloseWithError(err)
+	}()
+
+	// Timestamped names sort chronologically, which rotation relies on
+	name := backupPrefix + "portfolio-" + started.Format("20060102T150405Z") + extension
+	err := s.store.Put(name, reader)
+	if err != nil {
+		// Stops a dump the upload gave up on
+		reader.CloseWithError(err)
+		cancel()
+	}
+	<-done
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// dumpPostgres writes the output of pg_dump to w
+func (s *BackupService) dumpPostgres(ctx context.Context, w io.Writer) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pg_dump", "--no-owner", "--no-privileges", "--dbname", s.databaseURL)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_dump: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return nil
+}
+
+// dumpSQLite copies the embedded database with VACUUM INTO, which takes a
+// consistent snapshot while the server keeps writing, and writes the copy
+// to w
+func (s *BackupService) dumpSQLite(ctx context.Context, w io.Writer) error {
+	dir, err := os.MkdirTemp("", "portfolio-backup-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "portfolio.db")
+	if err := s.db.WithContext(ctx).Exec("VACUUM INTO ?", path).Error; err != nil {
+		return fmt.Errorf("vacuum into: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// rotate deletes all but the newest keep backups
+func (s *BackupService) rotate() error {
+	backups, err := s.store.List(backupPrefix)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(backups)-s.keep; i++ {
+		if err := s.store.Delete(backups[i].Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStatus returns the schedule, the outcome of the latest run and the
+// backups currently stored
+func (s *BackupService) GetStatus(ctx context.Context) (*BackupStatus, error) {
+	status := &BackupStatus{
+		Enabled:  s.Enabled(),
+		Schedule: s.spec,
+		Keep:     s.keep,
+		Backups:  []storage.ObjectInfo{},
+	}
+	if !s.Enabled() {
+		return status, nil
+	}
+
+	next := s.schedule.Next(time.Now()).UTC()
+	status.NextRunAt = &next
+
+	fields, err := s.redis.HGetAll(ctx, backupStatusKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	status.LastRunAt = parseBackupTime(fields["last_run_at"])
+	status.LastSuccessAt = parseBackupTime(fields["last_success_at"])
+	status.LastError = fields["last_error"]
+	status.LastBackup = fields["last_backup"]
+
+	backups, err := s.store.List(backupPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if backups != nil {
+		status.Backups = backups
+	}
+	return status, nil
+}
+
+func parseBackupTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
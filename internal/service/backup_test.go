@@ -0,0 +1,86 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/storage"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func newTestBackupService(t *testing.T) (*BackupService, *gorm.DB, storage.Storage) {
+	dialector, err := database.SQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("CREATE TABLE notes (text TEXT)").Error; err != nil {
+		t.Fatal(err)
+	}
+	store, err := storage.NewLocalStorage(storage.LocalConfig{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, client := newTestJobService(t)
+	s, err := NewBackupService(db, "", store, client, "@daily", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, db, store
+}
+
+func TestBackupSQLite(t *testing.T) {
+	s, _, store := newTestBackupService(t)
+
+	name, err := s.dumpAndUpload(context.Background(), time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "backups/portfolio-20261016T030000Z.sqlite.gz" {
+		t.Errorf("name = %q", name)
+	}
+
+	file, err := store.Get(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	dump, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("SQLite format 3\x00")) {
+		t.Errorf("backup is not an SQLite database")
+	}
+}
+
+func TestBackupFailureStoresNothing(t *testing.T) {
+	s, db, store := newTestBackupService(t)
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+
+	if _, err := s.dumpAndUpload(context.Background(), time.Now()); err == nil || !strings.Contains(err.Error(), "vacuum into") {
+		t.Fatalf("err = %v, want the dump's error", err)
+	}
+	if backups, _ := store.List(backupPrefix); len(backups) != 0 {
+		t.Errorf("failed backup stored %v", backups)
+	}
+}
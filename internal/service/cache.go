@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/metrics"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// cacheGet decodes the value cached under key into dest and reports whether
+// there was one. The lookup is recorded in the cache metrics under name,
+// which must not vary with the key.
+func cacheGet(ctx context.Context, client *redis.Client, name, key string, dest interface{}) bool {
+	start := time.Now()
+	result := metrics.Hit
+	defer func() {
+		metrics.ObserveCacheLookup(name, result, time.Since(start))
+	}()
+
+	cached, err := client.Get(ctx, key).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		result = metrics.Miss
+		return false
+	case err != nil:
+		result = metrics.Error
+		return false
+	}
+
+	if err := json.Unmarshal(cached, dest); err != nil {
+		result = metrics.Stale
+		return false
+	}
+	return true
+}
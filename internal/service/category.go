@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CategoryService handles project category operations
+type CategoryService struct {
+	repo   *repository.CategoryRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewCategoryService(repo *repository.CategoryRepository, redis *redis.Client, events *events.Bus) *CategoryService {
+	return &CategoryService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+func (s *CategoryService) GetCategories(ctx context.Context) ([]models.Category, error) {
+	// Try to get from cache first
+	var categories []models.Category
+	if cacheGet(ctx, s.redis, "categories", tenant.CacheKey(ctx, "categories"), &categories) {
+		return categories, nil
+	}
+
+	// Get from database
+	categories, err := s.repo.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	categoriesJSON, _ := json.Marshal(categories)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "categories"), categoriesJSON, contentCacheTTL())
+
+	return categories, nil
+}
+
+type CategoryCreateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+func (s *CategoryService) CreateCategory(ctx context.Context, req *CategoryCreateRequest) (*models.Category, error) {
+	category := &models.Category{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+
+	createdCategory, err := s.repo.CreateCategory(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "categories")
+
+	s.events.Publish(ctx, events.CategoryCreated, createdCategory)
+
+	return createdCategory, nil
+}
+
+type CategoryUpdateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+func (s *CategoryService) UpdateCategory(ctx context.Context, id uint, req *CategoryUpdateRequest) (*models.Category, error) {
+	category := &models.Category{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+
+	updatedCategory, err := s.repo.UpdateCategory(ctx, id, category)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects embed their category, so their listings are stale too
+	invalidate(ctx, s.redis, append([]string{"categories"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.CategoryUpdated, updatedCategory)
+
+	return updatedCategory, nil
+}
+
+func (s *CategoryService) DeleteCategory(ctx context.Context, id uint) error {
+	err := s.repo.DeleteCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Projects embed their category, so their listings are stale too
+	invalidate(ctx, s.redis, append([]string{"categories"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.CategoryDeleted, map[string]uint{"id": id})
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// certificationCheckInterval is how often expiring certifications are looked
+// for
+const certificationCheckInterval = time.Hour
+
+// CertificationReminders configures the notices sent before certifications
+// expire
+type CertificationReminders struct {
+	Days     int    // How long before the expiry date to remind; 0 sends none
+	Email    string // Recipient of reminder emails; empty sends none
+	Telegram bool   // Whether reminders also go to the configured Telegram chat
+}
+
+// CertificationService handles certification-related operations
+type CertificationService struct {
+	repo        *repository.CertificationRepository
+	redis       *redis.Client
+	events      *events.Bus
+	jobs        *JobService
+	reminders   CertificationReminders
+	hideExpired bool
+}
+
+// NewCertificationService returns the certification service. With
+// hideExpired set, visitors are not shown expired certifications.
+func NewCertificationService(repo *repository.CertificationRepository, redis *redis.Client, events *events.Bus, jobs *JobService, reminders CertificationReminders, hideExpired bool) *CertificationService {
+	return &CertificationService{
+		repo:        repo,
+		redis:       redis,
+		events:      events,
+		jobs:        jobs,
+		reminders:   reminders,
+		hideExpired: hideExpired,
+	}
+}
+
+// GetCertifications returns the certifications, most recently issued first,
+// flagging those that have expired. Expired ones are left out when they are
+// configured to be hidden, unless includeExpired is set.
+func (s *CertificationService) GetCertifications(ctx context.Context, includeExpired bool) ([]models.Certification, error) {
+	certifications, err := s.loadCertifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expiry is judged on every call, since the cached list outlives days
+	now := time.Now()
+	shown := make([]models.Certification, 0, len(certifications))
+	for _, certification := range certifications {
+		certification.Expired = certification.ExpiryDate != nil && !certification.ExpiryDate.After(now)
+		if certification.Expired && s.hideExpired && !includeExpired {
+			continue
+		}
+		shown = append(shown, certification)
+	}
+	return shown, nil
+}
+
+func (s *CertificationService) loadCertifications(ctx context.Context) ([]models.Certification, error) {
+	// Try to get from cache first
+	var certifications []models.Certification
+	if cacheGet(ctx, s.redis, "certifications", tenant.CacheKey(ctx, "certifications"), &certifications) {
+		return certifications, nil
+	}
+
+	// Get from database
+	certifications, err := s.repo.GetCertifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	certificationsJSON, _ := json.Marshal(certifications)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "certifications"), certificationsJSON, contentCacheTTL())
+
+	return certifications, nil
+}
+
+type CertificationCreateRequest struct {
+	Name            string     `json:"name" binding:"required"`
+	Issuer          string     `json:"issuer" binding:"required"`
+	IssueDate       time.Time  `json:"issue_date" binding:"required"`
+	ExpiryDate      *time.Time `json:"expiry_date"`
+	CredentialID    string     `json:"credential_id"`
+	VerificationURL string     `json:"verification_url" binding:"omitempty,url"`
+	BadgeImage      string     `json:"badge_image"`
+}
+
+func (s *CertificationService) CreateCertification(ctx context.Context, req *CertificationCreateRequest) (*models.Certification, error) {
+	createdCertification, err := s.repo.CreateCertification(ctx, newCertification(req))
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "certifications")
+
+	s.events.Publish(ctx, events.CertificationCreated, createdCertification)
+
+	return createdCertification, nil
+}
+
+func newCertification(req *CertificationCreateRequest) *models.Certification {
+	return &models.Certification{
+		Name:            req.Name,
+		Issuer:          req.Issuer,
+		IssueDate:       req.IssueDate,
+		ExpiryDate:      req.ExpiryDate,
+		CredentialID:    req.CredentialID,
+		VerificationURL: req.VerificationURL,
+		BadgeImage:      req.BadgeImage,
+	}
+}
+
+type CertificationUpdateRequest struct {
+	Name            string     `json:"name" binding:"required"`
+	Issuer          string     `json:"issuer" binding:"required"`
+	IssueDate       time.Time  `json:"issue_date" binding:"required"`
+	ExpiryDate      *time.Time `json:"expiry_date"`
+	CredentialID    string     `json:"credential_id"`
+	VerificationURL string     `json:"verification_url" binding:"omitempty,url"`
+	BadgeImage      string     `json:"badge_image"`
+}
+
+func (s *CertificationService) UpdateCertification(ctx context.Context, id uint, req *CertificationUpdateRequest) (*models.Certification, error) {
+	certification := &models.Certification{
+		Name:            req.Name,
+		Issuer:          req.Issuer,
+		IssueDate:       req.IssueDate,
+		ExpiryDate:      req.ExpiryDate,
+		CredentialID:    req.CredentialID,
+		VerificationURL: req.VerificationURL,
+		BadgeImage:      req.BadgeImage,
+	}
+
+	updatedCertification, err := s.repo.UpdateCertification(ctx, id, certification)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "certifications")
+
+	s.events.Publish(ctx, events.CertificationUpdated, updatedCertification)
+
+	return updatedCertification, nil
+}
+
+func (s *CertificationService) DeleteCertification(ctx context.Context, id uint) error {
+	err := s.repo.DeleteCertification(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "certifications")
+
+	s.events.Publish(ctx, events.CertificationDeleted, map[string]uint{"id": id})
+
+	return nil
+}
+
+// RunExpiryCheck looks for expiring certifications every hour until the
+// context is cancelled
+func (s *CertificationService) RunExpiryCheck(ctx context.Context) {
+	ticker := time.NewTicker(certificationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		s.checkExpiry(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkExpiry reminds the owner of the certifications of every tenant that
+// are about to expire, and drops the cached pages of tenants with one that
+// expired since the previous check, so they show it flagged or hidden
+func (s *CertificationService) checkExpiry(ctx context.Context) {
+	now := time.Now()
+	expired, err := s.repo.GetExpiringCertifications(tenant.All(ctx), now.Add(-certificationCheckInterval), now)
+	if err != nil {
+		log.Printf("Warning: failed to load expired certifications: %v", err)
+		return
+	}
+	tenants := map[uint]bool{}
+	for _, certification := range expired {
+		if !tenants[certification.TenantID] {
+			tenants[certification.TenantID] = true
+			invalidate(tenant.WithID(ctx, certification.TenantID), s.redis, "certifications")
+		}
+	}
+
+	if s.reminders.Days <= 0 || (s.reminders.Email == "" && !s.reminders.Telegram) {
+		return
+	}
+	expiring, err := s.repo.GetExpiringCertifications(tenant.All(ctx), now, now.AddDate(0, 0, s.reminders.Days))
+	if err != nil {
+		log.Printf("Warning: failed to load expiring certifications: %v", err)
+		return
+	}
+	for i := range expiring {
+		certification := &expiring[i]
+		if certification.ReminderSentAt != nil {
+			continue
+		}
+		claimed, err := s.repo.ClaimReminder(tenant.All(ctx), certification.ID)
+		if err != nil {
+			log.Printf("Warning: failed to record expiry reminder of certification %d: %v", certification.ID, err)
+			continue
+		}
+		if claimed {
+			s.remind(tenant.WithID(ctx, certification.TenantID), certification)
+		}
+	}
+}
+
+// remind emails and messages the owner about a certification that expires
+// soon
+func (s *CertificationService) remind(ctx context.Context, certification *models.Certification) {
+	expires := certification.ExpiryDate.UTC().Format("January 2, 2006")
+	text := fmt.Sprintf("Your %s certification from %s expires on %s.\n\nOnce it is renewed, update its expiry date through PUT /api/v1/admin/certifications/%d.",
+		certification.Name, certification.Issuer, expires, certification.ID)
+
+	if s.reminders.Email != "" {
+		s.jobs.SendMail(ctx, &mailer.Message{
+			To:      []string{s.reminders.Email},
+			Subject: certification.Name + " expires on " + expires,
+			Body:    text,
+		})
+	}
+	if s.reminders.Telegram {
+		s.jobs.SendTelegram(ctx, text)
+	}
+}
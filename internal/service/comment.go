@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/akismet"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	commentRateWindow = time.Hour
+	commentRateLimit  = 5 // Comments per IP per window
+)
+
+// CommentService handles visitor comments on projects and posts
+type CommentService struct {
+	repo     *repository.CommentRepository
+	redis    *redis.Client
+	events   *events.Bus
+	projects *ProjectService
+	posts    *PostService
+	akismet  *akismet.Client // nil disables external spam checks
+}
+
+func NewCommentService(repo *repository.CommentRepository, redis *redis.Client, events *events.Bus, projects *ProjectService, posts *PostService, akismet *akismet.Client) *CommentService {
+	return &CommentService{
+		repo:     repo,
+		redis:    redis,
+		events:   events,
+		projects: projects,
+		posts:    posts,
+		akismet:  akismet,
+	}
+}
+
+// CommentTarget names the published project or post a comment belongs to.
+// Projects are named by ID and posts by slug, as in their public URLs.
+type CommentTarget struct {
+	Type string // models.CommentOnProject or models.CommentOnPost
+	ID   uint
+	Slug string
+}
+
+// resolve returns the ID of the target, or an error when it is not
+// published
+func (s *CommentService) resolve(ctx context.Context, target CommentTarget) (uint, error) {
+	switch target.Type {
+	case models.CommentOnProject:
+		if _, err := s.projects.GetProjectSkills(ctx, target.ID); err != nil {
+			return 0, err
+		}
+		return target.ID, nil
+	case models.CommentOnPost:
+		post, err := s.posts.GetPost(ctx, target.Slug, false)
+		if err != nil {
+			return 0, err
+		}
+		return post.ID, nil
+	}
+	return 0, errors.New("invalid comment target")
+}
+
+func commentsCacheKey(targetType string, targetID uint) string {
+	return "comments:" + targetType + ":" + strconv.FormatUint(uint64(targetID), 10)
+}
+
+// GetComments returns the approved comments on a project or post, oldest
+// first, without the details only moderators see
+func (s *CommentService) GetComments(ctx context.Context, target CommentTarget) ([]models.Comment, error) {
+	targetID, err := s.resolve(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to get from cache first
+	cacheKey := commentsCacheKey(target.Type, targetID)
+	var comments []models.Comment
+	if cacheGet(ctx, s.redis, "comments", tenant.CacheKey(ctx, cacheKey), &comments) {
+		return comments, nil
+	}
+
+	// Get from database
+	comments, err = s.repo.GetApprovedComments(ctx, target.Type, targetID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		comments[i].Email = ""
+		comments[i].SpamScore = 0
+		comments[i].SpamVerdict = ""
+		comments[i].IPAddress = ""
+		comments[i].UserAgent = ""
+	}
+
+	// Cache the result
+	commentsJSON, _ := json.Marshal(comments)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, cacheKey), commentsJSON, contentCacheTTL())
+
+	return comments, nil
+}
+
+type CommentCreateRequest struct {
+	Author    string `json:"author" binding:"required,max=100"`
+	Email     string `json:"email" binding:"omitempty,email"`
+	Body      string `json:"body" binding:"required,max=5000"`
+	Website   string `json:"website"` // Honeypot: hidden from people, so any value marks a bot
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// CreateComment holds a visitor's comment for moderation. Comments scoring
+// as spam are stored with status spam and nobody is notified about them.
+func (s *CommentService) CreateComment(ctx context.Context, target CommentTarget, req *CommentCreateRequest) (*models.Comment, error) {
+	targetID, err := s.resolve(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IPAddress != "" {
+		rateKey := "comments:rate:" + req.IPAddress
+		count, err := s.redis.Incr(ctx, rateKey).Result()
+		if err != nil {
+			return nil, err
+		}
+		if count == 1 {
+			s.redis.Expire(ctx, rateKey, commentRateWindow)
+		}
+		if count > commentRateLimit {
+			return nil, errors.New("rate limit exceeded")
+		}
+	}
+
+	comment := &models.Comment{
+		TargetType: target.Type,
+		TargetID:   targetID,
+		Author:     req.Author,
+		Email:      req.Email,
+		Body:       req.Body,
+		IPAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+		SpamScore:  spamScore(ctx, s.redis, "comments", req.Website, req.Author+"\n"+req.Body, req.IPAddress),
+		Status:     "pending",
+	}
+	verdict, score := checkSpam(ctx, s.akismet, comment.SpamScore, &akismet.Submission{
+		Type:        akismet.Comment,
+		IPAddress:   comment.IPAddress,
+		UserAgent:   comment.UserAgent,
+		Author:      comment.Author,
+		AuthorEmail: comment.Email,
+		Content:     comment.Body,
+	})
+	comment.SpamVerdict = string(verdict)
+	comment.SpamScore += score
+	if comment.SpamScore >= spamThreshold {
+		comment.Status = "spam"
+	}
+
+	createdComment, err := s.repo.CreateComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdComment.Status != "spam" {
+		s.events.Publish(ctx, events.CommentCreated, createdComment)
+	}
+
+	return createdComment, nil
+}
+
+// GetModerationQueue returns the comments with the given status, newest
+// first; pending comments when status is empty
+func (s *CommentService) GetModerationQueue(ctx context.Context, status string) ([]models.Comment, error) {
+	if status == "" {
+		status = "pending"
+	}
+	return s.repo.GetComments(ctx, status)
+}
+
+// ModerateComment approves a comment, which shows it on its project or
+// post, or rejects it, which hides it again
+func (s *CommentService) ModerateComment(ctx context.Context, id uint, approved bool) (*models.Comment, error) {
+	status := "rejected"
+	if approved {
+		status = "approved"
+	}
+	comment, err := s.repo.SetCommentStatus(ctx, id, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, commentsCacheKey(comment.TargetType, comment.TargetID))
+
+	s.events.Publish(ctx, events.CommentUpdated, comment)
+
+	return comment, nil
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, id uint) error {
+	comment, err := s.repo.DeleteComment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, commentsCacheKey(comment.TargetType, comment.TargetID))
+
+	s.events.Publish(ctx, events.CommentDeleted, map[string]uint{"id": id})
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// companyCacheKeys are the cached listings that embed companies
+var companyCacheKeys = []string{"companies", "experiences", "experiences:drafts", "testimonials"}
+
+// CompanyService handles company operations
+type CompanyService struct {
+	repo   *repository.CompanyRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewCompanyService(repo *repository.CompanyRepository, redis *redis.Client, events *events.Bus) *CompanyService {
+	return &CompanyService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+func (s *CompanyService) GetCompanies(ctx context.Context) ([]models.Company, error) {
+	// Try to get from cache first
+	var companies []models.Company
+	if cacheGet(ctx, s.redis, "companies", tenant.CacheKey(ctx, "companies"), &companies) {
+		return companies, nil
+	}
+
+	// Get from database
+	companies, err := s.repo.GetCompanies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	companiesJSON, _ := json.Marshal(companies)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "companies"), companiesJSON, contentCacheTTL())
+
+	return companies, nil
+}
+
+// GetCompanyHistory returns a company with the roles held there and its
+// testimonials
+func (s *CompanyService) GetCompanyHistory(ctx context.Context, id uint, includeDrafts bool) (*repository.CompanyHistory, error) {
+	return s.repo.GetCompanyHistory(ctx, id, includeDrafts)
+}
+
+type CompanyCreateRequest struct {
+	Name     string `json:"name" binding:"required,max=200"`
+	Logo     string `json:"logo" binding:"max=2048"`
+	URL      string `json:"url" binding:"omitempty,url"`
+	Industry string `json:"industry" binding:"max=100"`
+}
+
+func (s *CompanyService) CreateCompany(ctx context.Context, req *CompanyCreateRequest) (*models.Company, error) {
+	company := &models.Company{
+		Name:     strings.TrimSpace(req.Name),
+		Logo:     req.Logo,
+		URL:      req.URL,
+		Industry: req.Industry,
+	}
+
+	createdCompany, err := s.repo.CreateCompany(ctx, company)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "companies")
+
+	s.events.Publish(ctx, events.CompanyCreated, createdCompany)
+
+	return createdCompany, nil
+}
+
+type CompanyUpdateRequest struct {
+	Name     string `json:"name" binding:"required,max=200"`
+	Logo     string `json:"logo" binding:"max=2048"`
+	URL      string `json:"url" binding:"omitempty,url"`
+	Industry string `json:"industry" binding:"max=100"`
+}
+
+func (s *CompanyService) UpdateCompany(ctx context.Context, id uint, req *CompanyUpdateRequest) (*models.Company, error) {
+	company := &models.Company{
+		Name:     strings.TrimSpace(req.Name),
+		Logo:     req.Logo,
+		URL:      req.URL,
+		Industry: req.Industry,
+	}
+
+	updatedCompany, err := s.repo.UpdateCompany(ctx, id, company)
+	if err != nil {
+		return nil, err
+	}
+
+	// Experiences and testimonials embed their company
+	invalidate(ctx, s.redis, companyCacheKeys...)
+
+	s.events.Publish(ctx, events.CompanyUpdated, updatedCompany)
+
+	return updatedCompany, nil
+}
+
+func (s *CompanyService) DeleteCompany(ctx context.Context, id uint) error {
+	err := s.repo.DeleteCompany(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Experiences and testimonials embed their company
+	invalidate(ctx, s.redis, companyCacheKeys...)
+
+	s.events.Publish(ctx, events.CompanyDeleted, map[string]uint{"id": id})
+
+	return nil
+}
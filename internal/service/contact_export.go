@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const contactExportBatchSize = 500
+
+var contactExportHeader = []string{
+	"id", "created_at", "name", "email", "subject", "message", "status",
+	"spam_score", "service_id", "replied_auto", "ip_address", "country", "city", "user_agent", "contact_group", "repeat",
+}
+
+// ExportContactsCSV writes the contacts matching the status filter to w as
+// CSV, oldest first. Rows are written batch by batch so large inboxes are
+// never held in memory.
+func (s *ContactService) ExportContactsCSV(ctx context.Context, w io.Writer, status string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(contactExportHeader); err != nil {
+		return err
+	}
+
+	err := s.repo.EachContactBatch(ctx, status, contactExportBatchSize, func(contacts []models.Contact) error {
+		for _, contact := range contacts {
+			serviceID := ""
+			if contact.ServiceID != nil {
+				serviceID = strconv.FormatUint(uint64(*contact.ServiceID), 10)
+			}
+
+			record := []string{
+				strconv.FormatUint(uint64(contact.ID), 10),
+				contact.CreatedAt.UTC().Format(time.RFC3339),
+				csvSafe(contact.Name),
+				csvSafe(contact.Email),
+				csvSafe(contact.Subject),
+				csvSafe(contact.Message),
+				contact.Status,
+				strconv.Itoa(contact.SpamScore),
+				serviceID,
+				strconv.FormatBool(contact.RepliedAuto),
+				contact.IPAddress,
+				contact.Country,
+				csvSafe(contact.City),
+				csvSafe(contact.UserAgent),
+				strconv.FormatUint(uint64(contact.ContactGroup), 10),
+				strconv.FormatBool(contact.Repeat),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvSafe neutralises values that spreadsheet applications would otherwise
+// evaluate as formulas, since the fields come from anonymous visitors
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
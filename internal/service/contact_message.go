@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+)
+
+type ContactReplyRequest struct {
+	Subject string `json:"subject"` // Defaults to "Re: " and the contact's subject
+	Body    string `json:"body" binding:"required"`
+}
+
+// ReplyToContact emails a reply to the submitter, stores it in the contact's
+// thread and marks the contact as replied. Each reply references the earlier
+// ones so that mail clients group the conversation.
+func (s *ContactService) ReplyToContact(ctx context.Context, id, authorID uint, req *ContactReplyRequest) (*models.ContactMessage, error) {
+	contact, err := s.repo.GetContact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repo.GetMessages(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := req.Subject
+	if subject == "" {
+		subject = replySubject(contact.Subject)
+	}
+
+	message := &models.ContactMessage{
+		ContactID: id,
+		AuthorID:  authorID,
+		Subject:   subject,
+		Body:      req.Body,
+		MessageID: s.mailer.NewMessageID(),
+	}
+
+	headers := map[string]string{"Message-ID": message.MessageID}
+	if len(previous) > 0 {
+		references := make([]string, 0, len(previous))
+		for _, m := range previous {
+			if m.MessageID != "" {
+				references = append(references, m.MessageID)
+			}
+		}
+		if len(references) > 0 {
+			headers["In-Reply-To"] = references[len(references)-1]
+			headers["References"] = strings.Join(references, " ")
+		}
+	}
+
+	err = s.mailer.Send(&mailer.Message{
+		To:      []string{contact.Email},
+		ReplyTo: s.ownerEmail,
+		Subject: subject,
+		Body:    req.Body,
+		Headers: headers,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to send reply to contact %d: %v", id, err)
+		return nil, errors.New("failed to send reply")
+	}
+
+	// The email is out, so record it even if the request was cancelled meanwhile
+	return s.repo.AddReply(context.WithoutCancel(ctx), message)
+}
+
+// GetMessages returns the replies sent to a contact, oldest first
+func (s *ContactService) GetMessages(ctx context.Context, id uint) ([]models.ContactMessage, error) {
+	if _, err := s.repo.GetContact(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetMessages(ctx, id)
+}
+
+type ContactNoteRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// AddNote records an internal note on a contact, attributed to the admin
+// who wrote it
+func (s *ContactService) AddNote(ctx context.Context, id, authorID uint, author string, req *ContactNoteRequest) (*models.ContactNote, error) {
+	if _, err := s.repo.GetContact(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateNote(ctx, &models.ContactNote{
+		ContactID: id,
+		AuthorID:  authorID,
+		Author:    author,
+		Body:      req.Body,
+	})
+}
+
+// GetNotes returns the internal notes on a contact, oldest first
+func (s *ContactService) GetNotes(ctx context.Context, id uint) ([]models.ContactNote, error) {
+	if _, err := s.repo.GetContact(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetNotes(ctx, id)
+}
+
+func replySubject(subject string) string {
+	if subject == "" {
+		return "Re: Your message"
+	}
+	if strings.HasPrefix(strings.ToLower(subject), "re:") {
+		return subject
+	}
+	return "Re: " + subject
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/tenant"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const contactStreamChannel = "contacts:stream"
+
+// ContactStreamService fans newly created contacts out to every replica
+// through Redis pub/sub so connected admin dashboards see them live
+type ContactStreamService struct {
+	redis *redis.Client
+}
+
+func NewContactStreamService(redis *redis.Client) *ContactStreamService {
+	return &ContactStreamService{redis: redis}
+}
+
+// HandleEvent publishes contact.created events to the Redis channel of the
+// event's tenant. It is registered as an event bus subscriber.
+func (s *ContactStreamService) HandleEvent(event events.Event) {
+	if event.Type != events.ContactCreated {
+		return
+	}
+
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		log.Printf("Warning: failed to encode contact for stream: %v", err)
+		return
+	}
+
+	go func() {
+		ctx := event.Context()
+		if err := s.redis.Publish(ctx, tenant.CacheKey(ctx, contactStreamChannel), payload).Err(); err != nil {
+			log.Printf("Warning: failed to publish contact to stream: %v", err)
+		}
+	}()
+}
+
+// Subscribe returns a channel of JSON-encoded contacts of the tenant in ctx.
+// The subscription is closed when the context is cancelled.
+func (s *ContactStreamService) Subscribe(ctx context.Context) (<-chan string, error) {
+	pubsub := s.redis.Subscribe(ctx, tenant.CacheKey(ctx, contactStreamChannel))
+
+	// Wait for the subscription to be confirmed so no message is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case out <- msg.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
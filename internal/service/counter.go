@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Visitor counters such as skill endorsements and project likes accumulate
+// in a Redis hash of counts by ID and are written to the database in
+// batches. While a batch is written its counts wait in a second hash.
+
+// pendingCounts sums the counts not yet written to the database by ID
+func pendingCounts(ctx context.Context, rdb *redis.Client, pendingKey, flushingKey string) map[uint]int64 {
+	pending := make(map[uint]int64)
+	for _, key := range []string{pendingKey, flushingKey} {
+		counts, err := rdb.HGetAll(ctx, key).Result()
+		if err != nil {
+			continue
+		}
+		for field, value := range counts {
+			id, err := strconv.ParseUint(field, 10, 32)
+			if err != nil {
+				continue
+			}
+			n, _ := strconv.ParseInt(value, 10, 64)
+			pending[uint(id)] += n
+		}
+	}
+	return pending
+}
+
+// flushCounts moves the pending counts aside so new ones keep accumulating,
+// hands them to apply and clears them. A failed flush is retried on the
+// next run because the counts stay in the flushing hash. IDs are unique
+// across tenants, so one hash serves all of them.
+func flushCounts(ctx context.Context, rdb *redis.Client, pendingKey, flushingKey string, apply func(increments map[uint]int64) error) error {
+	exists, err := rdb.Exists(ctx, flushingKey).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		err := rdb.Rename(ctx, pendingKey, flushingKey).Err()
+		if err != nil {
+			if err.Error() == "ERR no such key" {
+				return nil // Nothing counted since the last flush
+			}
+			return err
+		}
+	}
+
+	counts, err := rdb.HGetAll(ctx, flushingKey).Result()
+	if err != nil {
+		return err
+	}
+
+	increments := make(map[uint]int64, len(counts))
+	for field, value := range counts {
+		id, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		increments[uint(id)] = n
+	}
+
+	if err := apply(increments); err != nil {
+		return err
+	}
+	return rdb.Del(ctx, flushingKey).Err()
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	activityKey   = "dashboard:activity"
+	activityLimit = 50
+)
+
+// Dashboard is the admin overview returned in a single call
+type Dashboard struct {
+	Counts         map[string]int64      `json:"counts"`
+	LastUpdated    map[string]*time.Time `json:"last_updated"`
+	Contacts       DashboardContacts     `json:"contacts"`
+	RecentActivity []ActivityEntry       `json:"recent_activity"`
+	CacheHitRate   *float64              `json:"cache_hit_rate"` // Redis keyspace hit ratio, null when unavailable
+	GeneratedAt    time.Time             `json:"generated_at"`
+}
+
+// DashboardContacts counts contact submissions
+type DashboardContacts struct {
+	Total int64 `json:"total"`
+	New   int64 `json:"new"` // Not yet read
+}
+
+// ActivityEntry is a published event as shown on the dashboard
+type ActivityEntry struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// DashboardService aggregates figures for the admin overview page
+type DashboardService struct {
+	repo  *repository.DashboardRepository
+	redis *redis.Client
+}
+
+func NewDashboardService(repo *repository.DashboardRepository, redis *redis.Client) *DashboardService {
+	return &DashboardService{
+		repo:  repo,
+		redis: redis,
+	}
+}
+
+// RecordEvent keeps the most recent events for the activity feed, shared by
+// every replica. It is registered as an event bus subscriber and returns
+// immediately.
+func (s *DashboardService) RecordEvent(event events.Event) {
+	go s.record(event)
+}
+
+func (s *DashboardService) record(event events.Event) {
+	entry, err := json.Marshal(ActivityEntry{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		return
+	}
+
+	ctx := event.Context()
+	key := tenant.CacheKey(ctx, activityKey)
+	pipe := s.redis.TxPipeline()
+	pipe.LPush(ctx, key, entry)
+	pipe.LTrim(ctx, key, 0, activityLimit-1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Warning: failed to record dashboard activity: %v", err)
+	}
+}
+
+func (s *DashboardService) GetDashboard(ctx context.Context) (*Dashboard, error) {
+	stats, err := s.repo.GetContentStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total, unread, err := s.repo.CountContacts(ctx, "new")
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &Dashboard{
+		Counts:         make(map[string]int64, len(stats)),
+		LastUpdated:    make(map[string]*time.Time, len(stats)),
+		Contacts:       DashboardContacts{Total: total, New: unread},
+		RecentActivity: s.recentActivity(ctx),
+		CacheHitRate:   s.cacheHitRate(),
+		GeneratedAt:    time.Now().UTC(),
+	}
+	for name, stat := range stats {
+		dashboard.Counts[name] = stat.Count
+		dashboard.LastUpdated[name] = stat.LastUpdated
+	}
+
+	return dashboard, nil
+}
+
+func (s *DashboardService) recentActivity(ctx context.Context) []ActivityEntry {
+	activity := []ActivityEntry{}
+	entries, err := s.redis.LRange(ctx, tenant.CacheKey(ctx, activityKey), 0, activityLimit-1).Result()
+	if err != nil {
+		return activity
+	}
+
+	for _, raw := range entries {
+		var entry ActivityEntry
+		if err := json.Unmarshal([]byte(raw), &entry); err == nil {
+			activity = append(activity, entry)
+		}
+	}
+	return activity
+}
+
+// cacheHitRate reads the keyspace hit ratio from Redis INFO. It covers every
+// lookup on the Redis instance, which is dominated by the content cache.
+func (s *DashboardService) cacheHitRate() *float64 {
+	info, err := s.redis.Info(context.Background(), "stats").Result()
+	if err != nil {
+		return nil
+	}
+
+	var hits, misses float64
+	for _, line := range strings.Split(info, "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "keyspace_hits":
+			hits, _ = strconv.ParseFloat(value, 64)
+		case "keyspace_misses":
+			misses, _ = strconv.ParseFloat(value, 64)
+		}
+	}
+
+	if hits+misses == 0 {
+		return nil
+	}
+	rate := hits / (hits + misses)
+	return &rate
+}
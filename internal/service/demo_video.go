@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/oembed"
+	"time"
+)
+
+// Provider metadata is the same for every tenant, so its cache keys are not
+// namespaced. Failed lookups are remembered for a while too, so a provider
+// outage does not slow down every rebuild of the project listings.
+const (
+	oembedCacheTTL   = 24 * time.Hour
+	oembedFailureTTL = 10 * time.Minute
+)
+
+// checkDemoVideo rejects demo video URLs that no player can show
+func checkDemoVideo(rawURL string) error {
+	if rawURL == "" {
+		return nil
+	}
+	if _, err := oembed.Detect(rawURL); err != nil {
+		return errors.New("unsupported demo video")
+	}
+	return nil
+}
+
+// demoVideo describes how to play a demo video, with the provider's
+// metadata when it can be had, or returns nil when there is no video
+func (s *ProjectService) demoVideo(ctx context.Context, rawURL string) *models.DemoVideo {
+	if rawURL == "" {
+		return nil
+	}
+	video, err := oembed.Detect(rawURL)
+	if err != nil {
+		// Stored before the URL was checked
+		return nil
+	}
+
+	demo := &models.DemoVideo{Provider: video.Provider, EmbedURL: video.EmbedURL}
+	if video.Provider == oembed.SelfHosted {
+		return demo
+	}
+
+	cacheKey := "oembed:" + video.PageURL
+	var metadata oembed.Metadata
+	if !cacheGet(ctx, s.redis, "oembed", cacheKey, &metadata) {
+		ttl := oembedCacheTTL
+		fetched, err := s.oembed.Fetch(ctx, video)
+		if err != nil {
+			log.Printf("Warning: failed to fetch demo video metadata: %v", err)
+			ttl = oembedFailureTTL
+		} else {
+			metadata = *fetched
+		}
+		metadataJSON, _ := json.Marshal(metadata)
+		s.redis.Set(ctx, cacheKey, metadataJSON, ttl)
+	}
+
+	demo.Title = metadata.Title
+	demo.AuthorName = metadata.AuthorName
+	demo.ThumbnailURL = metadata.ThumbnailURL
+	demo.Width = metadata.Width
+	demo.Height = metadata.Height
+	return demo
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EducationService handles education-related operations
+type EducationService struct {
+	repo   *repository.EducationRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewEducationService(repo *repository.EducationRepository, redis *redis.Client, events *events.Bus) *EducationService {
+	return &EducationService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+func (s *EducationService) GetEducation(ctx context.Context) ([]models.Education, error) {
+	// Try to get from cache first
+	var education []models.Education
+	if cacheGet(ctx, s.redis, "education", tenant.CacheKey(ctx, "education"), &education) {
+		return education, nil
+	}
+
+	// Get from database
+	education, err := s.repo.GetEducation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	educationJSON, _ := json.Marshal(education)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "education"), educationJSON, contentCacheTTL())
+
+	return education, nil
+}
+
+type EducationCreateRequest struct {
+	Institution string     `json:"institution" binding:"required"`
+	Degree      string     `json:"degree" binding:"required"`
+	Field       string     `json:"field"`
+	StartDate   time.Time  `json:"start_date" binding:"required"`
+	EndDate     *time.Time `json:"end_date"`
+	Description string     `json:"description"`
+	Position    int        `json:"position"`
+}
+
+func (s *EducationService) CreateEducation(ctx context.Context, req *EducationCreateRequest) (*models.Education, error) {
+	createdEducation, err := s.repo.CreateEducation(ctx, newEducation(req))
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "education")
+
+	s.events.Publish(ctx, events.EducationCreated, createdEducation)
+
+	return createdEducation, nil
+}
+
+func newEducation(req *EducationCreateRequest) *models.Education {
+	return &models.Education{
+		Institution: req.Institution,
+		Degree:      req.Degree,
+		Field:       req.Field,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Description: req.Description,
+		Position:    req.Position,
+	}
+}
+
+type EducationUpdateRequest struct {
+	Institution string     `json:"institution" binding:"required"`
+	Degree      string     `json:"degree" binding:"required"`
+	Field       string     `json:"field"`
+	StartDate   time.Time  `json:"start_date" binding:"required"`
+	EndDate     *time.Time `json:"end_date"`
+	Description string     `json:"description"`
+	Position    int        `json:"position"`
+}
+
+func (s *EducationService) UpdateEducation(ctx context.Context, id uint, req *EducationUpdateRequest) (*models.Education, error) {
+	education := &models.Education{
+		Institution: req.Institution,
+		Degree:      req.Degree,
+		Field:       req.Field,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Description: req.Description,
+		Position:    req.Position,
+	}
+
+	updatedEducation, err := s.repo.UpdateEducation(ctx, id, education)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "education")
+
+	s.events.Publish(ctx, events.EducationUpdated, updatedEducation)
+
+	return updatedEducation, nil
+}
+
+func (s *EducationService) DeleteEducation(ctx context.Context, id uint) error {
+	err := s.repo.DeleteEducation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "education")
+
+	s.events.Publish(ctx, events.EducationDeleted, map[string]uint{"id": id})
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const embedTokenCacheTTL = 5 * time.Minute
+
+// EmbedService handles embed token management and verification
+type EmbedService struct {
+	repo  *repository.EmbedTokenRepository
+	redis *redis.Client
+}
+
+func NewEmbedService(repo *repository.EmbedTokenRepository, redis *redis.Client) *EmbedService {
+	return &EmbedService{
+		repo:  repo,
+		redis: redis,
+	}
+}
+
+// Authorize checks that the token exists, is active and is bound to the
+// origin of the embedding page. It returns the origin that may be echoed
+// back in Access-Control-Allow-Origin. Tokens only authorize requests for
+// the tenant that created them.
+func (s *EmbedService) Authorize(ctx context.Context, token, origin string) (string, error) {
+	if token == "" {
+		return "", errors.New("embed token required")
+	}
+
+	embedToken, err := s.getToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if !embedToken.Active {
+		return "", errors.New("embed token not found")
+	}
+
+	origin = normalizeOrigin(origin)
+	for _, allowed := range embedToken.AllowedOrigins {
+		if allowed == "*" {
+			if origin == "" {
+				return "*", nil
+			}
+			return origin, nil
+		}
+		if origin != "" && normalizeOrigin(allowed) == origin {
+			return origin, nil
+		}
+	}
+
+	return "", errors.New("origin not allowed")
+}
+
+func (s *EmbedService) getToken(ctx context.Context, token string) (*models.EmbedToken, error) {
+	// Try to get from cache first
+	cacheKey := tenant.CacheKey(ctx, "embed_token:"+token)
+	var cachedToken models.EmbedToken
+	if cacheGet(ctx, s.redis, "embed_token", cacheKey, &cachedToken) {
+		return &cachedToken, nil
+	}
+
+	// Get from database
+	embedToken, err := s.repo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	tokenJSON, _ := json.Marshal(embedToken)
+	s.redis.Set(ctx, cacheKey, tokenJSON, embedTokenCacheTTL)
+
+	return embedToken, nil
+}
+
+func (s *EmbedService) GetTokens(ctx context.Context) ([]models.EmbedToken, error) {
+	return s.repo.GetTokens(ctx)
+}
+
+type EmbedTokenCreateRequest struct {
+	Name           string   `json:"name" binding:"required"`
+	AllowedOrigins []string `json:"allowed_origins" binding:"required,min=1"`
+}
+
+func (s *EmbedService) CreateToken(ctx context.Context, req *EmbedTokenCreateRequest) (*models.EmbedToken, error) {
+	value, err := models.GenerateRandomString(24)
+	if err != nil {
+		return nil, err
+	}
+
+	origins := make([]string, 0, len(req.AllowedOrigins))
+	for _, origin := range req.AllowedOrigins {
+		if origin == "*" {
+			origins = append(origins, origin)
+			continue
+		}
+		normalized := normalizeOrigin(origin)
+		if normalized == "" {
+			return nil, errors.New("invalid origin")
+		}
+		origins = append(origins, normalized)
+	}
+
+	token := &models.EmbedToken{
+		Name:           req.Name,
+		Token:          value,
+		AllowedOrigins: origins,
+		Active:         true,
+	}
+
+	return s.repo.CreateToken(ctx, token)
+}
+
+func (s *EmbedService) DeleteToken(ctx context.Context, id uint) error {
+	token, err := s.repo.DeleteToken(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "embed_token:"+token.Token)
+
+	return nil
+}
+
+// normalizeOrigin reduces an Origin or Referer value to scheme://host[:port]
+func normalizeOrigin(value string) string {
+	if value == "" {
+		return ""
+	}
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/llm"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+)
+
+// Job types that keep content embeddings up to date
+const (
+	JobEmbed     = "embed"
+	JobEmbedSync = "embed_sync"
+)
+
+const (
+	embeddingBatchSize = 32
+	// embeddingTextLimit keeps texts, in runes, well within the input
+	// limits of embedding models
+	embeddingTextLimit = 8000
+)
+
+// EmbeddingService keeps embeddings of published projects, experiences and
+// posts and finds content by meaning rather than by name
+type EmbeddingService struct {
+	repo   *repository.EmbeddingRepository
+	jobs   *JobService
+	client *llm.Client // nil when no embedding model is configured
+}
+
+// NewEmbeddingService returns an embedding service. When client is not
+// nil, content changes are embedded by background jobs.
+func NewEmbeddingService(repo *repository.EmbeddingRepository, jobs *JobService, client *llm.Client) *EmbeddingService {
+	s := &EmbeddingService{
+		repo:   repo,
+		jobs:   jobs,
+		client: client,
+	}
+	if client != nil {
+		jobs.Handle(JobEmbed, s.runEmbed)
+		jobs.Handle(JobEmbedSync, s.runSync)
+	}
+	return s
+}
+
+// Enabled reports whether an embedding model is configured
+func (s *EmbeddingService) Enabled() bool {
+	return s.client != nil
+}
+
+// SemanticSearch returns up to limit items of the given types, or of every
+// type when none are given, whose meaning is closest to query, best match
+// first. "distributed systems" finds a project about Kafka pipelines that
+// never uses the words.
+func (s *EmbeddingService) SemanticSearch(ctx context.Context, query string, types []string, limit int) ([]repository.SearchMatch, error) {
+	if s.client == nil {
+		return nil, errors.New("semantic search not configured")
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("query required")
+	}
+	if len(types) == 0 {
+		types = repository.SemanticSearchTypes
+	}
+	for _, kind := range types {
+		if !slices.Contains(repository.SemanticSearchTypes, kind) {
+			return nil, errors.New("invalid type")
+		}
+	}
+
+	vectors, err := s.client.Embed(ctx, []string{query})
+	if err != nil {
+		log.Printf("Warning: failed to embed search query: %v", err)
+		return nil, errors.New("semantic search unavailable")
+	}
+	return s.repo.SearchEmbeddings(ctx, vectors[0], s.client.Model(), types, limit)
+}
+
+// Setup queues embedding of the content that has none yet or whose text
+// or model changed while the server was down. It does nothing without an
+// embedding model.
+func (s *EmbeddingService) Setup(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+	return s.jobs.Enqueue(ctx, JobEmbedSync, struct{}{})
+}
+
+// embedJob names the item to embed again
+type embedJob struct {
+	Type string `json:"type"`
+	ID   uint   `json:"id"`
+}
+
+// embeddingEventTypes maps the events that change embedded content to the
+// type of content they are about
+var embeddingEventTypes = map[string]string{
+	events.ProjectCreated:    repository.SearchProjects,
+	events.ProjectUpdated:    repository.SearchProjects,
+	events.ProjectDeleted:    repository.SearchProjects,
+	events.ExperienceCreated: repository.SearchExperiences,
+	events.ExperienceUpdated: repository.SearchExperiences,
+	events.ExperienceDeleted: repository.SearchExperiences,
+	events.PostCreated:       repository.SearchPosts,
+	events.PostUpdated:       repository.SearchPosts,
+	events.PostDeleted:       repository.SearchPosts,
+}
+
+// HandleEvent queues embedding of the project, experience or post an event
+// is about. It is registered as an event bus subscriber; the jobs retry
+// while the embedding model is unreachable.
+func (s *EmbeddingService) HandleEvent(event events.Event) {
+	kind, ok := embeddingEventTypes[event.Type]
+	if s.client == nil || !ok {
+		return
+	}
+
+	job := embedJob{Type: kind}
+	switch data := event.Data.(type) {
+	case *models.Project:
+		job.ID = data.ID
+	case *models.Experience:
+		job.ID = data.ID
+	case *models.Post:
+		job.ID = data.ID
+	case map[string]uint:
+		job.ID = data["id"]
+	}
+	if job.ID == 0 {
+		return
+	}
+
+	if err := s.jobs.Enqueue(event.Context(), JobEmbed, job); err != nil {
+		log.Printf("Warning: failed to queue embedding for %s: %v", event.Type, err)
+	}
+}
+
+// runEmbed embeds an item again, or drops its embedding once it was
+// deleted, unpublished or archived
+func (s *EmbeddingService) runEmbed(ctx context.Context, payload json.RawMessage) error {
+	var job embedJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	sources, err := s.repo.GetEmbeddingSources(ctx, job.Type, job.ID)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		tenantID, ok := tenant.FromContext(ctx)
+		if !ok {
+			tenantID = tenant.DefaultID
+		}
+		return s.repo.DeleteEmbedding(ctx, tenantID, job.Type, job.ID)
+	}
+	existing, err := s.repo.GetEmbeddings(ctx, job.Type, job.ID)
+	if err != nil {
+		return err
+	}
+	return s.embed(ctx, sources, existing)
+}
+
+// runSync brings the embeddings of every tenant in line with the content:
+// items without a current embedding are embedded and embeddings of items
+// that are gone are dropped
+func (s *EmbeddingService) runSync(ctx context.Context, payload json.RawMessage) error {
+	ctx = tenant.All(ctx)
+	for _, kind := range repository.SemanticSearchTypes {
+		sources, err := s.repo.GetEmbeddingSources(ctx, kind, 0)
+		if err != nil {
+			return err
+		}
+		existing, err := s.repo.GetEmbeddings(ctx, kind, 0)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[[2]uint]bool, len(sources))
+		for _, source := range sources {
+			current[[2]uint{source.TenantID, source.ID}] = true
+		}
+		for _, embedding := range existing {
+			if !current[[2]uint{embedding.TenantID, embedding.ItemID}] {
+				if err := s.repo.DeleteEmbedding(ctx, embedding.TenantID, kind, embedding.ItemID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.embed(ctx, sources, existing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// embed computes and stores the embeddings of the sources whose stored
+// embedding is missing or was computed from other text or by another model.
+// Rows are saved in their item's tenant, as sync runs across all of them.
+func (s *EmbeddingService) embed(ctx context.Context, sources []repository.EmbeddingSource, existing []models.Embedding) error {
+	model := s.client.Model()
+	stored := make(map[[2]uint]models.Embedding, len(existing))
+	for _, embedding := range existing {
+		stored[[2]uint{embedding.TenantID, embedding.ItemID}] = embedding
+	}
+
+	var pending []models.Embedding
+	var texts []string
+	for _, source := range sources {
+		text := truncateText(source.Text, embeddingTextLimit)
+		sum := sha256.Sum256([]byte(text))
+		checksum := hex.EncodeToString(sum[:])
+
+		// The publication time is not part of the text, so rescheduling an
+		// item stores it again too
+		embedding, ok := stored[[2]uint{source.TenantID, source.ID}]
+		scheduled := embedding.PublishedAt == nil && source.PublishedAt == nil ||
+			embedding.PublishedAt != nil && source.PublishedAt != nil && embedding.PublishedAt.Equal(*source.PublishedAt)
+		if ok && embedding.Model == model && embedding.Checksum == checksum && scheduled {
+			continue
+		}
+		pending = append(pending, models.Embedding{
+			TenantID:    source.TenantID,
+			Type:        source.Type,
+			ItemID:      source.ID,
+			Title:       source.Title,
+			Subtitle:    source.Subtitle,
+			PublishedAt: source.PublishedAt,
+			Model:       model,
+			Checksum:    checksum,
+		})
+		texts = append(texts, text)
+	}
+
+	for start := 0; start < len(pending); start += embeddingBatchSize {
+		end := min(start+embeddingBatchSize, len(pending))
+		vectors, err := s.client.Embed(ctx, texts[start:end])
+		if err != nil {
+			return err
+		}
+		for i := start; i < end; i++ {
+			pending[i].Vector = vectors[i-start]
+			if err := s.repo.SaveEmbedding(tenant.WithID(ctx, pending[i].TenantID), &pending[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"time"
+)
+
+const (
+	endorsementPendingKey  = "endorsements:pending"
+	endorsementFlushingKey = "endorsements:flushing"
+	endorsementDedupWindow = 30 * 24 * time.Hour
+	endorsementRateWindow  = time.Hour
+	endorsementRateLimit   = 20 // Endorsements per IP per window
+)
+
+// EndorsementResult reports the outcome of an endorsement
+type EndorsementResult struct {
+	SkillID      uint  `json:"skill_id"`
+	Endorsements int64 `json:"endorsements"`
+	Counted      bool  `json:"counted"` // False when this visitor already endorsed the skill
+}
+
+// EndorseSkill counts an anonymous endorsement. Visitors are told apart by
+// a hash of their IP address and user agent, so repeated endorsements of the
+// same skill are accepted but not counted. Counts accumulate in Redis and are
+// written to the database by RunEndorsementFlush.
+func (s *SkillService) EndorseSkill(ctx context.Context, id uint, ipAddress, userAgent string) (*EndorsementResult, error) {
+	skills, err := s.GetSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var skill *models.Skill
+	for i := range skills {
+		if skills[i].ID == id {
+			skill = &skills[i]
+			break
+		}
+	}
+	if skill == nil {
+		return nil, errors.New("skill not found")
+	}
+
+	rateKey := "endorsements:rate:" + ipAddress
+	count, err := s.redis.Incr(ctx, rateKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		s.redis.Expire(ctx, rateKey, endorsementRateWindow)
+	}
+	if count > endorsementRateLimit {
+		return nil, errors.New("rate limit exceeded")
+	}
+
+	field := strconv.FormatUint(uint64(id), 10)
+	fingerprint := sha256.Sum256([]byte(ipAddress + "|" + userAgent))
+	dedupKey := "endorsements:seen:" + field + ":" + hex.EncodeToString(fingerprint[:])
+	counted, err := s.redis.SetNX(ctx, dedupKey, 1, endorsementDedupWindow).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if counted {
+		if err := s.redis.HIncrBy(ctx, endorsementPendingKey, field, 1).Err(); err != nil {
+			return nil, err
+		}
+		skill.Endorsements++
+	}
+
+	return &EndorsementResult{
+		SkillID:      id,
+		Endorsements: skill.Endorsements,
+		Counted:      counted,
+	}, nil
+}
+
+// addPendingEndorsements adds the endorsements not yet flushed to the
+// database to the skills' stored counts
+func (s *SkillService) addPendingEndorsements(ctx context.Context, skills []models.Skill) {
+	pending := pendingCounts(ctx, s.redis, endorsementPendingKey, endorsementFlushingKey)
+	for i := range skills {
+		skills[i].Endorsements += pending[skills[i].ID]
+	}
+}
+
+// RunEndorsementFlush writes pending endorsement counts to the database at
+// the given interval until the context is cancelled
+func (s *SkillService) RunEndorsementFlush(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.flushEndorsements(ctx); err != nil {
+				log.Printf("Warning: failed to flush skill endorsements: %v", err)
+			}
+		}
+	}
+}
+
+// flushEndorsements adds the pending endorsements to the database
+func (s *SkillService) flushEndorsements(ctx context.Context) error {
+	return flushCounts(ctx, s.redis, endorsementPendingKey, endorsementFlushingKey, func(increments map[uint]int64) error {
+		tenants, err := s.repo.AddEndorsements(tenant.All(ctx), increments)
+		if err != nil {
+			return err
+		}
+
+		// The cached skills now lag behind the database
+		for _, id := range tenants {
+			invalidate(tenant.WithID(ctx, id), s.redis, "skills")
+		}
+		return nil
+	})
+}
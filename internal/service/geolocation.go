@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"stackwhiz-portfolio-backend/internal/geoip"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// geoipCacheTTL keeps lookups of repeat visitors off the provider's rate
+// limit. Locations of an address rarely change within a day.
+const geoipCacheTTL = 24 * time.Hour
+
+// locate returns where a request came from, or nil when geolocation is
+// disabled (a nil locator) or the address cannot be located. A failed
+// lookup never stops a contact submission or a login.
+func locate(ctx context.Context, rdb *redis.Client, locator *geoip.Locator, ip string) *geoip.Location {
+	if locator == nil || ip == "" {
+		return nil
+	}
+
+	// Try to get from cache first
+	cacheKey := "geoip:" + ip
+	var cachedLocation geoip.Location
+	if cacheGet(ctx, rdb, "geoip", cacheKey, &cachedLocation) {
+		return &cachedLocation
+	}
+
+	location, err := locator.Lookup(ctx, ip)
+	if err != nil {
+		if !errors.Is(err, geoip.ErrNotPublic) {
+			log.Printf("Warning: failed to locate address: %v", err)
+		}
+		return nil
+	}
+
+	// Cache the result
+	locationJSON, _ := json.Marshal(location)
+	rdb.Set(ctx, cacheKey, locationJSON, geoipCacheTTL)
+
+	return location
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"stackwhiz-portfolio-backend/internal/github"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const githubActivityEventLimit = 30
+
+// GitHubService keeps GitHub-derived data on the portfolio up to date
+type GitHubService struct {
+	projects *repository.ProjectRepository
+	redis    *redis.Client
+	client   *github.Client
+	username string
+}
+
+func NewGitHubService(projects *repository.ProjectRepository, redis *redis.Client, client *github.Client, username string) *GitHubService {
+	return &GitHubService{
+		projects: projects,
+		redis:    redis,
+		client:   client,
+		username: username,
+	}
+}
+
+// GitHubActivity is the owner's recent public GitHub activity
+type GitHubActivity struct {
+	Username  string                       `json:"username"`
+	Calendar  *github.ContributionCalendar `json:"calendar"` // Null when no GitHub token is configured
+	Events    []github.Event               `json:"events"`
+	FetchedAt time.Time                    `json:"fetched_at"`
+}
+
+// GetActivity returns the contribution calendar and recent public events of
+// the configured GitHub user. Results are fetched server-side and cached so
+// visitors never hit GitHub's rate limits.
+func (s *GitHubService) GetActivity(ctx context.Context) (*GitHubActivity, error) {
+	if s.username == "" {
+		return nil, errors.New("github activity not configured")
+	}
+
+	// Try to get from cache first
+	var cachedActivity GitHubActivity
+	if cacheGet(ctx, s.redis, "github_activity", "github:activity", &cachedActivity) {
+		return &cachedActivity, nil
+	}
+
+	events, err := s.client.GetPublicEvents(ctx, s.username, githubActivityEventLimit)
+	if err != nil {
+		log.Printf("Warning: failed to fetch GitHub events: %v", err)
+		return nil, errors.New("github unavailable")
+	}
+
+	// The calendar needs a token, so its absence only leaves it out
+	calendar, err := s.client.GetContributionCalendar(ctx, s.username)
+	if err != nil {
+		log.Printf("Warning: failed to fetch GitHub contribution calendar: %v", err)
+	}
+
+	activity := &GitHubActivity{
+		Username:  s.username,
+		Calendar:  calendar,
+		Events:    events,
+		FetchedAt: time.Now().UTC(),
+	}
+
+	// Cache the result
+	activityJSON, _ := json.Marshal(activity)
+	s.redis.Set(ctx, "github:activity", activityJSON, time.Hour)
+
+	return activity, nil
+}
+
+// RunProjectSync refreshes the repository statistics of every project with a
+// GitHub URL every interval until the context is cancelled
+func (s *GitHubService) RunProjectSync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.syncProjects(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncProjects refreshes the linked projects of every tenant
+func (s *GitHubService) syncProjects(ctx context.Context) {
+	projects, err := s.projects.GetGitHubProjects(tenant.All(ctx))
+	if err != nil {
+		log.Printf("Warning: failed to load projects for GitHub sync: %v", err)
+		return
+	}
+
+	updated := map[uint]bool{}
+	for _, project := range projects {
+		owner, name, ok := github.ParseRepoURL(project.GitHubURL)
+		if !ok {
+			continue
+		}
+
+		repo, err := s.client.GetRepository(ctx, owner, name)
+		if err != nil {
+			// Keep the previous figures; synced_at shows how old they are
+			log.Printf("Warning: failed to fetch GitHub stats for project %d: %v", project.ID, err)
+			continue
+		}
+
+		now := time.Now()
+		pushedAt := repo.PushedAt
+		stats := models.GitHubStats{
+			Stars:      repo.Stars,
+			Forks:      repo.Forks,
+			OpenIssues: repo.OpenIssues,
+			PushedAt:   &pushedAt,
+			SyncedAt:   &now,
+		}
+		if err := s.projects.UpdateGitHubStats(tenant.All(ctx), project.ID, stats); err != nil {
+			log.Printf("Warning: failed to store GitHub stats for project %d: %v", project.ID, err)
+			continue
+		}
+		updated[project.TenantID] = true
+	}
+
+	for tenantID := range updated {
+		invalidate(tenant.WithID(ctx, tenantID), s.redis, projectCacheKeys...)
+	}
+}
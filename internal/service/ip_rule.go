@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ipRulesCacheKey holds the rules checked on every request. They apply to
+// the whole deployment, so the key is never namespaced by tenant.
+const (
+	ipRulesCacheKey = "ip_rules"
+	ipRulesCacheTTL = 10 * time.Minute
+)
+
+// IPRuleService manages the IP allow and deny rules and checks client
+// addresses against them
+type IPRuleService struct {
+	repo  *repository.IPRuleRepository
+	redis *redis.Client
+}
+
+func NewIPRuleService(repo *repository.IPRuleRepository, redis *redis.Client) *IPRuleService {
+	return &IPRuleService{
+		repo:  repo,
+		redis: redis,
+	}
+}
+
+func (s *IPRuleService) GetRules(ctx context.Context) ([]models.IPRule, error) {
+	// Try to get from cache first
+	var rules []models.IPRule
+	if cacheGet(ctx, s.redis, "ip_rules", ipRulesCacheKey, &rules) {
+		return rules, nil
+	}
+
+	// Get from database
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	rulesJSON, _ := json.Marshal(rules)
+	s.redis.Set(ctx, ipRulesCacheKey, rulesJSON, ipRulesCacheTTL)
+
+	return rules, nil
+}
+
+// Blocked reports whether a deny rule covers the client address
+func (s *IPRuleService) Blocked(ctx context.Context, ip string) (bool, error) {
+	rules, err := s.GetRules(ctx)
+	if err != nil {
+		return false, err
+	}
+	return denied(rules, net.ParseIP(ip)), nil
+}
+
+// AdminAllowed reports whether the client address may reach the admin API:
+// either no allow rule exists or one of them covers it
+func (s *IPRuleService) AdminAllowed(ctx context.Context, ip string) (bool, error) {
+	rules, err := s.GetRules(ctx)
+	if err != nil {
+		return false, err
+	}
+	return adminAllowed(rules, net.ParseIP(ip)), nil
+}
+
+type IPRuleRequest struct {
+	CIDR   string `json:"cidr" binding:"required"`   // An address or a CIDR range
+	Action string `json:"action" binding:"required"` // allow, deny
+	Note   string `json:"note"`
+}
+
+// CreateRule adds a rule. Rules that would keep the admin making the change,
+// at clientIP, out of the admin API are rejected.
+func (s *IPRuleService) CreateRule(ctx context.Context, req *IPRuleRequest, clientIP string) (*models.IPRule, error) {
+	rule, err := buildIPRule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLockout(append(rules, *rule), clientIP); err != nil {
+		return nil, err
+	}
+
+	createdRule, err := s.repo.CreateRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	s.redis.Del(context.WithoutCancel(ctx), ipRulesCacheKey)
+
+	return createdRule, nil
+}
+
+func (s *IPRuleService) UpdateRule(ctx context.Context, id uint, req *IPRuleRequest, clientIP string) (*models.IPRule, error) {
+	rule, err := buildIPRule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if rules[i].ID == id {
+			rules[i] = *rule
+		}
+	}
+	if err := checkLockout(rules, clientIP); err != nil {
+		return nil, err
+	}
+
+	updatedRule, err := s.repo.UpdateRule(ctx, id, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	s.redis.Del(context.WithoutCancel(ctx), ipRulesCacheKey)
+
+	return updatedRule, nil
+}
+
+func (s *IPRuleService) DeleteRule(ctx context.Context, id uint, clientIP string) error {
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return err
+	}
+	remaining := make([]models.IPRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.ID != id {
+			remaining = append(remaining, rule)
+		}
+	}
+	if err := checkLockout(remaining, clientIP); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRule(ctx, id); err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	s.redis.Del(context.WithoutCancel(ctx), ipRulesCacheKey)
+
+	return nil
+}
+
+// buildIPRule validates the request and stores the range in canonical CIDR
+// form
+func buildIPRule(req *IPRuleRequest) (*models.IPRule, error) {
+	action := strings.ToLower(strings.TrimSpace(req.Action))
+	if action != "allow" && action != "deny" {
+		return nil, errors.New("invalid action")
+	}
+
+	cidr := strings.TrimSpace(req.CIDR)
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return nil, errors.New("invalid cidr")
+		}
+		if ip.To4() != nil {
+			cidr += "/32"
+		} else {
+			cidr += "/128"
+		}
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.New("invalid cidr")
+	}
+
+	return &models.IPRule{
+		CIDR:   network.String(),
+		Action: action,
+		Note:   strings.TrimSpace(req.Note),
+	}, nil
+}
+
+// checkLockout rejects a rule set under which clientIP could no longer
+// manage it
+func checkLockout(rules []models.IPRule, clientIP string) error {
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return nil
+	}
+	if denied(rules, ip) || !adminAllowed(rules, ip) {
+		return errors.New("rule would lock you out")
+	}
+	return nil
+}
+
+func denied(rules []models.IPRule, ip net.IP) bool {
+	for _, rule := range rules {
+		if rule.Action == "deny" && ruleCovers(rule, ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func adminAllowed(rules []models.IPRule, ip net.IP) bool {
+	restricted := false
+	for _, rule := range rules {
+		if rule.Action != "allow" {
+			continue
+		}
+		if ruleCovers(rule, ip) {
+			return true
+		}
+		restricted = true
+	}
+	return !restricted
+}
+
+func ruleCovers(rule models.IPRule, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	_, network, err := net.ParseCIDR(rule.CIDR)
+	return err == nil && network.Contains(ip)
+}
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/telegram"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Jobs are shared by every replica and tenant, so their keys are never
+// namespaced. The queue, processing and dead-letter sets hold job IDs scored
+// by when the job is next due, when its lease expires and when it was given
+// up on; the hash holds the jobs.
+const (
+	jobQueueKey      = "jobs:queue"
+	jobProcessingKey = "jobs:processing"
+	jobDeadKey       = "jobs:dead"
+	jobDataKey       = "jobs:data"
+	jobSeqKey        = "jobs:seq"
+
+	jobMaxAttempts       = 6
+	jobBaseBackoff       = 30 * time.Second
+	jobVisibilityTimeout = 5 * time.Minute
+	jobPollInterval      = 5 * time.Second
+	jobBatchSize         = 20
+	jobDeadLimit         = 100
+)
+
+// moveJobScript moves a job ID from the sorted set KEYS[1] to KEYS[2] with
+// score ARGV[2]. It returns 0 when the ID is no longer in KEYS[1], which is
+// how a worker learns that another one claimed the job first.
+var moveJobScript = redis.NewScript(`
+if redis.call("ZREM", KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call("ZADD", KEYS[2], ARGV[2], ARGV[1])
+return 1
+`)
+
+// retryBackoff is how long to wait before retrying work that failed for the
+// given number of attempts. It doubles from jobBaseBackoff, reaching 16
+// minutes before the last of jobMaxAttempts.
+func retryBackoff(attempts int) time.Duration {
+	return jobBaseBackoff << (attempts - 1)
+}
+
+// Job types
+const (
+	JobSendMail     = "send_mail"
+	JobAutoReply    = "auto_reply"
+	JobSendTelegram = "send_telegram"
+	JobReportSpam   = "report_spam"
+)
+
+// Job is a unit of background work retried with exponential backoff until
+// it succeeds or runs out of attempts
+type Job struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	TenantID  uint            `json:"tenant_id"`
+	Payload   json.RawMessage `json:"payload" swaggertype:"object"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	FailedAt  *time.Time      `json:"failed_at,omitempty"`
+}
+
+// JobHandler runs one attempt of a job. The context is scoped to the tenant
+// that queued it.
+type JobHandler func(ctx context.Context, payload json.RawMessage) error
+
+// JobService queues background jobs in Redis, runs them and keeps the ones
+// that exhausted their retries in a dead-letter set for admins to inspect
+type JobService struct {
+	redis    *redis.Client
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+	wake     chan struct{}
+}
+
+// NewJobService returns a job service that sends mail. A nil telegram
+// client leaves Telegram messages without a handler, so none may be queued.
+func NewJobService(redis *redis.Client, mail *mailer.Mailer, telegram *telegram.Client) *JobService {
+	s := &JobService{
+		redis:    redis,
+		handlers: make(map[string]JobHandler),
+		wake:     make(chan struct{}, 1),
+	}
+	s.Handle(JobSendMail, func(ctx context.Context, payload json.RawMessage) error {
+		var msg mailer.Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			return err
+		}
+		return mail.Send(&msg)
+	})
+	if telegram != nil {
+		s.Handle(JobSendTelegram, func(ctx context.Context, payload json.RawMessage) error {
+			var msg telegramMessage
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				return err
+			}
+			return telegram.Send(ctx, msg.Text)
+		})
+	}
+	return s
+}
+
+type telegramMessage struct {
+	Text string `json:"text"`
+}
+
+// Handle registers the handler for a job type
+func (s *JobService) Handle(jobType string, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+// Enqueue queues a job for the tenant in ctx. The payload is encoded as
+// JSON and handed back to the job type's handler.
+func (s *JobService) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	return s.EnqueueAt(ctx, jobType, payload, time.Now())
+}
+
+// EnqueueAt queues a job like Enqueue that does not run before at
+func (s *JobService) EnqueueAt(ctx context.Context, jobType string, payload interface{}, at time.Time) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s job: %w", jobType, err)
+	}
+
+	// The job outlives the request that queued it
+	ctx = context.WithoutCancel(ctx)
+	seq, err := s.redis.Incr(ctx, jobSeqKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to queue %s job: %w", jobType, err)
+	}
+
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		tenantID = tenant.DefaultID
+	}
+	job := &Job{
+		ID:        strconv.FormatInt(seq, 10),
+		Type:      jobType,
+		TenantID:  tenantID,
+		Payload:   data,
+		CreatedAt: time.Now(),
+	}
+	if err := s.schedule(ctx, job, at); err != nil {
+		return fmt.Errorf("failed to queue %s job: %w", jobType, err)
+	}
+
+	s.notify()
+	return nil
+}
+
+// SendMail queues an email, logging when it cannot be queued
+func (s *JobService) SendMail(ctx context.Context, msg *mailer.Message) {
+	if err := s.Enqueue(ctx, JobSendMail, msg); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+// SendTelegram queues a Telegram message, logging when it cannot be queued
+func (s *JobService) SendTelegram(ctx context.Context, text string) {
+	if err := s.Enqueue(ctx, JobSendTelegram, telegramMessage{Text: text}); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+// Run works through due jobs until the context is cancelled. Jobs live in
+// Redis, so retries survive restarts and are shared between replicas.
+func (s *JobService) Run(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *JobService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *JobService) runDue(ctx context.Context) {
+	s.reapExpired(ctx)
+
+	ids, err := s.redis.ZRangeByScore(ctx, jobQueueKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(time.Now().Unix(), 10),
+		Count: jobBatchSize,
+	}).Result()
+	if err != nil {
+		log.Printf("Warning: failed to load due jobs: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		// Claiming leases the job to this worker until the visibility
+		// timeout, after which a crashed worker's job is queued again
+		claimed, err := s.move(ctx, jobQueueKey, jobProcessingKey, id, time.Now().Add(jobVisibilityTimeout))
+		if err != nil || !claimed {
+			continue
+		}
+
+		job, err := s.load(ctx, id)
+		if err != nil {
+			log.Printf("Warning: failed to load job %s: %v", id, err)
+			s.redis.ZRem(ctx, jobProcessingKey, id)
+			continue
+		}
+		s.attempt(ctx, job)
+	}
+}
+
+// reapExpired queues jobs again whose lease ran out, because the worker
+// running them crashed or was stopped mid-job
+func (s *JobService) reapExpired(ctx context.Context) {
+	ids, err := s.redis.ZRangeByScore(ctx, jobProcessingKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(time.Now().Unix(), 10),
+	}).Result()
+	if err != nil {
+		log.Printf("Warning: failed to load expired jobs: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		requeued, err := s.move(ctx, jobProcessingKey, jobQueueKey, id, time.Now())
+		if err != nil {
+			log.Printf("Warning: failed to requeue expired job %s: %v", id, err)
+			continue
+		}
+		if requeued {
+			log.Printf("Warning: job %s lease expired, queued again", id)
+		}
+	}
+}
+
+// attempt runs a job once, retrying it with exponential backoff on failure
+// and moving it to the dead-letter set after its last attempt
+func (s *JobService) attempt(ctx context.Context, job *Job) {
+	job.Attempts++
+
+	s.mu.RLock()
+	handler, ok := s.handlers[job.Type]
+	s.mu.RUnlock()
+
+	// Jobs are mostly queued by writes, so they read from the primary to
+	// see them. The attempt must finish within its lease.
+	err := errors.New("no handler for job type " + job.Type)
+	if ok {
+		jobCtx, cancel := context.WithTimeout(database.Primary(tenant.WithID(ctx, job.TenantID)), jobVisibilityTimeout)
+		err = handler(jobCtx, job.Payload)
+		cancel()
+	}
+	if err == nil {
+		pipe := s.redis.Pipeline()
+		pipe.ZRem(ctx, jobProcessingKey, job.ID)
+		pipe.HDel(ctx, jobDataKey, job.ID)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("Warning: failed to complete job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	job.LastError = err.Error()
+	if job.Attempts < jobMaxAttempts {
+		if err := s.release(ctx, job, jobQueueKey, time.Now().Add(retryBackoff(job.Attempts))); err != nil {
+			log.Printf("Warning: failed to reschedule job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	log.Printf("Warning: %s job %s failed after %d attempts: %v", job.Type, job.ID, job.Attempts, err)
+	now := time.Now()
+	job.FailedAt = &now
+	if err := s.release(ctx, job, jobDeadKey, now); err != nil {
+		log.Printf("Warning: failed to dead-letter job %s: %v", job.ID, err)
+	}
+}
+
+// release saves a failed job and hands it from the processing set over to
+// key, the queue for a retry or the dead-letter set
+func (s *JobService) release(ctx context.Context, job *Job, key string, at time.Time) error {
+	if err := s.save(ctx, job); err != nil {
+		return err
+	}
+	_, err := s.move(ctx, jobProcessingKey, key, job.ID, at)
+	return err
+}
+
+// GetDeadJobs returns the jobs that exhausted their retries, most recent
+// failure first
+func (s *JobService) GetDeadJobs(ctx context.Context) ([]Job, error) {
+	ids, err := s.redis.ZRevRange(ctx, jobDeadKey, 0, jobDeadLimit-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.load(ctx, id)
+		if err != nil {
+			continue
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, nil
+}
+
+// RequeueDeadJob gives a dead job a fresh set of attempts, starting now
+func (s *JobService) RequeueDeadJob(ctx context.Context, id string) (*Job, error) {
+	removed, err := s.redis.ZRem(ctx, jobDeadKey, id).Result()
+	if err != nil {
+		return nil, err
+	}
+	if removed == 0 {
+		return nil, errors.New("job not found")
+	}
+
+	job, err := s.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	job.Attempts = 0
+	job.FailedAt = nil
+	if err := s.schedule(ctx, job, time.Now()); err != nil {
+		return nil, err
+	}
+
+	s.notify()
+	return job, nil
+}
+
+// DeleteDeadJob discards a dead job
+func (s *JobService) DeleteDeadJob(ctx context.Context, id string) error {
+	removed, err := s.redis.ZRem(ctx, jobDeadKey, id).Result()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return errors.New("job not found")
+	}
+	return s.redis.HDel(ctx, jobDataKey, id).Err()
+}
+
+func (s *JobService) schedule(ctx context.Context, job *Job, at time.Time) error {
+	if err := s.save(ctx, job); err != nil {
+		return err
+	}
+	return s.redis.ZAdd(ctx, jobQueueKey, redis.Z{Score: float64(at.Unix()), Member: job.ID}).Err()
+}
+
+// move moves a job ID between sorted sets, reporting whether it was still
+// in the source set
+func (s *JobService) move(ctx context.Context, from, to, id string, at time.Time) (bool, error) {
+	moved, err := moveJobScript.Run(ctx, s.redis, []string{from, to}, id, at.Unix()).Int()
+	if err != nil {
+		return false, err
+	}
+	return moved == 1, nil
+}
+
+func (s *JobService) save(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return s.redis.HSet(ctx, jobDataKey, job.ID, data).Err()
+}
+
+func (s *JobService) load(ctx context.Context, id string) (*Job, error) {
+	data, err := s.redis.HGet(ctx, jobDataKey, id).Bytes()
+	if err == redis.Nil {
+		return nil, errors.New("job not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var job Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestJobService(t *testing.T) (*JobService, *redis.Client) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	return NewJobService(client, nil, nil), client
+}
+
+func TestJobLeaseExpiryRequeuesJob(t *testing.T) {
+	s, client := newTestJobService(t)
+	ctx := context.Background()
+
+	runs := 0
+	s.Handle("test", func(ctx context.Context, payload json.RawMessage) error {
+		runs++
+		return nil
+	})
+	if err := s.Enqueue(ctx, "test", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A worker claimed the job and crashed before its lease ran out
+	if claimed, err := s.move(ctx, jobQueueKey, jobProcessingKey, "1", time.Now().Add(-time.Second)); err != nil || !claimed {
+		t.Fatalf("claim = %v, %v", claimed, err)
+	}
+
+	s.runDue(ctx)
+	if runs != 1 {
+		t.Fatalf("got %d runs, want the expired job to run again", runs)
+	}
+	if n := client.ZCard(ctx, jobProcessingKey).Val() + client.HLen(ctx, jobDataKey).Val(); n != 0 {
+		t.Errorf("completed job left %d entries behind", n)
+	}
+}
+
+func TestJobDeadLettersAfterLastAttempt(t *testing.T) {
+	s, client := newTestJobService(t)
+	ctx := context.Background()
+
+	s.Handle("test", func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("smtp unavailable")
+	})
+	if err := s.Enqueue(ctx, "test", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= jobMaxAttempts; i++ {
+		// Make the retry due now instead of after its backoff
+		client.ZAdd(ctx, jobQueueKey, redis.Z{Score: 0, Member: "1"})
+		s.runDue(ctx)
+	}
+
+	jobs, err := s.GetDeadJobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Attempts != jobMaxAttempts || jobs[0].LastError != "smtp unavailable" {
+		t.Fatalf("got dead jobs %+v, want the job after %d attempts", jobs, jobMaxAttempts)
+	}
+	if n := client.ZCard(ctx, jobQueueKey).Val() + client.ZCard(ctx, jobProcessingKey).Val(); n != 0 {
+		t.Errorf("dead job still queued or leased (%d entries)", n)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sort"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"time"
+)
+
+const (
+	likePendingKey  = "likes:pending"
+	likeFlushingKey = "likes:flushing"
+	likeDayTTL      = 48 * time.Hour // Keeps a day's salt and likers until the day has ended everywhere
+	likeRateWindow  = time.Hour
+	likeRateLimit   = 30 // Likes per IP per window
+)
+
+// LikeResult reports the outcome of a like
+type LikeResult struct {
+	ProjectID uint  `json:"project_id"`
+	LikeCount int64 `json:"like_count"`
+	Counted   bool  `json:"counted"` // False when this visitor already liked the project today
+}
+
+// LikeProject counts an anonymous like of a published project. Visitors
+// are told apart by a hash of their IP address and user agent salted with
+// a secret that changes every day, so no address is stored and a visitor
+// can like each project once a day. Counts accumulate in Redis and are
+// written to the database by RunLikeFlush.
+func (s *ProjectService) LikeProject(ctx context.Context, id uint, ipAddress, userAgent string) (*LikeResult, error) {
+	projects, err := s.getProjects(ctx, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var project *models.Project
+	for i := range projects {
+		if projects[i].ID == id {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	rateKey := "likes:rate:" + ipAddress
+	count, err := s.redis.Incr(ctx, rateKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		s.redis.Expire(ctx, rateKey, likeRateWindow)
+	}
+	if count > likeRateLimit {
+		return nil, errors.New("rate limit exceeded")
+	}
+
+	day := time.Now().UTC().Format("2006-01-02")
+	salt, err := s.likeSalt(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+	field := strconv.FormatUint(uint64(id), 10)
+	fingerprint := sha256.Sum256([]byte(salt + "|" + ipAddress + "|" + userAgent))
+	seenKey := "likes:seen:" + day + ":" + field
+	added, err := s.redis.SAdd(ctx, seenKey, hex.EncodeToString(fingerprint[:])).Result()
+	if err != nil {
+		return nil, err
+	}
+	counted := added == 1
+
+	if counted {
+		s.redis.Expire(ctx, seenKey, likeDayTTL)
+		if err := s.redis.HIncrBy(ctx, likePendingKey, field, 1).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &LikeResult{
+		ProjectID: id,
+		LikeCount: project.LikeCount + pendingCounts(ctx, s.redis, likePendingKey, likeFlushingKey)[id],
+		Counted:   counted,
+	}, nil
+}
+
+// likeSalt returns the secret mixed into the visitor fingerprints of a day,
+// creating it on the first like
+func (s *ProjectService) likeSalt(ctx context.Context, day string) (string, error) {
+	key := "likes:salt:" + day
+	salt, err := models.GenerateRandomString(32)
+	if err != nil {
+		return "", err
+	}
+	if err := s.redis.SetNX(ctx, key, salt, likeDayTTL).Err(); err != nil {
+		return "", err
+	}
+	return s.redis.Get(ctx, key).Result()
+}
+
+// addPendingLikes adds the likes not yet flushed to the database to the
+// projects' stored counts
+func (s *ProjectService) addPendingLikes(ctx context.Context, projects []models.Project) {
+	pending := pendingCounts(ctx, s.redis, likePendingKey, likeFlushingKey)
+	for i := range projects {
+		projects[i].LikeCount += pending[projects[i].ID]
+	}
+}
+
+// ProjectLikes is a project's place in the like ranking
+type ProjectLikes struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Published bool   `json:"published"`
+	LikeCount int64  `json:"like_count"`
+}
+
+// GetMostLikedProjects returns the projects with the most likes, drafts
+// included, up to limit
+func (s *ProjectService) GetMostLikedProjects(ctx context.Context, limit int) ([]ProjectLikes, error) {
+	projects, err := s.GetProjects(ctx, nil, nil, "", true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ranking := make([]ProjectLikes, 0, len(projects))
+	for _, project := range projects {
+		ranking = append(ranking, ProjectLikes{
+			ID:        project.ID,
+			Name:      project.Name,
+			Published: project.Published,
+			LikeCount: project.LikeCount,
+		})
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].LikeCount > ranking[j].LikeCount
+	})
+	if len(ranking) > limit {
+		ranking = ranking[:limit]
+	}
+	return ranking, nil
+}
+
+// RunLikeFlush writes pending like counts to the database at the given
+// interval until the context is cancelled
+func (s *ProjectService) RunLikeFlush(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.flushLikes(ctx); err != nil {
+				log.Printf("Warning: failed to flush project likes: %v", err)
+			}
+		}
+	}
+}
+
+// flushLikes adds the pending likes to the database
+func (s *ProjectService) flushLikes(ctx context.Context) error {
+	return flushCounts(ctx, s.redis, likePendingKey, likeFlushingKey, func(increments map[uint]int64) error {
+		tenants, err := s.repo.AddLikes(tenant.All(ctx), increments)
+		if err != nil {
+			return err
+		}
+
+		// The cached projects now lag behind the database
+		for _, id := range tenants {
+			invalidate(tenant.WithID(ctx, id), s.redis, projectCacheKeys...)
+		}
+		return nil
+	})
+}
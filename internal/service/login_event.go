@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+	"time"
+)
+
+// What a login must come from to trigger an alert
+const (
+	LoginAlertsIP      = "ip"
+	LoginAlertsCountry = "country"
+)
+
+// LoginAlerts configures the notifications sent for logins from places a
+// user never logged in from before
+type LoginAlerts struct {
+	On       string // LoginAlertsIP, LoginAlertsCountry or empty for none
+	Email    string // Recipient of alert emails; empty sends none
+	Telegram bool   // Whether alerts also go to the configured Telegram chat
+}
+
+// GetLoginEvents returns the most recent login attempts, optionally only
+// those of one username or with one outcome
+func (s *AuthService) GetLoginEvents(ctx context.Context, username string, success *bool, limit int) ([]models.LoginEvent, error) {
+	return s.logins.GetLoginEvents(ctx, username, success, limit)
+}
+
+// recordLogin adds a login attempt to the history. Successful logins from
+// an address or country the user never logged in from are flagged; a new
+// address notifies admins and, as configured, sends an alert. The very
+// first login has nothing to compare against and is never new. Failures
+// here are logged and never stop a login.
+func (s *AuthService) recordLogin(ctx context.Context, req *LoginRequest, success bool) {
+	ctx = context.WithoutCancel(ctx)
+	event := &models.LoginEvent{
+		Username:  req.Username,
+		Success:   success,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		CreatedAt: time.Now(),
+	}
+	if location := locate(ctx, s.redis, s.locator, req.IPAddress); location != nil {
+		event.Country = location.Country
+		event.City = location.City
+	}
+
+	if success {
+		hasHistory, knownIP, knownCountry, err := s.logins.KnownLocations(ctx, event.Username, event.IPAddress, event.Country)
+		if err != nil {
+			log.Printf("Warning: failed to check login history: %v", err)
+		} else if hasHistory {
+			event.NewIP = event.IPAddress != "" && !knownIP
+			event.NewCountry = event.Country != "" && !knownCountry
+		}
+	}
+
+	if err := s.logins.CreateLoginEvent(ctx, event); err != nil {
+		log.Printf("Warning: failed to record login: %v", err)
+	}
+
+	if event.NewIP {
+		s.events.Publish(ctx, events.LoginFromNewIP, event)
+	}
+	s.alert(ctx, event)
+}
+
+// alert emails and messages the owner about a login from a new place
+func (s *AuthService) alert(ctx context.Context, event *models.LoginEvent) {
+	switch {
+	case s.alerts.On == LoginAlertsIP && event.NewIP:
+	case s.alerts.On == LoginAlertsCountry && event.NewCountry:
+	default:
+		return
+	}
+
+	where := event.IPAddress
+	if place := strings.Trim(event.City+", "+event.Country, ", "); place != "" {
+		where += " (" + place + ")"
+	}
+	text := fmt.Sprintf("New admin login as %s from %s at %s.\n\nBrowser: %s\n\nIf this was not you, change the password and review GET /api/v1/admin/security/logins.",
+		event.Username, where, event.CreatedAt.UTC().Format(time.RFC1123), event.UserAgent)
+
+	if s.alerts.Email != "" {
+		s.jobs.SendMail(ctx, &mailer.Message{
+			To:      []string{s.alerts.Email},
+			Subject: "New login from " + where,
+			Body:    text,
+		})
+	}
+	if s.alerts.Telegram {
+		s.jobs.SendTelegram(ctx, text)
+	}
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"stackwhiz-portfolio-backend/internal/imaging"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/storage"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"time"
+)
+
+// mediaExtensions lists the accepted upload types and the extension used
+// when storing them. The type is sniffed from the contents, never taken from
+// the client.
+var mediaExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// imageVariants are the resized copies generated for uploaded images, keyed
+// by name with the longest side in pixels
+var imageVariants = []struct {
+	name    string
+	maxSize int
+}{
+	{"thumb", 200},
+	{"card", 600},
+	{"full", 1600},
+}
+
+// Lifetimes of signed media URLs. S3 presigned URLs cannot outlive a week.
+const (
+	DefaultSignedURLTTL = 15 * time.Minute
+	MaxSignedURLTTL     = 7 * 24 * time.Hour
+)
+
+// maxConcurrentResizes bounds the memory used by decoding large images
+const maxConcurrentResizes = 2
+
+// MediaFile is the metadata and contents of a stored file
+type MediaFile struct {
+	io.ReadCloser
+	MimeType string
+	Size     int64
+	Checksum string
+	Private  bool
+}
+
+// MediaURL is a time-limited download link for an uploaded file
+type MediaURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// VariantsHook is called once the variants of an uploaded image are stored
+type VariantsHook func(ctx context.Context, media *models.Media)
+
+// MediaService handles file uploads and their metadata
+type MediaService struct {
+	repo         *repository.MediaRepository
+	storage      storage.Storage
+	maxSize      int64
+	resizeSlots  chan struct{}
+	variantHooks []VariantsHook
+}
+
+func NewMediaService(repo *repository.MediaRepository, storage storage.Storage, maxSize int64) *MediaService {
+	return &MediaService{
+		repo:        repo,
+		storage:     storage,
+		maxSize:     maxSize,
+		resizeSlots: make(chan struct{}, maxConcurrentResizes),
+	}
+}
+
+// OnVariantsReady registers a hook run after image variants are generated
+func (s *MediaService) OnVariantsReady(hook VariantsHook) {
+	s.variantHooks = append(s.variantHooks, hook)
+}
+
+// MaxSize returns the largest accepted upload in bytes
+func (s *MediaService) MaxSize() int64 {
+	return s.maxSize
+}
+
+func (s *MediaService) GetMedia(ctx context.Context) ([]models.Media, error) {
+	return s.repo.GetMedia(ctx)
+}
+
+// Upload stores a file and records its metadata. Private files are only
+// served through signed URLs.
+func (s *MediaService) Upload(ctx context.Context, filename string, r io.Reader, private bool) (*models.Media, error) {
+	reader := bufio.NewReaderSize(r, 512)
+	head, err := reader.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	mimeType := http.DetectContentType(head)
+	ext, ok := mediaExtensions[mimeType]
+	if !ok {
+		return nil, errors.New("unsupported file type")
+	}
+
+	name, err := models.GenerateRandomString(16)
+	if err != nil {
+		return nil, err
+	}
+	storedName := name + ext
+
+	// Read one byte past the limit so oversized uploads can be detected
+	hash := sha256.New()
+	counter := &countingWriter{}
+	body := io.TeeReader(io.LimitReader(reader, s.maxSize+1), io.MultiWriter(hash, counter))
+	if err := s.storage.Put(storedName, body); err != nil {
+		return nil, err
+	}
+	if counter.n > s.maxSize {
+		s.removeObject(storedName)
+		return nil, errors.New("file too large")
+	}
+
+	media := &models.Media{
+		Filename:   filename,
+		StoredName: storedName,
+		Size:       counter.n,
+		MimeType:   mimeType,
+		Checksum:   hex.EncodeToString(hash.Sum(nil)),
+		URL:        "/media/" + storedName,
+		Private:    private,
+	}
+
+	createdMedia, err := s.repo.CreateMedia(ctx, media)
+	if err != nil {
+		s.removeObject(storedName)
+		return nil, err
+	}
+
+	if strings.HasPrefix(mimeType, "image/") {
+		go s.generateVariants(context.WithoutCancel(ctx), createdMedia)
+	}
+
+	return createdMedia, nil
+}
+
+// generateVariants stores the resized copies of an uploaded image
+func (s *MediaService) generateVariants(ctx context.Context, media *models.Media) {
+	s.resizeSlots <- struct{}{}
+	defer func() { <-s.resizeSlots }()
+
+	file, err := s.storage.Get(media.StoredName)
+	if err != nil {
+		log.Printf("Warning: failed to open media %d for resizing: %v", media.ID, err)
+		return
+	}
+	src, err := imaging.Decode(file)
+	file.Close()
+	if err != nil {
+		log.Printf("Warning: failed to decode media %d: %v", media.ID, err)
+		return
+	}
+
+	keepAlpha := media.MimeType == "image/png" || media.MimeType == "image/gif"
+	for _, spec := range imageVariants {
+		variant, err := s.storeVariant(ctx, media, spec.name, imaging.Fit(src, spec.maxSize), keepAlpha)
+		if err != nil {
+			log.Printf("Warning: failed to store %s variant of media %d: %v", spec.name, media.ID, err)
+			return
+		}
+		media.Variants = append(media.Variants, *variant)
+	}
+
+	for _, hook := range s.variantHooks {
+		hook(ctx, media)
+	}
+}
+
+// storeVariant encodes img as the named variant of media and records it
+func (s *MediaService) storeVariant(ctx context.Context, media *models.Media, name string, img image.Image, keepAlpha bool) (*models.MediaVariant, error) {
+	var buf bytes.Buffer
+	mimeType, err := imaging.Encode(&buf, img, keepAlpha)
+	if err != nil {
+		return nil, err
+	}
+
+	baseName := strings.TrimSuffix(media.StoredName, path.Ext(media.StoredName))
+	storedName := baseName + "-" + name + mediaExtensions[mimeType]
+	if err := s.storage.Put(storedName, bytes.NewReader(buf.Bytes())); err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(buf.Bytes())
+	variant, err := s.repo.CreateVariant(ctx, &models.MediaVariant{
+		MediaID:    media.ID,
+		Name:       name,
+		StoredName: storedName,
+		URL:        "/media/" + storedName,
+		Width:      img.Bounds().Dx(),
+		Height:     img.Bounds().Dy(),
+		Size:       int64(buf.Len()),
+		MimeType:   mimeType,
+		Checksum:   hex.EncodeToString(checksum[:]),
+	})
+	if err != nil {
+		s.removeObject(storedName)
+		return nil, err
+	}
+	return variant, nil
+}
+
+// Open returns an uploaded file or one of its variants. Stored names are
+// unique across tenants, so files are served whichever host asks for them.
+// Private files and their variants need the signature of a signed URL in
+// query; without one they are reported as not found.
+func (s *MediaService) Open(ctx context.Context, storedName string, query url.Values) (*MediaFile, error) {
+	ctx = tenant.All(ctx)
+	file := &MediaFile{}
+	var private bool
+	if media, err := s.repo.GetMediaByStoredName(ctx, storedName); err == nil {
+		file.MimeType, file.Size, file.Checksum = media.MimeType, media.Size, media.Checksum
+		private = media.Private
+	} else if variant, err := s.repo.GetVariantByStoredName(ctx, storedName); err == nil {
+		file.MimeType, file.Size, file.Checksum = variant.MimeType, variant.Size, variant.Checksum
+		media, err := s.repo.GetMediaByID(ctx, variant.MediaID)
+		if err != nil {
+			return nil, err
+		}
+		private = media.Private
+	} else {
+		return nil, err
+	}
+
+	if private {
+		verifier, ok := s.storage.(storage.URLVerifier)
+		if !ok || !verifier.Verify(storedName, query) {
+			return nil, errors.New("media not found")
+		}
+	}
+	file.Private = private
+
+	contents, err := s.storage.Get(storedName)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, errors.New("media not found")
+		}
+		return nil, err
+	}
+	file.ReadCloser = contents
+	return file, nil
+}
+
+// SignedURL returns a link to an uploaded file, or to the named variant of
+// it, that works without credentials until ttl has passed. Backends that
+// serve files themselves return links to the bucket; local storage links
+// back to /media.
+func (s *MediaService) SignedURL(ctx context.Context, id uint, variant string, ttl time.Duration) (*MediaURL, error) {
+	if ttl <= 0 || ttl > MaxSignedURLTTL {
+		return nil, errors.New("invalid ttl")
+	}
+
+	media, err := s.repo.GetMediaByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	storedName := media.StoredName
+	if variant != "" {
+		storedName = ""
+		for _, v := range media.Variants {
+			if v.Name == variant {
+				storedName = v.StoredName
+				break
+			}
+		}
+		if storedName == "" {
+			return nil, errors.New("variant not found")
+		}
+	}
+
+	expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+	signedURL, err := s.storage.SignedURL(storedName, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &MediaURL{URL: signedURL, ExpiresAt: expiresAt}, nil
+}
+
+func (s *MediaService) DeleteMedia(ctx context.Context, id uint) error {
+	media, err := s.repo.DeleteMedia(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.removeObject(media.StoredName)
+	for _, variant := range media.Variants {
+		s.removeObject(variant.StoredName)
+	}
+	return nil
+}
+
+func (s *MediaService) removeObject(storedName string) {
+	if err := s.storage.Delete(storedName); err != nil {
+		log.Printf("Warning: failed to delete stored media %s: %v", storedName, err)
+	}
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/storage"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrivateMediaNeedsSignedURL(t *testing.T) {
+	dialector, err := database.SQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	db, err := database.Initialize(dialector, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := storage.NewLocalStorage(storage.LocalConfig{Dir: t.TempDir(), URLPrefix: "/media", SigningKey: []byte("secret")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := repository.NewMediaRepository(db)
+	s := NewMediaService(repo, store, 1<<20)
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+
+	for _, name := range []string{"private.pdf", "public.pdf"} {
+		if err := store.Put(name, strings.NewReader("contents of "+name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	private, err := repo.CreateMedia(ctx, &models.Media{Filename: "cv.pdf", StoredName: "private.pdf", MimeType: "application/pdf", Private: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateMedia(ctx, &models.Media{Filename: "talk.pdf", StoredName: "public.pdf", MimeType: "application/pdf"}); err != nil {
+		t.Fatal(err)
+	}
+
+	open := func(name string, query url.Values) (string, error) {
+		file, err := s.Open(ctx, name, query)
+		if err != nil {
+			return "", err
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		return string(data), err
+	}
+
+	if got, err := open("public.pdf", nil); err != nil || got != "contents of public.pdf" {
+		t.Errorf("public file = %q, %v", got, err)
+	}
+	if _, err := open("private.pdf", nil); err == nil || err.Error() != "media not found" {
+		t.Errorf("private file without a signature: err = %v, want media not found", err)
+	}
+
+	signed, err := s.SignedURL(ctx, private.ID, "", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(signed.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := open("private.pdf", u.Query()); err != nil || got != "contents of private.pdf" {
+		t.Errorf("private file with a signed URL = %q, %v", got, err)
+	}
+
+	tampered := u.Query()
+	tampered.Set("expires", "9999999999")
+	if _, err := open("private.pdf", tampered); err == nil {
+		t.Error("private file opened with a tampered signed URL")
+	}
+}
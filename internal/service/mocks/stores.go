@@ -0,0 +1,800 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stores.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stores.go -destination=mocks/stores.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "stackwhiz-portfolio-backend/internal/models"
+	repository "stackwhiz-portfolio-backend/internal/repository"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProfileStore is a mock of ProfileStore interface.
+type MockProfileStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileStoreMockRecorder
+}
+
+// MockProfileStoreMockRecorder is the mock recorder for MockProfileStore.
+type MockProfileStoreMockRecorder struct {
+	mock *MockProfileStore
+}
+
+// NewMockProfileStore creates a new mock instance.
+func NewMockProfileStore(ctrl *gomock.Controller) *MockProfileStore {
+	mock := &MockProfileStore{ctrl: ctrl}
+	mock.recorder = &MockProfileStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileStore) EXPECT() *MockProfileStoreMockRecorder {
+	return m.recorder
+}
+
+// GetProfile mocks base method.
+func (m *MockProfileStore) GetProfile(ctx context.Context) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockProfileStoreMockRecorder) GetProfile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockProfileStore)(nil).GetProfile), ctx)
+}
+
+// UpdateAvatar mocks base method.
+func (m *MockProfileStore) UpdateAvatar(ctx context.Context, avatar string, variants map[string]string) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvatar", ctx, avatar, variants)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAvatar indicates an expected call of UpdateAvatar.
+func (mr *MockProfileStoreMockRecorder) UpdateAvatar(ctx, avatar, variants any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatar", reflect.TypeOf((*MockProfileStore)(nil).UpdateAvatar), ctx, avatar, variants)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockProfileStore) UpdateProfile(ctx context.Context, profile *models.Profile) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, profile)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockProfileStoreMockRecorder) UpdateProfile(ctx, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockProfileStore)(nil).UpdateProfile), ctx, profile)
+}
+
+// MockExperienceStore is a mock of ExperienceStore interface.
+type MockExperienceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockExperienceStoreMockRecorder
+}
+
+// MockExperienceStoreMockRecorder is the mock recorder for MockExperienceStore.
+type MockExperienceStoreMockRecorder struct {
+	mock *MockExperienceStore
+}
+
+// NewMockExperienceStore creates a new mock instance.
+func NewMockExperienceStore(ctrl *gomock.Controller) *MockExperienceStore {
+	mock := &MockExperienceStore{ctrl: ctrl}
+	mock.recorder = &MockExperienceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExperienceStore) EXPECT() *MockExperienceStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateExperience mocks base method.
+func (m *MockExperienceStore) CreateExperience(ctx context.Context, experience *models.Experience) (*models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExperience", ctx, experience)
+	ret0, _ := ret[0].(*models.Experience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExperience indicates an expected call of CreateExperience.
+func (mr *MockExperienceStoreMockRecorder) CreateExperience(ctx, experience any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExperience", reflect.TypeOf((*MockExperienceStore)(nil).CreateExperience), ctx, experience)
+}
+
+// DeleteExperience mocks base method.
+func (m *MockExperienceStore) DeleteExperience(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExperience", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExperience indicates an expected call of DeleteExperience.
+func (mr *MockExperienceStoreMockRecorder) DeleteExperience(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExperience", reflect.TypeOf((*MockExperienceStore)(nil).DeleteExperience), ctx, id)
+}
+
+// GetExperiences mocks base method.
+func (m *MockExperienceStore) GetExperiences(ctx context.Context, includeDrafts bool) ([]models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExperiences", ctx, includeDrafts)
+	ret0, _ := ret[0].([]models.Experience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExperiences indicates an expected call of GetExperiences.
+func (mr *MockExperienceStoreMockRecorder) GetExperiences(ctx, includeDrafts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExperiences", reflect.TypeOf((*MockExperienceStore)(nil).GetExperiences), ctx, includeDrafts)
+}
+
+// ReorderExperiences mocks base method.
+func (m *MockExperienceStore) ReorderExperiences(ctx context.Context, ids []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderExperiences", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderExperiences indicates an expected call of ReorderExperiences.
+func (mr *MockExperienceStoreMockRecorder) ReorderExperiences(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderExperiences", reflect.TypeOf((*MockExperienceStore)(nil).ReorderExperiences), ctx, ids)
+}
+
+// UpdateExperience mocks base method.
+func (m *MockExperienceStore) UpdateExperience(ctx context.Context, id uint, experience *models.Experience) (*models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExperience", ctx, id, experience)
+	ret0, _ := ret[0].(*models.Experience)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExperience indicates an expected call of UpdateExperience.
+func (mr *MockExperienceStoreMockRecorder) UpdateExperience(ctx, id, experience any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExperience", reflect.TypeOf((*MockExperienceStore)(nil).UpdateExperience), ctx, id, experience)
+}
+
+// MockSkillStore is a mock of SkillStore interface.
+type MockSkillStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSkillStoreMockRecorder
+}
+
+// MockSkillStoreMockRecorder is the mock recorder for MockSkillStore.
+type MockSkillStoreMockRecorder struct {
+	mock *MockSkillStore
+}
+
+// NewMockSkillStore creates a new mock instance.
+func NewMockSkillStore(ctrl *gomock.Controller) *MockSkillStore {
+	mock := &MockSkillStore{ctrl: ctrl}
+	mock.recorder = &MockSkillStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSkillStore) EXPECT() *MockSkillStoreMockRecorder {
+	return m.recorder
+}
+
+// AddEndorsements mocks base method.
+func (m *MockSkillStore) AddEndorsements(ctx context.Context, increments map[uint]int64) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEndorsements", ctx, increments)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEndorsements indicates an expected call of AddEndorsements.
+func (mr *MockSkillStoreMockRecorder) AddEndorsements(ctx, increments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEndorsements", reflect.TypeOf((*MockSkillStore)(nil).AddEndorsements), ctx, increments)
+}
+
+// CreateSkill mocks base method.
+func (m *MockSkillStore) CreateSkill(ctx context.Context, skill *models.Skill) (*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSkill", ctx, skill)
+	ret0, _ := ret[0].(*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSkill indicates an expected call of CreateSkill.
+func (mr *MockSkillStoreMockRecorder) CreateSkill(ctx, skill any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSkill", reflect.TypeOf((*MockSkillStore)(nil).CreateSkill), ctx, skill)
+}
+
+// DeleteSkill mocks base method.
+func (m *MockSkillStore) DeleteSkill(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSkill", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSkill indicates an expected call of DeleteSkill.
+func (mr *MockSkillStoreMockRecorder) DeleteSkill(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSkill", reflect.TypeOf((*MockSkillStore)(nil).DeleteSkill), ctx, id)
+}
+
+// GetSkillPeriods mocks base method.
+func (m *MockSkillStore) GetSkillPeriods(ctx context.Context) ([]repository.SkillPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkillPeriods", ctx)
+	ret0, _ := ret[0].([]repository.SkillPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkillPeriods indicates an expected call of GetSkillPeriods.
+func (mr *MockSkillStoreMockRecorder) GetSkillPeriods(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkillPeriods", reflect.TypeOf((*MockSkillStore)(nil).GetSkillPeriods), ctx)
+}
+
+// GetSkills mocks base method.
+func (m *MockSkillStore) GetSkills(ctx context.Context) ([]models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSkills", ctx)
+	ret0, _ := ret[0].([]models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSkills indicates an expected call of GetSkills.
+func (mr *MockSkillStoreMockRecorder) GetSkills(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkills", reflect.TypeOf((*MockSkillStore)(nil).GetSkills), ctx)
+}
+
+// LinkExperienceSkills mocks base method.
+func (m *MockSkillStore) LinkExperienceSkills(ctx context.Context, links []repository.SkillLink, username string) ([]models.Skill, []models.Experience, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkExperienceSkills", ctx, links, username)
+	ret0, _ := ret[0].([]models.Skill)
+	ret1, _ := ret[1].([]models.Experience)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LinkExperienceSkills indicates an expected call of LinkExperienceSkills.
+func (mr *MockSkillStoreMockRecorder) LinkExperienceSkills(ctx, links, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkExperienceSkills", reflect.TypeOf((*MockSkillStore)(nil).LinkExperienceSkills), ctx, links, username)
+}
+
+// MergeSkillCategories mocks base method.
+func (m *MockSkillStore) MergeSkillCategories(ctx context.Context, from []string, to, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeSkillCategories", ctx, from, to, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeSkillCategories indicates an expected call of MergeSkillCategories.
+func (mr *MockSkillStoreMockRecorder) MergeSkillCategories(ctx, from, to, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeSkillCategories", reflect.TypeOf((*MockSkillStore)(nil).MergeSkillCategories), ctx, from, to, username)
+}
+
+// MergeSkills mocks base method.
+func (m *MockSkillStore) MergeSkills(ctx context.Context, sourceIDs []uint, targetID uint, username string) (*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeSkills", ctx, sourceIDs, targetID, username)
+	ret0, _ := ret[0].(*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeSkills indicates an expected call of MergeSkills.
+func (mr *MockSkillStoreMockRecorder) MergeSkills(ctx, sourceIDs, targetID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeSkills", reflect.TypeOf((*MockSkillStore)(nil).MergeSkills), ctx, sourceIDs, targetID, username)
+}
+
+// RenameSkillCategory mocks base method.
+func (m *MockSkillStore) RenameSkillCategory(ctx context.Context, from, to, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameSkillCategory", ctx, from, to, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameSkillCategory indicates an expected call of RenameSkillCategory.
+func (mr *MockSkillStoreMockRecorder) RenameSkillCategory(ctx, from, to, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameSkillCategory", reflect.TypeOf((*MockSkillStore)(nil).RenameSkillCategory), ctx, from, to, username)
+}
+
+// ReorderSkills mocks base method.
+func (m *MockSkillStore) ReorderSkills(ctx context.Context, ids []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderSkills", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderSkills indicates an expected call of ReorderSkills.
+func (mr *MockSkillStoreMockRecorder) ReorderSkills(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSkills", reflect.TypeOf((*MockSkillStore)(nil).ReorderSkills), ctx, ids)
+}
+
+// UpdateSkill mocks base method.
+func (m *MockSkillStore) UpdateSkill(ctx context.Context, id uint, skill *models.Skill) (*models.Skill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSkill", ctx, id, skill)
+	ret0, _ := ret[0].(*models.Skill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSkill indicates an expected call of UpdateSkill.
+func (mr *MockSkillStoreMockRecorder) UpdateSkill(ctx, id, skill any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSkill", reflect.TypeOf((*MockSkillStore)(nil).UpdateSkill), ctx, id, skill)
+}
+
+// MockProjectStore is a mock of ProjectStore interface.
+type MockProjectStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectStoreMockRecorder
+}
+
+// MockProjectStoreMockRecorder is the mock recorder for MockProjectStore.
+type MockProjectStoreMockRecorder struct {
+	mock *MockProjectStore
+}
+
+// NewMockProjectStore creates a new mock instance.
+func NewMockProjectStore(ctrl *gomock.Controller) *MockProjectStore {
+	mock := &MockProjectStore{ctrl: ctrl}
+	mock.recorder = &MockProjectStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectStore) EXPECT() *MockProjectStoreMockRecorder {
+	return m.recorder
+}
+
+// AddLikes mocks base method.
+func (m *MockProjectStore) AddLikes(ctx context.Context, increments map[uint]int64) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLikes", ctx, increments)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLikes indicates an expected call of AddLikes.
+func (mr *MockProjectStoreMockRecorder) AddLikes(ctx, increments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLikes", reflect.TypeOf((*MockProjectStore)(nil).AddLikes), ctx, increments)
+}
+
+// AddProjectImage mocks base method.
+func (m *MockProjectStore) AddProjectImage(ctx context.Context, projectID uint, image *models.ProjectImage) (*models.ProjectImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProjectImage", ctx, projectID, image)
+	ret0, _ := ret[0].(*models.ProjectImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProjectImage indicates an expected call of AddProjectImage.
+func (mr *MockProjectStoreMockRecorder) AddProjectImage(ctx, projectID, image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProjectImage", reflect.TypeOf((*MockProjectStore)(nil).AddProjectImage), ctx, projectID, image)
+}
+
+// CreateProject mocks base method.
+func (m *MockProjectStore) CreateProject(ctx context.Context, project *models.Project) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", ctx, project)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockProjectStoreMockRecorder) CreateProject(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProjectStore)(nil).CreateProject), ctx, project)
+}
+
+// CreateProjectMilestone mocks base method.
+func (m *MockProjectStore) CreateProjectMilestone(ctx context.Context, projectID uint, milestone *models.ProjectMilestone) (*models.ProjectMilestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProjectMilestone", ctx, projectID, milestone)
+	ret0, _ := ret[0].(*models.ProjectMilestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProjectMilestone indicates an expected call of CreateProjectMilestone.
+func (mr *MockProjectStoreMockRecorder) CreateProjectMilestone(ctx, projectID, milestone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProjectMilestone", reflect.TypeOf((*MockProjectStore)(nil).CreateProjectMilestone), ctx, projectID, milestone)
+}
+
+// DeleteProject mocks base method.
+func (m *MockProjectStore) DeleteProject(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockProjectStoreMockRecorder) DeleteProject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProjectStore)(nil).DeleteProject), ctx, id)
+}
+
+// DeleteProjectImage mocks base method.
+func (m *MockProjectStore) DeleteProjectImage(ctx context.Context, projectID, imageID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProjectImage", ctx, projectID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProjectImage indicates an expected call of DeleteProjectImage.
+func (mr *MockProjectStoreMockRecorder) DeleteProjectImage(ctx, projectID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectImage", reflect.TypeOf((*MockProjectStore)(nil).DeleteProjectImage), ctx, projectID, imageID)
+}
+
+// DeleteProjectMilestone mocks base method.
+func (m *MockProjectStore) DeleteProjectMilestone(ctx context.Context, projectID, milestoneID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProjectMilestone", ctx, projectID, milestoneID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProjectMilestone indicates an expected call of DeleteProjectMilestone.
+func (mr *MockProjectStoreMockRecorder) DeleteProjectMilestone(ctx, projectID, milestoneID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectMilestone", reflect.TypeOf((*MockProjectStore)(nil).DeleteProjectMilestone), ctx, projectID, milestoneID)
+}
+
+// GetProject mocks base method.
+func (m *MockProjectStore) GetProject(ctx context.Context, id uint) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", ctx, id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockProjectStoreMockRecorder) GetProject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockProjectStore)(nil).GetProject), ctx, id)
+}
+
+// GetProjects mocks base method.
+func (m *MockProjectStore) GetProjects(ctx context.Context, featured *bool, categoryID *uint, includeDrafts, includeArchived bool) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", ctx, featured, categoryID, includeDrafts, includeArchived)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockProjectStoreMockRecorder) GetProjects(ctx, featured, categoryID, includeDrafts, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockProjectStore)(nil).GetProjects), ctx, featured, categoryID, includeDrafts, includeArchived)
+}
+
+// RefreshImageVariants mocks base method.
+func (m *MockProjectStore) RefreshImageVariants(ctx context.Context, imageURL string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshImageVariants", ctx, imageURL)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshImageVariants indicates an expected call of RefreshImageVariants.
+func (mr *MockProjectStoreMockRecorder) RefreshImageVariants(ctx, imageURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshImageVariants", reflect.TypeOf((*MockProjectStore)(nil).RefreshImageVariants), ctx, imageURL)
+}
+
+// ReorderFeaturedProjects mocks base method.
+func (m *MockProjectStore) ReorderFeaturedProjects(ctx context.Context, ids []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderFeaturedProjects", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderFeaturedProjects indicates an expected call of ReorderFeaturedProjects.
+func (mr *MockProjectStoreMockRecorder) ReorderFeaturedProjects(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderFeaturedProjects", reflect.TypeOf((*MockProjectStore)(nil).ReorderFeaturedProjects), ctx, ids)
+}
+
+// ReorderProjectImages mocks base method.
+func (m *MockProjectStore) ReorderProjectImages(ctx context.Context, projectID uint, ids []uint) ([]models.ProjectImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderProjectImages", ctx, projectID, ids)
+	ret0, _ := ret[0].([]models.ProjectImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderProjectImages indicates an expected call of ReorderProjectImages.
+func (mr *MockProjectStoreMockRecorder) ReorderProjectImages(ctx, projectID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderProjectImages", reflect.TypeOf((*MockProjectStore)(nil).ReorderProjectImages), ctx, projectID, ids)
+}
+
+// SetProjectArchived mocks base method.
+func (m *MockProjectStore) SetProjectArchived(ctx context.Context, id uint, archived bool, username string) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProjectArchived", ctx, id, archived, username)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProjectArchived indicates an expected call of SetProjectArchived.
+func (mr *MockProjectStoreMockRecorder) SetProjectArchived(ctx, id, archived, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProjectArchived", reflect.TypeOf((*MockProjectStore)(nil).SetProjectArchived), ctx, id, archived, username)
+}
+
+// SkillExists mocks base method.
+func (m *MockProjectStore) SkillExists(ctx context.Context, id uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkillExists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkillExists indicates an expected call of SkillExists.
+func (mr *MockProjectStoreMockRecorder) SkillExists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkillExists", reflect.TypeOf((*MockProjectStore)(nil).SkillExists), ctx, id)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProjectStore) UpdateProject(ctx context.Context, id uint, project *models.Project) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, id, project)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectStoreMockRecorder) UpdateProject(ctx, id, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProjectStore)(nil).UpdateProject), ctx, id, project)
+}
+
+// UpdateProjectMilestone mocks base method.
+func (m *MockProjectStore) UpdateProjectMilestone(ctx context.Context, projectID, milestoneID uint, milestone *models.ProjectMilestone) (*models.ProjectMilestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectMilestone", ctx, projectID, milestoneID, milestone)
+	ret0, _ := ret[0].(*models.ProjectMilestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProjectMilestone indicates an expected call of UpdateProjectMilestone.
+func (mr *MockProjectStoreMockRecorder) UpdateProjectMilestone(ctx, projectID, milestoneID, milestone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectMilestone", reflect.TypeOf((*MockProjectStore)(nil).UpdateProjectMilestone), ctx, projectID, milestoneID, milestone)
+}
+
+// MockContactStore is a mock of ContactStore interface.
+type MockContactStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactStoreMockRecorder
+}
+
+// MockContactStoreMockRecorder is the mock recorder for MockContactStore.
+type MockContactStoreMockRecorder struct {
+	mock *MockContactStore
+}
+
+// NewMockContactStore creates a new mock instance.
+func NewMockContactStore(ctrl *gomock.Controller) *MockContactStore {
+	mock := &MockContactStore{ctrl: ctrl}
+	mock.recorder = &MockContactStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactStore) EXPECT() *MockContactStoreMockRecorder {
+	return m.recorder
+}
+
+// AddReply mocks base method.
+func (m *MockContactStore) AddReply(ctx context.Context, message *models.ContactMessage) (*models.ContactMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReply", ctx, message)
+	ret0, _ := ret[0].(*models.ContactMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReply indicates an expected call of AddReply.
+func (mr *MockContactStoreMockRecorder) AddReply(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReply", reflect.TypeOf((*MockContactStore)(nil).AddReply), ctx, message)
+}
+
+// CreateContact mocks base method.
+func (m *MockContactStore) CreateContact(ctx context.Context, contact *models.Contact) (*models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContact", ctx, contact)
+	ret0, _ := ret[0].(*models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContact indicates an expected call of CreateContact.
+func (mr *MockContactStoreMockRecorder) CreateContact(ctx, contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContact", reflect.TypeOf((*MockContactStore)(nil).CreateContact), ctx, contact)
+}
+
+// CreateNote mocks base method.
+func (m *MockContactStore) CreateNote(ctx context.Context, note *models.ContactNote) (*models.ContactNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNote", ctx, note)
+	ret0, _ := ret[0].(*models.ContactNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNote indicates an expected call of CreateNote.
+func (mr *MockContactStoreMockRecorder) CreateNote(ctx, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockContactStore)(nil).CreateNote), ctx, note)
+}
+
+// EachContactBatch mocks base method.
+func (m *MockContactStore) EachContactBatch(ctx context.Context, status string, size int, fn func([]models.Contact) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachContactBatch", ctx, status, size, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachContactBatch indicates an expected call of EachContactBatch.
+func (mr *MockContactStoreMockRecorder) EachContactBatch(ctx, status, size, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachContactBatch", reflect.TypeOf((*MockContactStore)(nil).EachContactBatch), ctx, status, size, fn)
+}
+
+// GetContact mocks base method.
+func (m *MockContactStore) GetContact(ctx context.Context, id uint) (*models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContact", ctx, id)
+	ret0, _ := ret[0].(*models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContact indicates an expected call of GetContact.
+func (mr *MockContactStoreMockRecorder) GetContact(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockContactStore)(nil).GetContact), ctx, id)
+}
+
+// GetContacts mocks base method.
+func (m *MockContactStore) GetContacts(ctx context.Context, status, country, email string) ([]models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, status, country, email)
+	ret0, _ := ret[0].([]models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockContactStoreMockRecorder) GetContacts(ctx, status, country, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContactStore)(nil).GetContacts), ctx, status, country, email)
+}
+
+// GetMessages mocks base method.
+func (m *MockContactStore) GetMessages(ctx context.Context, contactID uint) ([]models.ContactMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages", ctx, contactID)
+	ret0, _ := ret[0].([]models.ContactMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockContactStoreMockRecorder) GetMessages(ctx, contactID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockContactStore)(nil).GetMessages), ctx, contactID)
+}
+
+// GetNotes mocks base method.
+func (m *MockContactStore) GetNotes(ctx context.Context, contactID uint) ([]models.ContactNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotes", ctx, contactID)
+	ret0, _ := ret[0].([]models.ContactNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotes indicates an expected call of GetNotes.
+func (mr *MockContactStoreMockRecorder) GetNotes(ctx, contactID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotes", reflect.TypeOf((*MockContactStore)(nil).GetNotes), ctx, contactID)
+}
+
+// MarkAutoReplied mocks base method.
+func (m *MockContactStore) MarkAutoReplied(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAutoReplied", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAutoReplied indicates an expected call of MarkAutoReplied.
+func (mr *MockContactStoreMockRecorder) MarkAutoReplied(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAutoReplied", reflect.TypeOf((*MockContactStore)(nil).MarkAutoReplied), ctx, id)
+}
+
+// UpdateContactStatus mocks base method.
+func (m *MockContactStore) UpdateContactStatus(ctx context.Context, id uint, status string) (*models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactStatus", ctx, id, status)
+	ret0, _ := ret[0].(*models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContactStatus indicates an expected call of UpdateContactStatus.
+func (mr *MockContactStoreMockRecorder) UpdateContactStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactStatus", reflect.TypeOf((*MockContactStore)(nil).UpdateContactStatus), ctx, id, status)
+}
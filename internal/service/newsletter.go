@@ -0,0 +1,393 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"log"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/markdown"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// JobSendCampaign sends the next batch of a newsletter campaign
+const JobSendCampaign = "send_campaign"
+
+const (
+	subscribeRateWindow = time.Hour
+	subscribeIPLimit    = 10 // Signups per IP per window
+	subscribeEmailLimit = 3  // Confirmation emails per address per window
+)
+
+// NewsletterService manages newsletter subscriptions and sends campaigns
+// through the job queue
+type NewsletterService struct {
+	repo          *repository.NewsletterRepository
+	uow           *repository.UnitOfWork
+	jobs          *JobService
+	mailer        *mailer.Mailer
+	redis         *redis.Client
+	tenants       *TenantService
+	siteURL       string
+	ratePerMinute int
+}
+
+// NewNewsletterService returns the newsletter service. Links in mails point
+// to the tenant's domain, or below siteURL for tenants without one.
+// Campaigns are sent to at most ratePerMinute subscribers a minute.
+func NewNewsletterService(repo *repository.NewsletterRepository, uow *repository.UnitOfWork, jobs *JobService, mail *mailer.Mailer, redis *redis.Client, tenants *TenantService, siteURL string, ratePerMinute int) *NewsletterService {
+	s := &NewsletterService{
+		repo:          repo,
+		uow:           uow,
+		jobs:          jobs,
+		mailer:        mail,
+		redis:         redis,
+		tenants:       tenants,
+		siteURL:       strings.TrimSuffix(siteURL, "/"),
+		ratePerMinute: ratePerMinute,
+	}
+	jobs.Handle(JobSendCampaign, s.runSendCampaign)
+	return s
+}
+
+type NewsletterSubscribeRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	Name      string `json:"name"`
+	IPAddress string `json:"-"`
+}
+
+// apiBaseURL returns the public URL of the API below apiPath, which is the
+// path the API version is mounted at. It never comes from the request's
+// host, so links in mails cannot be pointed elsewhere.
+func (s *NewsletterService) apiBaseURL(ctx context.Context, apiPath string) (string, error) {
+	siteURL, err := s.tenants.SiteURL(ctx, s.siteURL)
+	if err != nil {
+		return "", err
+	}
+	if siteURL == "" {
+		siteURL = s.siteURL
+	}
+	if siteURL == "" {
+		return "", errors.New("site URL not configured")
+	}
+	return siteURL + apiPath, nil
+}
+
+// overLimit counts one more hit on key and reports whether it went past
+// limit within the window
+func (s *NewsletterService) overLimit(ctx context.Context, key string, limit int64) (bool, error) {
+	count, err := s.redis.Incr(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	if count == 1 {
+		s.redis.Expire(ctx, key, subscribeRateWindow)
+	}
+	return count > limit, nil
+}
+
+// Subscribe signs an address up and mails it a confirmation link below
+// apiPath. The answer is the same whether or not the address was already
+// subscribed, so signups do not reveal who is. Addresses that were mailed
+// too often lately are not mailed again.
+func (s *NewsletterService) Subscribe(ctx context.Context, req *NewsletterSubscribeRequest, apiPath string) error {
+	apiBaseURL, err := s.apiBaseURL(ctx, apiPath)
+	if err != nil {
+		return err
+	}
+	if req.IPAddress != "" {
+		over, err := s.overLimit(ctx, "newsletter:rate:ip:"+req.IPAddress, subscribeIPLimit)
+		if err != nil {
+			return err
+		}
+		if over {
+			return errors.New("rate limit exceeded")
+		}
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	over, err := s.overLimit(ctx, "newsletter:rate:email:"+email, subscribeEmailLimit)
+	if err != nil {
+		return err
+	}
+	if over {
+		return nil
+	}
+
+	subscriber, err := s.repo.GetSubscriberByEmail(ctx, email)
+	if err != nil && err.Error() != "subscriber not found" {
+		return err
+	}
+	if subscriber != nil && subscriber.Status == "subscribed" {
+		return nil
+	}
+
+	if subscriber == nil {
+		token, err := models.GenerateRandomString(24)
+		if err != nil {
+			return err
+		}
+		subscriber = &models.Subscriber{Email: email, Token: token}
+	}
+	subscriber.Name = strings.TrimSpace(req.Name)
+	subscriber.Status = "pending"
+	subscriber.UnsubscribedAt = nil
+	if err := s.repo.SaveSubscriber(ctx, subscriber); err != nil {
+		return err
+	}
+
+	confirmURL := apiBaseURL + "/newsletter/confirm?token=" + subscriber.Token
+	s.jobs.SendMail(ctx, &mailer.Message{
+		To:      []string{subscriber.Email},
+		Subject: "Confirm your newsletter subscription",
+		Body:    "Please confirm that you want to receive the newsletter by opening this link:\n\n" + confirmURL + "\n\nIf you did not sign up, ignore this email and you will not hear from us again.\n",
+	})
+	return nil
+}
+
+// Confirm activates the subscription the token was mailed for
+func (s *NewsletterService) Confirm(ctx context.Context, token string) error {
+	subscriber, err := s.repo.GetSubscriberByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if subscriber.Status != "pending" {
+		return nil
+	}
+
+	now := time.Now()
+	subscriber.Status = "subscribed"
+	subscriber.ConfirmedAt = &now
+	return s.repo.SaveSubscriber(ctx, subscriber)
+}
+
+// Unsubscribe ends the subscription of the token's address
+func (s *NewsletterService) Unsubscribe(ctx context.Context, token string) error {
+	subscriber, err := s.repo.GetSubscriberByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if subscriber.Status == "unsubscribed" {
+		return nil
+	}
+
+	now := time.Now()
+	subscriber.Status = "unsubscribed"
+	subscriber.UnsubscribedAt = &now
+	return s.repo.SaveSubscriber(ctx, subscriber)
+}
+
+func (s *NewsletterService) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	return s.repo.GetSubscribers(ctx)
+}
+
+func (s *NewsletterService) GetCampaigns(ctx context.Context) ([]models.Campaign, error) {
+	return s.repo.GetCampaigns(ctx)
+}
+
+func (s *NewsletterService) GetCampaign(ctx context.Context, id uint) (*models.Campaign, error) {
+	return s.repo.GetCampaign(ctx, id)
+}
+
+// CampaignRequest composes a campaign. The body is Markdown and may use
+// {{.Name}}, {{.Email}} and {{.UnsubscribeURL}} of each subscriber.
+type CampaignRequest struct {
+	Subject string `json:"subject" binding:"required"`
+	Body    string `json:"body" binding:"required"`
+}
+
+func (s *NewsletterService) CreateCampaign(ctx context.Context, req *CampaignRequest) (*models.Campaign, error) {
+	if _, err := parseCampaign(req.Body); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateCampaign(ctx, &models.Campaign{Subject: req.Subject, Body: req.Body})
+}
+
+// UpdateCampaign changes a campaign that has not been sent yet
+func (s *NewsletterService) UpdateCampaign(ctx context.Context, id uint, req *CampaignRequest) (*models.Campaign, error) {
+	if _, err := parseCampaign(req.Body); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateCampaign(ctx, id, req.Subject, req.Body)
+}
+
+// DeleteCampaign deletes a campaign that has not been sent yet
+func (s *NewsletterService) DeleteCampaign(ctx context.Context, id uint) error {
+	return s.repo.DeleteCampaign(ctx, id)
+}
+
+func (s *NewsletterService) GetCampaignDeliveries(ctx context.Context, id uint) ([]models.CampaignDelivery, error) {
+	if _, err := s.repo.GetCampaign(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveries(ctx, id)
+}
+
+// campaignJob is the payload of a send_campaign job
+type campaignJob struct {
+	CampaignID uint   `json:"campaign_id"`
+	APIBaseURL string `json:"api_base_url"` // Unsubscribe links point below it
+}
+
+// SendCampaign queues a draft for every confirmed subscriber. Unsubscribe
+// links in the mails point below apiPath.
+func (s *NewsletterService) SendCampaign(ctx context.Context, id uint, apiPath string) (*models.Campaign, error) {
+	if !s.mailer.Enabled() {
+		return nil, errors.New("mailer not configured")
+	}
+	apiBaseURL, err := s.apiBaseURL(ctx, apiPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var campaign *models.Campaign
+	err = s.uow.WithTransaction(ctx, func(repos *repository.Repositories) error {
+		var err error
+		campaign, err = repos.Newsletter.QueueCampaign(ctx, id)
+		if err != nil {
+			return err
+		}
+		// Queued last, so the campaign stays a draft when queueing fails
+		return s.jobs.Enqueue(ctx, JobSendCampaign, campaignJob{CampaignID: id, APIBaseURL: apiBaseURL})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// runSendCampaign sends one batch of a campaign and queues the next batch
+// a minute later, which holds sending to the configured rate. Deliveries
+// record their outcome as they go, so a batch run again after a crash only
+// sends what is still pending.
+func (s *NewsletterService) runSendCampaign(ctx context.Context, payload json.RawMessage) error {
+	var job campaignJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	campaign, err := s.repo.GetCampaign(ctx, job.CampaignID)
+	if err != nil {
+		if err.Error() == "campaign not found" {
+			return nil
+		}
+		return err
+	}
+	if campaign.Status != "sending" {
+		return nil
+	}
+	// A disabled mailer would drop the mails and have them counted as sent
+	if !s.mailer.Enabled() {
+		return errors.New("mailer not configured")
+	}
+
+	tmpl, err := parseCampaign(campaign.Body)
+	if err != nil {
+		return err
+	}
+	deliveries, err := s.repo.GetPendingDeliveries(ctx, campaign.ID, s.ratePerMinute)
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return s.repo.MarkCampaignSent(ctx, campaign.ID)
+	}
+
+	for i := range deliveries {
+		if err := s.deliver(ctx, campaign, tmpl, &deliveries[i], job.APIBaseURL); err != nil {
+			return err
+		}
+	}
+	return s.jobs.EnqueueAt(ctx, JobSendCampaign, job, time.Now().Add(time.Minute))
+}
+
+// deliver mails a campaign to one subscriber. A failed send stays pending
+// for the next batch until it has used up jobMaxAttempts.
+func (s *NewsletterService) deliver(ctx context.Context, campaign *models.Campaign, tmpl *template.Template, delivery *models.CampaignDelivery, apiBaseURL string) error {
+	subscriber, err := s.repo.GetSubscriber(ctx, delivery.SubscriberID)
+	if err != nil && err.Error() != "subscriber not found" {
+		return err
+	}
+	if subscriber == nil || subscriber.Status != "subscribed" {
+		delivery.Status = "skipped"
+		return s.repo.SaveDelivery(ctx, delivery)
+	}
+
+	delivery.Attempts++
+	msg, err := campaignMessage(campaign, tmpl, subscriber, apiBaseURL)
+	if err == nil {
+		err = s.mailer.Send(msg)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to send campaign %d to subscriber %d: %v", campaign.ID, subscriber.ID, err)
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= jobMaxAttempts {
+			delivery.Status = "failed"
+		}
+		return s.repo.SaveDelivery(ctx, delivery)
+	}
+
+	now := time.Now()
+	delivery.Status = "sent"
+	delivery.LastError = ""
+	delivery.SentAt = &now
+	return s.repo.SaveDelivery(ctx, delivery)
+}
+
+// campaignRecipient is what a campaign body is rendered with
+type campaignRecipient struct {
+	Name           string
+	Email          string
+	UnsubscribeURL string
+}
+
+// parseCampaign parses a campaign body and renders it once, so that
+// mistakes are reported when the campaign is saved rather than sent
+func parseCampaign(body string) (*template.Template, error) {
+	tmpl, err := template.New("campaign").Parse(body)
+	if err != nil {
+		return nil, errors.New("invalid template")
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, campaignRecipient{}); err != nil {
+		return nil, errors.New("invalid template")
+	}
+	return tmpl, nil
+}
+
+// campaignMessage renders a campaign for one subscriber: the Markdown as
+// the plain text part and converted to HTML as its alternative, both with
+// an unsubscribe footer. The List-Unsubscribe headers let mail clients
+// offer one-click unsubscribing (RFC 8058).
+func campaignMessage(campaign *models.Campaign, tmpl *template.Template, subscriber *models.Subscriber, apiBaseURL string) (*mailer.Message, error) {
+	unsubscribeURL := apiBaseURL + "/newsletter/unsubscribe?token=" + subscriber.Token
+
+	var body bytes.Buffer
+	err := tmpl.Execute(&body, campaignRecipient{
+		Name:           subscriber.Name,
+		Email:          subscriber.Email,
+		UnsubscribeURL: unsubscribeURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mailer.Message{
+		To:      []string{subscriber.Email},
+		Subject: campaign.Subject,
+		Body:    body.String() + "\n\n--\nYou receive this because you subscribed to the newsletter. Unsubscribe: " + unsubscribeURL + "\n",
+		HTML: markdown.ToHTML(body.String()) +
+			`<hr><p>You receive this because you subscribed to the newsletter. <a href="` + html.EscapeString(unsubscribeURL) + `">Unsubscribe</a></p>`,
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"testing"
+)
+
+func TestCampaignMessageRendersPerSubscriber(t *testing.T) {
+	tmpl, err := parseCampaign("Hi {{.Name}},\n\n**News**")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscriber := &models.Subscriber{Email: "ada@example.com", Name: "Ada", Token: "abc"}
+
+	msg, err := campaignMessage(&models.Campaign{Subject: "Issue 1"}, tmpl, subscriber, "https://api.example.com/api/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsubscribeURL := "https://api.example.com/api/v1/newsletter/unsubscribe?token=abc"
+	if !strings.HasPrefix(msg.Body, "Hi Ada,") || !strings.Contains(msg.Body, unsubscribeURL) {
+		t.Errorf("Body = %q", msg.Body)
+	}
+	if !strings.Contains(msg.HTML, "<strong>News</strong>") || !strings.Contains(msg.HTML, `href="`+unsubscribeURL+`"`) {
+		t.Errorf("HTML = %q", msg.HTML)
+	}
+	if msg.Headers["List-Unsubscribe"] != "<"+unsubscribeURL+">" {
+		t.Errorf("List-Unsubscribe = %q", msg.Headers["List-Unsubscribe"])
+	}
+}
+
+func TestParseCampaignRejectsUnknownFields(t *testing.T) {
+	if _, err := parseCampaign("Hi {{.Nickname}}"); err == nil || err.Error() != "invalid template" {
+		t.Errorf("err = %v, want invalid template", err)
+	}
+}
+
+func TestSubscribeLinksToSiteAndLimitsMails(t *testing.T) {
+	dialector, err := database.SQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	db, err := database.Initialize(dialector, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs, client := newTestJobService(t)
+	tenants := NewTenantService(repository.NewTenantRepository(db), client)
+	s := NewNewsletterService(repository.NewNewsletterRepository(db), repository.NewUnitOfWork(db), jobs, nil, client, tenants, "https://example.com/", 60)
+
+	var sent []mailer.Message
+	jobs.Handle(JobSendMail, func(ctx context.Context, payload json.RawMessage) error {
+		var msg mailer.Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			return err
+		}
+		sent = append(sent, msg)
+		return nil
+	})
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+
+	for i := 0; i < subscribeEmailLimit+2; i++ {
+		req := &NewsletterSubscribeRequest{Email: "ada@example.com", IPAddress: "203.0.113.7"}
+		if err := s.Subscribe(ctx, req, "/api/v2"); err != nil {
+			t.Fatalf("signup %d: %v", i+1, err)
+		}
+	}
+	jobs.runDue(ctx)
+	if len(sent) != subscribeEmailLimit {
+		t.Fatalf("sent %d confirmations, want %d", len(sent), subscribeEmailLimit)
+	}
+	if !strings.Contains(sent[0].Body, "https://example.com/api/v2/newsletter/confirm?token=") {
+		t.Errorf("Body = %q, want a link below SITE_URL", sent[0].Body)
+	}
+
+	for i := subscribeEmailLimit + 2; i < subscribeIPLimit; i++ {
+		req := &NewsletterSubscribeRequest{Email: "other@example.com", IPAddress: "203.0.113.7"}
+		if err := s.Subscribe(ctx, req, "/api/v1"); err != nil {
+			t.Fatalf("signup %d: %v", i+1, err)
+		}
+	}
+	req := &NewsletterSubscribeRequest{Email: "grace@example.com", IPAddress: "203.0.113.7"}
+	if err := s.Subscribe(ctx, req, "/api/v1"); err == nil || err.Error() != "rate limit exceeded" {
+		t.Errorf("err = %v, want rate limit exceeded", err)
+	}
+
+	s.siteURL = ""
+	req.IPAddress = "198.51.100.1"
+	if err := s.Subscribe(ctx, req, "/api/v1"); err == nil || err.Error() != "site URL not configured" {
+		t.Errorf("err = %v, want site URL not configured", err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OfferingService handles the consulting services on offer
+type OfferingService struct {
+	repo   *repository.ServiceRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewOfferingService(repo *repository.ServiceRepository, redis *redis.Client, events *events.Bus) *OfferingService {
+	return &OfferingService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+func (s *OfferingService) GetServices(ctx context.Context) ([]models.Service, error) {
+	// Try to get from cache first
+	var offerings []models.Service
+	if cacheGet(ctx, s.redis, "services", tenant.CacheKey(ctx, "services"), &offerings) {
+		return offerings, nil
+	}
+
+	// Get from database
+	offerings, err := s.repo.GetServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	offeringsJSON, _ := json.Marshal(offerings)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "services"), offeringsJSON, contentCacheTTL())
+
+	return offerings, nil
+}
+
+type ServiceCreateRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Description  string `json:"description"`
+	PricingHint  string `json:"pricing_hint"`
+	Availability string `json:"availability"`
+	Icon         string `json:"icon"`
+}
+
+func (s *OfferingService) CreateService(ctx context.Context, req *ServiceCreateRequest) (*models.Service, error) {
+	offering := &models.Service{
+		Name:         req.Name,
+		Description:  req.Description,
+		PricingHint:  req.PricingHint,
+		Availability: req.Availability,
+		Icon:         req.Icon,
+	}
+
+	createdService, err := s.repo.CreateService(ctx, offering)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "services")
+
+	s.events.Publish(ctx, events.ServiceCreated, createdService)
+
+	return createdService, nil
+}
+
+type ServiceUpdateRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Description  string `json:"description"`
+	PricingHint  string `json:"pricing_hint"`
+	Availability string `json:"availability"`
+	Icon         string `json:"icon"`
+}
+
+func (s *OfferingService) UpdateService(ctx context.Context, id uint, req *ServiceUpdateRequest) (*models.Service, error) {
+	offering := &models.Service{
+		Name:         req.Name,
+		Description:  req.Description,
+		PricingHint:  req.PricingHint,
+		Availability: req.Availability,
+		Icon:         req.Icon,
+	}
+
+	updatedService, err := s.repo.UpdateService(ctx, id, offering)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "services")
+
+	s.events.Publish(ctx, events.ServiceUpdated, updatedService)
+
+	return updatedService, nil
+}
+
+func (s *OfferingService) DeleteService(ctx context.Context, id uint) error {
+	err := s.repo.DeleteService(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "services")
+
+	s.events.Publish(ctx, events.ServiceDeleted, map[string]uint{"id": id})
+
+	return nil
+}
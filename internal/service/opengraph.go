@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/url"
+	"stackwhiz-portfolio-backend/internal/markdown"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// openGraphDescriptionLength is about what social cards show before they
+// cut the text themselves
+const openGraphDescriptionLength = 200
+
+// OpenGraph is the metadata of a page for social cards, ready to be copied
+// into og:* meta tags
+type OpenGraph struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image,omitempty"` // Absolute URL
+	URL         string `json:"url"`             // Canonical URL of the page
+	Type        string `json:"type"`
+	SiteName    string `json:"site_name"`
+}
+
+// GetProjectOpenGraph returns the social card metadata of a published
+// project. Canonical URLs point at the tenant's site: SITE_URL for the
+// default tenant, the first domain of any other, or apiBaseURL when neither
+// is known. Relative image URLs are served by the API, so they are resolved
+// against apiBaseURL.
+func (s *PortfolioService) GetProjectOpenGraph(ctx context.Context, id uint, apiBaseURL string) (*OpenGraph, error) {
+	projects, err := s.projectService.GetProjects(ctx, nil, nil, "", false, false)
+	if err != nil {
+		return nil, err
+	}
+	var project *models.Project
+	for i := range projects {
+		if projects[i].ID == id {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+
+	profile, err := s.profileService.GetProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	siteURL, err := s.siteURL(ctx, apiBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Text fields are stored sanitized, with markup characters escaped, and
+	// meta tag renderers escape them again
+	description := html.UnescapeString(project.Description)
+	if strings.TrimSpace(description) == "" {
+		description = markdown.ToText(project.LongDescription)
+	}
+
+	return &OpenGraph{
+		Title:       html.UnescapeString(project.Name),
+		Description: truncateText(strings.Join(strings.Fields(description), " "), openGraphDescriptionLength),
+		Image:       absoluteURL(apiBaseURL, project.Cover()),
+		URL:         siteURL + "/projects/" + strconv.FormatUint(uint64(project.ID), 10),
+		Type:        "website",
+		SiteName:    html.UnescapeString(profile.Name),
+	}, nil
+}
+
+// siteURL returns the base URL of the public site of the tenant in ctx
+func (s *PortfolioService) siteURL(ctx context.Context, fallback string) (string, error) {
+	siteURL, err := s.tenantService.SiteURL(ctx, s.siteBaseURL)
+	if err != nil {
+		return "", err
+	}
+	if siteURL == "" {
+		return fallback, nil
+	}
+	return siteURL, nil
+}
+
+// absoluteURL resolves ref against base, leaving absolute and empty
+// references alone
+func absoluteURL(base, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	baseURL, err := url.Parse(base + "/")
+	if err != nil {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+// truncateText cuts s to at most max runes at a word boundary, marking the
+// cut with an ellipsis
+func truncateText(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	cut := string([]rune(s)[:max-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// portfolioCacheKey holds the assembled portfolio. It is dropped whenever
+// any of the content it is built from changes; see portfolioResources.
+const portfolioCacheKey = "portfolio"
+
+// portfolioResources are the cached resources the portfolio is assembled
+// from
+var portfolioResources = map[string]bool{
+	"profile": true, "experiences": true, "education": true, "skills": true,
+	"projects": true, "categories": true, "tags": true, "certifications": true,
+	"publications": true, "awards": true, "testimonials": true,
+}
+
+// Portfolio is the whole public portfolio in a single document
+type Portfolio struct {
+	Profile          *models.Profile        `json:"profile"`
+	Experiences      []models.Experience    `json:"experiences"`
+	Education        []models.Education     `json:"education"`
+	Skills           []models.Skill         `json:"skills"`
+	SkillGroups      []SkillGroup           `json:"skill_groups"` // Skills by category, as served by /skills/grouped
+	Projects         []models.Project       `json:"projects"`
+	FeaturedProjects []models.Project       `json:"featured_projects"` // Featured projects by rank
+	Certifications   []models.Certification `json:"certifications"`
+	Publications     []models.Publication   `json:"publications"`
+	Awards           []models.Award         `json:"awards"`
+	Testimonials     []models.Testimonial   `json:"testimonials"`
+}
+
+// TimelineEntry is a dated item on the portfolio timeline
+type TimelineEntry struct {
+	Type     string     `json:"type"` // One of TimelineTypes
+	ID       uint       `json:"id"`
+	Title    string     `json:"title"`
+	Subtitle string     `json:"subtitle"`
+	Date     time.Time  `json:"date"`
+	EndDate  *time.Time `json:"end_date,omitempty"`
+}
+
+// TimelineTypes lists the entry types on the timeline. Projects appear as
+// launches, and only the featured ones, so minor work does not crowd out the
+// career milestones.
+var TimelineTypes = []string{"experience", "education", "certification", "publication", "award", "talk", "project"}
+
+// PortfolioService assembles the portfolio from the individual content
+// services, reusing their caches
+type PortfolioService struct {
+	redis                *redis.Client
+	profileService       *ProfileService
+	experienceService    *ExperienceService
+	educationService     *EducationService
+	skillService         *SkillService
+	projectService       *ProjectService
+	certificationService *CertificationService
+	publicationService   *PublicationService
+	awardService         *AwardService
+	testimonialService   *TestimonialService
+	talkService          *TalkService
+	tenantService        *TenantService
+	siteBaseURL          string
+}
+
+func NewPortfolioService(
+	redis *redis.Client,
+	profileService *ProfileService,
+	experienceService *ExperienceService,
+	educationService *EducationService,
+	skillService *SkillService,
+	projectService *ProjectService,
+	certificationService *CertificationService,
+	publicationService *PublicationService,
+	awardService *AwardService,
+	testimonialService *TestimonialService,
+	talkService *TalkService,
+	tenantService *TenantService,
+	siteURL string,
+) *PortfolioService {
+	return &PortfolioService{
+		redis:                redis,
+		profileService:       profileService,
+		experienceService:    experienceService,
+		educationService:     educationService,
+		skillService:         skillService,
+		projectService:       projectService,
+		certificationService: certificationService,
+		publicationService:   publicationService,
+		awardService:         awardService,
+		testimonialService:   testimonialService,
+		talkService:          talkService,
+		tenantService:        tenantService,
+		siteBaseURL:          strings.TrimSuffix(siteURL, "/"),
+	}
+}
+
+// GetPortfolio returns the whole portfolio. The assembled document is
+// cached as one value, so the landing page costs a single Redis read.
+func (s *PortfolioService) GetPortfolio(ctx context.Context) (*Portfolio, error) {
+	var portfolio Portfolio
+	if cacheGet(ctx, s.redis, "portfolio", tenant.CacheKey(ctx, portfolioCacheKey), &portfolio) {
+		return &portfolio, nil
+	}
+
+	if err := s.assemble(ctx, &portfolio); err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	portfolioJSON, _ := json.Marshal(portfolio)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, portfolioCacheKey), portfolioJSON, contentCacheTTL())
+
+	return &portfolio, nil
+}
+
+func (s *PortfolioService) assemble(ctx context.Context, portfolio *Portfolio) error {
+	var err error
+
+	if portfolio.Profile, err = s.profileService.GetProfile(ctx); err != nil {
+		return err
+	}
+	if portfolio.Experiences, err = s.experienceService.GetExperiences(ctx, false); err != nil {
+		return err
+	}
+	if portfolio.Education, err = s.educationService.GetEducation(ctx); err != nil {
+		return err
+	}
+	if portfolio.Skills, err = s.skillService.GetSkills(ctx); err != nil {
+		return err
+	}
+	portfolio.SkillGroups = groupSkills(portfolio.Skills)
+	if portfolio.Projects, err = s.projectService.GetProjects(ctx, nil, nil, "", false, false); err != nil {
+		return err
+	}
+	featured := true
+	if portfolio.FeaturedProjects, err = s.projectService.GetProjects(ctx, &featured, nil, "", false, false); err != nil {
+		return err
+	}
+	if portfolio.FeaturedProjects, err = OrderProjects(portfolio.FeaturedProjects, ProjectOrderFeaturedRank, 0); err != nil {
+		return err
+	}
+	if portfolio.Certifications, err = s.certificationService.GetCertifications(ctx, false); err != nil {
+		return err
+	}
+	if portfolio.Publications, err = s.publicationService.GetPublications(ctx); err != nil {
+		return err
+	}
+	if portfolio.Awards, err = s.awardService.GetAwards(ctx); err != nil {
+		return err
+	}
+	if portfolio.Testimonials, err = s.testimonialService.GetTestimonials(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTimeline merges the dated portfolio entries of the given types into a
+// single list, newest first. No types means all of them.
+func (s *PortfolioService) GetTimeline(ctx context.Context, types []string) ([]TimelineEntry, error) {
+	include := make(map[string]bool, len(TimelineTypes))
+	for _, t := range TimelineTypes {
+		include[t] = len(types) == 0
+	}
+	for _, t := range types {
+		if _, ok := include[t]; !ok {
+			return nil, errors.New("invalid type")
+		}
+		include[t] = true
+	}
+
+	timeline := []TimelineEntry{}
+
+	if include["experience"] {
+		experiences, err := s.experienceService.GetExperiences(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, experience := range experiences {
+			timeline = append(timeline, TimelineEntry{
+				Type:     "experience",
+				ID:       experience.ID,
+				Title:    experience.Position,
+				Subtitle: experience.Company,
+				Date:     experience.StartDate,
+				EndDate:  experience.EndDate,
+			})
+		}
+	}
+
+	if include["education"] {
+		education, err := s.educationService.GetEducation(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range education {
+			timeline = append(timeline, TimelineEntry{
+				Type:     "education",
+				ID:       entry.ID,
+				Title:    entry.Degree,
+				Subtitle: entry.Institution,
+				Date:     entry.StartDate,
+				EndDate:  entry.EndDate,
+			})
+		}
+	}
+
+	if include["certification"] {
+		certifications, err := s.certificationService.GetCertifications(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, certification := range certifications {
+			timeline = append(timeline, TimelineEntry{
+				Type:     "certification",
+				ID:       certification.ID,
+				Title:    certification.Name,
+				Subtitle: certification.Issuer,
+				Date:     certification.IssueDate,
+			})
+		}
+	}
+
+	if include["publication"] {
+		publications, err := s.publicationService.GetPublications(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, publication := range publications {
+			timeline = append(timeline, TimelineEntry{
+				Type:     "publication",
+				ID:       publication.ID,
+				Title:    publication.Title,
+				Subtitle: publication.Venue,
+				Date:     publication.Date,
+			})
+		}
+	}
+
+	if include["award"] {
+		awards, err := s.awardService.GetAwards(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, award := range awards {
+			timeline = append(timeline, TimelineEntry{
+				Type:     "award",
+				ID:       award.ID,
+				Title:    award.Title,
+				Subtitle: award.Issuer,
+				Date:     award.Date,
+			})
+		}
+	}
+
+	if include["talk"] {
+		talks, err := s.talkService.GetTalks(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, talk := range talks {
+			timeline = append(timeline, TimelineEntry{
+				Type:     "talk",
+				ID:       talk.ID,
+				Title:    talk.Title,
+				Subtitle: talk.Event,
+				Date:     talk.Date,
+			})
+		}
+	}
+
+	if include["project"] {
+		featured := true
+		projects, err := s.projectService.GetProjects(ctx, &featured, nil, "", false, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			timeline = append(timeline, projectLaunch(project))
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Date.After(timeline[j].Date)
+	})
+
+	return timeline, nil
+}
+
+// projectLaunch dates a project by when it was published, or when it was
+// added if it went live right away
+func projectLaunch(project models.Project) TimelineEntry {
+	entry := TimelineEntry{
+		Type:  "project",
+		ID:    project.ID,
+		Title: project.Name,
+		Date:  project.CreatedAt,
+	}
+	if project.PublishedAt != nil {
+		entry.Date = *project.PublishedAt
+	}
+	if project.Category != nil {
+		entry.Subtitle = project.Category.Name
+	}
+	return entry
+}
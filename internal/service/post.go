@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Posts are cached for at most postCacheTTL so that posts scheduled for a
+// future published_at show up soon after that moment
+const postCacheTTL = 10 * time.Minute
+
+// PostService handles blog post operations
+type PostService struct {
+	repo   *repository.PostRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewPostService(repo *repository.PostRepository, redis *redis.Client, events *events.Bus) *PostService {
+	return &PostService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+// GetPosts returns published posts, or every post when includeDrafts is set,
+// optionally narrowed to the posts carrying the tag with the given slug
+func (s *PostService) GetPosts(ctx context.Context, tag string, includeDrafts bool) ([]models.Post, error) {
+	posts, err := s.getPosts(ctx, includeDrafts)
+	if err != nil || tag == "" {
+		return posts, err
+	}
+
+	filtered := []models.Post{}
+	for _, post := range posts {
+		if hasTag(post.Tags, tag) {
+			filtered = append(filtered, post)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *PostService) getPosts(ctx context.Context, includeDrafts bool) ([]models.Post, error) {
+	// Try to get from cache first
+	cacheKey := draftsCacheKey("posts", includeDrafts)
+	var posts []models.Post
+	if cacheGet(ctx, s.redis, "posts", tenant.CacheKey(ctx, cacheKey), &posts) {
+		return posts, nil
+	}
+
+	// Get from database
+	posts, err := s.repo.GetPosts(ctx, !includeDrafts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	postsJSON, _ := json.Marshal(posts)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, cacheKey), postsJSON, min(postCacheTTL, contentCacheTTL()))
+
+	return posts, nil
+}
+
+// GetPost returns a post by slug. Unpublished posts are only returned when
+// includeDrafts is set.
+func (s *PostService) GetPost(ctx context.Context, slug string, includeDrafts bool) (*models.Post, error) {
+	// Try to get from cache first
+	cacheKey := draftsCacheKey("post:"+slug, includeDrafts)
+	var cachedPost models.Post
+	if cacheGet(ctx, s.redis, "post", tenant.CacheKey(ctx, cacheKey), &cachedPost) {
+		return &cachedPost, nil
+	}
+
+	// Get from database
+	post, err := s.repo.GetPostBySlug(ctx, slug, !includeDrafts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	postJSON, _ := json.Marshal(post)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, cacheKey), postJSON, min(postCacheTTL, contentCacheTTL()))
+
+	return post, nil
+}
+
+type PostCreateRequest struct {
+	Title       string     `json:"title" binding:"required"`
+	Slug        string     `json:"slug"`
+	Summary     string     `json:"summary"`
+	Body        string     `json:"body" binding:"required"`
+	Tags        []string   `json:"tags"` // Tag names; unknown tags are created
+	CoverImage  string     `json:"cover_image"`
+	Published   bool       `json:"published"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+func (s *PostService) CreatePost(ctx context.Context, username string, req *PostCreateRequest) (*models.Post, error) {
+	slug := postSlug(req.Slug, req.Title)
+	if slug == "" {
+		return nil, errors.New("invalid slug")
+	}
+
+	post := &models.Post{
+		Title:       req.Title,
+		Slug:        slug,
+		Summary:     req.Summary,
+		Body:        req.Body,
+		Tags:        tagsFromNames(req.Tags),
+		CoverImage:  req.CoverImage,
+		Published:   req.Published,
+		PublishedAt: publicationDate(req.Published, req.PublishedAt),
+		Attribution: models.Attribution{CreatedBy: username, UpdatedBy: username},
+	}
+
+	createdPost, err := s.repo.CreatePost(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "tags", "posts", "posts:drafts")
+
+	s.events.Publish(ctx, events.PostCreated, createdPost)
+
+	return createdPost, nil
+}
+
+type PostUpdateRequest struct {
+	Title       string     `json:"title" binding:"required"`
+	Slug        string     `json:"slug"`
+	Summary     string     `json:"summary"`
+	Body        string     `json:"body" binding:"required"`
+	Tags        []string   `json:"tags"` // Tag names; unknown tags are created
+	CoverImage  string     `json:"cover_image"`
+	Published   bool       `json:"published"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+func (s *PostService) UpdatePost(ctx context.Context, id uint, username string, req *PostUpdateRequest) (*models.Post, error) {
+	slug := postSlug(req.Slug, req.Title)
+	if slug == "" {
+		return nil, errors.New("invalid slug")
+	}
+
+	post := &models.Post{
+		Title:       req.Title,
+		Slug:        slug,
+		Summary:     req.Summary,
+		Body:        req.Body,
+		Tags:        tagsFromNames(req.Tags),
+		CoverImage:  req.CoverImage,
+		Published:   req.Published,
+		PublishedAt: req.PublishedAt,
+		Attribution: models.Attribution{UpdatedBy: username},
+	}
+
+	updatedPost, previousSlug, err := s.repo.UpdatePost(ctx, id, post)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "tags", "posts", "posts:drafts",
+		"post:"+previousSlug, "post:"+previousSlug+":drafts",
+		"post:"+updatedPost.Slug, "post:"+updatedPost.Slug+":drafts")
+
+	s.events.Publish(ctx, events.PostUpdated, updatedPost)
+
+	return updatedPost, nil
+}
+
+func (s *PostService) DeletePost(ctx context.Context, id uint) error {
+	post, err := s.repo.DeletePost(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "tags", "posts", "posts:drafts", "post:"+post.Slug, "post:"+post.Slug+":drafts")
+
+	s.events.Publish(ctx, events.PostDeleted, map[string]uint{"id": id})
+
+	return nil
+}
+
+// postSlug returns the requested slug, or derives one from the title
+func postSlug(slug, title string) string {
+	source := slug
+	if source == "" {
+		source = title
+	}
+	return models.Slugify(source)
+}
+
+// publicationDate stamps published content that has no explicit date
+func publicationDate(published bool, publishedAt *time.Time) *time.Time {
+	if publishedAt != nil || !published {
+		return publishedAt
+	}
+	now := time.Now()
+	return &now
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+)
+
+type ProjectImageRequest struct {
+	URL     string `json:"url" binding:"required,max=2048"` // Usually the url of an upload from /admin/media
+	Caption string `json:"caption" binding:"max=300"`
+}
+
+// AddProjectImage appends an image to the end of a project's gallery
+func (s *ProjectService) AddProjectImage(ctx context.Context, projectID uint, req *ProjectImageRequest) (*models.ProjectImage, error) {
+	image, err := s.repo.AddProjectImage(ctx, projectID, &models.ProjectImage{
+		URL:     req.URL,
+		Caption: req.Caption,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.projectChanged(ctx, projectID)
+	return image, nil
+}
+
+// DeleteProjectImage removes an image from a project's gallery
+func (s *ProjectService) DeleteProjectImage(ctx context.Context, projectID, imageID uint) error {
+	if err := s.repo.DeleteProjectImage(ctx, projectID, imageID); err != nil {
+		return err
+	}
+
+	s.projectChanged(ctx, projectID)
+	return nil
+}
+
+type ProjectImageReorderRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"` // Every image ID of the project, in display order
+}
+
+// ReorderProjectImages puts a project's gallery in the requested order and
+// returns it. The first image becomes the project's cover.
+func (s *ProjectService) ReorderProjectImages(ctx context.Context, projectID uint, req *ProjectImageReorderRequest) ([]models.ProjectImage, error) {
+	// A missing project has no images, which would read as a bad order
+	if _, err := s.repo.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	images, err := s.repo.ReorderProjectImages(ctx, projectID, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	s.projectChanged(ctx, projectID)
+	return images, nil
+}
+
+// projectChanged refreshes the cached listings, which embed the gallery
+// and milestones, and announces a change to either as an update of the
+// project
+func (s *ProjectService) projectChanged(ctx context.Context, projectID uint) {
+	// Invalidate cache
+	invalidate(ctx, s.redis, projectCacheKeys...)
+
+	project, err := s.repo.GetProject(ctx, projectID)
+	if err != nil {
+		return
+	}
+	s.events.Publish(ctx, events.ProjectUpdated, project)
+}
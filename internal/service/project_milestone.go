@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"stackwhiz-portfolio-backend/internal/models"
+	"time"
+)
+
+type ProjectMilestoneRequest struct {
+	Date        time.Time `json:"date" binding:"required"`
+	Title       string    `json:"title" binding:"required,max=200"`
+	Description string    `json:"description"`
+	Link        string    `json:"link" binding:"omitempty,url"`
+}
+
+// CreateProjectMilestone adds a milestone to a project's history
+func (s *ProjectService) CreateProjectMilestone(ctx context.Context, projectID uint, req *ProjectMilestoneRequest) (*models.ProjectMilestone, error) {
+	milestone, err := s.repo.CreateProjectMilestone(ctx, projectID, &models.ProjectMilestone{
+		Date:        req.Date,
+		Title:       req.Title,
+		Description: req.Description,
+		Link:        req.Link,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.projectChanged(ctx, projectID)
+	return milestone, nil
+}
+
+// UpdateProjectMilestone replaces a milestone of a project
+func (s *ProjectService) UpdateProjectMilestone(ctx context.Context, projectID, milestoneID uint, req *ProjectMilestoneRequest) (*models.ProjectMilestone, error) {
+	milestone, err := s.repo.UpdateProjectMilestone(ctx, projectID, milestoneID, &models.ProjectMilestone{
+		Date:        req.Date,
+		Title:       req.Title,
+		Description: req.Description,
+		Link:        req.Link,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.projectChanged(ctx, projectID)
+	return milestone, nil
+}
+
+// DeleteProjectMilestone removes a milestone from a project's history
+func (s *ProjectService) DeleteProjectMilestone(ctx context.Context, projectID, milestoneID uint) error {
+	if err := s.repo.DeleteProjectMilestone(ctx, projectID, milestoneID); err != nil {
+		return err
+	}
+
+	s.projectChanged(ctx, projectID)
+	return nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PublicationService handles publication-related operations
+type PublicationService struct {
+	repo   *repository.PublicationRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewPublicationService(repo *repository.PublicationRepository, redis *redis.Client, events *events.Bus) *PublicationService {
+	return &PublicationService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+func (s *PublicationService) GetPublications(ctx context.Context) ([]models.Publication, error) {
+	// Try to get from cache first
+	var publications []models.Publication
+	if cacheGet(ctx, s.redis, "publications", tenant.CacheKey(ctx, "publications"), &publications) {
+		return publications, nil
+	}
+
+	// Get from database
+	publications, err := s.repo.GetPublications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	publicationsJSON, _ := json.Marshal(publications)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "publications"), publicationsJSON, contentCacheTTL())
+
+	return publications, nil
+}
+
+type PublicationCreateRequest struct {
+	Title     string    `json:"title" binding:"required"`
+	Venue     string    `json:"venue"`
+	Date      time.Time `json:"date" binding:"required"`
+	CoAuthors []string  `json:"co_authors"`
+	DOI       string    `json:"doi"`
+	URL       string    `json:"url" binding:"omitempty,url"`
+	Abstract  string    `json:"abstract"`
+}
+
+func (s *PublicationService) CreatePublication(ctx context.Context, req *PublicationCreateRequest) (*models.Publication, error) {
+	createdPublication, err := s.repo.CreatePublication(ctx, newPublication(req))
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "publications")
+
+	s.events.Publish(ctx, events.PublicationCreated, createdPublication)
+
+	return createdPublication, nil
+}
+
+func newPublication(req *PublicationCreateRequest) *models.Publication {
+	return &models.Publication{
+		Title:     req.Title,
+		Venue:     req.Venue,
+		Date:      req.Date,
+		CoAuthors: req.CoAuthors,
+		DOI:       req.DOI,
+		URL:       req.URL,
+		Abstract:  req.Abstract,
+	}
+}
+
+type PublicationUpdateRequest struct {
+	Title     string    `json:"title" binding:"required"`
+	Venue     string    `json:"venue"`
+	Date      time.Time `json:"date" binding:"required"`
+	CoAuthors []string  `json:"co_authors"`
+	DOI       string    `json:"doi"`
+	URL       string    `json:"url" binding:"omitempty,url"`
+	Abstract  string    `json:"abstract"`
+}
+
+func (s *PublicationService) UpdatePublication(ctx context.Context, id uint, req *PublicationUpdateRequest) (*models.Publication, error) {
+	publication := &models.Publication{
+		Title:     req.Title,
+		Venue:     req.Venue,
+		Date:      req.Date,
+		CoAuthors: req.CoAuthors,
+		DOI:       req.DOI,
+		URL:       req.URL,
+		Abstract:  req.Abstract,
+	}
+
+	updatedPublication, err := s.repo.UpdatePublication(ctx, id, publication)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "publications")
+
+	s.events.Publish(ctx, events.PublicationUpdated, updatedPublication)
+
+	return updatedPublication, nil
+}
+
+func (s *PublicationService) DeletePublication(ctx context.Context, id uint) error {
+	err := s.repo.DeletePublication(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "publications")
+
+	s.events.Publish(ctx, events.PublicationDeleted, map[string]uint{"id": id})
+
+	return nil
+}
@@ -0,0 +1,784 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Resume is a JSON Resume document (https://jsonresume.org/schema). Only the
+// sections that map onto portfolio content are included.
+type Resume struct {
+	Basics       ResumeBasics        `json:"basics"`
+	Work         []ResumeWork        `json:"work"`
+	Education    []ResumeEducation   `json:"education"`
+	Awards       []ResumeAward       `json:"awards"`
+	Certificates []ResumeCertificate `json:"certificates"`
+	Publications []ResumePublication `json:"publications"`
+	Skills       []ResumeSkill       `json:"skills"`
+	Projects     []ResumeProject     `json:"projects"`
+	Meta         *ResumeMeta         `json:"meta,omitempty"`
+}
+
+// ResumeMeta names the variant a document was rendered for
+type ResumeMeta struct {
+	Variant string `json:"variant"`
+	File    string `json:"file,omitempty"` // The variant's prepared document
+}
+
+type ResumeBasics struct {
+	Name     string          `json:"name"`
+	Label    string          `json:"label"`
+	Image    string          `json:"image,omitempty"`
+	Email    string          `json:"email"`
+	Phone    string          `json:"phone,omitempty"`
+	URL      string          `json:"url,omitempty"`
+	Summary  string          `json:"summary,omitempty"`
+	Location ResumeLocation  `json:"location"`
+	Profiles []ResumeProfile `json:"profiles"`
+}
+
+type ResumeLocation struct {
+	Address     string `json:"address,omitempty"`
+	City        string `json:"city,omitempty"`
+	Region      string `json:"region,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+}
+
+type ResumeProfile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url"`
+}
+
+type ResumeWork struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position"`
+	Location   string   `json:"location,omitempty"`
+	StartDate  string   `json:"startDate"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights"`
+}
+
+type ResumeEducation struct {
+	Institution string `json:"institution"`
+	Area        string `json:"area,omitempty"`
+	StudyType   string `json:"studyType"`
+	StartDate   string `json:"startDate"`
+	EndDate     string `json:"endDate,omitempty"`
+}
+
+type ResumeAward struct {
+	Title   string `json:"title"`
+	Date    string `json:"date"`
+	Awarder string `json:"awarder,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type ResumeCertificate struct {
+	Name   string `json:"name"`
+	Date   string `json:"date"`
+	Issuer string `json:"issuer"`
+	URL    string `json:"url,omitempty"`
+}
+
+type ResumePublication struct {
+	Name        string `json:"name"`
+	Publisher   string `json:"publisher,omitempty"`
+	ReleaseDate string `json:"releaseDate"`
+	URL         string `json:"url,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+}
+
+type ResumeSkill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords"`
+}
+
+type ResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	URL         string   `json:"url,omitempty"`
+	Keywords    []string `json:"keywords"`
+}
+
+// ResumeImportResult summarises what an import created
+type ResumeImportResult struct {
+	Profile        bool     `json:"profile"`
+	Experiences    int      `json:"experiences"`
+	Education      int      `json:"education"`
+	Skills         int      `json:"skills"`
+	Projects       int      `json:"projects"`
+	Awards         int      `json:"awards"`
+	Certifications int      `json:"certifications"`
+	Publications   int      `json:"publications"`
+	Skipped        []string `json:"skipped"`
+}
+
+// Skill levels are 1-10 in the portfolio and free text in JSON Resume
+var resumeSkillLevels = []struct {
+	label string
+	level int
+}{
+	{"Beginner", 3},
+	{"Intermediate", 5},
+	{"Advanced", 7},
+	{"Expert", 9},
+	{"Master", 10},
+}
+
+// ResumeService converts between the portfolio and JSON Resume documents
+// and manages the resume variants
+type ResumeService struct {
+	portfolio *PortfolioService
+	repo      *repository.ResumeRepository
+	uow       *repository.UnitOfWork
+	redis     *redis.Client
+	events    *events.Bus
+}
+
+func NewResumeService(portfolio *PortfolioService, repo *repository.ResumeRepository, uow *repository.UnitOfWork, redis *redis.Client, events *events.Bus) *ResumeService {
+	return &ResumeService{
+		portfolio: portfolio,
+		repo:      repo,
+		uow:       uow,
+		redis:     redis,
+		events:    events,
+	}
+}
+
+// Export renders the portfolio as a JSON Resume document for the variant
+// with the given slug, or for the default variant when slug is empty. With
+// no default variant the whole portfolio is rendered.
+func (s *ResumeService) Export(ctx context.Context, slug string) (*Resume, error) {
+	variant, err := s.GetResumeVariant(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	portfolio, err := s.portfolio.GetPortfolio(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := portfolio.Profile
+	label, summary := profile.Title, profile.Summary
+	if variant != nil {
+		if variant.TargetRole != "" {
+			label = variant.TargetRole
+		}
+		if variant.Summary != "" {
+			summary = variant.Summary
+		}
+	}
+	resume := &Resume{
+		Basics: ResumeBasics{
+			Name:     profile.Name,
+			Label:    label,
+			Image:    profile.Avatar,
+			Email:    profile.Email,
+			Phone:    profile.Phone,
+			Summary:  summary,
+			Location: ResumeLocation{Address: profile.Location},
+			Profiles: []ResumeProfile{},
+		},
+		Work:         []ResumeWork{},
+		Education:    []ResumeEducation{},
+		Awards:       []ResumeAward{},
+		Certificates: []ResumeCertificate{},
+		Publications: []ResumePublication{},
+		Skills:       []ResumeSkill{},
+		Projects:     []ResumeProject{},
+	}
+
+	if profile.GitHub != "" {
+		resume.Basics.Profiles = append(resume.Basics.Profiles, ResumeProfile{Network: "GitHub", URL: profile.GitHub})
+	}
+	if profile.LinkedIn != "" {
+		resume.Basics.Profiles = append(resume.Basics.Profiles, ResumeProfile{Network: "LinkedIn", URL: profile.LinkedIn})
+	}
+	if profile.Telegram != "" {
+		resume.Basics.Profiles = append(resume.Basics.Profiles, ResumeProfile{Network: "Telegram", URL: profile.Telegram})
+	}
+
+	for _, experience := range portfolio.Experiences {
+		resume.Work = append(resume.Work, ResumeWork{
+			Name:       experience.Company,
+			Position:   experience.Position,
+			Location:   experience.Location,
+			StartDate:  formatResumeDate(experience.StartDate),
+			EndDate:    formatOptionalResumeDate(experience.EndDate),
+			Summary:    experience.Description,
+			Highlights: experience.Achievements,
+		})
+	}
+
+	for _, education := range portfolio.Education {
+		resume.Education = append(resume.Education, ResumeEducation{
+			Institution: education.Institution,
+			Area:        education.Field,
+			StudyType:   education.Degree,
+			StartDate:   formatResumeDate(education.StartDate),
+			EndDate:     formatOptionalResumeDate(education.EndDate),
+		})
+	}
+
+	for _, award := range portfolio.Awards {
+		resume.Awards = append(resume.Awards, ResumeAward{
+			Title:   award.Title,
+			Date:    formatResumeDate(award.Date),
+			Awarder: award.Issuer,
+			Summary: award.Description,
+		})
+	}
+
+	for _, certification := range portfolio.Certifications {
+		resume.Certificates = append(resume.Certificates, ResumeCertificate{
+			Name:   certification.Name,
+			Date:   formatResumeDate(certification.IssueDate),
+			Issuer: certification.Issuer,
+			URL:    certification.VerificationURL,
+		})
+	}
+
+	for _, publication := range portfolio.Publications {
+		url := publication.URL
+		if url == "" && publication.DOI != "" {
+			url = "https://doi.org/" + publication.DOI
+		}
+		resume.Publications = append(resume.Publications, ResumePublication{
+			Name:        publication.Title,
+			Publisher:   publication.Venue,
+			ReleaseDate: formatResumeDate(publication.Date),
+			URL:         url,
+			Summary:     publication.Abstract,
+		})
+	}
+
+	resume.Skills = exportResumeSkills(portfolio.Skills)
+
+	for _, project := range portfolio.Projects {
+		if variant != nil && len(variant.Tags) > 0 && !hasAnyTag(project.Tags, variant.Tags) {
+			continue
+		}
+		url := project.LiveURL
+		if url == "" {
+			url = project.GitHubURL
+		}
+		resume.Projects = append(resume.Projects, ResumeProject{
+			Name:        project.Name,
+			Description: project.Description,
+			URL:         url,
+			Keywords:    project.Technologies,
+		})
+	}
+
+	if variant != nil {
+		resume.Meta = &ResumeMeta{Variant: variant.Slug, File: variant.FileURL}
+	}
+	return resume, nil
+}
+
+// hasAnyTag reports whether one of the tags has one of the slugs
+func hasAnyTag(tags []models.Tag, slugs []string) bool {
+	for _, slug := range slugs {
+		if hasTag(tags, slug) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetResumes returns the resume variants, the default first
+func (s *ResumeService) GetResumes(ctx context.Context) ([]models.Resume, error) {
+	// Try to get from cache first
+	var resumes []models.Resume
+	if cacheGet(ctx, s.redis, "resumes", tenant.CacheKey(ctx, "resumes"), &resumes) {
+		return resumes, nil
+	}
+
+	// Get from database
+	resumes, err := s.repo.GetResumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	resumesJSON, _ := json.Marshal(resumes)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "resumes"), resumesJSON, contentCacheTTL())
+
+	return resumes, nil
+}
+
+// GetResumeVariant returns the variant with the given slug, or the default
+// variant when slug is empty, which is nil when there is none
+func (s *ResumeService) GetResumeVariant(ctx context.Context, slug string) (*models.Resume, error) {
+	resumes, err := s.GetResumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resumes {
+		if (slug == "" && resumes[i].IsDefault) || (slug != "" && resumes[i].Slug == slug) {
+			return &resumes[i], nil
+		}
+	}
+	if slug != "" {
+		return nil, errors.New("resume not found")
+	}
+	return nil, nil
+}
+
+type ResumeRequest struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	Slug       string   `json:"slug" binding:"max=100"` // Derived from the name when empty
+	TargetRole string   `json:"target_role" binding:"max=200"`
+	Summary    string   `json:"summary"`
+	FileURL    string   `json:"file_url" binding:"max=2048"`
+	Tags       []string `json:"tags"` // Tag slugs; projects with any of them are included
+	Default    bool     `json:"default"`
+}
+
+// resumeFromRequest builds a variant, deriving its slug from the name when
+// none is given
+func resumeFromRequest(req *ResumeRequest) (*models.Resume, error) {
+	slug := models.Slugify(req.Slug)
+	if slug == "" {
+		slug = models.Slugify(req.Name)
+	}
+	if slug == "" {
+		return nil, errors.New("invalid slug")
+	}
+
+	tags := make([]string, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		if tag = models.Slugify(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return &models.Resume{
+		Name:       strings.TrimSpace(req.Name),
+		Slug:       slug,
+		TargetRole: req.TargetRole,
+		Summary:    req.Summary,
+		FileURL:    req.FileURL,
+		Tags:       tags,
+		IsDefault:  req.Default,
+	}, nil
+}
+
+func (s *ResumeService) CreateResume(ctx context.Context, req *ResumeRequest) (*models.Resume, error) {
+	resume, err := resumeFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	createdResume, err := s.repo.CreateResume(ctx, resume)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "resumes")
+
+	s.events.Publish(ctx, events.ResumeCreated, createdResume)
+
+	return createdResume, nil
+}
+
+func (s *ResumeService) UpdateResume(ctx context.Context, id uint, req *ResumeRequest) (*models.Resume, error) {
+	resume, err := resumeFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedResume, err := s.repo.UpdateResume(ctx, id, resume)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "resumes")
+
+	s.events.Publish(ctx, events.ResumeUpdated, updatedResume)
+
+	return updatedResume, nil
+}
+
+func (s *ResumeService) DeleteResume(ctx context.Context, id uint) error {
+	err := s.repo.DeleteResume(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "resumes")
+
+	s.events.Publish(ctx, events.ResumeDeleted, map[string]uint{"id": id})
+
+	return nil
+}
+
+// exportResumeSkills groups skills by category, using the category's highest
+// level as the group level
+func exportResumeSkills(skills []models.Skill) []ResumeSkill {
+	var categories []string
+	groups := make(map[string]*ResumeSkill)
+	levels := make(map[string]int)
+
+	for _, skill := range skills {
+		group, ok := groups[skill.Category]
+		if !ok {
+			group = &ResumeSkill{Name: skill.Category, Keywords: []string{}}
+			groups[skill.Category] = group
+			categories = append(categories, skill.Category)
+		}
+		group.Keywords = append(group.Keywords, skill.Name)
+		if skill.Level > levels[skill.Category] {
+			levels[skill.Category] = skill.Level
+		}
+	}
+
+	result := make([]ResumeSkill, 0, len(categories))
+	for _, category := range categories {
+		group := groups[category]
+		group.Level = resumeSkillLabel(levels[category])
+		result = append(result, *group)
+	}
+	return result
+}
+
+// Import adds the content of a JSON Resume document to the portfolio. The
+// profile is replaced; all other sections are appended. Entries that cannot
+// be mapped are skipped and reported. Everything else is written in one
+// transaction, so an import that fails partway leaves the portfolio as it
+// was.
+func (s *ResumeService) Import(ctx context.Context, username string, resume *Resume) (*ResumeImportResult, error) {
+	result := &ResumeImportResult{Skipped: []string{}}
+	skip := func(section string, index int, reason string) {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("%s[%d]: %s", section, index, reason))
+	}
+
+	// Published once the transaction commits
+	type change struct {
+		event string
+		data  interface{}
+	}
+	var changes []change
+
+	err := s.uow.WithTransaction(ctx, func(repos *repository.Repositories) error {
+		switch basics := resume.Basics; {
+		case basics.Name == "":
+			// No basics section; keep the current profile
+		case basics.Label == "" || basics.Email == "":
+			skip("basics", 0, "missing label or email")
+		default:
+			req := &ProfileUpdateRequest{
+				Name:     basics.Name,
+				Title:    basics.Label,
+				Location: resumeLocation(basics.Location),
+				Email:    basics.Email,
+				Phone:    basics.Phone,
+				Summary:  basics.Summary,
+				Avatar:   basics.Image,
+			}
+			for _, profile := range basics.Profiles {
+				switch strings.ToLower(profile.Network) {
+				case "github":
+					req.GitHub = profile.URL
+				case "linkedin":
+					req.LinkedIn = profile.URL
+				case "telegram":
+					req.Telegram = profile.URL
+				}
+			}
+			profile, err := repos.Profile.UpdateProfile(ctx, newProfile(req))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{events.ProfileUpdated, profile})
+			result.Profile = true
+		}
+
+		for i, work := range resume.Work {
+			if work.Name == "" || work.Position == "" {
+				skip("work", i, "missing name or position")
+				continue
+			}
+			startDate, endDate, err := parseResumePeriod(work.StartDate, work.EndDate)
+			if err != nil {
+				skip("work", i, err.Error())
+				continue
+			}
+			experience, err := repos.Experience.CreateExperience(ctx, newExperience(username, &ExperienceCreateRequest{
+				Company:      work.Name,
+				Position:     work.Position,
+				Location:     work.Location,
+				StartDate:    startDate,
+				EndDate:      endDate,
+				Current:      endDate == nil,
+				Description:  work.Summary,
+				Achievements: work.Highlights,
+			}))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{events.ExperienceCreated, experience})
+			result.Experiences++
+		}
+
+		for i, education := range resume.Education {
+			if education.Institution == "" || education.StudyType == "" {
+				skip("education", i, "missing institution or study type")
+				continue
+			}
+			startDate, endDate, err := parseResumePeriod(education.StartDate, education.EndDate)
+			if err != nil {
+				skip("education", i, err.Error())
+				continue
+			}
+			created, err := repos.Education.CreateEducation(ctx, newEducation(&EducationCreateRequest{
+				Institution: education.Institution,
+				Degree:      education.StudyType,
+				Field:       education.Area,
+				StartDate:   startDate,
+				EndDate:     endDate,
+			}))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{events.EducationCreated, created})
+			result.Education++
+		}
+
+		// Skill names are unique, and a failed insert would abort the
+		// transaction, so names already taken are skipped up front
+		skills, err := repos.Skill.GetSkills(ctx)
+		if err != nil {
+			return err
+		}
+		taken := make(map[string]bool, len(skills))
+		for _, skill := range skills {
+			taken[skill.Name] = true
+		}
+
+		for i, skill := range resume.Skills {
+			level := resumeSkillLevel(skill.Level)
+			names := skill.Keywords
+			category := skill.Name
+			if len(names) == 0 {
+				names = []string{skill.Name}
+				category = "General"
+			}
+			for _, name := range names {
+				if name == "" {
+					continue
+				}
+				if taken[name] {
+					skip("skills", i, name+": skill already exists")
+					continue
+				}
+				taken[name] = true
+				created, err := repos.Skill.CreateSkill(ctx, newSkill(username, &SkillCreateRequest{
+					Name:     name,
+					Category: category,
+					Level:    level,
+				}))
+				if err != nil {
+					return err
+				}
+				changes = append(changes, change{events.SkillCreated, created})
+				result.Skills++
+			}
+		}
+
+		for i, project := range resume.Projects {
+			if project.Name == "" {
+				skip("projects", i, "missing name")
+				continue
+			}
+			req := &ProjectCreateRequest{
+				Name:         project.Name,
+				Description:  project.Description,
+				Technologies: project.Keywords,
+				LiveURL:      project.URL,
+				Status:       "completed",
+			}
+			if strings.Contains(project.URL, "github.com") {
+				req.LiveURL, req.GitHubURL = "", project.URL
+			}
+			created, err := repos.Project.CreateProject(ctx, newProject(username, req))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{events.ProjectCreated, created})
+			result.Projects++
+		}
+
+		for i, award := range resume.Awards {
+			if award.Title == "" {
+				skip("awards", i, "missing title")
+				continue
+			}
+			date, err := parseResumeDate(award.Date)
+			if err != nil {
+				skip("awards", i, err.Error())
+				continue
+			}
+			created, err := repos.Award.CreateAward(ctx, newAward(&AwardCreateRequest{
+				Title:       award.Title,
+				Issuer:      award.Awarder,
+				Date:        date,
+				Description: award.Summary,
+			}))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{events.AwardCreated, created})
+			result.Awards++
+		}
+
+		for i, certificate := range resume.Certificates {
+			if certificate.Name == "" || certificate.Issuer == "" {
+				skip("certificates", i, "missing name or issuer")
+				continue
+			}
+			date, err := parseResumeDate(certificate.Date)
+			if err != nil {
+				skip("certificates", i, err.Error())
+				continue
+			}
+			created, err := repos.Certification.CreateCertification(ctx, newCertification(&CertificationCreateRequest{
+				Name:            certificate.Name,
+				Issuer:          certificate.Issuer,
+				IssueDate:       date,
+				VerificationURL: certificate.URL,
+			}))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{events.CertificationCreated, created})
+			result.Certifications++
+		}
+
+		for i, publication := range resume.Publications {
+			if publication.Name == "" {
+				skip("publications", i, "missing name")
+				continue
+			}
+			date, err := parseResumeDate(publication.ReleaseDate)
+			if err != nil {
+				skip("publications", i, err.Error())
+				continue
+			}
+			created, err := repos.Publication.CreatePublication(ctx, newPublication(&PublicationCreateRequest{
+				Title:    publication.Name,
+				Venue:    publication.Publisher,
+				Date:     date,
+				URL:      publication.URL,
+				Abstract: publication.Summary,
+			}))
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change{events.PublicationCreated, created})
+			result.Publications++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	keys := []string{"profile", "experiences", "experiences:drafts", "education", "skills", "tags", "awards", "certifications", "publications"}
+	invalidate(ctx, s.redis, append(keys, projectCacheKeys...)...)
+
+	for _, c := range changes {
+		s.events.Publish(ctx, c.event, c.data)
+	}
+
+	return result, nil
+}
+
+func formatResumeDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func formatOptionalResumeDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatResumeDate(*t)
+}
+
+// parseResumeDate accepts the ISO 8601 forms allowed by JSON Resume:
+// YYYY-MM-DD, YYYY-MM and YYYY
+func parseResumeDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+func parseResumePeriod(start, end string) (time.Time, *time.Time, error) {
+	startDate, err := parseResumeDate(start)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if end == "" {
+		return startDate, nil, nil
+	}
+	endDate, err := parseResumeDate(end)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return startDate, &endDate, nil
+}
+
+func resumeLocation(location ResumeLocation) string {
+	if location.Address != "" {
+		return location.Address
+	}
+	var parts []string
+	for _, part := range []string{location.City, location.Region, location.CountryCode} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func resumeSkillLevel(label string) int {
+	if level, err := strconv.Atoi(label); err == nil && level >= 1 && level <= 10 {
+		return level
+	}
+	for _, l := range resumeSkillLevels {
+		if strings.EqualFold(l.label, label) {
+			return l.level
+		}
+	}
+	return 5
+}
+
+// resumeSkillLabel returns the highest label not above level
+func resumeSkillLabel(level int) string {
+	for i := len(resumeSkillLevels) - 1; i > 0; i-- {
+		if level >= resumeSkillLevels[i].level {
+			return resumeSkillLevels[i].label
+		}
+	}
+	return resumeSkillLevels[0].label
+}
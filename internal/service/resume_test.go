@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func newTestResumeService(t *testing.T) (*ResumeService, *gorm.DB) {
+	dialector, err := database.SQLite(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Skip(err)
+	}
+	db, err := database.Initialize(dialector, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, client := newTestJobService(t)
+	s := NewResumeService(nil, repository.NewResumeRepository(db), repository.NewUnitOfWork(db), client, events.NewBus())
+	return s, db
+}
+
+func testResume() *Resume {
+	return &Resume{
+		Work: []ResumeWork{
+			{Name: "Acme", Position: "Engineer", StartDate: "2020-01"},
+			{Name: "Initech", StartDate: "2018"},
+		},
+		Skills: []ResumeSkill{{Name: "Backend", Level: "Expert", Keywords: []string{"Go", "PostgreSQL"}}},
+		Awards: []ResumeAward{{Title: "Hackathon winner", Date: "2021-05-01"}},
+	}
+}
+
+func TestResumeImport(t *testing.T) {
+	s, db := newTestResumeService(t)
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+
+	result, err := s.Import(ctx, "admin", testResume())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Experiences != 1 || result.Skills != 2 || result.Awards != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "work[1]: missing name or position" {
+		t.Errorf("skipped = %q", result.Skipped)
+	}
+
+	// Importing again keeps the skills unique
+	result, err = s.Import(ctx, "admin", testResume())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Skills != 0 || len(result.Skipped) != 3 {
+		t.Errorf("second import = %+v", result)
+	}
+	var skills int64
+	db.WithContext(ctx).Model(&models.Skill{}).Count(&skills)
+	if skills != 2 {
+		t.Errorf("got %d skills, want 2", skills)
+	}
+}
+
+func TestResumeImportRollsBackOnFailure(t *testing.T) {
+	s, db := newTestResumeService(t)
+	ctx := tenant.WithID(context.Background(), tenant.DefaultID)
+
+	// The awards are written last, so everything before them is undone
+	if err := db.Migrator().DropTable(&models.Award{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Import(ctx, "admin", testResume()); err == nil {
+		t.Fatal("import succeeded without an awards table")
+	}
+
+	var experiences, skills int64
+	db.WithContext(ctx).Model(&models.Experience{}).Count(&experiences)
+	db.WithContext(ctx).Model(&models.Skill{}).Count(&skills)
+	if experiences != 0 || skills != 0 {
+		t.Errorf("failed import left %d experiences and %d skills", experiences, skills)
+	}
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/meilisearch"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Job types that keep the search engine index up to date
+const (
+	JobSearchIndex   = "search_index"
+	JobSearchReindex = "search_reindex"
+)
+
+// SearchService finds published projects, skills and experiences by name,
+// with an external search engine when one is configured and the database
+// otherwise
+type SearchService struct {
+	repo   *repository.SearchRepository
+	jobs   *JobService
+	engine *meilisearch.Client // nil searches the database only
+	index  string
+}
+
+// NewSearchService returns a search service. When engine is not nil,
+// content changes are indexed in the engine's index by background jobs.
+func NewSearchService(repo *repository.SearchRepository, jobs *JobService, engine *meilisearch.Client, index string) *SearchService {
+	s := &SearchService{
+		repo:   repo,
+		jobs:   jobs,
+		engine: engine,
+		index:  index,
+	}
+	if engine != nil {
+		jobs.Handle(JobSearchIndex, s.runIndex)
+		jobs.Handle(JobSearchReindex, s.runReindex)
+	}
+	return s
+}
+
+// searchDocument is how content is stored in the search engine. Documents
+// of every tenant share one index and are told apart by filters.
+type searchDocument struct {
+	ID          string  `json:"id"` // Type and item ID, as in project-12
+	TenantID    uint    `json:"tenant_id"`
+	Type        string  `json:"type"`
+	ItemID      uint    `json:"item_id"`
+	Title       string  `json:"title"`
+	Subtitle    string  `json:"subtitle"`
+	PublishedAt int64   `json:"published_at"`            // Unix time from which the item is shown, 0 when it always is
+	Score       float64 `json:"_rankingScore,omitempty"` // Only set on search hits
+}
+
+var searchSettings = &meilisearch.Settings{
+	SearchableAttributes: []string{"title"},
+	FilterableAttributes: []string{"tenant_id", "type", "published_at"},
+}
+
+func searchDocumentID(kind string, id uint) string {
+	return kind + "-" + strconv.FormatUint(uint64(id), 10)
+}
+
+func newSearchDocument(kind string, item *repository.SearchItem) searchDocument {
+	doc := searchDocument{
+		ID:       searchDocumentID(kind, item.ID),
+		TenantID: item.TenantID,
+		Type:     kind,
+		ItemID:   item.ID,
+		Title:    item.Title,
+		Subtitle: item.Subtitle,
+	}
+	if item.PublishedAt != nil {
+		doc.PublishedAt = item.PublishedAt.Unix()
+	}
+	return doc
+}
+
+// Search returns up to limit items of the given types, or of every type
+// when none are given, whose names resemble query, best match first.
+// Misspellings such as "kuberentes" still find "Kubernetes".
+func (s *SearchService) Search(ctx context.Context, query string, types []string, limit int) ([]repository.SearchMatch, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("query required")
+	}
+	if len(types) == 0 {
+		types = repository.SearchTypes
+	}
+	for _, kind := range types {
+		if !slices.Contains(repository.SearchTypes, kind) {
+			return nil, errors.New("invalid type")
+		}
+	}
+
+	if s.engine != nil {
+		matches, err := s.searchEngine(ctx, query, types, limit)
+		if err == nil {
+			return matches, nil
+		}
+		log.Printf("Warning: search engine failed, searching the database instead: %v", err)
+	}
+	return s.repo.Search(ctx, query, types, limit)
+}
+
+// searchEngine queries the search engine for the tenant's items that are
+// published by now
+func (s *SearchService) searchEngine(ctx context.Context, query string, types []string, limit int) ([]repository.SearchMatch, error) {
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		tenantID = tenant.DefaultID
+	}
+	filter := fmt.Sprintf("tenant_id = %d AND published_at <= %d AND type IN [%s]",
+		tenantID, time.Now().Unix(), strings.Join(types, ", "))
+
+	var hits []searchDocument
+	err := s.engine.Search(ctx, s.index, &meilisearch.SearchRequest{
+		Query:            query,
+		Filter:           filter,
+		Limit:            limit,
+		ShowRankingScore: true,
+	}, &hits)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]repository.SearchMatch, 0, len(hits))
+	for _, hit := range hits {
+		matches = append(matches, repository.SearchMatch{
+			Type:     hit.Type,
+			ID:       hit.ItemID,
+			Title:    hit.Title,
+			Subtitle: hit.Subtitle,
+			Score:    hit.Score,
+		})
+	}
+	return matches, nil
+}
+
+// Setup applies the index settings and queues a full reindex, so the index
+// catches up with changes made while the engine was not configured. It
+// does nothing without a search engine.
+func (s *SearchService) Setup(ctx context.Context) error {
+	if s.engine == nil {
+		return nil
+	}
+	if err := s.engine.UpdateSettings(ctx, s.index, searchSettings); err != nil {
+		return err
+	}
+	return s.jobs.Enqueue(ctx, JobSearchReindex, struct{}{})
+}
+
+// Reindex queues a rebuild of the whole index from the database
+func (s *SearchService) Reindex(ctx context.Context) error {
+	if s.engine == nil {
+		return errors.New("search engine not configured")
+	}
+	return s.jobs.Enqueue(ctx, JobSearchReindex, struct{}{})
+}
+
+// searchIndexJob names the item to index again, or every item of the type
+// in the tenant when ID is 0
+type searchIndexJob struct {
+	Type string `json:"type"`
+	ID   uint   `json:"id"`
+}
+
+// searchEventTypes maps the events that change searchable content to the
+// type of content they are about
+var searchEventTypes = map[string]string{
+	events.ProjectCreated:      repository.SearchProjects,
+	events.ProjectUpdated:      repository.SearchProjects,
+	events.ProjectDeleted:      repository.SearchProjects,
+	events.SkillCreated:        repository.SearchSkills,
+	events.SkillUpdated:        repository.SearchSkills,
+	events.SkillDeleted:        repository.SearchSkills,
+	events.SkillsRecategorized: repository.SearchSkills,
+	events.ExperienceCreated:   repository.SearchExperiences,
+	events.ExperienceUpdated:   repository.SearchExperiences,
+	events.ExperienceDeleted:   repository.SearchExperiences,
+}
+
+// HandleEvent queues indexing of the project, skill or experience an event
+// is about. It is registered as an event bus subscriber; the jobs retry
+// while the engine is unreachable.
+func (s *SearchService) HandleEvent(event events.Event) {
+	kind, ok := searchEventTypes[event.Type]
+	if s.engine == nil || !ok {
+		return
+	}
+
+	// Created and updated events carry the item and deleted events its ID.
+	// Recategorizing changes many skills, so they are all indexed again.
+	job := searchIndexJob{Type: kind}
+	switch data := event.Data.(type) {
+	case *models.Project:
+		job.ID = data.ID
+	case *models.Skill:
+		job.ID = data.ID
+	case *models.Experience:
+		job.ID = data.ID
+	case map[string]uint:
+		job.ID = data["id"]
+	}
+
+	if err := s.jobs.Enqueue(event.Context(), JobSearchIndex, job); err != nil {
+		log.Printf("Warning: failed to queue search indexing for %s: %v", event.Type, err)
+	}
+}
+
+// runIndex writes the current state of items to the index, removing an
+// item that was deleted or unpublished
+func (s *SearchService) runIndex(ctx context.Context, payload json.RawMessage) error {
+	var job searchIndexJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	items, err := s.repo.GetSearchItems(ctx, job.Type, job.ID)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		if job.ID == 0 {
+			return nil
+		}
+		return s.engine.DeleteDocument(ctx, s.index, searchDocumentID(job.Type, job.ID))
+	}
+	return s.addDocuments(ctx, job.Type, items)
+}
+
+// runReindex rebuilds the index from the content of every tenant
+func (s *SearchService) runReindex(ctx context.Context, payload json.RawMessage) error {
+	ctx = tenant.All(ctx)
+	if err := s.engine.DeleteAllDocuments(ctx, s.index); err != nil {
+		return err
+	}
+	for _, kind := range repository.SearchTypes {
+		items, err := s.repo.GetSearchItems(ctx, kind, 0)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			continue
+		}
+		if err := s.addDocuments(ctx, kind, items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SearchService) addDocuments(ctx context.Context, kind string, items []repository.SearchItem) error {
+	docs := make([]searchDocument, 0, len(items))
+	for i := range items {
+		docs = append(docs, newSearchDocument(kind, &items[i]))
+	}
+	return s.engine.AddDocuments(ctx, s.index, "id", docs)
+}
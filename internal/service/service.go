@@ -4,48 +4,68 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"stackwhiz-portfolio-backend/internal/akismet"
+	"stackwhiz-portfolio-backend/internal/captcha"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/geoip"
+	"stackwhiz-portfolio-backend/internal/httpcache"
+	"stackwhiz-portfolio-backend/internal/mailer"
 	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/oembed"
 	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"stackwhiz-portfolio-backend/internal/token"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 // ProfileService handles profile-related operations
 type ProfileService struct {
-	repo  *repository.ProfileRepository
-	redis *redis.Client
+	repo   ProfileStore
+	redis  *redis.Client
+	events *events.Bus
+	media  *MediaService
+	group  singleflight.Group
 }
 
-func NewProfileService(repo *repository.ProfileRepository, redis *redis.Client) *ProfileService {
+func NewProfileService(repo ProfileStore, redis *redis.Client, events *events.Bus, media *MediaService) *ProfileService {
 	return &ProfileService{
-		repo:  repo,
-		redis: redis,
+		repo:   repo,
+		redis:  redis,
+		events: events,
+		media:  media,
 	}
 }
 
-func (s *ProfileService) GetProfile() (*models.Profile, error) {
+func (s *ProfileService) GetProfile(ctx context.Context) (*models.Profile, error) {
 	// Try to get from cache first
-	ctx := context.Background()
-	cached, err := s.redis.Get(ctx, "profile").Result()
-	if err == nil {
-		var profile models.Profile
-		if err := json.Unmarshal([]byte(cached), &profile); err == nil {
-			return &profile, nil
-		}
+	var profile models.Profile
+	if cacheGet(ctx, s.redis, "profile", tenant.CacheKey(ctx, "profile"), &profile) {
+		return &profile, nil
 	}
 
-	// Get from database
-	profile, err := s.repo.GetProfile()
-	if err != nil {
-		return nil, err
-	}
+	// Get from database, once for all concurrent callers
+	return loadOnce(ctx, &s.group, "profile", func(ctx context.Context) (*models.Profile, error) {
+		profile, err := s.repo.GetProfile(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	// Cache the result
-	profileJSON, _ := json.Marshal(profile)
-	s.redis.Set(ctx, "profile", profileJSON, time.Hour)
+		// Cache the result
+		profileJSON, _ := json.Marshal(profile)
+		s.redis.Set(ctx, tenant.CacheKey(ctx, "profile"), profileJSON, contentCacheTTL())
 
-	return profile, nil
+		return profile, nil
+	})
 }
 
 type ProfileUpdateRequest struct {
@@ -60,10 +80,25 @@ type ProfileUpdateRequest struct {
 	Summary   string `json:"summary"`
 	Avatar    string `json:"avatar"`
 	ResumeURL string `json:"resume_url"`
+	Version   uint   `json:"version"` // Version the edit is based on; 0 skips the conflict check
+}
+
+func (s *ProfileService) UpdateProfile(ctx context.Context, req *ProfileUpdateRequest) (*models.Profile, error) {
+	updatedProfile, err := s.repo.UpdateProfile(ctx, newProfile(req))
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "profile")
+
+	s.events.Publish(ctx, events.ProfileUpdated, updatedProfile)
+
+	return updatedProfile, nil
 }
 
-func (s *ProfileService) UpdateProfile(req *ProfileUpdateRequest) (*models.Profile, error) {
-	profile := &models.Profile{
+func newProfile(req *ProfileUpdateRequest) *models.Profile {
+	return &models.Profile{
 		Name:      req.Name,
 		Title:     req.Title,
 		Location:  req.Location,
@@ -75,59 +110,132 @@ func (s *ProfileService) UpdateProfile(req *ProfileUpdateRequest) (*models.Profi
 		Summary:   req.Summary,
 		Avatar:    req.Avatar,
 		ResumeURL: req.ResumeURL,
+		Version:   req.Version,
 	}
+}
 
-	updatedProfile, err := s.repo.UpdateProfile(profile)
-	if err != nil {
-		return nil, err
+// cacheTTL is how long cached content lives, in nanoseconds. It follows
+// CACHE_TTL_SECONDS and can change while the server runs.
+var cacheTTL atomic.Int64
+
+// SetCacheTTL sets how long content cached from now on lives
+func SetCacheTTL(ttl time.Duration) {
+	cacheTTL.Store(int64(ttl))
+}
+
+func contentCacheTTL() time.Duration {
+	if ttl := time.Duration(cacheTTL.Load()); ttl > 0 {
+		return ttl
+	}
+	return time.Hour
+}
+
+// loadTimeout bounds a shared cache load, which outlives the caller that
+// started it
+const loadTimeout = 30 * time.Second
+
+// loadOnce runs load for a cache key at most once at a time. Callers that
+// miss the cache while a load is in flight wait for it and share its result
+// instead of all querying the database. The load is shared, so a caller
+// that gives up stops waiting without cancelling it for the others.
+func loadOnce[T any](ctx context.Context, group *singleflight.Group, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	result := group.DoChan(tenant.CacheKey(ctx, key), func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		return load(loadCtx)
+	})
+
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-result:
+		if r.Err != nil {
+			return zero, r.Err
+		}
+		return r.Val.(T), nil
 	}
+}
 
-	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "profile")
+// invalidate drops the tenant's cached keys after a write, together with
+// the cached HTTP responses of the resources they belong to ("projects" for
+// "projects:featured") and the portfolio assembled from them. The write has
+// already been committed, so this runs even if the request was cancelled
+// meanwhile.
+func invalidate(ctx context.Context, client *redis.Client, keys ...string) {
+	scoped := make([]string, 0, 2*len(keys)+2)
+	resources := make(map[string]bool)
+	for _, key := range keys {
+		scoped = append(scoped, tenant.CacheKey(ctx, key))
+
+		resource, _, _ := strings.Cut(key, ":")
+		if !resources[resource] {
+			resources[resource] = true
+			scoped = append(scoped, tenant.CacheKey(ctx, httpcache.Key(resource)))
+		}
+		if portfolioResources[resource] && !resources[portfolioCacheKey] {
+			resources[portfolioCacheKey] = true
+			scoped = append(scoped,
+				tenant.CacheKey(ctx, portfolioCacheKey),
+				tenant.CacheKey(ctx, httpcache.Key(portfolioCacheKey)))
+		}
+	}
+	client.Del(context.WithoutCancel(ctx), scoped...)
+}
 
-	return updatedProfile, nil
+// draftsCacheKey keeps listings that include unpublished content apart from
+// the public ones
+func draftsCacheKey(key string, includeDrafts bool) string {
+	if includeDrafts {
+		return key + ":drafts"
+	}
+	return key
 }
 
 // ExperienceService handles experience-related operations
 type ExperienceService struct {
-	repo  *repository.ExperienceRepository
-	redis *redis.Client
+	repo   ExperienceStore
+	redis  *redis.Client
+	events *events.Bus
+	group  singleflight.Group
 }
 
-func NewExperienceService(repo *repository.ExperienceRepository, redis *redis.Client) *ExperienceService {
+func NewExperienceService(repo ExperienceStore, redis *redis.Client, events *events.Bus) *ExperienceService {
 	return &ExperienceService{
-		repo:  repo,
-		redis: redis,
+		repo:   repo,
+		redis:  redis,
+		events: events,
 	}
 }
 
-func (s *ExperienceService) GetExperiences() ([]models.Experience, error) {
+// GetExperiences returns published experiences, or every experience when
+// includeDrafts is set
+func (s *ExperienceService) GetExperiences(ctx context.Context, includeDrafts bool) ([]models.Experience, error) {
 	// Try to get from cache first
-	ctx := context.Background()
-	cached, err := s.redis.Get(ctx, "experiences").Result()
-	if err == nil {
-		var experiences []models.Experience
-		if err := json.Unmarshal([]byte(cached), &experiences); err == nil {
-			return experiences, nil
-		}
+	cacheKey := draftsCacheKey("experiences", includeDrafts)
+	var experiences []models.Experience
+	if cacheGet(ctx, s.redis, "experiences", tenant.CacheKey(ctx, cacheKey), &experiences) {
+		return experiences, nil
 	}
 
-	// Get from database
-	experiences, err := s.repo.GetExperiences()
-	if err != nil {
-		return nil, err
-	}
+	// Get from database, once for all concurrent callers
+	return loadOnce(ctx, &s.group, cacheKey, func(ctx context.Context) ([]models.Experience, error) {
+		experiences, err := s.repo.GetExperiences(ctx, includeDrafts)
+		if err != nil {
+			return nil, err
+		}
 
-	// Cache the result
-	experiencesJSON, _ := json.Marshal(experiences)
-	s.redis.Set(ctx, "experiences", experiencesJSON, time.Hour)
+		// Cache the result
+		experiencesJSON, _ := json.Marshal(experiences)
+		s.redis.Set(ctx, tenant.CacheKey(ctx, cacheKey), experiencesJSON, contentCacheTTL())
 
-	return experiences, nil
+		return experiences, nil
+	})
 }
 
 type ExperienceCreateRequest struct {
-	Company      string     `json:"company" binding:"required"`
+	Company      string     `json:"company" binding:"required_without=CompanyID"`
+	CompanyID    *uint      `json:"company_id"` // Links a company, whose name replaces company
 	Position     string     `json:"position" binding:"required"`
 	Location     string     `json:"location"`
 	StartDate    time.Time  `json:"start_date" binding:"required"`
@@ -136,11 +244,31 @@ type ExperienceCreateRequest struct {
 	Description  string     `json:"description"`
 	Achievements []string   `json:"achievements"`
 	Technologies []string   `json:"technologies"`
+	SkillIDs     []uint     `json:"skill_ids"` // Skills used in the role
+	Pinned       bool       `json:"pinned"`
+	Published    *bool      `json:"published"` // Defaults to true
+	PublishedAt  *time.Time `json:"published_at"`
 }
 
-func (s *ExperienceService) CreateExperience(req *ExperienceCreateRequest) (*models.Experience, error) {
-	experience := &models.Experience{
+func (s *ExperienceService) CreateExperience(ctx context.Context, username string, req *ExperienceCreateRequest) (*models.Experience, error) {
+	createdExperience, err := s.repo.CreateExperience(ctx, newExperience(username, req))
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "experiences", "experiences:drafts", "skills")
+
+	s.events.Publish(ctx, events.ExperienceCreated, createdExperience)
+
+	return createdExperience, nil
+}
+
+func newExperience(username string, req *ExperienceCreateRequest) *models.Experience {
+	published := req.Published == nil || *req.Published
+	return &models.Experience{
 		Company:      req.Company,
+		CompanyID:    req.CompanyID,
 		Position:     req.Position,
 		Location:     req.Location,
 		StartDate:    req.StartDate,
@@ -149,22 +277,17 @@ func (s *ExperienceService) CreateExperience(req *ExperienceCreateRequest) (*mod
 		Description:  req.Description,
 		Achievements: req.Achievements,
 		Technologies: req.Technologies,
+		Skills:       skillsFromIDs(req.SkillIDs),
+		Pinned:       req.Pinned,
+		Published:    published,
+		PublishedAt:  publicationDate(published, req.PublishedAt),
+		Attribution:  models.Attribution{CreatedBy: username, UpdatedBy: username},
 	}
-
-	createdExperience, err := s.repo.CreateExperience(experience)
-	if err != nil {
-		return nil, err
-	}
-
-	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "experiences")
-
-	return createdExperience, nil
 }
 
 type ExperienceUpdateRequest struct {
 	Company      string     `json:"company"`
+	CompanyID    *uint      `json:"company_id"` // Links a company, whose name replaces company
 	Position     string     `json:"position"`
 	Location     string     `json:"location"`
 	StartDate    time.Time  `json:"start_date"`
@@ -173,11 +296,18 @@ type ExperienceUpdateRequest struct {
 	Description  string     `json:"description"`
 	Achievements []string   `json:"achievements"`
 	Technologies []string   `json:"technologies"`
+	SkillIDs     []uint     `json:"skill_ids"` // Skills used in the role
+	Pinned       bool       `json:"pinned"`
+	Published    *bool      `json:"published"` // Defaults to true
+	PublishedAt  *time.Time `json:"published_at"`
+	Version      uint       `json:"version"` // Version the edit is based on; 0 skips the conflict check
 }
 
-func (s *ExperienceService) UpdateExperience(id uint, req *ExperienceUpdateRequest) (*models.Experience, error) {
+func (s *ExperienceService) UpdateExperience(ctx context.Context, id uint, username string, req *ExperienceUpdateRequest) (*models.Experience, error) {
+	published := req.Published == nil || *req.Published
 	experience := &models.Experience{
 		Company:      req.Company,
+		CompanyID:    req.CompanyID,
 		Position:     req.Position,
 		Location:     req.Location,
 		StartDate:    req.StartDate,
@@ -186,68 +316,196 @@ func (s *ExperienceService) UpdateExperience(id uint, req *ExperienceUpdateReque
 		Description:  req.Description,
 		Achievements: req.Achievements,
 		Technologies: req.Technologies,
+		Skills:       skillsFromIDs(req.SkillIDs),
+		Pinned:       req.Pinned,
+		Published:    published,
+		PublishedAt:  req.PublishedAt,
+		Version:      req.Version,
+		Attribution:  models.Attribution{UpdatedBy: username},
 	}
 
-	updatedExperience, err := s.repo.UpdateExperience(id, experience)
+	updatedExperience, err := s.repo.UpdateExperience(ctx, id, experience)
 	if err != nil {
 		return nil, err
 	}
 
 	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "experiences")
+	invalidate(ctx, s.redis, "experiences", "experiences:drafts", "skills")
+
+	s.events.Publish(ctx, events.ExperienceUpdated, updatedExperience)
 
 	return updatedExperience, nil
 }
 
-func (s *ExperienceService) DeleteExperience(id uint) error {
-	err := s.repo.DeleteExperience(id)
+type ExperienceReorderRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"` // Every experience ID, in display order
+}
+
+// ReorderExperiences applies a new sort order and returns every experience,
+// drafts included, in the resulting order
+func (s *ExperienceService) ReorderExperiences(ctx context.Context, req *ExperienceReorderRequest) ([]models.Experience, error) {
+	if err := s.repo.ReorderExperiences(ctx, req.IDs); err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "experiences", "experiences:drafts")
+
+	s.events.Publish(ctx, events.ExperiencesReordered, map[string][]uint{"ids": req.IDs})
+
+	return s.GetExperiences(ctx, true)
+}
+
+func (s *ExperienceService) DeleteExperience(ctx context.Context, id uint) error {
+	err := s.repo.DeleteExperience(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "experiences")
+	invalidate(ctx, s.redis, "experiences", "experiences:drafts", "skills")
+
+	s.events.Publish(ctx, events.ExperienceDeleted, map[string]uint{"id": id})
 
 	return nil
 }
 
 // SkillService handles skill-related operations
 type SkillService struct {
-	repo  *repository.SkillRepository
-	redis *redis.Client
+	repo        SkillStore
+	experiences ExperienceStore // Scanned for skill suggestions
+	redis       *redis.Client
+	events      *events.Bus
+	group       singleflight.Group
 }
 
-func NewSkillService(repo *repository.SkillRepository, redis *redis.Client) *SkillService {
+func NewSkillService(repo SkillStore, experiences ExperienceStore, redis *redis.Client, events *events.Bus) *SkillService {
 	return &SkillService{
-		repo:  repo,
-		redis: redis,
+		repo:        repo,
+		experiences: experiences,
+		redis:       redis,
+		events:      events,
 	}
 }
 
-func (s *SkillService) GetSkills() ([]models.Skill, error) {
-	// Try to get from cache first
-	ctx := context.Background()
-	cached, err := s.redis.Get(ctx, "skills").Result()
-	if err == nil {
-		var skills []models.Skill
-		if err := json.Unmarshal([]byte(cached), &skills); err == nil {
-			return skills, nil
-		}
+// GetSkills returns all skills with their endorsements, including those not
+// yet flushed to the database
+func (s *SkillService) GetSkills(ctx context.Context) ([]models.Skill, error) {
+	shared, err := s.getSkills(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get from database
-	skills, err := s.repo.GetSkills()
+	// The loaded slice may be shared with concurrent callers, so adjust a copy
+	skills := append([]models.Skill(nil), shared...)
+	s.addPendingEndorsements(ctx, skills)
+	return skills, nil
+}
+
+// SkillGroup holds the skills of one category
+type SkillGroup struct {
+	Category string         `json:"category"`
+	Count    int            `json:"count"`
+	Skills   []models.Skill `json:"skills"`
+}
+
+// GetSkillGroups returns the skills grouped by category. Categories follow
+// the display order of their first skill, and skills keep their display
+// order within a category.
+func (s *SkillService) GetSkillGroups(ctx context.Context) ([]SkillGroup, error) {
+	skills, err := s.GetSkills(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return groupSkills(skills), nil
+}
 
-	// Cache the result
-	skillsJSON, _ := json.Marshal(skills)
-	s.redis.Set(ctx, "skills", skillsJSON, time.Hour)
+func groupSkills(skills []models.Skill) []SkillGroup {
+	groups := []SkillGroup{}
+	index := map[string]int{}
+	for _, skill := range skills {
+		i, ok := index[skill.Category]
+		if !ok {
+			i = len(groups)
+			index[skill.Category] = i
+			groups = append(groups, SkillGroup{Category: skill.Category})
+		}
+		groups[i].Skills = append(groups[i].Skills, skill)
+		groups[i].Count++
+	}
+	return groups
+}
 
-	return skills, nil
+func (s *SkillService) getSkills(ctx context.Context) ([]models.Skill, error) {
+	// Try to get from cache first
+	var skills []models.Skill
+	if cacheGet(ctx, s.redis, "skills", tenant.CacheKey(ctx, "skills"), &skills) {
+		return skills, nil
+	}
+
+	// Get from database, once for all concurrent callers
+	return loadOnce(ctx, &s.group, "skills", func(ctx context.Context) ([]models.Skill, error) {
+		skills, err := s.repo.GetSkills(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.setYearsOfUse(ctx, skills); err != nil {
+			return nil, err
+		}
+
+		// Cache the result
+		skillsJSON, _ := json.Marshal(skills)
+		s.redis.Set(ctx, tenant.CacheKey(ctx, "skills"), skillsJSON, contentCacheTTL())
+
+		return skills, nil
+	})
+}
+
+// setYearsOfUse fills in each skill's years of use from the published
+// experiences linked to it
+func (s *SkillService) setYearsOfUse(ctx context.Context, skills []models.Skill) error {
+	periods, err := s.repo.GetSkillPeriods(ctx)
+	if err != nil {
+		return err
+	}
+
+	bySkill := make(map[uint][]repository.SkillPeriod)
+	for _, period := range periods {
+		bySkill[period.SkillID] = append(bySkill[period.SkillID], period)
+	}
+
+	now := time.Now()
+	for i := range skills {
+		skills[i].YearsOfUse = yearsOfUse(bySkill[skills[i].ID], now)
+	}
+	return nil
+}
+
+// yearsOfUse adds up periods sorted by start date, counting overlapping
+// roles once, and rounds to a tenth of a year
+func yearsOfUse(periods []repository.SkillPeriod, now time.Time) float64 {
+	var total time.Duration
+	var spanStart, spanEnd time.Time
+	for _, period := range periods {
+		end := now
+		if !period.Current && period.EndDate != nil {
+			end = *period.EndDate
+		}
+		if !end.After(period.StartDate) {
+			continue
+		}
+
+		if spanEnd.IsZero() || period.StartDate.After(spanEnd) {
+			total += spanEnd.Sub(spanStart)
+			spanStart, spanEnd = period.StartDate, end
+		} else if end.After(spanEnd) {
+			spanEnd = end
+		}
+	}
+	total += spanEnd.Sub(spanStart)
+
+	years := total.Hours() / (24 * 365.25)
+	return math.Round(years*10) / 10
 }
 
 type SkillCreateRequest struct {
@@ -258,85 +516,393 @@ type SkillCreateRequest struct {
 	Icon        string `json:"icon"`
 }
 
-func (s *SkillService) CreateSkill(req *SkillCreateRequest) (*models.Skill, error) {
-	skill := &models.Skill{
-		Name:        req.Name,
-		Category:    req.Category,
-		Level:       req.Level,
-		Description: req.Description,
-		Icon:        req.Icon,
-	}
-
-	createdSkill, err := s.repo.CreateSkill(skill)
+func (s *SkillService) CreateSkill(ctx context.Context, username string, req *SkillCreateRequest) (*models.Skill, error) {
+	createdSkill, err := s.repo.CreateSkill(ctx, newSkill(username, req))
 	if err != nil {
 		return nil, err
 	}
 
 	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "skills")
+	invalidate(ctx, s.redis, "skills")
+
+	s.events.Publish(ctx, events.SkillCreated, createdSkill)
 
 	return createdSkill, nil
 }
 
+func newSkill(username string, req *SkillCreateRequest) *models.Skill {
+	return &models.Skill{
+		Name:        req.Name,
+		Category:    req.Category,
+		Level:       req.Level,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Attribution: models.Attribution{CreatedBy: username, UpdatedBy: username},
+	}
+}
+
 type SkillUpdateRequest struct {
 	Name        string `json:"name"`
 	Category    string `json:"category"`
 	Level       int    `json:"level" binding:"min=1,max=10"`
 	Description string `json:"description"`
 	Icon        string `json:"icon"`
+	Version     uint   `json:"version"` // Version the edit is based on; 0 skips the conflict check
 }
 
-func (s *SkillService) UpdateSkill(id uint, req *SkillUpdateRequest) (*models.Skill, error) {
+func (s *SkillService) UpdateSkill(ctx context.Context, id uint, username string, req *SkillUpdateRequest) (*models.Skill, error) {
 	skill := &models.Skill{
 		Name:        req.Name,
 		Category:    req.Category,
 		Level:       req.Level,
 		Description: req.Description,
 		Icon:        req.Icon,
+		Version:     req.Version,
+		Attribution: models.Attribution{UpdatedBy: username},
 	}
 
-	updatedSkill, err := s.repo.UpdateSkill(id, skill)
+	updatedSkill, err := s.repo.UpdateSkill(ctx, id, skill)
 	if err != nil {
 		return nil, err
 	}
 
-	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "skills")
+	// Projects embed their skills, so their listings are stale too
+	invalidate(ctx, s.redis, append([]string{"skills"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.SkillUpdated, updatedSkill)
 
 	return updatedSkill, nil
 }
 
-func (s *SkillService) DeleteSkill(id uint) error {
-	err := s.repo.DeleteSkill(id)
+type SkillReorderRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"` // Every skill ID, in display order
+}
+
+// ReorderSkills applies a new display order and returns the reordered skills
+func (s *SkillService) ReorderSkills(ctx context.Context, req *SkillReorderRequest) ([]models.Skill, error) {
+	if err := s.repo.ReorderSkills(ctx, req.IDs); err != nil {
+		return nil, err
+	}
+
+	// Projects embed their skills, so their listings are stale too
+	invalidate(ctx, s.redis, append([]string{"skills"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.SkillsReordered, map[string][]uint{"ids": req.IDs})
+
+	return s.GetSkills(ctx)
+}
+
+// SkillCategory is a category in use by at least one skill
+type SkillCategory struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// GetSkillCategories returns the categories in use with the number of
+// skills in each, in the order GetSkillGroups lists them
+func (s *SkillService) GetSkillCategories(ctx context.Context) ([]SkillCategory, error) {
+	skills, err := s.getSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := groupSkills(skills)
+	categories := make([]SkillCategory, len(groups))
+	for i, group := range groups {
+		categories[i] = SkillCategory{Name: group.Category, Count: group.Count}
+	}
+	return categories, nil
+}
+
+type SkillCategoryRenameRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"` // Must not be in use yet; merge into existing categories instead
+}
+
+// RenameSkillCategory renames a category on every skill using it and
+// returns the updated categories
+func (s *SkillService) RenameSkillCategory(ctx context.Context, username string, req *SkillCategoryRenameRequest) ([]SkillCategory, error) {
+	from, to := strings.TrimSpace(req.From), strings.TrimSpace(req.To)
+	if from == "" || to == "" || from == to {
+		return nil, errors.New("invalid category")
+	}
+
+	moved, err := s.repo.RenameSkillCategory(ctx, from, to, username)
+	if err != nil {
+		return nil, err
+	}
+	return s.recategorized(ctx, []string{from}, to, moved)
+}
+
+type SkillCategoryMergeRequest struct {
+	From []string `json:"from" binding:"required,min=1"` // Categories to merge away
+	To   string   `json:"to" binding:"required"`         // Category to merge into, new or existing
+}
+
+// MergeSkillCategories moves the skills of several categories into one and
+// returns the updated categories
+func (s *SkillService) MergeSkillCategories(ctx context.Context, username string, req *SkillCategoryMergeRequest) ([]SkillCategory, error) {
+	to := strings.TrimSpace(req.To)
+	if to == "" {
+		return nil, errors.New("invalid category")
+	}
+	var from []string
+	for _, category := range req.From {
+		if category = strings.TrimSpace(category); category != "" && category != to {
+			from = append(from, category)
+		}
+	}
+	if len(from) == 0 {
+		return nil, errors.New("invalid category")
+	}
+
+	moved, err := s.repo.MergeSkillCategories(ctx, from, to, username)
+	if err != nil {
+		return nil, err
+	}
+	return s.recategorized(ctx, from, to, moved)
+}
+
+func (s *SkillService) recategorized(ctx context.Context, from []string, to string, moved int64) ([]SkillCategory, error) {
+	// Projects embed their skills, so their listings are stale too
+	invalidate(ctx, s.redis, append([]string{"skills"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.SkillsRecategorized, map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"moved": moved,
+	})
+
+	return s.GetSkillCategories(ctx)
+}
+
+type SkillMergeRequest struct {
+	SourceIDs []uint `json:"source_ids" binding:"required,min=1"` // Duplicates to merge away
+	TargetID  uint   `json:"target_id" binding:"required"`        // Skill to keep
+}
+
+// MergeSkills folds duplicate skills into one, moving their projects,
+// experiences and endorsements, and returns the merged skill
+func (s *SkillService) MergeSkills(ctx context.Context, username string, req *SkillMergeRequest) (*models.Skill, error) {
+	var sources []uint
+	seen := make(map[uint]bool, len(req.SourceIDs))
+	for _, id := range req.SourceIDs {
+		if id == req.TargetID {
+			return nil, errors.New("invalid merge")
+		}
+		if !seen[id] {
+			seen[id] = true
+			sources = append(sources, id)
+		}
+	}
+
+	skill, err := s.repo.MergeSkills(ctx, sources, req.TargetID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Endorsements not yet flushed would otherwise be written to the deleted
+	// skills. Moving them with increments keeps those counted meanwhile.
+	target := strconv.FormatUint(uint64(req.TargetID), 10)
+	for _, id := range sources {
+		field := strconv.FormatUint(uint64(id), 10)
+		pending, err := s.redis.HGet(ctx, endorsementPendingKey, field).Int64()
+		if err != nil || pending == 0 {
+			continue
+		}
+		s.redis.HIncrBy(ctx, endorsementPendingKey, target, pending)
+		s.redis.HIncrBy(ctx, endorsementPendingKey, field, -pending)
+	}
+
+	invalidate(ctx, s.redis, append([]string{"skills", "experiences", "experiences:drafts"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.SkillUpdated, skill)
+	for _, id := range sources {
+		s.events.Publish(ctx, events.SkillDeleted, map[string]uint{"id": id})
+	}
+
+	return skill, nil
+}
+
+func (s *SkillService) DeleteSkill(ctx context.Context, id uint) error {
+	err := s.repo.DeleteSkill(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "skills")
+	// Projects embed their skills, so their listings are stale too
+	invalidate(ctx, s.redis, append([]string{"skills"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.SkillDeleted, map[string]uint{"id": id})
 
 	return nil
 }
 
+// skillsFromIDs turns skill IDs from a request into references that are
+// checked when the project is saved
+func skillsFromIDs(ids []uint) []models.Skill {
+	skills := make([]models.Skill, 0, len(ids))
+	for _, id := range ids {
+		skills = append(skills, models.Skill{ID: id})
+	}
+	return skills
+}
+
+// projectCacheKeys lists every cached project listing
+var projectCacheKeys = []string{
+	"projects", "projects:featured", "projects:non-featured",
+	"projects:drafts", "projects:featured:drafts", "projects:non-featured:drafts",
+	"projects:archived", "projects:featured:archived", "projects:non-featured:archived",
+	"projects:drafts:archived", "projects:featured:drafts:archived", "projects:non-featured:drafts:archived",
+}
+
 // ProjectService handles project-related operations
 type ProjectService struct {
-	repo  *repository.ProjectRepository
-	redis *redis.Client
+	repo   ProjectStore
+	redis  *redis.Client
+	events *events.Bus
+	oembed *oembed.Client
+	group  singleflight.Group
 }
 
-func NewProjectService(repo *repository.ProjectRepository, redis *redis.Client) *ProjectService {
+func NewProjectService(repo ProjectStore, redis *redis.Client, events *events.Bus, oembed *oembed.Client) *ProjectService {
 	return &ProjectService{
-		repo:  repo,
-		redis: redis,
+		repo:   repo,
+		redis:  redis,
+		events: events,
+		oembed: oembed,
+	}
+}
+
+// GetProjects returns published projects, or every project when
+// includeDrafts is set, optionally narrowed to one category and to the
+// projects carrying the tag with the given slug. Archived projects are left
+// out unless includeArchived is set. Narrowed listings are filtered from the
+// cached listing rather than cached apart. Like counts include the likes not
+// yet flushed to the database.
+func (s *ProjectService) GetProjects(ctx context.Context, featured *bool, categoryID *uint, tag string, includeDrafts, includeArchived bool) ([]models.Project, error) {
+	shared, err := s.getProjects(ctx, featured, includeDrafts, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	// The loaded slice may be shared with concurrent callers, so adjust a copy
+	projects := append(make([]models.Project, 0, len(shared)), shared...)
+	s.addPendingLikes(ctx, projects)
+	if categoryID == nil && tag == "" {
+		return projects, nil
+	}
+
+	filtered := []models.Project{}
+	for _, project := range projects {
+		if categoryID != nil && (project.CategoryID == nil || *project.CategoryID != *categoryID) {
+			continue
+		}
+		if tag != "" && !hasTag(project.Tags, tag) {
+			continue
+		}
+		filtered = append(filtered, project)
+	}
+	return filtered, nil
+}
+
+// GetProject returns a project, which must be published unless
+// includeDrafts is set and not archived unless includeArchived is set
+func (s *ProjectService) GetProject(ctx context.Context, id uint, includeDrafts, includeArchived bool) (*models.Project, error) {
+	projects, err := s.getProjects(ctx, nil, includeDrafts, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range projects {
+		if projects[i].ID == id {
+			// The loaded listing may be shared with concurrent callers
+			project := projects[i]
+			project.LikeCount += pendingCounts(ctx, s.redis, likePendingKey, likeFlushingKey)[id]
+			return &project, nil
+		}
+	}
+	return nil, errors.New("project not found")
+}
+
+// GetProjectSkills returns the skills of a published project
+func (s *ProjectService) GetProjectSkills(ctx context.Context, id uint) ([]models.Skill, error) {
+	projects, err := s.getProjects(ctx, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if project.ID == id {
+			return project.Skills, nil
+		}
+	}
+	return nil, errors.New("project not found")
+}
+
+// GetProjectsBySkill returns the published projects demonstrating a skill
+func (s *ProjectService) GetProjectsBySkill(ctx context.Context, skillID uint) ([]models.Project, error) {
+	exists, err := s.repo.SkillExists(ctx, skillID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("skill not found")
+	}
+
+	projects, err := s.getProjects(ctx, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []models.Project{}
+	for _, project := range projects {
+		for _, skill := range project.Skills {
+			if skill.ID == skillID {
+				filtered = append(filtered, project)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// Project listing orders
+const (
+	ProjectOrderNewest       = "created_at"    // Most recently created first
+	ProjectOrderFeaturedRank = "featured_rank" // Ranked featured projects first, by rank, then the rest newest first
+)
+
+// OrderProjects returns the projects sorted by order and cut to limit, where
+// a limit of 0 keeps them all. Listings come straight from the cache and may
+// be shared, so a sorted copy is returned.
+func OrderProjects(projects []models.Project, order string, limit int) ([]models.Project, error) {
+	switch order {
+	case "", ProjectOrderNewest:
+		// Listings are loaded newest first
+	case ProjectOrderFeaturedRank:
+		ranked := make([]models.Project, len(projects))
+		copy(ranked, projects)
+		sort.SliceStable(ranked, func(i, j int) bool {
+			a, b := ranked[i].FeaturedRank, ranked[j].FeaturedRank
+			if a == 0 || b == 0 {
+				return b == 0 && a != 0
+			}
+			return a < b
+		})
+		projects = ranked
+	default:
+		return nil, errors.New("invalid order")
+	}
+
+	if limit > 0 && len(projects) > limit {
+		projects = projects[:limit]
 	}
+	return projects, nil
 }
 
-func (s *ProjectService) GetProjects(featured *bool) ([]models.Project, error) {
+func (s *ProjectService) getProjects(ctx context.Context, featured *bool, includeDrafts, includeArchived bool) ([]models.Project, error) {
 	// Try to get from cache first
-	ctx := context.Background()
 	cacheKey := "projects"
 	if featured != nil {
 		if *featured {
@@ -345,81 +911,115 @@ func (s *ProjectService) GetProjects(featured *bool) ([]models.Project, error) {
 			cacheKey = "projects:non-featured"
 		}
 	}
+	cacheKey = draftsCacheKey(cacheKey, includeDrafts)
+	if includeArchived {
+		cacheKey += ":archived"
+	}
+
+	var projects []models.Project
+	if cacheGet(ctx, s.redis, "projects", tenant.CacheKey(ctx, cacheKey), &projects) {
+		return projects, nil
+	}
 
-	cached, err := s.redis.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var projects []models.Project
-		if err := json.Unmarshal([]byte(cached), &projects); err == nil {
-			return projects, nil
+	// Get from database, once for all concurrent callers
+	return loadOnce(ctx, &s.group, cacheKey, func(ctx context.Context) ([]models.Project, error) {
+		projects, err := s.repo.GetProjects(ctx, featured, nil, includeDrafts, includeArchived)
+		if err != nil {
+			return nil, err
+		}
+		for i := range projects {
+			projects[i].Demo = s.demoVideo(ctx, projects[i].DemoVideoURL)
 		}
+
+		// Cache the result
+		projectsJSON, _ := json.Marshal(projects)
+		s.redis.Set(ctx, tenant.CacheKey(ctx, cacheKey), projectsJSON, contentCacheTTL())
+
+		return projects, nil
+	})
+}
+
+type ProjectCreateRequest struct {
+	Name            string     `json:"name" binding:"required"`
+	Description     string     `json:"description" binding:"required"`
+	LongDescription string     `json:"long_description"`
+	Technologies    []string   `json:"technologies"`
+	Tags            []string   `json:"tags"`      // Tag names; unknown tags are created
+	SkillIDs        []uint     `json:"skill_ids"` // Skills the project demonstrates
+	GitHubURL       string     `json:"github_url"`
+	LiveURL         string     `json:"live_url"`
+	DemoVideoURL    string     `json:"demo_video_url"` // YouTube or Vimeo link, or a video file
+	Featured        bool       `json:"featured"`
+	CategoryID      *uint      `json:"category_id"`
+	Status          string     `json:"status"`
+	Published       *bool      `json:"published"` // Defaults to true
+	PublishedAt     *time.Time `json:"published_at"`
+}
+
+func (s *ProjectService) CreateProject(ctx context.Context, username string, req *ProjectCreateRequest) (*models.Project, error) {
+	if err := checkDemoVideo(req.DemoVideoURL); err != nil {
+		return nil, err
 	}
 
-	// Get from database
-	projects, err := s.repo.GetProjects(featured)
+	createdProject, err := s.repo.CreateProject(ctx, newProject(username, req))
 	if err != nil {
 		return nil, err
 	}
+	createdProject.Demo = s.demoVideo(ctx, createdProject.DemoVideoURL)
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, append([]string{"tags"}, projectCacheKeys...)...)
 
-	// Cache the result
-	projectsJSON, _ := json.Marshal(projects)
-	s.redis.Set(ctx, cacheKey, projectsJSON, time.Hour)
+	s.events.Publish(ctx, events.ProjectCreated, createdProject)
 
-	return projects, nil
+	return createdProject, nil
 }
 
-type ProjectCreateRequest struct {
-	Name            string   `json:"name" binding:"required"`
-	Description     string   `json:"description" binding:"required"`
-	LongDescription string   `json:"long_description"`
-	Technologies    []string `json:"technologies"`
-	GitHubURL       string   `json:"github_url"`
-	LiveURL         string   `json:"live_url"`
-	ImageURL        string   `json:"image_url"`
-	Featured        bool     `json:"featured"`
-	Category        string   `json:"category"`
-	Status          string   `json:"status"`
-}
-
-func (s *ProjectService) CreateProject(req *ProjectCreateRequest) (*models.Project, error) {
-	project := &models.Project{
+func newProject(username string, req *ProjectCreateRequest) *models.Project {
+	published := req.Published == nil || *req.Published
+	return &models.Project{
 		Name:            req.Name,
 		Description:     req.Description,
 		LongDescription: req.LongDescription,
 		Technologies:    req.Technologies,
 		GitHubURL:       req.GitHubURL,
 		LiveURL:         req.LiveURL,
-		ImageURL:        req.ImageURL,
+		DemoVideoURL:    req.DemoVideoURL,
 		Featured:        req.Featured,
-		Category:        req.Category,
+		CategoryID:      req.CategoryID,
+		Tags:            tagsFromNames(req.Tags),
+		Skills:          skillsFromIDs(req.SkillIDs),
 		Status:          req.Status,
+		Published:       published,
+		PublishedAt:     publicationDate(published, req.PublishedAt),
+		Attribution:     models.Attribution{CreatedBy: username, UpdatedBy: username},
 	}
+}
 
-	createdProject, err := s.repo.CreateProject(project)
-	if err != nil {
+type ProjectUpdateRequest struct {
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	LongDescription string     `json:"long_description"`
+	Technologies    []string   `json:"technologies"`
+	Tags            []string   `json:"tags"`      // Tag names; unknown tags are created
+	SkillIDs        []uint     `json:"skill_ids"` // Skills the project demonstrates
+	GitHubURL       string     `json:"github_url"`
+	LiveURL         string     `json:"live_url"`
+	DemoVideoURL    string     `json:"demo_video_url"` // YouTube or Vimeo link, or a video file
+	Featured        bool       `json:"featured"`
+	CategoryID      *uint      `json:"category_id"`
+	Status          string     `json:"status"`
+	Published       *bool      `json:"published"` // Defaults to true
+	PublishedAt     *time.Time `json:"published_at"`
+	Version         uint       `json:"version"` // Version the edit is based on; 0 skips the conflict check
+}
+
+func (s *ProjectService) UpdateProject(ctx context.Context, id uint, username string, req *ProjectUpdateRequest) (*models.Project, error) {
+	if err := checkDemoVideo(req.DemoVideoURL); err != nil {
 		return nil, err
 	}
 
-	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "projects", "projects:featured", "projects:non-featured")
-
-	return createdProject, nil
-}
-
-type ProjectUpdateRequest struct {
-	Name            string   `json:"name"`
-	Description     string   `json:"description"`
-	LongDescription string   `json:"long_description"`
-	Technologies    []string `json:"technologies"`
-	GitHubURL       string   `json:"github_url"`
-	LiveURL         string   `json:"live_url"`
-	ImageURL        string   `json:"image_url"`
-	Featured        bool     `json:"featured"`
-	Category        string   `json:"category"`
-	Status          string   `json:"status"`
-}
-
-func (s *ProjectService) UpdateProject(id uint, req *ProjectUpdateRequest) (*models.Project, error) {
+	published := req.Published == nil || *req.Published
 	project := &models.Project{
 		Name:            req.Name,
 		Description:     req.Description,
@@ -427,48 +1027,135 @@ func (s *ProjectService) UpdateProject(id uint, req *ProjectUpdateRequest) (*mod
 		Technologies:    req.Technologies,
 		GitHubURL:       req.GitHubURL,
 		LiveURL:         req.LiveURL,
-		ImageURL:        req.ImageURL,
+		DemoVideoURL:    req.DemoVideoURL,
 		Featured:        req.Featured,
-		Category:        req.Category,
+		CategoryID:      req.CategoryID,
+		Tags:            tagsFromNames(req.Tags),
+		Skills:          skillsFromIDs(req.SkillIDs),
 		Status:          req.Status,
+		Published:       published,
+		PublishedAt:     req.PublishedAt,
+		Version:         req.Version,
+		Attribution:     models.Attribution{UpdatedBy: username},
 	}
 
-	updatedProject, err := s.repo.UpdateProject(id, project)
+	updatedProject, err := s.repo.UpdateProject(ctx, id, project)
 	if err != nil {
 		return nil, err
 	}
+	updatedProject.Demo = s.demoVideo(ctx, updatedProject.DemoVideoURL)
 
 	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "projects", "projects:featured", "projects:non-featured")
+	invalidate(ctx, s.redis, append([]string{"tags"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.ProjectUpdated, updatedProject)
 
 	return updatedProject, nil
 }
 
-func (s *ProjectService) DeleteProject(id uint) error {
-	err := s.repo.DeleteProject(id)
+type ProjectReorderRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"` // Every featured project ID, in rank order
+}
+
+// ReorderFeaturedProjects ranks the featured projects and returns them in
+// their new order
+func (s *ProjectService) ReorderFeaturedProjects(ctx context.Context, req *ProjectReorderRequest) ([]models.Project, error) {
+	if err := s.repo.ReorderFeaturedProjects(ctx, req.IDs); err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, projectCacheKeys...)
+
+	s.events.Publish(ctx, events.ProjectsReordered, map[string][]uint{"ids": req.IDs})
+
+	featured := true
+	projects, err := s.getProjects(ctx, &featured, true, true)
+	if err != nil {
+		return nil, err
+	}
+	return OrderProjects(projects, ProjectOrderFeaturedRank, 0)
+}
+
+// ArchiveProject hides a project from listings, or brings it back when
+// archived is false, keeping everything about it
+func (s *ProjectService) ArchiveProject(ctx context.Context, id uint, username string, archived bool) (*models.Project, error) {
+	project, err := s.repo.SetProjectArchived(ctx, id, archived, username)
+	if err != nil {
+		return nil, err
+	}
+	project.Demo = s.demoVideo(ctx, project.DemoVideoURL)
+
+	// Tag usage counts leave archived projects out
+	invalidate(ctx, s.redis, append([]string{"tags"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.ProjectUpdated, project)
+
+	return project, nil
+}
+
+func (s *ProjectService) DeleteProject(ctx context.Context, id uint) error {
+	err := s.repo.DeleteProject(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	// Invalidate cache
-	ctx := context.Background()
-	s.redis.Del(ctx, "projects", "projects:featured", "projects:non-featured")
+	invalidate(ctx, s.redis, append([]string{"tags"}, projectCacheKeys...)...)
+
+	s.events.Publish(ctx, events.ProjectDeleted, map[string]uint{"id": id})
 
 	return nil
 }
 
+// RefreshImageVariants updates the gallery images showing an upload once
+// its resized variants are available. It is registered as a media hook.
+func (s *ProjectService) RefreshImageVariants(ctx context.Context, media *models.Media) {
+	updated, err := s.repo.RefreshImageVariants(ctx, media.URL)
+	if err != nil {
+		log.Printf("Warning: failed to refresh image variants for %s: %v", media.URL, err)
+		return
+	}
+	if updated == 0 {
+		return
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, projectCacheKeys...)
+}
+
 // ContactService handles contact-related operations
 type ContactService struct {
-	repo  *repository.ContactRepository
-	redis *redis.Client
+	repo       ContactStore
+	redis      *redis.Client
+	mailer     *mailer.Mailer
+	jobs       *JobService
+	events     *events.Bus
+	ownerEmail string
+	autoReply  *AutoReply        // nil disables the acknowledgment email
+	captcha    *captcha.Verifier // nil disables captcha verification
+	locator    *geoip.Locator    // nil disables IP geolocation
+	akismet    *akismet.Client   // nil disables external spam checks
 }
 
-func NewContactService(repo *repository.ContactRepository, redis *redis.Client) *ContactService {
-	return &ContactService{
-		repo:  repo,
-		redis: redis,
+func NewContactService(repo ContactStore, redis *redis.Client, mailer *mailer.Mailer, jobs *JobService, events *events.Bus, ownerEmail string, autoReply *AutoReply, captcha *captcha.Verifier, locator *geoip.Locator, akismet *akismet.Client) *ContactService {
+	s := &ContactService{
+		repo:       repo,
+		redis:      redis,
+		mailer:     mailer,
+		jobs:       jobs,
+		events:     events,
+		ownerEmail: ownerEmail,
+		autoReply:  autoReply,
+		captcha:    captcha,
+		locator:    locator,
+		akismet:    akismet,
+	}
+	jobs.Handle(JobAutoReply, s.runAutoReply)
+	if akismet != nil {
+		jobs.Handle(JobReportSpam, s.runReportSpam)
 	}
+	return s
 }
 
 type ContactCreateRequest struct {
@@ -476,6 +1163,9 @@ type ContactCreateRequest struct {
 	Email     string `json:"email" binding:"required,email"`
 	Subject   string `json:"subject"`
 	Message   string `json:"message" binding:"required"`
+	ServiceID *uint  `json:"service_id"`
+	Website   string `json:"website"` // Honeypot: hidden from people, so any value marks a bot
+	Captcha   string `json:"captcha_token"`
 	IPAddress string `json:"ip_address"`
 	UserAgent string `json:"user_agent"`
 }
@@ -484,47 +1174,137 @@ type ContactStatusUpdateRequest struct {
 	Status string `json:"status" binding:"required"`
 }
 
-func (s *ContactService) CreateContact(req *ContactCreateRequest) (*models.Contact, error) {
+func (s *ContactService) CreateContact(ctx context.Context, req *ContactCreateRequest) (*models.Contact, error) {
+	if err := s.verifyCaptcha(ctx, req); err != nil {
+		return nil, err
+	}
+
 	contact := &models.Contact{
 		Name:      req.Name,
 		Email:     req.Email,
 		Subject:   req.Subject,
 		Message:   req.Message,
+		ServiceID: req.ServiceID,
 		IPAddress: req.IPAddress,
 		UserAgent: req.UserAgent,
+		SpamScore: spamScore(ctx, s.redis, "contacts", req.Website, req.Name+"\n"+req.Subject+"\n"+req.Message, req.IPAddress),
 		Status:    "new",
 	}
+	verdict, score := checkSpam(ctx, s.akismet, contact.SpamScore, spamSubmission(contact))
+	contact.SpamVerdict = string(verdict)
+	contact.SpamScore += score
+	if contact.SpamScore >= spamThreshold {
+		contact.Status = "spam"
+	}
+	if location := locate(ctx, s.redis, s.locator, req.IPAddress); location != nil {
+		contact.Country = location.Country
+		contact.City = location.City
+	}
 
-	createdContact, err := s.repo.CreateContact(contact)
+	createdContact, err := s.repo.CreateContact(ctx, contact)
 	if err != nil {
 		return nil, err
 	}
 
+	// Spam is kept for review but nobody is notified about it
+	if createdContact.Status == "spam" {
+		return createdContact, nil
+	}
+
+	s.events.Publish(ctx, events.ContactCreated, createdContact)
+	s.notifyOwner(ctx, createdContact)
+	s.sendAutoReply(ctx, createdContact)
+
 	return createdContact, nil
 }
 
-func (s *ContactService) GetContacts() ([]models.Contact, error) {
-	return s.repo.GetContacts()
+// verifyCaptcha checks the submitted captcha token when verification is
+// enabled. Provider outages are reported separately from rejected tokens.
+func (s *ContactService) verifyCaptcha(ctx context.Context, req *ContactCreateRequest) error {
+	if s.captcha == nil {
+		return nil
+	}
+
+	err := s.captcha.Verify(ctx, req.Captcha, req.IPAddress)
+	if err == nil || errors.Is(err, captcha.ErrMissingToken) || errors.Is(err, captcha.ErrRejected) {
+		return err
+	}
+	log.Printf("Warning: captcha verification unavailable: %v", err)
+	return errors.New("captcha unavailable")
+}
+
+// notifyOwner queues an email of the new message to the portfolio owner so
+// that the submitter never waits on SMTP and a failed send is retried
+func (s *ContactService) notifyOwner(ctx context.Context, contact *models.Contact) {
+	if s.ownerEmail == "" {
+		return
+	}
+
+	subject := contact.Subject
+	if subject == "" {
+		subject = "(no subject)"
+	}
+
+	body := fmt.Sprintf("New message from the portfolio contact form.\n\nName: %s\nEmail: %s\nSubject: %s\nReceived: %s\n",
+		contact.Name, contact.Email, subject, contact.CreatedAt.Format(time.RFC1123))
+	if contact.ServiceID != nil {
+		body += fmt.Sprintf("Service: #%d\n", *contact.ServiceID)
+	}
+	if contact.Repeat {
+		body += fmt.Sprintf("Repeat sender: thread #%d\n", contact.ContactGroup)
+	}
+	body += "\n" + contact.Message + "\n"
+
+	s.jobs.SendMail(ctx, &mailer.Message{
+		To:      []string{s.ownerEmail},
+		ReplyTo: contact.Email,
+		Subject: "New contact: " + subject,
+		Body:    body,
+	})
+}
+
+// GetContacts returns the submissions with the given status, or every
+// submission except spam when status is empty. A country code narrows them
+// to submissions located in that country. An email address narrows them to
+// one sender's history, which then includes spam unless a status is given.
+func (s *ContactService) GetContacts(ctx context.Context, status, country, email string) ([]models.Contact, error) {
+	return s.repo.GetContacts(ctx, status, strings.ToUpper(country), strings.TrimSpace(email))
 }
 
-func (s *ContactService) UpdateContactStatus(id uint, status string) (*models.Contact, error) {
-	return s.repo.UpdateContactStatus(id, status)
+func (s *ContactService) UpdateContactStatus(ctx context.Context, id uint, status string) (*models.Contact, error) {
+	return s.repo.UpdateContactStatus(ctx, id, status)
 }
 
 // AuthService handles authentication-related operations
 type AuthService struct {
+	users     *repository.UserRepository
+	logins    *repository.LoginEventRepository
 	jwtSecret string
+	redis     *redis.Client
+	jobs      *JobService
+	events    *events.Bus
+	locator   *geoip.Locator // nil disables locating logins
+	alerts    LoginAlerts
 }
 
-func NewAuthService(jwtSecret string) *AuthService {
+func NewAuthService(users *repository.UserRepository, logins *repository.LoginEventRepository, jwtSecret string, redis *redis.Client, jobs *JobService, events *events.Bus, locator *geoip.Locator, alerts LoginAlerts) *AuthService {
 	return &AuthService{
+		users:     users,
+		logins:    logins,
 		jwtSecret: jwtSecret,
+		redis:     redis,
+		jobs:      jobs,
+		events:    events,
+		locator:   locator,
+		alerts:    alerts,
 	}
 }
 
 type LoginRequest struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username  string `json:"username" binding:"required"`
+	Password  string `json:"password" binding:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type LoginResponse struct {
@@ -537,35 +1317,89 @@ type LoginResponse struct {
 	} `json:"user"`
 }
 
-func (s *AuthService) Login(req *LoginRequest) (*LoginResponse, error) {
-	// This is a simplified implementation
-	// In a real application, you would:
-	// 1. Hash the password
-	// 2. Compare with stored hash
-	// 3. Generate JWT token
-
-	// For demo purposes, accept any username/password
+func (s *AuthService) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
 	if req.Username == "" || req.Password == "" {
 		return nil, errors.New("invalid credentials")
 	}
 
-	// Generate JWT token (simplified)
-	token := "demo-jwt-token-" + req.Username
+	user, err := s.authenticate(ctx, req)
+	if err != nil {
+		if err.Error() == "invalid credentials" {
+			s.recordLogin(ctx, req, false)
+		}
+		return nil, err
+	}
+
+	tenantID, ok := tenant.FromContext(ctx)
+	if !ok {
+		tenantID = tenant.DefaultID
+	}
+	signed, err := token.Issue(s.jwtSecret, token.Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	response := &LoginResponse{
-		Token: token,
+		Token: signed,
 		User: struct {
 			ID       uint   `json:"id"`
 			Username string `json:"username"`
 			Email    string `json:"email"`
 			Role     string `json:"role"`
 		}{
-			ID:       1,
-			Username: req.Username,
-			Email:    "admin@example.com",
-			Role:     "admin",
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
 		},
 	}
 
+	s.recordLogin(ctx, req, true)
+
 	return response, nil
 }
+
+// authenticate checks the credentials against the admin users of the tenant
+// in ctx
+func (s *AuthService) authenticate(ctx context.Context, req *LoginRequest) (*models.User, error) {
+	user, err := s.users.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, errors.New("invalid credentials")
+		}
+		return nil, err
+	}
+	if !user.Active || !models.CheckPasswordHash(req.Password, user.Password) {
+		return nil, errors.New("invalid credentials")
+	}
+	return user, nil
+}
+
+// EnsureAdmin creates the first admin user of the tenant in ctx while it has
+// none, and reports whether the tenant has an admin afterwards. Without a
+// password no user is created, so nobody can log in until one exists.
+func (s *AuthService) EnsureAdmin(ctx context.Context, username, email, password string) (bool, error) {
+	count, err := s.users.CountUsers(ctx)
+	if err != nil {
+		return false, err
+	}
+	if count > 0 || password == "" {
+		return count > 0, nil
+	}
+	err = s.users.CreateUser(ctx, &models.User{
+		Username: strings.TrimSpace(username),
+		Email:    strings.TrimSpace(email),
+		Password: password,
+		Role:     "admin",
+		Active:   true,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/service/mocks"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestProfileCacheIsPerTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockProfileStore(ctrl)
+	_, client := newTestJobService(t)
+	s := NewProfileService(store, client, events.NewBus(), nil)
+
+	// Each tenant loads its own profile once; later reads come from its cache
+	store.EXPECT().GetProfile(gomock.Any()).DoAndReturn(func(ctx context.Context) (*models.Profile, error) {
+		id, _ := tenant.FromContext(ctx)
+		return &models.Profile{Name: fmt.Sprintf("Tenant %d", id)}, nil
+	}).Times(2)
+
+	for _, id := range []uint{1, 2, 1, 2} {
+		profile, err := s.GetProfile(tenant.WithID(context.Background(), id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("Tenant %d", id); profile.Name != want {
+			t.Errorf("tenant %d got the profile of %q", id, profile.Name)
+		}
+	}
+}
+
+func newTestContactService(t *testing.T, ownerEmail string) (*ContactService, *mocks.MockContactStore, *JobService) {
+	ctrl := gomock.NewController(t)
+	store := mocks.NewMockContactStore(ctrl)
+	jobs, client := newTestJobService(t)
+	s := NewContactService(store, client, nil, jobs, events.NewBus(), ownerEmail, nil, nil, nil, nil)
+	return s, store, jobs
+}
+
+// sentMail replaces the mail job with one that records the messages, then
+// works through the due jobs
+func sentMail(t *testing.T, jobs *JobService) []mailer.Message {
+	var sent []mailer.Message
+	jobs.Handle(JobSendMail, func(ctx context.Context, payload json.RawMessage) error {
+		var msg mailer.Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			t.Fatal(err)
+		}
+		sent = append(sent, msg)
+		return nil
+	})
+	jobs.runDue(context.Background())
+	return sent
+}
+
+func TestCreateContactQueuesOwnerNotification(t *testing.T) {
+	s, store, jobs := newTestContactService(t, "owner@example.com")
+	ctx := tenant.WithID(context.Background(), 1)
+
+	store.EXPECT().CreateContact(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, contact *models.Contact) (*models.Contact, error) {
+		contact.ID = 7
+		return contact, nil
+	})
+
+	contact, err := s.CreateContact(ctx, &ContactCreateRequest{
+		Name:    "Ada",
+		Email:   "ada@example.com",
+		Subject: "Hello",
+		Message: "Are you available for a project?",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contact.Status != "new" {
+		t.Fatalf("status = %q, want new", contact.Status)
+	}
+
+	sent := sentMail(t, jobs)
+	if len(sent) != 1 {
+		t.Fatalf("queued %d mails, want the owner notification", len(sent))
+	}
+	if msg := sent[0]; msg.To[0] != "owner@example.com" || msg.ReplyTo != "ada@example.com" || msg.Subject != "New contact: Hello" {
+		t.Errorf("notification = %+v", msg)
+	}
+}
+
+func TestCreateContactDoesNotNotifyAboutSpam(t *testing.T) {
+	s, store, jobs := newTestContactService(t, "owner@example.com")
+	ctx := tenant.WithID(context.Background(), 1)
+
+	store.EXPECT().CreateContact(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, contact *models.Contact) (*models.Contact, error) {
+		return contact, nil
+	})
+
+	// Only bots fill in the hidden honeypot field
+	contact, err := s.CreateContact(ctx, &ContactCreateRequest{
+		Name:    "Bot",
+		Email:   "bot@example.com",
+		Message: "Cheap followers",
+		Website: "https://spam.example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contact.Status != "spam" {
+		t.Fatalf("status = %q, want spam", contact.Status)
+	}
+	if sent := sentMail(t, jobs); len(sent) != 0 {
+		t.Errorf("queued %d mails for spam", len(sent))
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/url"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	shortLinkCacheTTL = 5 * time.Minute
+	shortLinkCodeMax  = 64
+)
+
+// ShortLinkService manages short links and resolves them for redirects
+type ShortLinkService struct {
+	repo  *repository.ShortLinkRepository
+	redis *redis.Client
+}
+
+func NewShortLinkService(repo *repository.ShortLinkRepository, redis *redis.Client) *ShortLinkService {
+	return &ShortLinkService{
+		repo:  repo,
+		redis: redis,
+	}
+}
+
+// Resolve counts a visit of the active link with the given code and returns
+// where to send the visitor. utm_* parameters of the visit are passed on to
+// the target, replacing any the target already has, so campaigns can be
+// told apart on the destination's analytics.
+func (s *ShortLinkService) Resolve(ctx context.Context, code string, query url.Values) (string, error) {
+	link, err := s.getLink(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if !link.Active {
+		return "", errors.New("short link not found")
+	}
+
+	// A lost count must not cost the visitor the redirect
+	if err := s.repo.RecordClick(ctx, link.ID, time.Now()); err != nil {
+		log.Printf("Warning: failed to count click on short link %s: %v", code, err)
+	}
+
+	return withUTM(link.TargetURL, query), nil
+}
+
+func (s *ShortLinkService) getLink(ctx context.Context, code string) (*models.ShortLink, error) {
+	// Try to get from cache first
+	cacheKey := tenant.CacheKey(ctx, "short_link:"+code)
+	var cachedLink models.ShortLink
+	if cacheGet(ctx, s.redis, "short_link", cacheKey, &cachedLink) {
+		return &cachedLink, nil
+	}
+
+	// Get from database
+	link, err := s.repo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	linkJSON, _ := json.Marshal(link)
+	s.redis.Set(ctx, cacheKey, linkJSON, shortLinkCacheTTL)
+
+	return link, nil
+}
+
+func (s *ShortLinkService) GetLinks(ctx context.Context) ([]models.ShortLink, error) {
+	return s.repo.GetLinks(ctx)
+}
+
+type ShortLinkCreateRequest struct {
+	Code      string `json:"code"` // Lowercase letters, digits and dashes; generated when empty
+	TargetURL string `json:"target_url" binding:"required"`
+	Note      string `json:"note"`
+}
+
+func (s *ShortLinkService) CreateLink(ctx context.Context, req *ShortLinkCreateRequest) (*models.ShortLink, error) {
+	code := req.Code
+	if code == "" {
+		generated, err := models.GenerateRandomString(4)
+		if err != nil {
+			return nil, err
+		}
+		code = generated
+	}
+
+	link, err := buildShortLink(code, req.TargetURL, req.Note, true)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.CreateLink(ctx, link)
+}
+
+type ShortLinkUpdateRequest struct {
+	Code      string `json:"code" binding:"required"`
+	TargetURL string `json:"target_url" binding:"required"`
+	Note      string `json:"note"`
+	Active    bool   `json:"active"`
+}
+
+func (s *ShortLinkService) UpdateLink(ctx context.Context, id uint, req *ShortLinkUpdateRequest) (*models.ShortLink, error) {
+	link, err := buildShortLink(req.Code, req.TargetURL, req.Note, req.Active)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedLink, previousCode, err := s.repo.UpdateLink(ctx, id, link)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "short_link:"+previousCode, "short_link:"+updatedLink.Code)
+
+	return updatedLink, nil
+}
+
+func (s *ShortLinkService) DeleteLink(ctx context.Context, id uint) error {
+	link, err := s.repo.DeleteLink(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "short_link:"+link.Code)
+
+	return nil
+}
+
+// buildShortLink validates the code and target. Targets are absolute http
+// or https URLs or paths on this host; protocol-relative paths such as
+// //example.com are refused, since they would redirect off-site unnoticed.
+func buildShortLink(code, target, note string, active bool) (*models.ShortLink, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || len(code) > shortLinkCodeMax || models.Slugify(code) != code {
+		return nil, errors.New("invalid code")
+	}
+
+	target = strings.TrimSpace(target)
+	u, err := url.Parse(target)
+	switch {
+	case err != nil:
+		return nil, errors.New("invalid target")
+	case u.IsAbs():
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.New("invalid target")
+		}
+	case !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//"):
+		return nil, errors.New("invalid target")
+	}
+
+	return &models.ShortLink{
+		Code:      code,
+		TargetURL: target,
+		Note:      strings.TrimSpace(note),
+		Active:    active,
+	}, nil
+}
+
+// withUTM copies the utm_* parameters of query onto target
+func withUTM(target string, query url.Values) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+
+	params := u.Query()
+	passed := false
+	for key, values := range query {
+		if strings.HasPrefix(key, "utm_") {
+			params[key] = values
+			passed = true
+		}
+	}
+	if !passed {
+		return target
+	}
+	u.RawQuery = params.Encode()
+	return u.String()
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// knownTechnology is a technology suggested as a new skill when experience
+// text mentions it by name or by one of its aliases
+type knownTechnology struct {
+	Name     string
+	Category string
+	Aliases  []string
+}
+
+// knownTechnologies are recognized in experience text even when no skill
+// has their name yet
+var knownTechnologies = []knownTechnology{
+	{"Go", "Languages", []string{"Golang"}},
+	{"Rust", "Languages", nil},
+	{"Python", "Languages", nil},
+	{"JavaScript", "Languages", nil},
+	{"TypeScript", "Languages", nil},
+	{"Java", "Languages", nil},
+	{"Kotlin", "Languages", nil},
+	{"Swift", "Languages", nil},
+	{"C++", "Languages", nil},
+	{"C#", "Languages", nil},
+	{"Ruby", "Languages", nil},
+	{"PHP", "Languages", nil},
+	{"Scala", "Languages", nil},
+	{"Elixir", "Languages", nil},
+	{"Solidity", "Languages", nil},
+	{"SQL", "Languages", nil},
+	{"React", "Frameworks", []string{"React.js", "ReactJS"}},
+	{"Vue.js", "Frameworks", []string{"Vue", "VueJS"}},
+	{"Angular", "Frameworks", nil},
+	{"Next.js", "Frameworks", nil},
+	{"Django", "Frameworks", nil},
+	{"Flask", "Frameworks", nil},
+	{"FastAPI", "Frameworks", nil},
+	{"Spring Boot", "Frameworks", nil},
+	{"Ruby on Rails", "Frameworks", []string{"Rails"}},
+	{".NET", "Frameworks", nil},
+	{"Gin", "Frameworks", nil},
+	{"Actix", "Frameworks", nil},
+	{"Axum", "Frameworks", nil},
+	{"Node.js", "Backend", []string{"NodeJS"}},
+	{"GraphQL", "Backend", nil},
+	{"gRPC", "Backend", nil},
+	{"Kafka", "Backend", []string{"Apache Kafka"}},
+	{"RabbitMQ", "Backend", nil},
+	{"PostgreSQL", "Databases", []string{"Postgres"}},
+	{"MySQL", "Databases", nil},
+	{"MongoDB", "Databases", nil},
+	{"Redis", "Databases", nil},
+	{"Elasticsearch", "Databases", nil},
+	{"SQLite", "Databases", nil},
+	{"DynamoDB", "Databases", nil},
+	{"Cassandra", "Databases", nil},
+	{"Docker", "DevOps", nil},
+	{"Kubernetes", "DevOps", []string{"K8s"}},
+	{"Terraform", "DevOps", nil},
+	{"Ansible", "DevOps", nil},
+	{"AWS", "DevOps", []string{"Amazon Web Services"}},
+	{"GCP", "DevOps", []string{"Google Cloud"}},
+	{"Azure", "DevOps", nil},
+	{"GitHub Actions", "DevOps", nil},
+	{"Jenkins", "DevOps", nil},
+	{"Prometheus", "DevOps", nil},
+	{"Grafana", "DevOps", nil},
+	{"Ethereum", "Blockchain", nil},
+	{"Solana", "Blockchain", nil},
+	{"Hyperledger", "Blockchain", []string{"Hyperledger Fabric"}},
+	{"Web3", "Blockchain", nil},
+}
+
+// Experience fields suggestions are found in
+const (
+	SuggestionFromTechnologies = "technologies"
+	SuggestionFromDescription  = "description"
+	SuggestionFromAchievements = "achievements"
+)
+
+// SkillSuggestion proposes linking an experience to a skill its text
+// mentions
+type SkillSuggestion struct {
+	ExperienceID uint   `json:"experience_id"`
+	Company      string `json:"company"`
+	Position     string `json:"position"`
+	SkillID      *uint  `json:"skill_id"` // Nil when no skill has the name yet and accepting creates one
+	Name         string `json:"name"`
+	Category     string `json:"category"`
+	Term         string `json:"term"`   // Name or alias found in the text
+	Source       string `json:"source"` // technologies, description or achievements
+}
+
+// skillCandidate is a skill, existing or not, and the terms that mention it
+type skillCandidate struct {
+	id       *uint
+	name     string
+	category string
+	terms    []string
+}
+
+// GetSkillSuggestions scans the technologies, description and achievements
+// of every experience, drafts included, for the names of the tenant's
+// skills and of well-known technologies, and proposes the links that are
+// missing. Matching is on whole words and ignores case, except for names of
+// two letters or fewer such as Go, which must be written as the skill is.
+func (s *SkillService) GetSkillSuggestions(ctx context.Context) ([]SkillSuggestion, error) {
+	skills, err := s.repo.GetSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	experiences, err := s.experiences.GetExperiences(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := skillCandidates(skills)
+	suggestions := []SkillSuggestion{}
+	for _, experience := range experiences {
+		linked := make(map[uint]bool, len(experience.Skills))
+		for _, skill := range experience.Skills {
+			linked[skill.ID] = true
+		}
+		sources := []struct {
+			name string
+			text []string
+		}{
+			{SuggestionFromTechnologies, experience.Technologies},
+			{SuggestionFromDescription, []string{experience.Description}},
+			{SuggestionFromAchievements, experience.Achievements},
+		}
+
+		for _, candidate := range candidates {
+			if candidate.id != nil && linked[*candidate.id] {
+				continue
+			}
+		search:
+			for _, source := range sources {
+				for _, text := range source.text {
+					if term := candidate.mentionedIn(text); term != "" {
+						suggestions = append(suggestions, SkillSuggestion{
+							ExperienceID: experience.ID,
+							Company:      experience.Company,
+							Position:     experience.Position,
+							SkillID:      candidate.id,
+							Name:         candidate.name,
+							Category:     candidate.category,
+							Term:         term,
+							Source:       source.name,
+						})
+						break search
+					}
+				}
+			}
+		}
+	}
+	return suggestions, nil
+}
+
+// skillCandidates lists the tenant's skills, then the known technologies no
+// skill stands for. A skill is mentioned by its name, by each part of a
+// name like JavaScript/TypeScript, and by the aliases of the known
+// technology of the same name.
+func skillCandidates(skills []models.Skill) []skillCandidate {
+	known := make(map[string]int, len(knownTechnologies))
+	for i, technology := range knownTechnologies {
+		for _, term := range append([]string{technology.Name}, technology.Aliases...) {
+			known[strings.ToLower(term)] = i
+		}
+	}
+
+	candidates := make([]skillCandidate, 0, len(skills)+len(knownTechnologies))
+	covered := make(map[int]bool)
+	for i := range skills {
+		candidate := skillCandidate{id: &skills[i].ID, name: skills[i].Name, category: skills[i].Category}
+		terms := []string{skills[i].Name}
+		if strings.Contains(skills[i].Name, "/") {
+			terms = append(terms, strings.Split(skills[i].Name, "/")...)
+		}
+		for _, term := range terms {
+			term = strings.TrimSpace(term)
+			if term == "" {
+				continue
+			}
+			candidate.terms = append(candidate.terms, term)
+			if index, ok := known[strings.ToLower(term)]; ok && !covered[index] {
+				covered[index] = true
+				candidate.terms = append(candidate.terms, knownTechnologies[index].Aliases...)
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	for i, technology := range knownTechnologies {
+		if !covered[i] {
+			candidates = append(candidates, skillCandidate{
+				name:     technology.Name,
+				category: technology.Category,
+				terms:    append([]string{technology.Name}, technology.Aliases...),
+			})
+		}
+	}
+	return candidates
+}
+
+// mentionedIn returns the first of the candidate's terms that text
+// mentions, or an empty string
+func (c skillCandidate) mentionedIn(text string) string {
+	for _, term := range c.terms {
+		if mentions(text, term) {
+			return term
+		}
+	}
+	return ""
+}
+
+// mentions reports whether text contains term as a whole word. Terms may
+// start or end with symbols, as in C++, C# or .NET; a term ending in a
+// letter does not match when + or # follows, so C is not found in C++.
+func mentions(text, term string) bool {
+	if utf8.RuneCountInString(term) > 2 {
+		text, term = strings.ToLower(text), strings.ToLower(term)
+	}
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if start > 0 && isWordRune(before) && isWordRune(rune(term[0])) {
+			offset = end
+			continue
+		}
+		if end < len(text) && (isWordRune(after) || after == '+' || after == '#') && isWordRune(rune(term[len(term)-1])) {
+			offset = end
+			continue
+		}
+		return true
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+type SkillLinkRequest struct {
+	ExperienceID uint   `json:"experience_id" binding:"required"`
+	SkillID      *uint  `json:"skill_id"`                                    // Existing skill to link
+	Name         string `json:"name" binding:"required_without=SkillID"`     // Skill to link, created when missing
+	Category     string `json:"category" binding:"required_without=SkillID"` // Category of a created skill
+}
+
+type SkillSuggestionAcceptRequest struct {
+	Links []SkillLinkRequest `json:"links" binding:"required,min=1,dive"`
+}
+
+// SkillSuggestionResult reports what accepting suggestions changed
+type SkillSuggestionResult struct {
+	Created     []models.Skill      `json:"created"`     // Skills that did not exist yet
+	Experiences []models.Experience `json:"experiences"` // Experiences that gained skills
+}
+
+// AcceptSkillSuggestions links experiences to skills in one transaction,
+// creating the skills named but not found. Suggestions from
+// GetSkillSuggestions can be passed back as they are.
+func (s *SkillService) AcceptSkillSuggestions(ctx context.Context, username string, req *SkillSuggestionAcceptRequest) (*SkillSuggestionResult, error) {
+	links := make([]repository.SkillLink, len(req.Links))
+	for i, link := range req.Links {
+		if link.SkillID == nil && (strings.TrimSpace(link.Name) == "" || strings.TrimSpace(link.Category) == "") {
+			return nil, errors.New("invalid skill")
+		}
+		links[i] = repository.SkillLink{
+			ExperienceID: link.ExperienceID,
+			SkillID:      link.SkillID,
+			Name:         link.Name,
+			Category:     link.Category,
+		}
+	}
+
+	created, experiences, err := s.repo.LinkExperienceSkills(ctx, links, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skill years come from the linked experiences
+	invalidate(ctx, s.redis, "experiences", "experiences:drafts", "skills")
+
+	for i := range created {
+		s.events.Publish(ctx, events.SkillCreated, &created[i])
+	}
+	for i := range experiences {
+		s.events.Publish(ctx, events.ExperienceUpdated, &experiences[i])
+	}
+
+	return &SkillSuggestionResult{Created: created, Experiences: experiences}, nil
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultSlotWindowDays = 14
+	maxSlotWindowDays     = 60
+	slotLockTTL           = 30 * time.Second
+)
+
+// SlotService handles interview availability and booking operations
+type SlotService struct {
+	repo       *repository.SlotRepository
+	redis      *redis.Client
+	jobs       *JobService
+	events     *events.Bus
+	ownerEmail string
+}
+
+func NewSlotService(repo *repository.SlotRepository, redis *redis.Client, jobs *JobService, events *events.Bus, ownerEmail string) *SlotService {
+	return &SlotService{
+		repo:       repo,
+		redis:      redis,
+		jobs:       jobs,
+		events:     events,
+		ownerEmail: ownerEmail,
+	}
+}
+
+// GetAvailableSlots expands the weekly slots into concrete occurrences over
+// the next days, skipping occurrences that are in the past or already booked
+func (s *SlotService) GetAvailableSlots(ctx context.Context, days int) ([]models.SlotOccurrence, error) {
+	if days <= 0 {
+		days = defaultSlotWindowDays
+	}
+	if days > maxSlotWindowDays {
+		days = maxSlotWindowDays
+	}
+
+	slots, err := s.repo.GetSlots(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	until := now.AddDate(0, 0, days)
+
+	bookings, err := s.repo.GetBookingsBetween(ctx, now, until)
+	if err != nil {
+		return nil, err
+	}
+	booked := make(map[string]bool, len(bookings))
+	for _, booking := range bookings {
+		booked[bookingKey(booking.SlotID, booking.StartsAt)] = true
+	}
+
+	occurrences := []models.SlotOccurrence{}
+	for _, slot := range slots {
+		loc, err := time.LoadLocation(slot.Timezone)
+		if err != nil {
+			continue
+		}
+		hour, minute, err := parseClock(slot.StartTime)
+		if err != nil {
+			continue
+		}
+
+		day := now.In(loc)
+		for i := 0; i <= days; i++ {
+			date := day.AddDate(0, 0, i)
+			if int(date.Weekday()) != slot.Weekday {
+				continue
+			}
+			start := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc)
+			if !start.After(now) || !start.Before(until) {
+				continue
+			}
+			if booked[bookingKey(slot.ID, start)] {
+				continue
+			}
+			occurrences = append(occurrences, models.SlotOccurrence{
+				SlotID:   slot.ID,
+				Title:    slot.Title,
+				StartsAt: start,
+				EndsAt:   start.Add(time.Duration(slot.DurationMinutes) * time.Minute),
+				Timezone: slot.Timezone,
+			})
+		}
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool {
+		return occurrences[i].StartsAt.Before(occurrences[j].StartsAt)
+	})
+	return occurrences, nil
+}
+
+func (s *SlotService) GetSlots(ctx context.Context) ([]models.Slot, error) {
+	return s.repo.GetSlots(ctx, false)
+}
+
+type SlotCreateRequest struct {
+	Weekday         int    `json:"weekday" binding:"min=0,max=6"`
+	StartTime       string `json:"start_time" binding:"required"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=5,max=480"`
+	Timezone        string `json:"timezone"`
+	Title           string `json:"title"`
+	Location        string `json:"location"`
+	Active          *bool  `json:"active"`
+}
+
+func (s *SlotService) CreateSlot(ctx context.Context, req *SlotCreateRequest) (*models.Slot, error) {
+	slot, err := buildSlot(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.CreateSlot(ctx, slot)
+}
+
+type SlotUpdateRequest struct {
+	Weekday         int    `json:"weekday" binding:"min=0,max=6"`
+	StartTime       string `json:"start_time" binding:"required"`
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=5,max=480"`
+	Timezone        string `json:"timezone"`
+	Title           string `json:"title"`
+	Location        string `json:"location"`
+	Active          *bool  `json:"active"`
+}
+
+func (s *SlotService) UpdateSlot(ctx context.Context, id uint, req *SlotUpdateRequest) (*models.Slot, error) {
+	slot, err := buildSlot((*SlotCreateRequest)(req))
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateSlot(ctx, id, slot)
+}
+
+func (s *SlotService) DeleteSlot(ctx context.Context, id uint) error {
+	return s.repo.DeleteSlot(ctx, id)
+}
+
+type SlotBookingRequest struct {
+	StartsAt  time.Time `json:"starts_at" binding:"required"`
+	Name      string    `json:"name" binding:"required"`
+	Email     string    `json:"email" binding:"required,email"`
+	Message   string    `json:"message"`
+	IPAddress string    `json:"-"`
+	UserAgent string    `json:"-"`
+}
+
+// BookSlot reserves one occurrence of a slot. A short-lived Redis lock keeps
+// concurrent requests for the same occurrence from racing past the check.
+func (s *SlotService) BookSlot(ctx context.Context, id uint, req *SlotBookingRequest) (*models.Booking, error) {
+	slot, err := s.repo.GetSlot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !slot.Active {
+		return nil, errors.New("slot not found")
+	}
+
+	startsAt, err := matchOccurrence(slot, req.StartsAt)
+	if err != nil {
+		return nil, err
+	}
+	lockKey := "slot:lock:" + bookingKey(slot.ID, startsAt)
+	locked, err := s.redis.SetNX(ctx, lockKey, 1, slotLockTTL).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, errors.New("slot already booked")
+	}
+	defer s.redis.Del(ctx, lockKey)
+
+	booked, err := s.repo.IsBooked(ctx, slot.ID, startsAt)
+	if err != nil {
+		return nil, err
+	}
+	if booked {
+		return nil, errors.New("slot already booked")
+	}
+
+	endsAt := startsAt.Add(time.Duration(slot.DurationMinutes) * time.Minute)
+	title := slot.Title
+	if title == "" {
+		title = "Interview"
+	}
+
+	contact := &models.Contact{
+		Name:      req.Name,
+		Email:     req.Email,
+		Subject:   fmt.Sprintf("%s booked for %s", title, startsAt.Format(time.RFC1123)),
+		Message:   req.Message,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Status:    "new",
+	}
+	if contact.Message == "" {
+		contact.Message = "Booked via the availability calendar."
+	}
+
+	booking := &models.Booking{
+		SlotID:   slot.ID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Name:     req.Name,
+		Email:    req.Email,
+	}
+
+	createdBooking, err := s.repo.CreateBooking(ctx, booking, contact)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.Publish(ctx, events.ContactCreated, contact)
+	s.sendInvite(ctx, slot, createdBooking, title)
+
+	return createdBooking, nil
+}
+
+func (s *SlotService) sendInvite(ctx context.Context, slot *models.Slot, booking *models.Booking, title string) {
+	recipients := []string{booking.Email}
+	if s.ownerEmail != "" {
+		recipients = append(recipients, s.ownerEmail)
+	}
+
+	event := &mailer.CalendarEvent{
+		UID:         fmt.Sprintf("booking-%d@portfolio", booking.ID),
+		Summary:     fmt.Sprintf("%s with %s", title, booking.Name),
+		Description: "Scheduled through the portfolio booking page.",
+		Location:    slot.Location,
+		Start:       booking.StartsAt,
+		End:         booking.EndsAt,
+		Organizer:   s.ownerEmail,
+		Attendees:   recipients,
+	}
+
+	s.jobs.SendMail(ctx, &mailer.Message{
+		To:          recipients,
+		Subject:     "Invitation: " + event.Summary,
+		Body:        fmt.Sprintf("Your %s is confirmed for %s.\n\nThe calendar invite is attached.", strings.ToLower(title), booking.StartsAt.Format(time.RFC1123)),
+		Attachments: []mailer.Attachment{event.Attachment()},
+	})
+}
+
+func buildSlot(req *SlotCreateRequest) (*models.Slot, error) {
+	if _, _, err := parseClock(req.StartTime); err != nil {
+		return nil, errors.New("invalid start time")
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, errors.New("invalid timezone")
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &models.Slot{
+		Weekday:         req.Weekday,
+		StartTime:       req.StartTime,
+		DurationMinutes: req.DurationMinutes,
+		Timezone:        timezone,
+		Title:           req.Title,
+		Location:        req.Location,
+		Active:          active,
+	}, nil
+}
+
+// matchOccurrence checks that the requested start lines up with the slot's
+// weekday and start time, and that it is still in the future
+func matchOccurrence(slot *models.Slot, requested time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(slot.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	hour, minute, err := parseClock(slot.StartTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := requested.In(loc)
+	if int(local.Weekday()) != slot.Weekday || local.Hour() != hour || local.Minute() != minute || local.Second() != 0 {
+		return time.Time{}, errors.New("invalid slot time")
+	}
+	if !requested.After(time.Now()) {
+		return time.Time{}, errors.New("invalid slot time")
+	}
+	return local, nil
+}
+
+func parseClock(value string) (int, int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, err
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+func bookingKey(slotID uint, startsAt time.Time) string {
+	return fmt.Sprintf("%d:%d", slotID, startsAt.Unix())
+}
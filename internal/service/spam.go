@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"regexp"
+	"stackwhiz-portfolio-backend/internal/akismet"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	spamThreshold      = 5 // Submissions scoring at least this are stored as spam
+	spamHoneypotScore  = spamThreshold
+	spamLinkAllowance  = 2 // Links tolerated before each extra one counts
+	spamLinkScore      = 2
+	spamKeywordScore   = 2
+	spamVelocityWindow = 10 * time.Minute
+	spamVelocityLimit  = 3 // Submissions per IP per window before each extra one counts
+	spamVelocityScore  = 3
+	spamVerdictScore   = 3 // Added when Akismet calls a submission spam without being sure
+)
+
+var spamLinkPattern = regexp.MustCompile(`(?i)https?://|www\.|\[url=`)
+
+// spamKeywords are phrases that show up in bulk solicitations far more often
+// than in genuine enquiries
+var spamKeywords = []string{
+	"seo services", "backlinks", "guest post", "casino", "crypto investment",
+	"forex", "viagra", "loan offer", "web traffic", "rank your website",
+	"click here", "work from home", "bitcoin doubler", "limited time offer",
+}
+
+// spamScore rates a submission from its honeypot field, text and sender;
+// higher scores are more likely spam. A filled honeypot alone is enough to
+// reach the threshold because the field is hidden from people and only bots
+// fill it in. kind keeps the submission rates of different forms apart.
+func spamScore(ctx context.Context, rdb *redis.Client, kind, honeypot, text, ipAddress string) int {
+	score := 0
+
+	if strings.TrimSpace(honeypot) != "" {
+		score += spamHoneypotScore
+	}
+
+	if links := len(spamLinkPattern.FindAllStringIndex(text, -1)); links > spamLinkAllowance {
+		score += (links - spamLinkAllowance) * spamLinkScore
+	}
+
+	lower := strings.ToLower(text)
+	for _, keyword := range spamKeywords {
+		if strings.Contains(lower, keyword) {
+			score += spamKeywordScore
+		}
+	}
+
+	if ipAddress != "" {
+		velocityKey := kind + ":velocity:" + ipAddress
+		count, err := rdb.Incr(ctx, velocityKey).Result()
+		if err != nil {
+			log.Printf("Warning: failed to track %s velocity: %v", kind, err)
+		} else {
+			if count == 1 {
+				rdb.Expire(ctx, velocityKey, spamVelocityWindow)
+			}
+			if count > spamVelocityLimit {
+				score += int(count-spamVelocityLimit) * spamVelocityScore
+			}
+		}
+	}
+
+	return score
+}
+
+// checkSpam asks Akismet about a submission the local checks let through
+// and returns its verdict with the score it adds. Blatant spam reaches the
+// threshold on its own, while a plain spam verdict needs another signal to
+// tip the balance. Failures are logged and leave the submission unchecked.
+func checkSpam(ctx context.Context, client *akismet.Client, score int, submission *akismet.Submission) (akismet.Verdict, int) {
+	if client == nil || score >= spamThreshold {
+		return "", 0
+	}
+
+	verdict, err := client.Check(ctx, submission)
+	if err != nil {
+		log.Printf("Warning: spam check failed: %v", err)
+		return "", 0
+	}
+	switch verdict {
+	case akismet.Blatant:
+		return verdict, spamThreshold
+	case akismet.Spam:
+		return verdict, spamVerdictScore
+	}
+	return verdict, 0
+}
+
+func spamSubmission(contact *models.Contact) *akismet.Submission {
+	content := contact.Message
+	if contact.Subject != "" {
+		content = contact.Subject + "\n\n" + content
+	}
+	return &akismet.Submission{
+		Type:        akismet.ContactForm,
+		IPAddress:   contact.IPAddress,
+		UserAgent:   contact.UserAgent,
+		Author:      contact.Name,
+		AuthorEmail: contact.Email,
+		Content:     content,
+		CreatedAt:   contact.CreatedAt,
+	}
+}
+
+// MarkContactSpam moves a submission to spam. When spam checks are enabled
+// Akismet is told about it, so similar messages are caught next time.
+func (s *ContactService) MarkContactSpam(ctx context.Context, id uint) (*models.Contact, error) {
+	contact, err := s.repo.UpdateContactStatus(ctx, id, "spam")
+	if err != nil {
+		return nil, err
+	}
+	s.reportSpam(ctx, contact.ID, true)
+	return contact, nil
+}
+
+// MarkContactHam returns a submission wrongly stored as spam to the inbox
+// as new and, when spam checks are enabled, tells Akismet about the
+// mistake. Submissions that are not spam keep their status.
+func (s *ContactService) MarkContactHam(ctx context.Context, id uint) (*models.Contact, error) {
+	contact, err := s.repo.GetContact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if contact.Status == "spam" {
+		if contact, err = s.repo.UpdateContactStatus(ctx, id, "new"); err != nil {
+			return nil, err
+		}
+	}
+	s.reportSpam(ctx, contact.ID, false)
+	return contact, nil
+}
+
+// spamReportJob is the payload of a report_spam job
+type spamReportJob struct {
+	ContactID uint `json:"contact_id"`
+	Spam      bool `json:"spam"`
+}
+
+// reportSpam queues a correction for Akismet, which may be slow or down
+// without holding up the admin
+func (s *ContactService) reportSpam(ctx context.Context, id uint, spam bool) {
+	if s.akismet == nil {
+		return
+	}
+	if err := s.jobs.Enqueue(ctx, JobReportSpam, spamReportJob{ContactID: id, Spam: spam}); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+func (s *ContactService) runReportSpam(ctx context.Context, payload json.RawMessage) error {
+	var job spamReportJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	contact, err := s.repo.GetContact(ctx, job.ContactID)
+	if err != nil {
+		// A deleted submission has nothing left to report
+		if err.Error() == "contact not found" {
+			return nil
+		}
+		return err
+	}
+	if job.Spam {
+		return s.akismet.SubmitSpam(ctx, spamSubmission(contact))
+	}
+	return s.akismet.SubmitHam(ctx, spamSubmission(contact))
+}
@@ -0,0 +1,87 @@
+package service
+
+//go:generate mockgen -source=stores.go -destination=mocks/stores.go -package=mocks
+
+import (
+	"context"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+)
+
+// The services below depend on these interfaces rather than on the concrete
+// repositories, so they can be exercised against the generated mocks in
+// internal/service/mocks without a database. Each interface only lists what
+// its service uses; the repository types implement them.
+
+// ProfileStore persists the profile
+type ProfileStore interface {
+	GetProfile(ctx context.Context) (*models.Profile, error)
+	UpdateProfile(ctx context.Context, profile *models.Profile) (*models.Profile, error)
+	UpdateAvatar(ctx context.Context, avatar string, variants map[string]string) (*models.Profile, error)
+}
+
+// ExperienceStore persists work experiences
+type ExperienceStore interface {
+	GetExperiences(ctx context.Context, includeDrafts bool) ([]models.Experience, error)
+	CreateExperience(ctx context.Context, experience *models.Experience) (*models.Experience, error)
+	UpdateExperience(ctx context.Context, id uint, experience *models.Experience) (*models.Experience, error)
+	ReorderExperiences(ctx context.Context, ids []uint) error
+	DeleteExperience(ctx context.Context, id uint) error
+}
+
+// SkillStore persists skills and their endorsement counts
+type SkillStore interface {
+	GetSkills(ctx context.Context) ([]models.Skill, error)
+	CreateSkill(ctx context.Context, skill *models.Skill) (*models.Skill, error)
+	UpdateSkill(ctx context.Context, id uint, skill *models.Skill) (*models.Skill, error)
+	GetSkillPeriods(ctx context.Context) ([]repository.SkillPeriod, error)
+	AddEndorsements(ctx context.Context, increments map[uint]int64) ([]uint, error)
+	ReorderSkills(ctx context.Context, ids []uint) error
+	RenameSkillCategory(ctx context.Context, from, to, username string) (int64, error)
+	MergeSkillCategories(ctx context.Context, from []string, to, username string) (int64, error)
+	DeleteSkill(ctx context.Context, id uint) error
+	MergeSkills(ctx context.Context, sourceIDs []uint, targetID uint, username string) (*models.Skill, error)
+	LinkExperienceSkills(ctx context.Context, links []repository.SkillLink, username string) ([]models.Skill, []models.Experience, error)
+}
+
+// ProjectStore persists projects
+type ProjectStore interface {
+	GetProjects(ctx context.Context, featured *bool, categoryID *uint, includeDrafts, includeArchived bool) ([]models.Project, error)
+	CreateProject(ctx context.Context, project *models.Project) (*models.Project, error)
+	UpdateProject(ctx context.Context, id uint, project *models.Project) (*models.Project, error)
+	DeleteProject(ctx context.Context, id uint) error
+	ReorderFeaturedProjects(ctx context.Context, ids []uint) error
+	SetProjectArchived(ctx context.Context, id uint, archived bool, username string) (*models.Project, error)
+	SkillExists(ctx context.Context, id uint) (bool, error)
+	GetProject(ctx context.Context, id uint) (*models.Project, error)
+	AddProjectImage(ctx context.Context, projectID uint, image *models.ProjectImage) (*models.ProjectImage, error)
+	DeleteProjectImage(ctx context.Context, projectID, imageID uint) error
+	ReorderProjectImages(ctx context.Context, projectID uint, ids []uint) ([]models.ProjectImage, error)
+	CreateProjectMilestone(ctx context.Context, projectID uint, milestone *models.ProjectMilestone) (*models.ProjectMilestone, error)
+	UpdateProjectMilestone(ctx context.Context, projectID, milestoneID uint, milestone *models.ProjectMilestone) (*models.ProjectMilestone, error)
+	DeleteProjectMilestone(ctx context.Context, projectID, milestoneID uint) error
+	RefreshImageVariants(ctx context.Context, imageURL string) (int64, error)
+	AddLikes(ctx context.Context, increments map[uint]int64) ([]uint, error)
+}
+
+// ContactStore persists contact submissions and the conversation around them
+type ContactStore interface {
+	CreateContact(ctx context.Context, contact *models.Contact) (*models.Contact, error)
+	GetContact(ctx context.Context, id uint) (*models.Contact, error)
+	GetContacts(ctx context.Context, status, country, email string) ([]models.Contact, error)
+	EachContactBatch(ctx context.Context, status string, size int, fn func([]models.Contact) error) error
+	UpdateContactStatus(ctx context.Context, id uint, status string) (*models.Contact, error)
+	MarkAutoReplied(ctx context.Context, id uint) error
+	GetMessages(ctx context.Context, contactID uint) ([]models.ContactMessage, error)
+	AddReply(ctx context.Context, message *models.ContactMessage) (*models.ContactMessage, error)
+	GetNotes(ctx context.Context, contactID uint) ([]models.ContactNote, error)
+	CreateNote(ctx context.Context, note *models.ContactNote) (*models.ContactNote, error)
+}
+
+var (
+	_ ProfileStore    = (*repository.ProfileRepository)(nil)
+	_ ExperienceStore = (*repository.ExperienceRepository)(nil)
+	_ SkillStore      = (*repository.SkillRepository)(nil)
+	_ ProjectStore    = (*repository.ProjectRepository)(nil)
+	_ ContactStore    = (*repository.ContactRepository)(nil)
+)
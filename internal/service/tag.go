@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tagCacheKeys lists the cached listings that embed tags. Single posts cached
+// by slug are left to expire on their own.
+var tagCacheKeys = append([]string{"tags", "posts", "posts:drafts", "talks"}, projectCacheKeys...)
+
+// TagService handles the tag taxonomy shared by projects, posts and talks
+type TagService struct {
+	repo   *repository.TagRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewTagService(repo *repository.TagRepository, redis *redis.Client, events *events.Bus) *TagService {
+	return &TagService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+// GetTags returns every tag with its usage counts
+func (s *TagService) GetTags(ctx context.Context) ([]models.TagUsage, error) {
+	// Try to get from cache first
+	var tags []models.TagUsage
+	if cacheGet(ctx, s.redis, "tags", tenant.CacheKey(ctx, "tags"), &tags) {
+		return tags, nil
+	}
+
+	// Get from database
+	tags, err := s.repo.GetTagUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	tagsJSON, _ := json.Marshal(tags)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "tags"), tagsJSON, contentCacheTTL())
+
+	return tags, nil
+}
+
+type TagCreateRequest struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug"`
+}
+
+func (s *TagService) CreateTag(ctx context.Context, req *TagCreateRequest) (*models.Tag, error) {
+	tag, err := buildTag(req.Name, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	createdTag, err := s.repo.CreateTag(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "tags")
+
+	s.events.Publish(ctx, events.TagCreated, createdTag)
+
+	return createdTag, nil
+}
+
+type TagUpdateRequest struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug"`
+}
+
+func (s *TagService) UpdateTag(ctx context.Context, id uint, req *TagUpdateRequest) (*models.Tag, error) {
+	tag, err := buildTag(req.Name, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedTag, err := s.repo.UpdateTag(ctx, id, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, tagCacheKeys...)
+
+	s.events.Publish(ctx, events.TagUpdated, updatedTag)
+
+	return updatedTag, nil
+}
+
+func (s *TagService) DeleteTag(ctx context.Context, id uint) error {
+	err := s.repo.DeleteTag(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, tagCacheKeys...)
+
+	s.events.Publish(ctx, events.TagDeleted, map[string]uint{"id": id})
+
+	return nil
+}
+
+func buildTag(name, slug string) (*models.Tag, error) {
+	tag := &models.Tag{
+		Name: strings.TrimSpace(name),
+		Slug: postSlug(slug, name),
+	}
+	if tag.Slug == "" {
+		return nil, errors.New("invalid slug")
+	}
+	return tag, nil
+}
+
+// tagsFromNames turns tag names from a request into tags keyed by slug;
+// unknown tags are created when the content is saved
+func tagsFromNames(names []string) []models.Tag {
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, models.Tag{Name: strings.TrimSpace(name), Slug: models.Slugify(name)})
+	}
+	return tags
+}
+
+// hasTag reports whether tags contain the tag with the given slug
+func hasTag(tags []models.Tag, slug string) bool {
+	for _, tag := range tags {
+		if tag.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TalkService handles talk-related operations
+type TalkService struct {
+	repo   *repository.TalkRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewTalkService(repo *repository.TalkRepository, redis *redis.Client, events *events.Bus) *TalkService {
+	return &TalkService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+// GetTalks returns all talks, optionally narrowed to the talks carrying the
+// tag with the given slug
+func (s *TalkService) GetTalks(ctx context.Context, tag string) ([]models.Talk, error) {
+	talks, err := s.getTalks(ctx)
+	if err != nil || tag == "" {
+		return talks, err
+	}
+
+	filtered := []models.Talk{}
+	for _, talk := range talks {
+		if hasTag(talk.Tags, tag) {
+			filtered = append(filtered, talk)
+		}
+	}
+	return filtered, nil
+}
+
+func (s *TalkService) getTalks(ctx context.Context) ([]models.Talk, error) {
+	// Try to get from cache first
+	var talks []models.Talk
+	if cacheGet(ctx, s.redis, "talks", tenant.CacheKey(ctx, "talks"), &talks) {
+		return talks, nil
+	}
+
+	// Get from database
+	talks, err := s.repo.GetTalks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	talksJSON, _ := json.Marshal(talks)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "talks"), talksJSON, contentCacheTTL())
+
+	return talks, nil
+}
+
+type TalkCreateRequest struct {
+	Title     string    `json:"title" binding:"required"`
+	Event     string    `json:"event" binding:"required"`
+	Date      time.Time `json:"date" binding:"required"`
+	Location  string    `json:"location"`
+	SlidesURL string    `json:"slides_url" binding:"omitempty,url"`
+	VideoURL  string    `json:"video_url" binding:"omitempty,url"`
+	Abstract  string    `json:"abstract"`
+	Tags      []string  `json:"tags"` // Tag names; unknown tags are created
+}
+
+func (s *TalkService) CreateTalk(ctx context.Context, req *TalkCreateRequest) (*models.Talk, error) {
+	talk := &models.Talk{
+		Title:     req.Title,
+		Event:     req.Event,
+		Date:      req.Date,
+		Location:  req.Location,
+		SlidesURL: req.SlidesURL,
+		VideoURL:  req.VideoURL,
+		Abstract:  req.Abstract,
+		Tags:      tagsFromNames(req.Tags),
+	}
+
+	createdTalk, err := s.repo.CreateTalk(ctx, talk)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "tags", "talks")
+
+	s.events.Publish(ctx, events.TalkCreated, createdTalk)
+
+	return createdTalk, nil
+}
+
+type TalkUpdateRequest struct {
+	Title     string    `json:"title" binding:"required"`
+	Event     string    `json:"event" binding:"required"`
+	Date      time.Time `json:"date" binding:"required"`
+	Location  string    `json:"location"`
+	SlidesURL string    `json:"slides_url" binding:"omitempty,url"`
+	VideoURL  string    `json:"video_url" binding:"omitempty,url"`
+	Abstract  string    `json:"abstract"`
+	Tags      []string  `json:"tags"` // Tag names; unknown tags are created
+}
+
+func (s *TalkService) UpdateTalk(ctx context.Context, id uint, req *TalkUpdateRequest) (*models.Talk, error) {
+	talk := &models.Talk{
+		Title:     req.Title,
+		Event:     req.Event,
+		Date:      req.Date,
+		Location:  req.Location,
+		SlidesURL: req.SlidesURL,
+		VideoURL:  req.VideoURL,
+		Abstract:  req.Abstract,
+		Tags:      tagsFromNames(req.Tags),
+	}
+
+	updatedTalk, err := s.repo.UpdateTalk(ctx, id, talk)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "tags", "talks")
+
+	s.events.Publish(ctx, events.TalkUpdated, updatedTalk)
+
+	return updatedTalk, nil
+}
+
+func (s *TalkService) DeleteTalk(ctx context.Context, id uint) error {
+	err := s.repo.DeleteTalk(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "tags", "talks")
+
+	s.events.Publish(ctx, events.TalkDeleted, map[string]uint{"id": id})
+
+	return nil
+}
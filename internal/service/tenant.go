@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tenantsCacheKey holds the tenant list every request is resolved against.
+// It is shared by all tenants, so it is never namespaced.
+const (
+	tenantsCacheKey = "tenants"
+	tenantsCacheTTL = 10 * time.Minute
+)
+
+// TenantService manages the portfolios hosted by the deployment and maps
+// requests to them
+type TenantService struct {
+	repo  *repository.TenantRepository
+	redis *redis.Client
+}
+
+func NewTenantService(repo *repository.TenantRepository, redis *redis.Client) *TenantService {
+	return &TenantService{
+		repo:  repo,
+		redis: redis,
+	}
+}
+
+func (s *TenantService) GetTenants(ctx context.Context) ([]models.Tenant, error) {
+	// Try to get from cache first
+	var tenants []models.Tenant
+	if cacheGet(ctx, s.redis, "tenants", tenantsCacheKey, &tenants) {
+		return tenants, nil
+	}
+
+	// Get from database
+	tenants, err := s.repo.GetTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	tenantsJSON, _ := json.Marshal(tenants)
+	s.redis.Set(ctx, tenantsCacheKey, tenantsJSON, tenantsCacheTTL)
+
+	return tenants, nil
+}
+
+// TenantIDs returns the ID of every tenant
+func (s *TenantService) TenantIDs(ctx context.Context) ([]uint, error) {
+	tenants, err := s.GetTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(tenants))
+	for i, t := range tenants {
+		ids[i] = t.ID
+	}
+	return ids, nil
+}
+
+// SiteURL returns the public address of the tenant in ctx: defaultURL for
+// the default tenant and the first domain of any other one. It is empty
+// when that tenant has no domain.
+func (s *TenantService) SiteURL(ctx context.Context, defaultURL string) (string, error) {
+	id, ok := tenant.FromContext(ctx)
+	if !ok || id == tenant.DefaultID {
+		return defaultURL, nil
+	}
+
+	tenants, err := s.GetTenants(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range tenants {
+		if t.ID == id && len(t.Domains) > 0 {
+			return "https://" + t.Domains[0], nil
+		}
+	}
+	return "", nil
+}
+
+// Resolve returns the tenant a request is for. A slug from the /t/{slug}
+// path prefix must name an existing tenant; otherwise the tenant is picked
+// by the request's host name, falling back to the default tenant.
+func (s *TenantService) Resolve(ctx context.Context, host, slug string) (uint, error) {
+	tenants, err := s.GetTenants(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if slug != "" {
+		for _, t := range tenants {
+			if t.Slug == slug {
+				return t.ID, nil
+			}
+		}
+		return 0, errors.New("tenant not found")
+	}
+
+	host = normalizeHost(host)
+	for _, t := range tenants {
+		for _, domain := range t.Domains {
+			if normalizeHost(domain) == host {
+				return t.ID, nil
+			}
+		}
+	}
+	return tenant.DefaultID, nil
+}
+
+type TenantCreateRequest struct {
+	Slug          string   `json:"slug" binding:"required"`
+	Name          string   `json:"name" binding:"required"`
+	Domains       []string `json:"domains"`
+	AdminUsername string   `json:"admin_username" binding:"required"`
+	AdminEmail    string   `json:"admin_email" binding:"required,email"`
+	AdminPassword string   `json:"admin_password" binding:"required,min=8"`
+}
+
+// CreateTenant adds a tenant together with the admin user that manages its
+// content
+func (s *TenantService) CreateTenant(ctx context.Context, req *TenantCreateRequest) (*models.Tenant, error) {
+	t, err := buildTenant(req.Slug, req.Name, req.Domains)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := &models.User{
+		Username: strings.TrimSpace(req.AdminUsername),
+		Email:    strings.TrimSpace(req.AdminEmail),
+		Password: req.AdminPassword,
+		Role:     "admin",
+		Active:   true,
+	}
+
+	createdTenant, err := s.repo.CreateTenant(ctx, t, admin)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	s.redis.Del(context.WithoutCancel(ctx), tenantsCacheKey)
+
+	return createdTenant, nil
+}
+
+type TenantUpdateRequest struct {
+	Slug    string   `json:"slug" binding:"required"`
+	Name    string   `json:"name" binding:"required"`
+	Domains []string `json:"domains"`
+}
+
+func (s *TenantService) UpdateTenant(ctx context.Context, id uint, req *TenantUpdateRequest) (*models.Tenant, error) {
+	t, err := buildTenant(req.Slug, req.Name, req.Domains)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedTenant, err := s.repo.UpdateTenant(ctx, id, t)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	s.redis.Del(context.WithoutCancel(ctx), tenantsCacheKey)
+
+	return updatedTenant, nil
+}
+
+func buildTenant(slug, name string, domains []string) (*models.Tenant, error) {
+	t := &models.Tenant{
+		Slug:    models.Slugify(slug),
+		Name:    strings.TrimSpace(name),
+		Domains: []string{},
+	}
+	if t.Slug == "" || t.Slug != slug {
+		return nil, errors.New("invalid slug")
+	}
+
+	for _, domain := range domains {
+		domain = normalizeHost(domain)
+		if domain == "" {
+			return nil, errors.New("invalid domain")
+		}
+		t.Domains = append(t.Domains, domain)
+	}
+	return t, nil
+}
+
+// normalizeHost lower-cases a host name and strips any port
+func normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestimonialService handles testimonial operations
+type TestimonialService struct {
+	repo   *repository.TestimonialRepository
+	redis  *redis.Client
+	events *events.Bus
+}
+
+func NewTestimonialService(repo *repository.TestimonialRepository, redis *redis.Client, events *events.Bus) *TestimonialService {
+	return &TestimonialService{
+		repo:   repo,
+		redis:  redis,
+		events: events,
+	}
+}
+
+// GetTestimonials returns approved testimonials
+func (s *TestimonialService) GetTestimonials(ctx context.Context) ([]models.Testimonial, error) {
+	// Try to get from cache first
+	var testimonials []models.Testimonial
+	if cacheGet(ctx, s.redis, "testimonials", tenant.CacheKey(ctx, "testimonials"), &testimonials) {
+		return testimonials, nil
+	}
+
+	// Get from database
+	testimonials, err := s.repo.GetTestimonials(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result
+	testimonialsJSON, _ := json.Marshal(testimonials)
+	s.redis.Set(ctx, tenant.CacheKey(ctx, "testimonials"), testimonialsJSON, contentCacheTTL())
+
+	return testimonials, nil
+}
+
+// GetAllTestimonials returns every testimonial including unapproved ones (admin)
+func (s *TestimonialService) GetAllTestimonials(ctx context.Context) ([]models.Testimonial, error) {
+	return s.repo.GetTestimonials(ctx, false)
+}
+
+type TestimonialCreateRequest struct {
+	Author    string `json:"author" binding:"required"`
+	Role      string `json:"role"`
+	Company   string `json:"company"`
+	CompanyID *uint  `json:"company_id"` // Links a company, whose name replaces company
+	Text      string `json:"text" binding:"required"`
+	Avatar    string `json:"avatar"`
+	Approved  bool   `json:"approved"`
+}
+
+func (s *TestimonialService) CreateTestimonial(ctx context.Context, req *TestimonialCreateRequest) (*models.Testimonial, error) {
+	testimonial := &models.Testimonial{
+		Author:    req.Author,
+		Role:      req.Role,
+		Company:   req.Company,
+		CompanyID: req.CompanyID,
+		Text:      req.Text,
+		Avatar:    req.Avatar,
+		Approved:  req.Approved,
+	}
+
+	createdTestimonial, err := s.repo.CreateTestimonial(ctx, testimonial)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "testimonials")
+
+	s.events.Publish(ctx, events.TestimonialCreated, createdTestimonial)
+
+	return createdTestimonial, nil
+}
+
+type TestimonialUpdateRequest struct {
+	Author    string `json:"author" binding:"required"`
+	Role      string `json:"role"`
+	Company   string `json:"company"`
+	CompanyID *uint  `json:"company_id"` // Links a company, whose name replaces company
+	Text      string `json:"text" binding:"required"`
+	Avatar    string `json:"avatar"`
+	Approved  bool   `json:"approved"`
+}
+
+func (s *TestimonialService) UpdateTestimonial(ctx context.Context, id uint, req *TestimonialUpdateRequest) (*models.Testimonial, error) {
+	testimonial := &models.Testimonial{
+		Author:    req.Author,
+		Role:      req.Role,
+		Company:   req.Company,
+		CompanyID: req.CompanyID,
+		Text:      req.Text,
+		Avatar:    req.Avatar,
+		Approved:  req.Approved,
+	}
+
+	updatedTestimonial, err := s.repo.UpdateTestimonial(ctx, id, testimonial)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "testimonials")
+
+	s.events.Publish(ctx, events.TestimonialUpdated, updatedTestimonial)
+
+	return updatedTestimonial, nil
+}
+
+// ModerateTestimonial approves or rejects a testimonial. Rejected entries are
+// kept but hidden from the public listing.
+func (s *TestimonialService) ModerateTestimonial(ctx context.Context, id uint, approved bool) (*models.Testimonial, error) {
+	testimonial, err := s.repo.SetApproved(ctx, id, approved)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "testimonials")
+
+	s.events.Publish(ctx, events.TestimonialUpdated, testimonial)
+
+	return testimonial, nil
+}
+
+func (s *TestimonialService) DeleteTestimonial(ctx context.Context, id uint) error {
+	err := s.repo.DeleteTestimonial(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	invalidate(ctx, s.redis, "testimonials")
+
+	s.events.Publish(ctx, events.TestimonialDeleted, map[string]uint{"id": id})
+
+	return nil
+}
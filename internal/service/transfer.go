@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// portfolioExportVersion is bumped whenever the export document changes in
+// a way older imports cannot read
+const portfolioExportVersion = 1
+
+// PortfolioExport is a full copy of the portfolio content, drafts included,
+// suitable for backups and for cloning an environment
+type PortfolioExport struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	repository.Snapshot
+}
+
+// PortfolioImportResult summarises an import
+type PortfolioImportResult struct {
+	DryRun      bool `json:"dry_run"`
+	Profile     bool `json:"profile"`
+	Experiences int  `json:"experiences"`
+	Skills      int  `json:"skills"`
+	Categories  int  `json:"categories"`
+	Projects    int  `json:"projects"`
+}
+
+// errDryRun rolls back an import that was only meant to be checked
+var errDryRun = errors.New("dry run")
+
+// TransferService exports and imports the portfolio as one document
+type TransferService struct {
+	uow   *repository.UnitOfWork
+	redis *redis.Client
+}
+
+func NewTransferService(uow *repository.UnitOfWork, redis *redis.Client) *TransferService {
+	return &TransferService{
+		uow:   uow,
+		redis: redis,
+	}
+}
+
+// Export reads everything in one read-only snapshot so that an edit made
+// while exporting cannot leave the document referencing missing rows
+func (s *TransferService) Export(ctx context.Context) (*PortfolioExport, error) {
+	var snapshot *repository.Snapshot
+	err := s.uow.WithTransaction(ctx, func(repos *repository.Repositories) error {
+		var err error
+		snapshot, err = repos.Transfer.Export(ctx)
+		return err
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return &PortfolioExport{
+		Version:    portfolioExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Snapshot:   *snapshot,
+	}, nil
+}
+
+// Import replaces the profile, experiences, skills, categories and projects
+// with the exported ones. A dry run performs the whole import and rolls it
+// back, so it fails exactly when the real import would.
+func (s *TransferService) Import(ctx context.Context, doc *PortfolioExport, dryRun bool) (*PortfolioImportResult, error) {
+	if doc.Version != portfolioExportVersion {
+		return nil, errors.New("unsupported export version")
+	}
+
+	err := s.uow.WithTransaction(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Transfer.Restore(ctx, &doc.Snapshot); err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	if !dryRun {
+		// Invalidate cache
+		keys := []string{"profile", "experiences", "experiences:drafts", "skills", "categories", "tags"}
+		invalidate(ctx, s.redis, append(keys, projectCacheKeys...)...)
+	}
+
+	return &PortfolioImportResult{
+		DryRun:      dryRun,
+		Profile:     doc.Profile != nil,
+		Experiences: len(doc.Experiences),
+		Skills:      len(doc.Skills),
+		Categories:  len(doc.Categories),
+		Projects:    len(doc.Projects),
+	}, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strings"
+)
+
+// QR code contents of the profile
+const (
+	ProfileQRVCard = "vcard"
+	ProfileQRURL   = "url"
+)
+
+var vcardEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+
+// GetProfileQRContent returns the text a profile QR code encodes: the
+// profile as a vCard that phones offer to save as a contact, or the URL of
+// the portfolio site. apiBaseURL stands in for the site URL as in
+// GetProjectOpenGraph.
+func (s *PortfolioService) GetProfileQRContent(ctx context.Context, content, apiBaseURL string) (string, error) {
+	if content != ProfileQRVCard && content != ProfileQRURL {
+		return "", errors.New("invalid content")
+	}
+
+	siteURL, err := s.siteURL(ctx, apiBaseURL)
+	if err != nil {
+		return "", err
+	}
+	if content == ProfileQRURL {
+		return siteURL, nil
+	}
+
+	profile, err := s.profileService.GetProfile(ctx)
+	if err != nil {
+		return "", err
+	}
+	return buildVCard(profile, siteURL), nil
+}
+
+// buildVCard renders a vCard 3.0, the version every phone's camera app
+// understands, leaving out empty fields
+func buildVCard(profile *models.Profile, siteURL string) string {
+	var b strings.Builder
+	line := func(name, value string) {
+		if value = strings.TrimSpace(html.UnescapeString(value)); value != "" {
+			b.WriteString(name + ":" + vcardEscaper.Replace(value) + "\r\n")
+		}
+	}
+
+	b.WriteString("BEGIN:VCARD\r\nVERSION:3.0\r\n")
+	line("FN", profile.Name)
+	// N is required by 3.0; splitting names into parts is guesswork, so the
+	// whole name goes into the family name component
+	b.WriteString("N:" + vcardEscaper.Replace(strings.TrimSpace(html.UnescapeString(profile.Name))) + ";;;;\r\n")
+	line("TITLE", profile.Title)
+	line("EMAIL;TYPE=INTERNET", profile.Email)
+	line("TEL;TYPE=CELL", profile.Phone)
+	if location := strings.TrimSpace(html.UnescapeString(profile.Location)); location != "" {
+		b.WriteString("ADR;TYPE=WORK:;;;" + vcardEscaper.Replace(location) + ";;;\r\n")
+	}
+	line("URL", siteURL)
+	line("X-SOCIALPROFILE;TYPE=github", profileLink(profile.GitHub, "https://github.com/"))
+	line("X-SOCIALPROFILE;TYPE=linkedin", profileLink(profile.LinkedIn, "https://www.linkedin.com/in/"))
+	line("X-SOCIALPROFILE;TYPE=telegram", profileLink(strings.TrimPrefix(profile.Telegram, "@"), "https://t.me/"))
+	b.WriteString("END:VCARD\r\n")
+	return b.String()
+}
+
+// profileLink turns a social profile given as a handle, a bare host and path
+// or a full URL into a URL
+func profileLink(value, base string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return ""
+	case strings.HasPrefix(value, "http://"), strings.HasPrefix(value, "https://"):
+		return value
+	case strings.Contains(value, "/"):
+		return "https://" + value
+	default:
+		return base + value
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"log"
+	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strings"
+	"sync"
+)
+
+// warmEventPrefixes are the event types whose writes invalidate the keys
+// the warmer fills
+var warmEventPrefixes = []string{"profile.", "experience.", "skill.", "project.", "category.", "tag."}
+
+// CacheWarmer fills the most visited cache keys right after boot and again
+// after admin writes, so visitors never pay for a cold cache
+type CacheWarmer struct {
+	tenants     *TenantService
+	profiles    *ProfileService
+	experiences *ExperienceService
+	skills      *SkillService
+	projects    *ProjectService
+	wake        chan struct{}
+
+	mu      sync.Mutex
+	pending map[uint]bool // Tenants written to since the last warm-up
+}
+
+func NewCacheWarmer(tenants *TenantService, profiles *ProfileService, experiences *ExperienceService, skills *SkillService, projects *ProjectService) *CacheWarmer {
+	return &CacheWarmer{
+		tenants:     tenants,
+		profiles:    profiles,
+		experiences: experiences,
+		skills:      skills,
+		projects:    projects,
+		wake:        make(chan struct{}, 1),
+		pending:     map[uint]bool{},
+	}
+}
+
+// HandleEvent schedules a warm-up after writes to cached content. It is
+// registered as an event bus subscriber; bursts of writes coalesce into a
+// single warm-up of the tenants written to.
+func (w *CacheWarmer) HandleEvent(event events.Event) {
+	for _, prefix := range warmEventPrefixes {
+		if strings.HasPrefix(event.Type, prefix) {
+			w.mu.Lock()
+			w.pending[event.TenantID] = true
+			w.mu.Unlock()
+
+			select {
+			case w.wake <- struct{}{}:
+			default:
+			}
+			return
+		}
+	}
+}
+
+// Run warms the cache of every tenant once and then after every scheduled
+// warm-up until the context is cancelled
+func (w *CacheWarmer) Run(ctx context.Context) {
+	ids, err := w.tenants.TenantIDs(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to list tenants to warm: %v", err)
+	}
+
+	for {
+		// Warm-ups follow writes, which a lagging replica may not have yet
+		for _, id := range ids {
+			w.warm(database.Primary(tenant.WithID(ctx, id)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		}
+
+		w.mu.Lock()
+		ids = make([]uint, 0, len(w.pending))
+		for id := range w.pending {
+			ids = append(ids, id)
+		}
+		w.pending = map[uint]bool{}
+		w.mu.Unlock()
+	}
+}
+
+// warm reads each public listing of the tenant in ctx through its service, which loads and
+// caches whatever is missing
+func (w *CacheWarmer) warm(ctx context.Context) {
+	featured, notFeatured := true, false
+	loads := []struct {
+		name string
+		load func() error
+	}{
+		{"profile", func() error { _, err := w.profiles.GetProfile(ctx); return err }},
+		{"experiences", func() error { _, err := w.experiences.GetExperiences(ctx, false); return err }},
+		{"skills", func() error { _, err := w.skills.GetSkills(ctx); return err }},
+		{"projects", func() error { _, err := w.projects.GetProjects(ctx, nil, nil, "", false, false); return err }},
+		{"featured projects", func() error { _, err := w.projects.GetProjects(ctx, &featured, nil, "", false, false); return err }},
+		{"non-featured projects", func() error { _, err := w.projects.GetProjects(ctx, &notFeatured, nil, "", false, false); return err }},
+	}
+
+	for _, l := range loads {
+		if err := l.load(); err != nil {
+			log.Printf("Warning: failed to warm %s cache: %v", l.name, err)
+		}
+	}
+}
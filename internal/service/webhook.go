@@ -0,0 +1,337 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/models"
+	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Deliveries are retried on the job queue's schedule: jobMaxAttempts
+// attempts backing off with retryBackoff
+const (
+	webhookTimeout       = 10 * time.Second
+	webhookBatchSize     = 50
+	webhookPollInterval  = 15 * time.Second
+	webhookDeliveryLimit = 100
+)
+
+// errWebhookDestination is returned for deliveries to addresses inside the
+// server's own network
+var errWebhookDestination = errors.New("webhook destination not allowed")
+
+// WebhookService manages webhook subscriptions and delivers events to them
+type WebhookService struct {
+	repo   *repository.WebhookRepository
+	client *http.Client
+	wake   chan struct{}
+}
+
+func NewWebhookService(repo *repository.WebhookRepository) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		client: newWebhookClient(),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// newWebhookClient returns the client deliveries are posted with. Its dialer
+// checks every address after name resolution, so neither a host name nor a
+// DNS answer changed later can point webhooks at internal services.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout, Control: webhookDialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: webhookTimeout, Transport: transport}
+}
+
+// webhookDialControl refuses connections to loopback, private, link-local
+// and other non-public addresses
+func webhookDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return errWebhookDestination
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
+
+// HandleEvent queues a delivery for every active subscription interested in
+// the event. It is registered as an event bus subscriber and returns
+// immediately so publishing never waits on the database.
+func (s *WebhookService) HandleEvent(event events.Event) {
+	// Only content events are offered to subscribers
+	if !events.IsValidType(event.Type) {
+		return
+	}
+	go s.enqueue(event)
+}
+
+func (s *WebhookService) enqueue(event events.Event) {
+	ctx := event.Context()
+	subscriptions, err := s.repo.GetActiveSubscriptions(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to load webhook subscriptions: %v", err)
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Warning: failed to encode webhook payload for %s: %v", event.Type, err)
+		return
+	}
+
+	queued := false
+	for _, subscription := range subscriptions {
+		if !subscription.Subscribes(event.Type) {
+			continue
+		}
+
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         "pending",
+			NextAttemptAt:  time.Now(),
+		}
+		if _, err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+			log.Printf("Warning: failed to queue webhook delivery for subscription %d: %v", subscription.ID, err)
+			continue
+		}
+		queued = true
+	}
+
+	if queued {
+		s.notify()
+	}
+}
+
+// Run delivers due webhooks until the context is cancelled. Pending
+// deliveries live in the database, so retries survive restarts.
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *WebhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliverDue works through the due deliveries of every tenant
+func (s *WebhookService) deliverDue(ctx context.Context) {
+	deliveries, err := s.repo.GetDueDeliveries(tenant.All(ctx), time.Now(), webhookBatchSize)
+	if err != nil {
+		log.Printf("Warning: failed to load due webhook deliveries: %v", err)
+		return
+	}
+
+	for i := range deliveries {
+		// The claim leases the delivery; one left in flight by a crashed
+		// worker is due again once the lease expires
+		deliveryCtx := tenant.WithID(ctx, deliveries[i].TenantID)
+		claimed, err := s.repo.ClaimDelivery(deliveryCtx, deliveries[i].ID, time.Now(), time.Now().Add(jobVisibilityTimeout))
+		if err != nil || !claimed {
+			continue
+		}
+		s.attempt(deliveryCtx, &deliveries[i])
+	}
+}
+
+// attempt performs one delivery attempt and schedules a retry with
+// exponential backoff on failure
+func (s *WebhookService) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+
+	subscription, err := s.repo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		delivery.Status = "failed"
+		delivery.LastError = err.Error()
+		s.save(ctx, delivery)
+		return
+	}
+
+	status, err := s.post(subscription, delivery)
+	delivery.ResponseStatus = status
+	if err == nil {
+		now := time.Now()
+		delivery.Status = "succeeded"
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		s.save(ctx, delivery)
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= jobMaxAttempts {
+		delivery.Status = "failed"
+	} else {
+		delivery.Status = "pending"
+		delivery.NextAttemptAt = time.Now().Add(retryBackoff(delivery.Attempts))
+	}
+	s.save(ctx, delivery)
+}
+
+func (s *WebhookService) post(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "StackWhiz-Portfolio-Webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *WebhookService) save(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := s.repo.SaveDelivery(ctx, delivery); err != nil {
+		log.Printf("Warning: failed to save webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// SignWebhookPayload computes the hex HMAC-SHA256 of "timestamp.payload".
+// Receivers recompute it with the shared secret to verify authenticity.
+func SignWebhookPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.repo.GetSubscriptions(ctx)
+}
+
+type WebhookCreateRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+	Active     *bool    `json:"active"`
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, req *WebhookCreateRequest) (*models.WebhookSubscription, error) {
+	subscription, err := buildSubscription(req.URL, req.Secret, req.EventTypes, req.Active)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Secret == "" {
+		secret, err := models.GenerateRandomString(32)
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+
+	return s.repo.CreateSubscription(ctx, subscription)
+}
+
+type WebhookUpdateRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	Secret     string   `json:"secret"` // Leave empty to keep the current secret
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+	Active     *bool    `json:"active"`
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id uint, req *WebhookUpdateRequest) (*models.WebhookSubscription, error) {
+	subscription, err := buildSubscription(req.URL, req.Secret, req.EventTypes, req.Active)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateSubscription(ctx, id, subscription)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uint) error {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, id uint) ([]models.WebhookDelivery, error) {
+	if _, err := s.repo.GetSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveries(ctx, id, webhookDeliveryLimit)
+}
+
+func buildSubscription(rawURL, secret string, eventTypes []string, active *bool) (*models.WebhookSubscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("invalid webhook url")
+	}
+	// Host names are checked when deliveries connect
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !isPublicIP(ip) {
+		return nil, errors.New("invalid webhook url")
+	}
+
+	for _, eventType := range eventTypes {
+		if eventType != "*" && !events.IsValidType(eventType) {
+			return nil, errors.New("invalid event type")
+		}
+	}
+
+	isActive := true
+	if active != nil {
+		isActive = *active
+	}
+
+	return &models.WebhookSubscription{
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     isActive,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"stackwhiz-portfolio-backend/internal/models"
+	"strconv"
+	"testing"
+)
+
+func TestWebhookRefusesInternalDestinations(t *testing.T) {
+	for _, rawURL := range []string{"http://127.0.0.1:8080/hook", "http://[::1]/hook", "http://10.0.0.5/hook", "http://169.254.169.254/latest/meta-data"} {
+		if _, err := buildSubscription(rawURL, "", []string{"*"}, nil); err == nil {
+			t.Errorf("buildSubscription(%q) accepted an internal address", rawURL)
+		}
+	}
+	if _, err := buildSubscription("https://hooks.example.com/portfolio", "", []string{"*"}, nil); err != nil {
+		t.Errorf("buildSubscription rejected a public host: %v", err)
+	}
+
+	// A host name resolving to loopback passes validation and is refused
+	// when the delivery connects
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	rawURL := "http://localhost:" + strconv.Itoa(server.Listener.Addr().(*net.TCPAddr).Port) + "/hook"
+	subscription, err := buildSubscription(rawURL, "secret", []string{"*"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewWebhookService(nil)
+	if _, err := s.post(subscription, &models.WebhookDelivery{Payload: "{}"}); !errors.Is(err, errWebhookDestination) {
+		t.Errorf("post to %s: err = %v, want %v", rawURL, err, errWebhookDestination)
+	}
+	if called {
+		t.Error("delivery reached a loopback server")
+	}
+	if err := webhookDialControl("tcp4", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("public address refused: %v", err)
+	}
+}
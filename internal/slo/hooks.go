@@ -0,0 +1,40 @@
+package slo
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// LogHook writes alerts to the application log
+func LogHook(alert Alert) {
+	log.Printf("SLO alert [%s] %s: burn rate %.1f/%.1f over %s (target %.4f)",
+		alert.Severity, alert.Endpoint, alert.LongBurn, alert.ShortBurn, alert.Windows, alert.Target)
+}
+
+// WebhookHook posts alerts as JSON to the given URL (e.g. a Slack or
+// Alertmanager compatible receiver)
+func WebhookHook(url string) AlertHook {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	return func(alert Alert) {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			log.Printf("Warning: failed to encode SLO alert: %v", err)
+			return
+		}
+
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("Warning: failed to deliver SLO alert: %v", err)
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			log.Printf("Warning: SLO alert webhook returned %s", resp.Status)
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package slo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix        = "slo:"
+	endpointsKey     = keyPrefix + "endpoints"
+	evaluateLockKey  = keyPrefix + "evaluate:lock"
+	alertDedupWindow = time.Hour
+	budgetWindow     = 30 * 24 * time.Hour
+)
+
+// rollup is one granularity of counters. Each endpoint keeps a single hash
+// per rollup holding "<bucket>:total" and "<bucket>:errors" fields, so a
+// whole window is read with one HMGET.
+type rollup struct {
+	name    string
+	step    time.Duration
+	buckets int
+	ttl     time.Duration
+}
+
+var (
+	minutes = rollup{name: "m", step: time.Minute, buckets: 6 * 60, ttl: 7 * time.Hour}
+	hours   = rollup{name: "h", step: time.Hour, buckets: 24, ttl: 25 * time.Hour}
+	days    = rollup{name: "d", step: 24 * time.Hour, buckets: 30, ttl: 31 * 24 * time.Hour}
+	rollups = []rollup{minutes, hours, days}
+)
+
+// Window is a rolling window over which success rates are computed
+type Window struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Windows reported for every endpoint. Windows up to 6h are computed from
+// minute buckets, 24h from hour buckets and 30d from day buckets.
+var Windows = []Window{
+	{Name: "5m", Duration: 5 * time.Minute},
+	{Name: "30m", Duration: 30 * time.Minute},
+	{Name: "1h", Duration: time.Hour},
+	{Name: "6h", Duration: 6 * time.Hour},
+	{Name: "24h", Duration: 24 * time.Hour},
+	{Name: "30d", Duration: budgetWindow},
+}
+
+// burnRule fires when both the long and short window burn faster than the
+// threshold (multiwindow, multi-burn-rate alerting from the SRE workbook)
+type burnRule struct {
+	Severity  string
+	Long      string
+	Short     string
+	Threshold float64
+}
+
+var burnRules = []burnRule{
+	{Severity: "page", Long: "1h", Short: "5m", Threshold: 14.4},
+	{Severity: "ticket", Long: "6h", Short: "30m", Threshold: 6},
+}
+
+// WindowStats holds the request counts and derived rates for one window
+type WindowStats struct {
+	Window      string  `json:"window"`
+	Total       int64   `json:"total"`
+	Errors      int64   `json:"errors"`
+	SuccessRate float64 `json:"success_rate"`
+	BurnRate    float64 `json:"burn_rate"`
+}
+
+// EndpointReport summarizes an endpoint's performance against its SLO
+type EndpointReport struct {
+	Endpoint             string        `json:"endpoint"`
+	Target               float64       `json:"target"`
+	ErrorBudgetRemaining float64       `json:"error_budget_remaining"`
+	Windows              []WindowStats `json:"windows"`
+	Alerts               []string      `json:"alerts"`
+}
+
+// Alert is raised when an endpoint burns its error budget too fast
+type Alert struct {
+	Endpoint  string    `json:"endpoint"`
+	Severity  string    `json:"severity"`
+	Target    float64   `json:"target"`
+	LongBurn  float64   `json:"long_burn_rate"`
+	ShortBurn float64   `json:"short_burn_rate"`
+	Windows   string    `json:"windows"`
+	FiredAt   time.Time `json:"fired_at"`
+}
+
+// AlertHook is notified whenever an alert fires
+type AlertHook func(Alert)
+
+// Tracker records per-endpoint outcomes in Redis and evaluates them against
+// the configured SLO targets
+type Tracker struct {
+	redis            *redis.Client
+	defaultTarget    float64
+	targets          map[string]float64
+	latencyThreshold time.Duration
+	hooks            []AlertHook
+}
+
+// NewTracker creates a tracker. targets maps "METHOD /route" to an
+// availability target and overrides defaultTarget. Requests slower than
+// latencyThreshold count as failures when the threshold is positive.
+func NewTracker(redis *redis.Client, defaultTarget float64, targets map[string]float64, latencyThreshold time.Duration) *Tracker {
+	if targets == nil {
+		targets = map[string]float64{}
+	}
+	return &Tracker{
+		redis:            redis,
+		defaultTarget:    defaultTarget,
+		targets:          targets,
+		latencyThreshold: latencyThreshold,
+	}
+}
+
+// OnAlert registers a hook called when an alert fires
+func (t *Tracker) OnAlert(hook AlertHook) {
+	t.hooks = append(t.hooks, hook)
+}
+
+// Target returns the availability target for an endpoint
+func (t *Tracker) Target(endpoint string) float64 {
+	if target, ok := t.targets[endpoint]; ok {
+		return target
+	}
+	return t.defaultTarget
+}
+
+// Record stores the outcome of a single request
+func (t *Tracker) Record(endpoint string, status int, latency time.Duration) {
+	failed := status >= 500 || (t.latencyThreshold > 0 && latency > t.latencyThreshold)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	now := time.Now().UTC()
+	pipe := t.redis.Pipeline()
+	pipe.SAdd(ctx, endpointsKey, endpoint)
+	for _, r := range rollups {
+		key, bucket := r.key(endpoint), r.bucket(now)
+		pipe.HIncrBy(ctx, key, bucket+":total", 1)
+		if failed {
+			pipe.HIncrBy(ctx, key, bucket+":errors", 1)
+		}
+		pipe.Expire(ctx, key, r.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Warning: failed to record SLO sample for %s: %v", endpoint, err)
+	}
+}
+
+// Report computes the SLO report for every endpoint seen so far. All
+// rollups of all endpoints are read in a single pipeline.
+func (t *Tracker) Report(ctx context.Context) ([]EndpointReport, error) {
+	endpoints, err := t.redis.SMembers(ctx, endpointsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(endpoints)
+	if len(endpoints) == 0 {
+		return []EndpointReport{}, nil
+	}
+
+	now := time.Now().UTC()
+	pipe := t.redis.Pipeline()
+	cmds := make([]map[string]*redis.SliceCmd, len(endpoints))
+	for i, endpoint := range endpoints {
+		cmds[i] = make(map[string]*redis.SliceCmd, len(rollups))
+		for _, r := range rollups {
+			cmds[i][r.name] = pipe.HMGet(ctx, r.key(endpoint), r.fields(now, 0, r.buckets)...)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	reports := make([]EndpointReport, 0, len(endpoints))
+	for i, endpoint := range endpoints {
+		samples := make(map[string][]interface{}, len(rollups))
+		for name, cmd := range cmds[i] {
+			samples[name] = cmd.Val()
+		}
+		reports = append(reports, t.endpointReport(endpoint, samples))
+	}
+	return reports, nil
+}
+
+func (t *Tracker) endpointReport(endpoint string, samples map[string][]interface{}) EndpointReport {
+	target := t.Target(endpoint)
+	report := EndpointReport{
+		Endpoint: endpoint,
+		Target:   target,
+		Alerts:   []string{},
+	}
+
+	byName := make(map[string]WindowStats, len(Windows))
+	for _, window := range Windows {
+		r := rollupFor(window.Duration)
+		total, errors := sum(samples[r.name], int(window.Duration/r.step))
+		stats := WindowStats{
+			Window:      window.Name,
+			Total:       total,
+			Errors:      errors,
+			SuccessRate: 1,
+		}
+		if total > 0 {
+			stats.SuccessRate = 1 - float64(errors)/float64(total)
+			stats.BurnRate = burnRate(stats.SuccessRate, target)
+		}
+		byName[window.Name] = stats
+		report.Windows = append(report.Windows, stats)
+	}
+
+	budget := byName["30d"]
+	report.ErrorBudgetRemaining = 1
+	if budget.Total > 0 && target < 1 {
+		allowed := float64(budget.Total) * (1 - target)
+		report.ErrorBudgetRemaining = 1 - float64(budget.Errors)/allowed
+	}
+
+	for _, rule := range burnRules {
+		if byName[rule.Long].BurnRate > rule.Threshold && byName[rule.Short].BurnRate > rule.Threshold {
+			report.Alerts = append(report.Alerts, rule.Severity)
+		}
+	}
+
+	return report
+}
+
+// Evaluate checks every endpoint against the burn-rate rules, notifies the
+// alert hooks and prunes buckets that fell out of their rollup. Each alert
+// fires at most once per dedup window.
+func (t *Tracker) Evaluate(ctx context.Context) {
+	reports, err := t.Report(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to evaluate SLOs: %v", err)
+		return
+	}
+
+	for _, report := range reports {
+		windows := make(map[string]WindowStats, len(report.Windows))
+		for _, stats := range report.Windows {
+			windows[stats.Window] = stats
+		}
+
+		for _, rule := range burnRules {
+			long, short := windows[rule.Long], windows[rule.Short]
+			if long.BurnRate <= rule.Threshold || short.BurnRate <= rule.Threshold {
+				continue
+			}
+
+			dedupKey := keyPrefix + "alerted:" + rule.Severity + ":" + report.Endpoint
+			fire, err := t.redis.SetNX(ctx, dedupKey, 1, alertDedupWindow).Result()
+			if err != nil || !fire {
+				continue
+			}
+
+			alert := Alert{
+				Endpoint:  report.Endpoint,
+				Severity:  rule.Severity,
+				Target:    report.Target,
+				LongBurn:  long.BurnRate,
+				ShortBurn: short.BurnRate,
+				Windows:   rule.Long + "/" + rule.Short,
+				FiredAt:   time.Now(),
+			}
+			for _, hook := range t.hooks {
+				hook(alert)
+			}
+		}
+	}
+
+	t.prune(ctx, reports)
+}
+
+// Run evaluates the SLOs on every tick until the context is cancelled. The
+// evaluation lock is held for most of the interval, so only one replica
+// evaluates (and alerts) per tick.
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			locked, err := t.redis.SetNX(ctx, evaluateLockKey, 1, interval-interval/10).Result()
+			if err != nil || !locked {
+				continue
+			}
+			t.Evaluate(ctx)
+		}
+	}
+}
+
+// prune drops the buckets of the previous full span of every rollup. Record
+// keeps refreshing the hash TTLs, so without pruning the fields of a busy
+// endpoint would never expire.
+func (t *Tracker) prune(ctx context.Context, reports []EndpointReport) {
+	if len(reports) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	pipe := t.redis.Pipeline()
+	for _, report := range reports {
+		for _, r := range rollups {
+			pipe.HDel(ctx, r.key(report.Endpoint), r.fields(now, r.buckets, 2*r.buckets)...)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Warning: failed to prune SLO buckets: %v", err)
+	}
+}
+
+// ParseTargets parses "GET /api/v1/profile=0.999;POST /api/v1/contact=0.99"
+func ParseTargets(value string) (map[string]float64, error) {
+	targets := map[string]float64{}
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		endpoint, raw, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid SLO target %q", entry)
+		}
+		target, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || target <= 0 || target > 1 {
+			return nil, fmt.Errorf("invalid SLO target %q", entry)
+		}
+		targets[strings.TrimSpace(endpoint)] = target
+	}
+	return targets, nil
+}
+
+func burnRate(successRate, target float64) float64 {
+	if target >= 1 {
+		return 0
+	}
+	return (1 - successRate) / (1 - target)
+}
+
+// rollupFor returns the finest rollup that covers the window
+func rollupFor(window time.Duration) rollup {
+	for _, r := range rollups {
+		if window <= r.step*time.Duration(r.buckets) {
+			return r
+		}
+	}
+	return days
+}
+
+func (r rollup) key(endpoint string) string {
+	return keyPrefix + r.name + ":" + endpoint
+}
+
+func (r rollup) bucket(at time.Time) string {
+	return strconv.FormatInt(at.Truncate(r.step).Unix(), 10)
+}
+
+// fields lists the total and errors fields of the buckets from (inclusive)
+// to to (exclusive) steps before now, newest first
+func (r rollup) fields(now time.Time, from, to int) []string {
+	fields := make([]string, 0, 2*(to-from))
+	for i := from; i < to; i++ {
+		bucket := r.bucket(now.Add(-time.Duration(i) * r.step))
+		fields = append(fields, bucket+":total", bucket+":errors")
+	}
+	return fields
+}
+
+// sum adds up the newest n buckets of an HMGET reply over fields
+func sum(values []interface{}, n int) (int64, int64) {
+	var total, errors int64
+	for i := 0; i < n && 2*i+1 < len(values); i++ {
+		total += toInt(values[2*i])
+		errors += toInt(values[2*i+1])
+	}
+	return total, errors
+}
+
+func toInt(value interface{}) int64 {
+	s, ok := value.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
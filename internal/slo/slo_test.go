@@ -0,0 +1,61 @@
+package slo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestReportSumsRollups(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	tracker := NewTracker(client, 0.99, nil, 0)
+
+	for i := 0; i < 9; i++ {
+		tracker.Record("GET /api/v1/profile", http.StatusOK, time.Millisecond)
+	}
+	tracker.Record("GET /api/v1/profile", http.StatusInternalServerError, time.Millisecond)
+
+	reports, err := tracker.Report(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("got %d reports, want 1", len(reports))
+	}
+	for _, stats := range reports[0].Windows {
+		if stats.Total != 10 || stats.Errors != 1 {
+			t.Errorf("window %s counted %d/%d, want 10/1", stats.Window, stats.Errors, stats.Total)
+		}
+	}
+	if got := len(server.Keys()); got != 4 {
+		t.Errorf("got %d keys, want the endpoint set and one hash per rollup", got)
+	}
+}
+
+func TestRunEvaluatesOnOneReplica(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	alerts := make(chan Alert, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		tracker := NewTracker(client, 0.99, nil, 0)
+		tracker.OnAlert(func(alert Alert) { alerts <- alert })
+		if i == 0 {
+			tracker.Record("GET /api/v1/profile", http.StatusInternalServerError, time.Millisecond)
+		}
+		go tracker.Run(ctx, 50*time.Millisecond)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	if got := len(alerts); got != len(burnRules) {
+		t.Errorf("got %d alerts, want one per burn rule", got)
+	}
+}
@@ -0,0 +1,25 @@
+package storage
+
+// gcsEndpoint serves the XML API of Cloud Storage, which speaks the S3
+// protocol to clients using HMAC keys
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// GCSConfig describes a Google Cloud Storage bucket accessed with an HMAC
+// key of a service account
+type GCSConfig struct {
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// NewGCSStorage returns storage backed by a Cloud Storage bucket. Requests
+// go through the S3-compatible XML API, so the S3 client serves both.
+func NewGCSStorage(cfg GCSConfig) (*S3Storage, error) {
+	return NewS3Storage(S3Config{
+		Endpoint:  gcsEndpoint,
+		Region:    "auto",
+		Bucket:    cfg.Bucket,
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	})
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// fakeS3 implements the single and multipart uploads of the S3 API
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	parts   map[int][]byte
+	singles int
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.parts = map[int][]byte{}
+		io.WriteString(w, "<InitiateMultipartUploadResult><UploadId>u1</UploadId></InitiateMultipartUploadResult>")
+	case r.Method == http.MethodPut && query.Get("uploadId") == "u1":
+		n, _ := strconv.Atoi(query.Get("partNumber"))
+		f.parts[n] = body
+		w.Header().Set("ETag", `"part`+strconv.Itoa(n)+`"`)
+	case r.Method == http.MethodPost && query.Get("uploadId") == "u1":
+		var complete struct {
+			Parts []s3Part `xml:"Part"`
+		}
+		xml.Unmarshal(body, &complete)
+		var object []byte
+		for i, part := range complete.Parts {
+			if part.PartNumber != i+1 || part.ETag != `"part`+strconv.Itoa(i+1)+`"` {
+				http.Error(w, "invalid part", http.StatusBadRequest)
+				return
+			}
+			object = append(object, f.parts[part.PartNumber]...)
+		}
+		f.objects[r.URL.Path] = object
+		io.WriteString(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodPut:
+		f.singles++
+		f.objects[r.URL.Path] = body
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+func TestS3PutUploadsLargeObjectsInParts(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s, err := NewS3Storage(S3Config{Endpoint: server.URL, Bucket: "b", AccessKey: "a", SecretKey: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	small := []byte("small")
+	large := bytes.Repeat([]byte("0123456789"), s3PartSize/5+1) // Two full parts and a bit
+	if err := s.Put("small", bytes.NewReader(small)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put("large", bytes.NewReader(large)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(fake.objects["/b/small"], small) || fake.singles != 1 {
+		t.Errorf("small object was not uploaded in one request")
+	}
+	if !bytes.Equal(fake.objects["/b/large"], large) || len(fake.parts) != 3 {
+		t.Errorf("large object uploaded in %d parts, want 3 that add up to it", len(fake.parts))
+	}
+}
@@ -0,0 +1,261 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Backends selectable with New
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+	BackendGCS   = "gcs"
+)
+
+// Storage stores files by name. Implementations must treat names as opaque
+// keys; slashes group objects, as in backups/<file>, so List can find them
+// by prefix.
+type Storage interface {
+	Put(name string, r io.Reader) error
+	Get(name string) (io.ReadCloser, error)
+	Delete(name string) error
+	List(prefix string) ([]ObjectInfo, error)
+	// SignedURL returns a URL that downloads the object without further
+	// credentials until ttl has passed
+	SignedURL(name string, ttl time.Duration) (string, error)
+}
+
+// URLVerifier is implemented by backends whose signed URLs lead back to
+// this server, which must check them before serving the object
+type URLVerifier interface {
+	// Verify reports whether the query of a request for name carries a
+	// genuine signature that has not expired
+	Verify(name string, query url.Values) bool
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Name         string    `json:"name"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// Config selects a backend and holds the settings of each
+type Config struct {
+	Backend string // local, s3 or gcs
+	Local   LocalConfig
+	S3      S3Config
+	GCS     GCSConfig
+}
+
+// New opens the storage backend selected by cfg
+func New(cfg Config) (Storage, error) {
+	switch cfg.Backend {
+	case BackendLocal:
+		return NewLocalStorage(cfg.Local)
+	case BackendS3:
+		return NewS3Storage(cfg.S3)
+	case BackendGCS:
+		return NewGCSStorage(cfg.GCS)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// LocalConfig describes a directory on the local filesystem. The directory
+// is not served as is, so signed URLs point below URLPrefix, where the
+// server hands out the files.
+type LocalConfig struct {
+	Dir        string
+	URLPrefix  string // e.g. /media
+	SigningKey []byte // Secret the key of signed URLs is derived from
+}
+
+// LocalStorage keeps files in a directory on the local filesystem
+type LocalStorage struct {
+	dir        string
+	urlPrefix  string
+	signingKey []byte
+}
+
+func NewLocalStorage(cfg LocalConfig) (*LocalStorage, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{
+		dir:        cfg.Dir,
+		urlPrefix:  strings.TrimRight(cfg.URLPrefix, "/"),
+		signingKey: urlSigningKey(cfg.SigningKey),
+	}, nil
+}
+
+// urlSigningKey derives the key signed URLs are HMAC'd with, so a signature
+// is never computed with the secret itself, which may also sign other
+// things such as JWTs
+func urlSigningKey(secret []byte) []byte {
+	if len(secret) == 0 {
+		return nil
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("media-url"))
+	return mac.Sum(nil)
+}
+
+func (s *LocalStorage) Put(name string, r io.Reader) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see partial uploads
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStorage) Get(name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *LocalStorage) Delete(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List returns the objects whose names start with prefix, sorted by name.
+// Temporary files of uploads in progress are left out.
+func (s *LocalStorage) List(prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	err := filepath.WalkDir(s.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && p != s.dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, ObjectInfo{Name: name, Size: info.Size(), LastModified: info.ModTime().UTC()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+	return objects, nil
+}
+
+// SignedURL returns a URL below the configured prefix carrying the expiry
+// and an HMAC of the name and expiry
+func (s *LocalStorage) SignedURL(name string, ttl time.Duration) (string, error) {
+	if _, err := s.path(name); err != nil {
+		return "", err
+	}
+	if len(s.signingKey) == 0 {
+		return "", errors.New("local storage has no signing key")
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {s.signature(name, expires)}}
+	return s.urlPrefix + "/" + (&url.URL{Path: name}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+func (s *LocalStorage) Verify(name string, query url.Values) bool {
+	if len(s.signingKey) == 0 {
+		return false
+	}
+	expires := query.Get("expires")
+	at, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > at {
+		return false
+	}
+	return hmac.Equal([]byte(query.Get("signature")), []byte(s.signature(name, expires)))
+}
+
+func (s *LocalStorage) signature(name, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(name + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path resolves a name inside the storage directory, rejecting anything that
+// could escape it or clash with temporary files
+func (s *LocalStorage) path(name string) (string, error) {
+	if name == "" || path.Clean(name) != name || strings.HasPrefix(name, "/") || strings.Contains(name, `\`) {
+		return "", errors.New("invalid object name")
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return "", errors.New("invalid object name")
+		}
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(name)), nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStorage(t *testing.T) *LocalStorage {
+	s, err := NewLocalStorage(LocalConfig{Dir: t.TempDir(), URLPrefix: "/media/", SigningKey: []byte("jwt-secret")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// signedQuery returns the query of a signed URL for name
+func signedQuery(t *testing.T, s *LocalStorage, name string, ttl time.Duration) url.Values {
+	signedURL, err := s.SignedURL(name, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(signedURL, "/media/"+name+"?") {
+		t.Fatalf("SignedURL = %q", signedURL)
+	}
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Query()
+}
+
+func TestLocalSignedURLs(t *testing.T) {
+	s := newTestLocalStorage(t)
+
+	query := signedQuery(t, s, "photo.jpg", time.Minute)
+	if !s.Verify("photo.jpg", query) {
+		t.Fatal("a fresh signed URL was rejected")
+	}
+	if s.Verify("other.jpg", query) {
+		t.Error("the signature of one file opened another")
+	}
+
+	later := url.Values{"expires": {"9999999999"}, "signature": query["signature"]}
+	if s.Verify("photo.jpg", later) {
+		t.Error("a signed URL with a moved expiry was accepted")
+	}
+	forged := url.Values{"expires": query["expires"], "signature": {strings.Repeat("0", 64)}}
+	if s.Verify("photo.jpg", forged) {
+		t.Error("a forged signature was accepted")
+	}
+
+	expired := signedQuery(t, s, "photo.jpg", -time.Second)
+	if s.Verify("photo.jpg", expired) {
+		t.Error("an expired signed URL was accepted")
+	}
+}
+
+func TestLocalSignedURLsDoNotUseTheSecret(t *testing.T) {
+	s := newTestLocalStorage(t)
+	expires := signedQuery(t, s, "photo.jpg", time.Minute).Get("expires")
+
+	// A signature made with the secret itself, as anyone holding a key
+	// for another purpose could compute, must not open files
+	mac := hmac.New(sha256.New, []byte("jwt-secret"))
+	mac.Write([]byte("photo.jpg\n" + expires))
+	query := url.Values{"expires": {expires}, "signature": {hex.EncodeToString(mac.Sum(nil))}}
+	if s.Verify("photo.jpg", query) {
+		t.Error("a signature made with the raw secret was accepted")
+	}
+
+	unsigned, err := NewLocalStorage(LocalConfig{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unsigned.SignedURL("photo.jpg", time.Minute); err == nil {
+		t.Error("storage without a signing key signed a URL")
+	}
+}
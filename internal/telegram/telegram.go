@@ -0,0 +1,78 @@
+// Package telegram sends notifications to a chat through the Telegram Bot
+// API
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const apiURL = "https://api.telegram.org/bot"
+
+// Client posts messages to one chat as a bot
+type Client struct {
+	token  string
+	chatID string
+	client *http.Client
+}
+
+// NewClient returns a client for the bot token issued by @BotFather and the
+// chat it reports to, e.g. the numeric ID of a private chat with the bot
+func NewClient(token, chatID string) *Client {
+	return &Client{
+		token:  token,
+		chatID: chatID,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send posts a plain text message to the chat
+func (c *Client) Send(ctx context.Context, text string) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"chat_id":                  c.chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL+c.token+"/sendMessage", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// The request URL carries the token, so keep it out of the error
+		return fmt.Errorf("telegram: sendMessage failed: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Description string `json:"description"`
+		}
+		json.NewDecoder(io.LimitReader(resp.Body, 1<<10)).Decode(&body)
+		return fmt.Errorf("telegram: sendMessage returned %s: %s", resp.Status, strings.TrimSpace(body.Description))
+	}
+	return nil
+}
+
+// unwrapURLError drops the request URL from a client error
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
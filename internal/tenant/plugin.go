@@ -0,0 +1,106 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrMissing is returned for statements on tenant-owned tables whose context
+// names no tenant. Failing is safer than touching every tenant's rows.
+var ErrMissing = errors.New("no tenant in context")
+
+// fieldName is the field that marks a model as owned by a tenant
+const fieldName = "TenantID"
+
+// Plugin scopes statements on models with a TenantID field to the tenant in
+// the statement's context: queries, updates and deletes only match that
+// tenant's rows, and created rows are assigned to it. Raw SQL is not
+// rewritten and must filter by tenant_id itself.
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "tenant"
+}
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenant:create", assign); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:query", scope); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant:row", scope); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:update", func(db *gorm.DB) {
+		// Save writes every column, so keep the tenant of the row
+		assign(db)
+		scope(db)
+	}); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("tenant:delete", scope)
+}
+
+// target returns the tenant column of the statement's model and the tenant
+// to limit it to. ok is false when the statement needs no scoping or has
+// already failed.
+func target(db *gorm.DB) (field *schema.Field, id uint, ok bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, 0, false
+	}
+	field = db.Statement.Schema.LookUpField(fieldName)
+	if field == nil || isAll(db.Statement.Context) {
+		return nil, 0, false
+	}
+
+	id, ok = FromContext(db.Statement.Context)
+	if !ok {
+		db.AddError(fmt.Errorf("%w: %s", ErrMissing, db.Statement.Schema.Table))
+		return nil, 0, false
+	}
+	return field, id, true
+}
+
+func scope(db *gorm.DB) {
+	field, id, ok := target(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: id},
+	}})
+}
+
+// assign sets the tenant on the rows being written
+func assign(db *gorm.DB) {
+	field, id, ok := target(db)
+	if !ok {
+		return
+	}
+
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			setTenant(db, field, reflect.Indirect(rv.Index(i)), id)
+		}
+	case reflect.Struct:
+		setTenant(db, field, rv, id)
+	}
+}
+
+func setTenant(db *gorm.DB, field *schema.Field, rv reflect.Value, id uint) {
+	if rv.Kind() != reflect.Struct || !rv.CanAddr() {
+		return
+	}
+	if err := field.Set(db.Statement.Context, rv, id); err != nil {
+		db.AddError(err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package tenant tells apart the portfolios hosted by one deployment. The
+// tenant a request or job works on travels in its context; the GORM plugin
+// in this package limits every query to that tenant's rows.
+package tenant
+
+import (
+	"context"
+	"strconv"
+)
+
+// DefaultID is the tenant that owns all content created before the
+// deployment hosted several portfolios, and the one served when a request
+// names no other
+const DefaultID uint = 1
+
+type contextKey struct{}
+
+// allTenants marks a context that may read and write every tenant's rows
+const allTenants uint = 0
+
+// WithID returns a copy of ctx scoped to the given tenant
+func WithID(ctx context.Context, id uint) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// All returns a copy of ctx that is not limited to any tenant, for
+// background jobs that work across all of them
+func All(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, allTenants)
+}
+
+// FromContext returns the tenant ctx is scoped to. ok is false when ctx
+// carries no tenant or was made by All.
+func FromContext(ctx context.Context) (id uint, ok bool) {
+	id, found := ctx.Value(contextKey{}).(uint)
+	return id, found && id != allTenants
+}
+
+// isAll reports whether ctx was made by All
+func isAll(ctx context.Context) bool {
+	id, found := ctx.Value(contextKey{}).(uint)
+	return found && id == allTenants
+}
+
+// CacheKey namespaces a Redis key or channel by the tenant in ctx. Keys of
+// the default tenant, and of contexts without a tenant, are left unchanged
+// so single-portfolio deployments keep their existing keys.
+func CacheKey(ctx context.Context, key string) string {
+	id, ok := FromContext(ctx)
+	if !ok || id == DefaultID {
+		return key
+	}
+	return "tenant:" + strconv.FormatUint(uint64(id), 10) + ":" + key
+}
@@ -0,0 +1,61 @@
+package token
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestIssueAndParse(t *testing.T) {
+	signed, err := Issue("secret", Claims{UserID: 3, Username: "ada", Role: "admin", TenantID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := Parse("secret", signed)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if claims.UserID != 3 || claims.Username != "ada" || claims.Role != "admin" || claims.TenantID != 2 {
+		t.Errorf("claims = %+v", claims)
+	}
+}
+
+func TestParseRejectsForgedTokens(t *testing.T) {
+	signed, err := Issue("secret", Claims{UserID: 1, Username: "ada", Role: "admin", TenantID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(signed, ".")
+	otherTenant := base64.RawURLEncoding.EncodeToString([]byte(`{"uid":1,"sub":"ada","role":"admin","tid":2,"exp":9999999999}`))
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	tests := map[string]string{
+		"other secret":      mustIssue(t, "other", Claims{UserID: 1, Username: "ada", Role: "admin", TenantID: 1}),
+		"changed claims":    parts[0] + "." + otherTenant + "." + parts[2],
+		"unsigned":          none + "." + otherTenant + ".",
+		"legacy demo token": "demo-jwt-token-x@2",
+		"empty":             "",
+	}
+	for name, forged := range tests {
+		if _, err := Parse("secret", forged); err == nil {
+			t.Errorf("%s: Parse accepted the token", name)
+		}
+	}
+}
+
+func TestParseRejectsExpiredTokens(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"uid":1,"sub":"ada","role":"admin","tid":1,"exp":1}`))
+	unsigned := header + "." + payload
+	if _, err := Parse("secret", unsigned+"."+sign("secret", unsigned)); err == nil {
+		t.Error("Parse accepted an expired token")
+	}
+}
+
+func mustIssue(t *testing.T, secret string, claims Claims) string {
+	t.Helper()
+	signed, err := Issue(secret, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
@@ -0,0 +1,222 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	notificationChannel = "admin:notifications"
+
+	// Time allowed to write a message to the peer
+	writeWait = 10 * time.Second
+	// Time allowed to read the next pong message from the peer
+	pongWait = 60 * time.Second
+	// Send pings to peer with this period. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+	// Admin clients only send control frames
+	maxMessageSize = 512
+	// Messages buffered per client before it is considered too slow
+	sendBufferSize = 32
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Access is guarded by the admin token, not by the page origin
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Hub keeps track of connected admin clients on this replica and broadcasts
+// notifications to them. Notifications travel through Redis pub/sub so that
+// clients connected to any replica receive every event.
+type Hub struct {
+	redis   *redis.Client
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+}
+
+func NewHub(redis *redis.Client) *Hub {
+	return &Hub{
+		redis:   redis,
+		clients: make(map[*client]struct{}),
+	}
+}
+
+// HandleEvent publishes an event to all replicas. It is registered as an
+// event bus subscriber.
+func (h *Hub) HandleEvent(event events.Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Warning: failed to encode notification %s: %v", event.Type, err)
+		return
+	}
+
+	go func() {
+		if err := h.redis.Publish(context.Background(), notificationChannel, payload).Err(); err != nil {
+			log.Printf("Warning: failed to publish notification %s: %v", event.Type, err)
+		}
+	}()
+}
+
+// Run relays notifications from Redis to the local clients of the tenant
+// each event belongs to until the context is cancelled
+func (h *Hub) Run(ctx context.Context) {
+	pubsub := h.redis.Subscribe(ctx, notificationChannel)
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var event struct {
+				TenantID uint `json:"tenant_id"`
+			}
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				continue
+			}
+			h.broadcast(event.TenantID, []byte(msg.Payload))
+		}
+	}
+}
+
+// Count returns the number of clients connected to this replica
+func (h *Hub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+// ServeWS upgrades the request to a WebSocket and registers the client for
+// the notifications of the request's tenant
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request) error {
+	tenantID, ok := tenant.FromContext(r.Context())
+	if !ok {
+		tenantID = tenant.DefaultID
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+
+	c := &client{
+		hub:      h,
+		tenantID: tenantID,
+		conn:     conn,
+		send:     make(chan []byte, sendBufferSize),
+	}
+	h.register(c)
+
+	go c.writePump()
+	go c.readPump()
+	return nil
+}
+
+func (h *Hub) register(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[c] = struct{}{}
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		close(c.send)
+	}
+}
+
+func (h *Hub) broadcast(tenantID uint, message []byte) {
+	h.mu.RLock()
+	var slow []*client
+	for c := range h.clients {
+		if c.tenantID != tenantID {
+			continue
+		}
+		select {
+		case c.send <- message:
+		default:
+			slow = append(slow, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	// Drop clients that cannot keep up rather than blocking everyone
+	for _, c := range slow {
+		h.unregister(c)
+	}
+}
+
+// client is a single admin WebSocket connection
+type client struct {
+	hub      *Hub
+	tenantID uint
+	conn     *websocket.Conn
+	send     chan []byte
+}
+
+// readPump processes control frames and detects dead peers through the
+// pong deadline
+func (c *client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writePump delivers queued messages and sends heartbeat pings
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				// The hub closed the channel
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -1,16 +1,48 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"net/http/pprof"
 	"os"
+	"os/signal"
+	"stackwhiz-portfolio-backend/docs"
+	"stackwhiz-portfolio-backend/internal/akismet"
 	"stackwhiz-portfolio-backend/internal/api"
+	"stackwhiz-portfolio-backend/internal/captcha"
 	"stackwhiz-portfolio-backend/internal/config"
 	"stackwhiz-portfolio-backend/internal/database"
+	"stackwhiz-portfolio-backend/internal/events"
+	"stackwhiz-portfolio-backend/internal/geoip"
+	"stackwhiz-portfolio-backend/internal/github"
+	"stackwhiz-portfolio-backend/internal/httpcache"
+	"stackwhiz-portfolio-backend/internal/llm"
+	"stackwhiz-portfolio-backend/internal/mailer"
+	"stackwhiz-portfolio-backend/internal/meilisearch"
 	"stackwhiz-portfolio-backend/internal/middleware"
+	"stackwhiz-portfolio-backend/internal/oembed"
+	"stackwhiz-portfolio-backend/internal/reporting"
 	"stackwhiz-portfolio-backend/internal/repository"
+	"stackwhiz-portfolio-backend/internal/sanitize"
 	"stackwhiz-portfolio-backend/internal/service"
+	"stackwhiz-portfolio-backend/internal/slo"
+	"stackwhiz-portfolio-backend/internal/storage"
+	"stackwhiz-portfolio-backend/internal/telegram"
+	"stackwhiz-portfolio-backend/internal/tenant"
+	"stackwhiz-portfolio-backend/internal/ws"
+	"stackwhiz-portfolio-backend/web"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 // @title Portfolio API
@@ -34,17 +66,122 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
+	// Cancelled on SIGINT/SIGTERM to stop background workers and drain the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Report panics and server errors to Sentry
+	if err := reporting.Init(cfg.SentryDSN, cfg.Environment, cfg.SentryRelease); err != nil {
+		log.Fatal("Invalid SENTRY_DSN:", err)
+	}
 
-	// Initialize database
-	db, err := database.Initialize(cfg.DatabaseURL)
+	// Initialize database; embedded mode keeps everything in a SQLite file
+	dialector := database.Postgres(cfg.DatabaseURL)
+	if cfg.Embedded {
+		dialector, err = database.SQLite(cfg.SQLitePath)
+		if err != nil {
+			log.Fatal("Failed to open SQLite database:", err)
+		}
+	}
+	// Rich-text fields are sanitized as they are written
+	sanitizePolicies, err := sanitize.ParsePolicies(cfg.SanitizePolicies)
+	if err != nil {
+		log.Fatal("Invalid SANITIZE_POLICIES:", err)
+	}
+	db, err := database.Initialize(dialector, cfg.ReplicaURL, cfg.SeedFile, sanitize.NewPlugin(sanitizePolicies))
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	// Initialize Redis
-	redisClient := database.InitializeRedis(cfg.RedisURL)
+	// Initialize Redis; embedded mode runs an in-process replacement
+	var redisClient *redis.Client
+	if cfg.Embedded {
+		redisClient, err = database.InitializeMemoryRedis(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		redisClient = database.InitializeRedis(cfg.RedisURL)
+	}
+
+	// Initialize mailer
+	mail := mailer.NewMailer(cfg)
+
+	var autoReply *service.AutoReply
+	if cfg.AutoReplyEnabled {
+		autoReply, err = service.NewAutoReply(cfg.AutoReplySubject, cfg.AutoReplyTemplate)
+		if err != nil {
+			log.Fatal("Invalid AUTO_REPLY_TEMPLATE:", err)
+		}
+	}
+
+	var captchaVerifier *captcha.Verifier
+	if cfg.CaptchaProvider != "" && !cfg.CaptchaBypass {
+		captchaVerifier, err = captcha.NewVerifier(cfg.CaptchaProvider, cfg.CaptchaSecret)
+		if err != nil {
+			log.Fatal("Invalid captcha configuration:", err)
+		}
+	} else {
+		log.Println("Warning: captcha verification is disabled for the contact form")
+	}
+
+	// Initialize contact and login IP geolocation
+	var locator *geoip.Locator
+	if cfg.GeoIPProvider != "" {
+		locator, err = geoip.NewLocator(cfg.GeoIPProvider, cfg.GeoIPToken)
+		if err != nil {
+			log.Fatal("Invalid geolocation configuration:", err)
+		}
+	}
+
+	// Initialize contact spam checks
+	var akismetClient *akismet.Client
+	if cfg.AkismetAPIKey != "" {
+		akismetClient = akismet.NewClient(cfg.AkismetAPIKey, cfg.SiteURL)
+	}
+
+	// Initialize the external search engine
+	var searchEngine *meilisearch.Client
+	if cfg.MeilisearchURL != "" {
+		searchEngine = meilisearch.NewClient(cfg.MeilisearchURL, cfg.MeilisearchAPIKey)
+	}
+
+	// Initialize the language model drafting copy and answering visitors
+	var llmClient *llm.Client
+	if cfg.LLMProvider != "" {
+		llmClient = llm.NewClient(cfg.LLMProvider, cfg.LLMAPIKey, cfg.LLMModel, cfg.LLMBaseURL)
+	}
+	var embeddingClient *llm.Client
+	if cfg.EmbeddingModel != "" {
+		embeddingClient = llm.NewClient(llm.OpenAI, cfg.EmbeddingAPIKey, cfg.EmbeddingModel, cfg.EmbeddingBaseURL)
+	}
+
+	// Initialize media storage
+	mediaStorage, err := storage.New(storageConfig(cfg, cfg.MediaStorage, cfg.MediaDir, "/media"))
+	if err != nil {
+		log.Fatal("Failed to initialize media storage:", err)
+	}
+
+	// Initialize SLO tracking
+	sloTargets, err := slo.ParseTargets(cfg.SLOTargets)
+	if err != nil {
+		log.Fatal("Invalid SLO_TARGETS:", err)
+	}
+	sloTracker := slo.NewTracker(redisClient, cfg.SLOTarget, sloTargets, time.Duration(cfg.SLOLatencyThresholdMS)*time.Millisecond)
+	sloTracker.OnAlert(slo.LogHook)
+	if cfg.SLOAlertWebhookURL != "" {
+		sloTracker.OnAlert(slo.WebhookHook(cfg.SLOAlertWebhookURL))
+	}
+	go sloTracker.Run(ctx, time.Minute)
+
+	// Initialize event bus
+	eventBus := events.NewBus()
 
 	// Initialize repositories
 	profileRepo := repository.NewProfileRepository(db)
@@ -52,14 +189,178 @@ func main() {
 	skillRepo := repository.NewSkillRepository(db)
 	projectRepo := repository.NewProjectRepository(db)
 	contactRepo := repository.NewContactRepository(db)
+	slotRepo := repository.NewSlotRepository(db)
+	embedTokenRepo := repository.NewEmbedTokenRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	postRepo := repository.NewPostRepository(db)
+	testimonialRepo := repository.NewTestimonialRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	educationRepo := repository.NewEducationRepository(db)
+	certificationRepo := repository.NewCertificationRepository(db)
+	publicationRepo := repository.NewPublicationRepository(db)
+	awardRepo := repository.NewAwardRepository(db)
+	offeringRepo := repository.NewServiceRepository(db)
+	talkRepo := repository.NewTalkRepository(db)
+	mediaRepo := repository.NewMediaRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	companyRepo := repository.NewCompanyRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	dashboardRepo := repository.NewDashboardRepository(db)
+	tenantRepo := repository.NewTenantRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	ipRuleRepo := repository.NewIPRuleRepository(db)
+	shortLinkRepo := repository.NewShortLinkRepository(db)
+	resumeRepo := repository.NewResumeRepository(db)
+	loginEventRepo := repository.NewLoginEventRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	embeddingRepo := repository.NewEmbeddingRepository(db)
+	newsletterRepo := repository.NewNewsletterRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
+
+	// Send mail and Telegram messages through the job queue so failed sends
+	// are retried
+	var telegramClient *telegram.Client
+	if cfg.TelegramBotToken != "" {
+		telegramClient = telegram.NewClient(cfg.TelegramBotToken, cfg.TelegramChatID)
+	}
+	jobService := service.NewJobService(redisClient, mail, telegramClient)
 
 	// Initialize services
-	profileService := service.NewProfileService(profileRepo, redisClient)
-	experienceService := service.NewExperienceService(experienceRepo, redisClient)
-	skillService := service.NewSkillService(skillRepo, redisClient)
-	projectService := service.NewProjectService(projectRepo, redisClient)
-	contactService := service.NewContactService(contactRepo, redisClient)
-	authService := service.NewAuthService(cfg.JWTSecret)
+	mediaService := service.NewMediaService(mediaRepo, mediaStorage, int64(cfg.MediaMaxSizeMB)<<20)
+	profileService := service.NewProfileService(profileRepo, redisClient, eventBus, mediaService)
+	experienceService := service.NewExperienceService(experienceRepo, redisClient, eventBus)
+	skillService := service.NewSkillService(skillRepo, experienceRepo, redisClient, eventBus)
+	projectService := service.NewProjectService(projectRepo, redisClient, eventBus, oembed.NewClient())
+	contactService := service.NewContactService(contactRepo, redisClient, mail, jobService, eventBus, cfg.OwnerEmail, autoReply, captchaVerifier, locator, akismetClient)
+	authService := service.NewAuthService(userRepo, loginEventRepo, cfg.JWTSecret, redisClient, jobService, eventBus, locator, service.LoginAlerts{
+		On:       cfg.LoginAlerts,
+		Email:    cfg.OwnerEmail,
+		Telegram: telegramClient != nil,
+	})
+	hasAdmin, err := authService.EnsureAdmin(tenant.WithID(ctx, tenant.DefaultID), cfg.AdminUsername, cfg.AdminEmail, cfg.AdminPassword)
+	if err != nil {
+		log.Fatalf("Failed to create admin user: %v", err)
+	}
+	if !hasAdmin {
+		log.Printf("Warning: no admin user exists; set ADMIN_PASSWORD to create one, logins are refused until then")
+	}
+	slotService := service.NewSlotService(slotRepo, redisClient, jobService, eventBus, cfg.OwnerEmail)
+	embedService := service.NewEmbedService(embedTokenRepo, redisClient)
+	webhookService := service.NewWebhookService(webhookRepo)
+	postService := service.NewPostService(postRepo, redisClient, eventBus)
+	testimonialService := service.NewTestimonialService(testimonialRepo, redisClient, eventBus)
+	commentService := service.NewCommentService(commentRepo, redisClient, eventBus, projectService, postService, akismetClient)
+	educationService := service.NewEducationService(educationRepo, redisClient, eventBus)
+	certificationService := service.NewCertificationService(certificationRepo, redisClient, eventBus, jobService, service.CertificationReminders{
+		Days:     cfg.CertificationReminderDays,
+		Email:    cfg.OwnerEmail,
+		Telegram: telegramClient != nil,
+	}, cfg.ExpiredCertifications == "hide")
+	publicationService := service.NewPublicationService(publicationRepo, redisClient, eventBus)
+	awardService := service.NewAwardService(awardRepo, redisClient, eventBus)
+	offeringService := service.NewOfferingService(offeringRepo, redisClient, eventBus)
+	talkService := service.NewTalkService(talkRepo, redisClient, eventBus)
+	categoryService := service.NewCategoryService(categoryRepo, redisClient, eventBus)
+	companyService := service.NewCompanyService(companyRepo, redisClient, eventBus)
+	tagService := service.NewTagService(tagRepo, redisClient, eventBus)
+	dashboardService := service.NewDashboardService(dashboardRepo, redisClient)
+	tenantService := service.NewTenantService(tenantRepo, redisClient)
+	ipRuleService := service.NewIPRuleService(ipRuleRepo, redisClient)
+	shortLinkService := service.NewShortLinkService(shortLinkRepo, redisClient)
+	searchService := service.NewSearchService(searchRepo, jobService, searchEngine, cfg.MeilisearchIndex)
+	embeddingService := service.NewEmbeddingService(embeddingRepo, jobService, embeddingClient)
+	transferService := service.NewTransferService(unitOfWork, redisClient)
+	mediaService.OnVariantsReady(projectService.RefreshImageVariants)
+	portfolioService := service.NewPortfolioService(
+		redisClient,
+		profileService,
+		experienceService,
+		educationService,
+		skillService,
+		projectService,
+		certificationService,
+		publicationService,
+		awardService,
+		testimonialService,
+		talkService,
+		tenantService,
+		cfg.SiteURL,
+	)
+	resumeService := service.NewResumeService(portfolioService, resumeRepo, unitOfWork, redisClient, eventBus)
+
+	// Run queued jobs, retrying failures until they are dead-lettered
+	go jobService.Run(ctx)
+
+	// Deliver content change events to webhook subscribers
+	eventBus.Subscribe(webhookService.HandleEvent)
+	go webhookService.Run(ctx)
+
+	// Keep recent events for the admin dashboard activity feed
+	eventBus.Subscribe(dashboardService.RecordEvent)
+
+	// Write skill endorsements and project likes collected in Redis to the
+	// database
+	go skillService.RunEndorsementFlush(ctx, time.Minute)
+	go projectService.RunLikeFlush(ctx, time.Minute)
+
+	// Remind the owner of certifications about to expire
+	go certificationService.RunExpiryCheck(ctx)
+
+	// Index content changes in the search engine
+	eventBus.Subscribe(searchService.HandleEvent)
+	if err := searchService.Setup(ctx); err != nil {
+		log.Printf("Warning: failed to set up the search index: %v", err)
+	}
+
+	// Embed content changes for semantic search
+	eventBus.Subscribe(embeddingService.HandleEvent)
+	if err := embeddingService.Setup(ctx); err != nil {
+		log.Printf("Warning: failed to queue embedding sync: %v", err)
+	}
+
+	// Keep the most visited listings cached from boot onwards
+	cacheWarmer := service.NewCacheWarmer(tenantService, profileService, experienceService, skillService, projectService)
+	eventBus.Subscribe(cacheWarmer.HandleEvent)
+	go cacheWarmer.Run(ctx)
+
+	// Refresh repository statistics for projects linked to GitHub
+	githubClient := github.NewClient(cfg.GitHubToken)
+	githubService := service.NewGitHubService(projectRepo, redisClient, githubClient, cfg.GitHubUsername)
+	go githubService.RunProjectSync(ctx, time.Duration(cfg.GitHubSyncIntervalMins)*time.Minute)
+	assistantService := service.NewAssistantService(projectRepo, profileRepo, githubClient, llmClient)
+	chatService := service.NewChatService(portfolioService, embeddingService, redisClient, llmClient)
+	newsletterService := service.NewNewsletterService(newsletterRepo, unitOfWork, jobService, mail, redisClient, tenantService, cfg.SiteURL, cfg.NewsletterRatePerMinute)
+
+	// Back up the database to storage on a cron schedule
+	var backupStorage storage.Storage
+	if cfg.BackupSchedule != "" {
+		backupStorage, err = storage.New(storageConfig(cfg, cfg.BackupStorage, cfg.BackupDir, ""))
+		if err != nil {
+			log.Fatal("Invalid backup storage configuration:", err)
+		}
+	}
+	backupService, err := service.NewBackupService(db, cfg.DatabaseURL, backupStorage, redisClient, cfg.BackupSchedule, cfg.BackupKeep)
+	if err != nil {
+		log.Fatal("Invalid BACKUP_SCHEDULE:", err)
+	}
+	go backupService.Run(ctx)
+
+	// Push new contacts to connected admin dashboards on every replica
+	contactStreamService := service.NewContactStreamService(redisClient)
+	eventBus.Subscribe(contactStreamService.HandleEvent)
+
+	// Broadcast every event to admin WebSocket clients on every replica
+	hub := ws.NewHub(redisClient)
+	eventBus.Subscribe(hub.HandleEvent)
+	go hub.Run(ctx)
+
+	// Apply rate limits, CORS origins, cache TTLs and feature flags again
+	// when the config file changes
+	liveConfig := config.NewLive(cfg)
+	liveConfig.OnChange(func(runtime *config.Runtime) {
+		service.SetCacheTTL(time.Duration(runtime.CacheTTLSeconds) * time.Second)
+	})
+	go liveConfig.Run(ctx)
 
 	// Initialize handlers
 	handlers := api.NewHandlers(
@@ -69,78 +370,470 @@ func main() {
 		projectService,
 		contactService,
 		authService,
+		slotService,
+		embedService,
+		sloTracker,
+		webhookService,
+		contactStreamService,
+		hub,
+		postService,
+		testimonialService,
+		commentService,
+		educationService,
+		certificationService,
+		publicationService,
+		awardService,
+		portfolioService,
+		offeringService,
+		talkService,
+		mediaService,
+		resumeService,
+		categoryService,
+		companyService,
+		tagService,
+		dashboardService,
+		githubService,
+		transferService,
+		backupService,
+		tenantService,
+		ipRuleService,
+		jobService,
+		shortLinkService,
+		searchService,
+		embeddingService,
+		assistantService,
+		chatService,
+		newsletterService,
+		liveConfig,
 	)
 
 	// Setup router
-	router := setupRouter(handlers, cfg)
+	router := setupRouter(handlers, tenantService, ipRuleService, redisClient, cfg, liveConfig, sloTracker)
 
 	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	if err := serve(ctx, router, cfg); err != nil {
+		reporting.Flush(2 * time.Second)
+		log.Fatal("Failed to start server:", err)
 	}
+	log.Println("Server stopped")
+}
 
-	log.Printf("Server starting on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+// storageConfig selects a storage backend. Local storage keeps files in dir
+// and signs URLs below urlPrefix with a key derived from the JWT secret,
+// while media and backups on the same bucket are told apart by the
+// backups/ prefix.
+func storageConfig(cfg *config.Config, backend, dir, urlPrefix string) storage.Config {
+	return storage.Config{
+		Backend: backend,
+		Local: storage.LocalConfig{
+			Dir:        dir,
+			URLPrefix:  urlPrefix,
+			SigningKey: []byte(cfg.JWTSecret),
+		},
+		S3: storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		},
+		GCS: storage.GCSConfig{
+			Bucket:    cfg.GCSBucket,
+			AccessKey: cfg.GCSAccessKey,
+			SecretKey: cfg.GCSSecretKey,
+		},
 	}
 }
 
-func setupRouter(handlers *api.Handlers, cfg *config.Config) *gin.Engine {
+// shutdownTimeout bounds how long in-flight requests may run on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// serve runs the API over plain HTTP, or with TLS_DOMAINS set over HTTPS and
+// HTTP/2 with certificates obtained and renewed from Let's Encrypt. In HTTPS
+// mode a second listener answers ACME challenges and redirects everything
+// else to HTTPS. The server drains in-flight requests once ctx is cancelled.
+func serve(ctx context.Context, router http.Handler, cfg *config.Config) error {
+	hosts := cfg.TLSHosts()
+	if len(hosts) == 0 {
+		server := &http.Server{Addr: ":" + cfg.Port, Handler: router}
+		log.Printf("Server starting on port %s", cfg.Port)
+		return shutdownOnDone(ctx, server, server.ListenAndServe)
+	}
+
+	certManager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(hosts...),
+		Cache:      autocert.DirCache(cfg.TLSCacheDir),
+		Email:      cfg.TLSEmail,
+	}
+
+	go func() {
+		log.Printf("Redirecting HTTP on port %s to HTTPS", cfg.TLSHTTPPort)
+		if err := http.ListenAndServe(":"+cfg.TLSHTTPPort, certManager.HTTPHandler(nil)); err != nil {
+			log.Fatal("Failed to start HTTP redirect server:", err)
+		}
+	}()
+
+	server := &http.Server{
+		Addr:      ":" + cfg.TLSPort,
+		Handler:   router,
+		TLSConfig: certManager.TLSConfig(),
+	}
+	log.Printf("Server starting on port %s with TLS for %s", cfg.TLSPort, strings.Join(hosts, ", "))
+	return shutdownOnDone(ctx, server, func() error { return server.ListenAndServeTLS("", "") })
+}
+
+// shutdownOnDone runs listen until ctx is cancelled, then gives in-flight
+// requests up to shutdownTimeout to finish before closing the server
+func shutdownOnDone(ctx context.Context, server *http.Server, listen func() error) error {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Warning: graceful shutdown failed: %v", err)
+		}
+	}()
+
+	if err := listen(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-stopped
+	return nil
+}
+
+func setupRouter(handlers *api.Handlers, tenantService *service.TenantService, ipRuleService *service.IPRuleService, redisClient *redis.Client, cfg *config.Config, liveConfig *config.Live, sloTracker *slo.Tracker) *gin.Engine {
 	// Set Gin mode
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
+	// Without TRUSTED_PROXIES no peer may name the client address, so
+	// X-Forwarded-For cannot be used to dodge rate limits and IP rules
+	if err := router.SetTrustedProxies(cfg.TrustedProxyList()); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
 
 	// Middleware
-	router.Use(gin.Logger())
-	router.Use(gin.Recovery())
-	router.Use(middleware.CORS())
-	router.Use(middleware.RateLimit())
+	router.Use(middleware.Logger())
+	router.Use(gin.CustomRecovery(reporting.Recovery))
+	router.Use(reporting.Errors())
+	router.Use(middleware.CORS(liveConfig.CORSOrigins))
+	router.Use(middleware.RateLimit(liveConfig.RateLimit))
 	router.Use(middleware.SecurityHeaders())
+	router.Use(middleware.DenyIPs(ipRuleService.Blocked))
+	router.Use(middleware.SLO(sloTracker))
+	// The chat answer and the contact feed stream for longer than any timeout
+	router.Use(middleware.Timeout(time.Duration(cfg.RequestTimeoutSeconds)*time.Second, "/chat", "/admin/contacts/stream"))
 
 	// Health check
 	router.GET("/health", handlers.HealthCheck)
 
-	// API routes
-	v1 := router.Group("/api/v1")
-	{
-		// Public routes
-		public := v1.Group("/")
+	// Uploaded media
+	router.GET("/media/:name", handlers.ServeMedia)
+
+	// Prometheus metrics
+	if cfg.MetricsEnabled {
+		router.GET("/metrics", middleware.StaticToken(cfg.MetricsToken), gin.WrapH(promhttp.Handler()))
+	}
+
+	// Runtime profiles for diagnosing a misbehaving server, e.g.
+	// `go tool pprof -http=: http://host/debug/pprof/heap` with the admin
+	// token in an Authorization header
+	if cfg.PprofEnabled {
+		profiles := router.Group("/debug/pprof")
+		profiles.Use(middleware.AllowIPs(ipRuleService.AdminAllowed), middleware.AuthMiddleware(cfg.JWTSecret), middleware.DefaultTenantOnly())
 		{
-			public.GET("/profile", handlers.GetProfile)
-			public.GET("/experiences", handlers.GetExperiences)
-			public.GET("/skills", handlers.GetSkills)
-			public.GET("/projects", handlers.GetProjects)
-			public.POST("/contact", handlers.CreateContact)
+			profiles.GET("/", gin.WrapF(pprof.Index))
+			profiles.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+			profiles.GET("/profile", gin.WrapF(pprof.Profile))
+			profiles.GET("/symbol", gin.WrapF(pprof.Symbol))
+			profiles.POST("/symbol", gin.WrapF(pprof.Symbol))
+			profiles.GET("/trace", gin.WrapF(pprof.Trace))
+			profiles.GET("/:name", gin.WrapF(pprof.Index))
 		}
+	}
 
-		// Admin routes (protected)
-		admin := v1.Group("/admin")
-		admin.Use(middleware.AuthMiddleware(cfg.JWTSecret))
-		{
-			admin.PUT("/profile", handlers.UpdateProfile)
-			admin.POST("/experiences", handlers.CreateExperience)
-			admin.PUT("/experiences/:id", handlers.UpdateExperience)
-			admin.DELETE("/experiences/:id", handlers.DeleteExperience)
-			admin.POST("/skills", handlers.CreateSkill)
-			admin.PUT("/skills/:id", handlers.UpdateSkill)
-			admin.DELETE("/skills/:id", handlers.DeleteSkill)
-			admin.POST("/projects", handlers.CreateProject)
-			admin.PUT("/projects/:id", handlers.UpdateProject)
-			admin.DELETE("/projects/:id", handlers.DeleteProject)
-			admin.GET("/contacts", handlers.GetContacts)
-			admin.PUT("/contacts/:id/status", handlers.UpdateContactStatus)
+	// Interactive API documentation generated by `make docs`
+	if cfg.DocsEnabled {
+		// Let Swagger UI call the host it was loaded from
+		docs.SwaggerInfo.Host = ""
+		router.GET("/docs", func(c *gin.Context) {
+			c.Redirect(http.StatusMovedPermanently, "/docs/index.html")
+		})
+		router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+
+	// Built frontend for every path the API does not handle
+	if cfg.FrontendEnabled {
+		frontend, err := web.Handler()
+		if err != nil {
+			log.Fatal("Failed to serve frontend:", err)
 		}
+		router.NoRoute(frontend)
+	}
+
+	// Each tenant is served on its own domains and under /t/{slug} on any
+	// host; requests to other hosts go to the default tenant
+	for _, prefix := range []string{"", "/t/:tenant"} {
+		site := router.Group(prefix)
+		site.Use(middleware.Tenant(tenantService.Resolve))
 
-		// Auth routes
-		auth := v1.Group("/auth")
+		// Embeddable widgets (token and origin checked per request)
+		embed := site.Group("/embed")
+		embed.Use(middleware.Feature("widgets", liveConfig.Enabled))
 		{
-			auth.POST("/login", handlers.Login)
+			embed.GET("/projects", handlers.EmbedProjects)
+			embed.GET("/skills", handlers.EmbedSkills)
 		}
+
+		// Short links shared outside the site
+		site.GET("/l/:code", handlers.FollowShortLink)
+
+		// API routes
+		v1 := site.Group("/api/v1")
+		v1.Use(middleware.APIVersion(api.V1, api.V1, api.V2))
+		registerRoutes(v1, handlers, ipRuleService, redisClient, cfg, liveConfig)
+
+		// v2 shares the handlers with v1 but uses enveloped responses,
+		// pagination metadata and RFC 7807 errors
+		v2 := site.Group("/api/v2")
+		v2.Use(middleware.APIVersion(api.V2, api.V1, api.V2))
+		registerRoutes(v2, handlers, ipRuleService, redisClient, cfg, liveConfig)
 	}
 
 	return router
 }
+
+// registerRoutes mounts the versioned API routes onto the given group
+func registerRoutes(group *gin.RouterGroup, handlers *api.Handlers, ipRuleService *service.IPRuleService, redisClient *redis.Client, cfg *config.Config, liveConfig *config.Live) {
+	feature := func(name string) gin.HandlerFunc {
+		return middleware.Feature(name, liveConfig.Enabled)
+	}
+	responseCache := func(resource string, params ...string) gin.HandlerFunc {
+		if !cfg.ResponseCacheEnabled {
+			return func(c *gin.Context) { c.Next() }
+		}
+		return httpcache.Middleware(redisClient, resource, liveConfig.CacheTTL, params...)
+	}
+	adminIPs := middleware.AllowIPs(ipRuleService.AdminAllowed)
+	// CACHE_CONTROL_ROUTES was validated when the config was loaded
+	cacheRoutes, _ := middleware.ParseCacheRoutes(cfg.CacheControlRoutes)
+	cachePolicy := middleware.CachePolicy{
+		Default: cfg.CacheControl,
+		Vary:    cfg.CacheControlVary,
+		Routes:  cacheRoutes,
+	}
+	bodyLimit := func(maxBytes int64) gin.HandlerFunc {
+		return middleware.BodyLimit(maxBytes, cfg.MaxJSONDepth)
+	}
+
+	// Public routes
+	public := group.Group("/")
+	public.Use(bodyLimit(int64(cfg.MaxBodyKB)<<10), middleware.OptionalAuth(cfg.JWTSecret), middleware.CacheControl(cachePolicy))
+	{
+		public.GET("/portfolio", responseCache("portfolio", "render"), handlers.GetPortfolio)
+		public.GET("/timeline", handlers.GetTimeline)
+		public.GET("/resume", handlers.ExportResume)
+		public.GET("/resumes", handlers.GetResumes)
+		public.POST("/chat", handlers.Chat)
+		public.GET("/profile", responseCache("profile", "render"), handlers.GetProfile)
+		public.GET("/profile/qr.png", handlers.GetProfileQRPNG)
+		public.GET("/profile/qr.svg", handlers.GetProfileQRSVG)
+		public.GET("/experiences", responseCache("experiences", "page", "per_page"), handlers.GetExperiences)
+		public.GET("/skills", responseCache("skills", "page", "per_page"), handlers.GetSkills)
+		public.GET("/skills/grouped", responseCache("skills", "page", "per_page"), handlers.GetSkillGroups)
+		public.GET("/skills/categories", responseCache("skills", "page", "per_page"), handlers.GetSkillCategories)
+		public.GET("/skills/:id/projects", handlers.GetSkillProjects)
+		public.POST("/skills/:id/endorse", feature("endorsements"), handlers.EndorseSkill)
+		public.GET("/projects", responseCache("projects", "featured", "category_id", "tag", "order", "limit", "render", "page", "per_page"), handlers.GetProjects)
+		public.GET("/projects/:id", handlers.GetProject)
+		public.GET("/projects/:id/skills", handlers.GetProjectSkills)
+		public.GET("/projects/:id/og", handlers.GetProjectOpenGraph)
+		public.POST("/projects/:id/like", feature("likes"), handlers.LikeProject)
+		public.GET("/projects/:id/comments", feature("comments"), handlers.GetProjectComments)
+		public.POST("/projects/:id/comments", feature("comments"), handlers.CreateProjectComment)
+		public.GET("/posts", handlers.GetPosts)
+		public.GET("/posts/:slug", handlers.GetPost)
+		public.GET("/posts/:slug/comments", feature("comments"), handlers.GetPostComments)
+		public.POST("/posts/:slug/comments", feature("comments"), handlers.CreatePostComment)
+		public.GET("/testimonials", handlers.GetTestimonials)
+		public.GET("/education", handlers.GetEducation)
+		public.GET("/certifications", handlers.GetCertifications)
+		public.GET("/publications", handlers.GetPublications)
+		public.GET("/awards", handlers.GetAwards)
+		public.GET("/services", handlers.GetServices)
+		public.GET("/talks", handlers.GetTalks)
+		public.GET("/categories", handlers.GetCategories)
+		public.GET("/companies", handlers.GetCompanies)
+		public.GET("/companies/:id", handlers.GetCompany)
+		public.GET("/tags", handlers.GetTags)
+		public.GET("/search", handlers.Search)
+		public.GET("/search/semantic", handlers.SemanticSearch)
+		public.GET("/activity/github", feature("github_activity"), handlers.GetGitHubActivity)
+		public.POST("/contact", feature("contact_form"), bodyLimit(int64(cfg.ContactMaxBodyKB)<<10), handlers.CreateContact)
+		public.GET("/slots", feature("bookings"), handlers.GetAvailableSlots)
+		public.POST("/slots/:id/book", feature("bookings"), handlers.BookSlot)
+		public.POST("/newsletter/subscribe", feature("newsletter"), handlers.SubscribeNewsletter)
+		public.GET("/newsletter/confirm", feature("newsletter"), middleware.NoStore(), handlers.ConfirmNewsletter)
+		public.GET("/newsletter/unsubscribe", middleware.NoStore(), handlers.UnsubscribeNewsletter)
+		public.POST("/newsletter/unsubscribe", handlers.UnsubscribeNewsletter)
+	}
+
+	// Admin WebSocket (token may be passed as a query parameter)
+	group.GET("/admin/ws", adminIPs, middleware.QueryToken(), middleware.AuthMiddleware(cfg.JWTSecret), handlers.AdminWebSocket)
+
+	// Admin routes (protected)
+	admin := group.Group("/admin")
+	admin.Use(adminIPs, middleware.NoStore(), middleware.AuthMiddleware(cfg.JWTSecret), bodyLimit(int64(cfg.AdminMaxBodyMB)<<20))
+	{
+		admin.PUT("/profile", handlers.UpdateProfile)
+		admin.POST("/profile/avatar", bodyLimit(int64(cfg.MediaMaxSizeMB+1)<<20), handlers.UploadAvatar)
+		admin.POST("/profile/summary/suggestion", handlers.SuggestProfileSummary)
+		admin.POST("/experiences", handlers.CreateExperience)
+		admin.PUT("/experiences/reorder", handlers.ReorderExperiences)
+		admin.PUT("/experiences/:id", handlers.UpdateExperience)
+		admin.DELETE("/experiences/:id", handlers.DeleteExperience)
+		admin.POST("/skills", handlers.CreateSkill)
+		admin.PUT("/skills/reorder", handlers.ReorderSkills)
+		admin.GET("/skills/suggestions", handlers.GetSkillSuggestions)
+		admin.POST("/skills/suggestions/accept", handlers.AcceptSkillSuggestions)
+		admin.POST("/skills/categories/rename", handlers.RenameSkillCategory)
+		admin.POST("/skills/categories/merge", handlers.MergeSkillCategories)
+		admin.POST("/skills/merge", handlers.MergeSkills)
+		admin.PUT("/skills/:id", handlers.UpdateSkill)
+		admin.DELETE("/skills/:id", handlers.DeleteSkill)
+		admin.POST("/projects", handlers.CreateProject)
+		admin.PUT("/projects/featured/reorder", handlers.ReorderFeaturedProjects)
+		admin.GET("/projects/likes", handlers.GetMostLikedProjects)
+		admin.PUT("/projects/:id", handlers.UpdateProject)
+		admin.DELETE("/projects/:id", handlers.DeleteProject)
+		admin.PUT("/projects/:id/archive", handlers.ArchiveProject)
+		admin.POST("/projects/:id/description/suggestion", handlers.SuggestProjectDescription)
+		admin.PUT("/projects/:id/unarchive", handlers.UnarchiveProject)
+		admin.POST("/projects/:id/images", handlers.AddProjectImage)
+		admin.PUT("/projects/:id/images/reorder", handlers.ReorderProjectImages)
+		admin.DELETE("/projects/:id/images/:image_id", handlers.DeleteProjectImage)
+		admin.POST("/projects/:id/milestones", handlers.CreateProjectMilestone)
+		admin.PUT("/projects/:id/milestones/:milestone_id", handlers.UpdateProjectMilestone)
+		admin.DELETE("/projects/:id/milestones/:milestone_id", handlers.DeleteProjectMilestone)
+		admin.GET("/contacts", handlers.GetContacts)
+		admin.GET("/contacts/stream", handlers.StreamContacts)
+		admin.GET("/contacts/export", handlers.ExportContacts)
+		admin.PUT("/contacts/:id/status", handlers.UpdateContactStatus)
+		admin.POST("/contacts/:id/spam", handlers.MarkContactSpam)
+		admin.POST("/contacts/:id/ham", handlers.MarkContactHam)
+		admin.POST("/contacts/:id/reply", handlers.ReplyToContact)
+		admin.GET("/contacts/:id/messages", handlers.GetContactMessages)
+		admin.POST("/contacts/:id/notes", handlers.CreateContactNote)
+		admin.GET("/contacts/:id/notes", handlers.GetContactNotes)
+		admin.GET("/slots", handlers.GetSlots)
+		admin.POST("/slots", handlers.CreateSlot)
+		admin.PUT("/slots/:id", handlers.UpdateSlot)
+		admin.DELETE("/slots/:id", handlers.DeleteSlot)
+		admin.GET("/embed-tokens", handlers.GetEmbedTokens)
+		admin.POST("/embed-tokens", handlers.CreateEmbedToken)
+		admin.DELETE("/embed-tokens/:id", handlers.DeleteEmbedToken)
+		admin.GET("/short-links", handlers.GetShortLinks)
+		admin.POST("/short-links", handlers.CreateShortLink)
+		admin.PUT("/short-links/:id", handlers.UpdateShortLink)
+		admin.DELETE("/short-links/:id", handlers.DeleteShortLink)
+		admin.GET("/slo", handlers.GetSLOReport)
+		admin.GET("/dashboard", handlers.GetDashboard)
+		admin.GET("/webhooks", handlers.GetWebhooks)
+		admin.POST("/webhooks", handlers.CreateWebhook)
+		admin.PUT("/webhooks/:id", handlers.UpdateWebhook)
+		admin.DELETE("/webhooks/:id", handlers.DeleteWebhook)
+		admin.GET("/webhooks/:id/deliveries", handlers.GetWebhookDeliveries)
+		admin.GET("/posts", handlers.GetAllPosts)
+		admin.POST("/posts", handlers.CreatePost)
+		admin.PUT("/posts/:id", handlers.UpdatePost)
+		admin.DELETE("/posts/:id", handlers.DeletePost)
+		admin.GET("/testimonials", handlers.GetAllTestimonials)
+		admin.POST("/testimonials", handlers.CreateTestimonial)
+		admin.PUT("/testimonials/:id", handlers.UpdateTestimonial)
+		admin.PUT("/testimonials/:id/approve", handlers.ApproveTestimonial)
+		admin.PUT("/testimonials/:id/reject", handlers.RejectTestimonial)
+		admin.DELETE("/testimonials/:id", handlers.DeleteTestimonial)
+		admin.GET("/comments", handlers.GetCommentQueue)
+		admin.PUT("/comments/:id/approve", handlers.ApproveComment)
+		admin.PUT("/comments/:id/reject", handlers.RejectComment)
+		admin.DELETE("/comments/:id", handlers.DeleteComment)
+		admin.POST("/education", handlers.CreateEducation)
+		admin.PUT("/education/:id", handlers.UpdateEducation)
+		admin.DELETE("/education/:id", handlers.DeleteEducation)
+		admin.POST("/certifications", handlers.CreateCertification)
+		admin.PUT("/certifications/:id", handlers.UpdateCertification)
+		admin.DELETE("/certifications/:id", handlers.DeleteCertification)
+		admin.POST("/publications", handlers.CreatePublication)
+		admin.PUT("/publications/:id", handlers.UpdatePublication)
+		admin.DELETE("/publications/:id", handlers.DeletePublication)
+		admin.POST("/awards", handlers.CreateAward)
+		admin.PUT("/awards/:id", handlers.UpdateAward)
+		admin.DELETE("/awards/:id", handlers.DeleteAward)
+		admin.POST("/services", handlers.CreateService)
+		admin.PUT("/services/:id", handlers.UpdateService)
+		admin.DELETE("/services/:id", handlers.DeleteService)
+		admin.POST("/talks", handlers.CreateTalk)
+		admin.PUT("/talks/:id", handlers.UpdateTalk)
+		admin.DELETE("/talks/:id", handlers.DeleteTalk)
+		admin.POST("/categories", handlers.CreateCategory)
+		admin.PUT("/categories/:id", handlers.UpdateCategory)
+		admin.DELETE("/categories/:id", handlers.DeleteCategory)
+		admin.POST("/companies", handlers.CreateCompany)
+		admin.PUT("/companies/:id", handlers.UpdateCompany)
+		admin.DELETE("/companies/:id", handlers.DeleteCompany)
+		admin.POST("/tags", handlers.CreateTag)
+		admin.PUT("/tags/:id", handlers.UpdateTag)
+		admin.DELETE("/tags/:id", handlers.DeleteTag)
+		admin.GET("/media", handlers.GetMedia)
+		admin.POST("/media", bodyLimit(int64(cfg.MediaMaxSizeMB+1)<<20), handlers.UploadMedia)
+		admin.GET("/media/:id/url", handlers.GetMediaURL)
+		admin.DELETE("/media/:id", handlers.DeleteMedia)
+		admin.POST("/resume/import", handlers.ImportResume)
+		admin.POST("/resumes", handlers.CreateResume)
+		admin.PUT("/resumes/:id", handlers.UpdateResume)
+		admin.DELETE("/resumes/:id", handlers.DeleteResume)
+		admin.GET("/export", handlers.ExportPortfolio)
+		admin.POST("/import", handlers.ImportPortfolio)
+		admin.GET("/security/logins", handlers.GetLoginEvents)
+		admin.GET("/newsletter/subscribers", handlers.GetSubscribers)
+		admin.GET("/newsletter/campaigns", handlers.GetCampaigns)
+		admin.POST("/newsletter/campaigns", handlers.CreateCampaign)
+		admin.GET("/newsletter/campaigns/:id", handlers.GetCampaign)
+		admin.PUT("/newsletter/campaigns/:id", handlers.UpdateCampaign)
+		admin.DELETE("/newsletter/campaigns/:id", handlers.DeleteCampaign)
+		admin.POST("/newsletter/campaigns/:id/send", handlers.SendCampaign)
+		admin.GET("/newsletter/campaigns/:id/deliveries", handlers.GetCampaignDeliveries)
+	}
+
+	// Routes that affect the whole deployment
+	platform := admin.Group("/")
+	platform.Use(middleware.DefaultTenantOnly())
+	{
+		platform.GET("/backups", handlers.GetBackupStatus)
+		platform.GET("/config", handlers.GetConfig)
+		platform.GET("/cache/stats", handlers.GetCacheStats)
+		platform.GET("/tenants", handlers.GetTenants)
+		platform.POST("/tenants", handlers.CreateTenant)
+		platform.PUT("/tenants/:id", handlers.UpdateTenant)
+		platform.GET("/security/ip-rules", handlers.GetIPRules)
+		platform.POST("/security/ip-rules", handlers.CreateIPRule)
+		platform.PUT("/security/ip-rules/:id", handlers.UpdateIPRule)
+		platform.DELETE("/security/ip-rules/:id", handlers.DeleteIPRule)
+		platform.GET("/jobs/dead", handlers.GetDeadJobs)
+		platform.POST("/jobs/dead/:id/requeue", handlers.RequeueDeadJob)
+		platform.DELETE("/jobs/dead/:id", handlers.DeleteDeadJob)
+		platform.POST("/search/reindex", handlers.ReindexSearch)
+	}
+
+	// Auth routes
+	auth := group.Group("/auth")
+	auth.Use(middleware.NoStore(), bodyLimit(int64(cfg.MaxBodyKB)<<10))
+	{
+		auth.POST("/login", handlers.Login)
+	}
+}
@@ -0,0 +1,75 @@
+// Package web embeds the built frontend so a single binary serves the whole
+// portfolio site. Build the frontend into web/dist before compiling; the
+// directory only holds a placeholder otherwise.
+package web
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+// immutableDirs hold the content-hashed assets emitted by Vite and Create
+// React App, which never change under the same name
+var immutableDirs = []string{"assets/", "static/"}
+
+// Handler serves the embedded frontend. Paths without a file fall back to
+// index.html so client-side routes survive a reload, while missing assets
+// and unknown /api paths still answer 404.
+func Handler() (gin.HandlerFunc, error) {
+	files, err := fs.Sub(dist, "dist")
+	if err != nil {
+		return nil, err
+	}
+	index, err := fs.ReadFile(files, "index.html")
+	if err != nil {
+		return nil, errors.New("no frontend build embedded: build it into web/dist and recompile")
+	}
+	fileServer := http.FileServer(http.FS(files))
+
+	return func(c *gin.Context) {
+		name := strings.TrimPrefix(path.Clean(c.Request.URL.Path), "/")
+		if (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) || name == "api" || strings.HasPrefix(name, "api/") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Not found",
+			})
+			return
+		}
+
+		if name != "" && name != "index.html" {
+			if info, err := fs.Stat(files, name); err == nil && !info.IsDir() {
+				c.Header("Cache-Control", cacheControl(name))
+				fileServer.ServeHTTP(c.Writer, c.Request)
+				return
+			}
+			if path.Ext(name) != "" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"error": "Not found",
+				})
+				return
+			}
+		}
+
+		// index.html references the current asset names, so browsers must
+		// revalidate it after every deploy
+		c.Header("Cache-Control", "no-cache")
+		c.Data(http.StatusOK, "text/html; charset=utf-8", index)
+	}, nil
+}
+
+func cacheControl(name string) string {
+	for _, dir := range immutableDirs {
+		if strings.HasPrefix(name, dir) {
+			return "public, max-age=31536000, immutable"
+		}
+	}
+	return "public, max-age=3600"
+}